@@ -27,6 +27,7 @@ import (
 	siclifecycle "github.com/kubernetes-incubator/service-catalog/plugin/pkg/admission/servicebindings/lifecycle"
 	"github.com/kubernetes-incubator/service-catalog/plugin/pkg/admission/serviceplan/changevalidator"
 	"github.com/kubernetes-incubator/service-catalog/plugin/pkg/admission/serviceplan/defaultserviceplan"
+	"github.com/kubernetes-incubator/service-catalog/plugin/pkg/admission/serviceplan/parametersvalidator"
 )
 
 // registerAllAdmissionPlugins registers all admission plugins
@@ -35,4 +36,5 @@ func registerAllAdmissionPlugins(plugins *admission.Plugins) {
 	siclifecycle.Register(plugins)
 	changevalidator.Register(plugins)
 	authsarcheck.Register(plugins)
+	parametersvalidator.Register(plugins)
 }
@@ -40,11 +40,13 @@ import (
 	"github.com/kubernetes-incubator/service-catalog/pkg/kubernetes/pkg/util/configz"
 	"github.com/kubernetes-incubator/service-catalog/pkg/metrics"
 	"github.com/kubernetes-incubator/service-catalog/pkg/metrics/osbclientproxy"
+	"github.com/kubernetes-incubator/service-catalog/pkg/tracing"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/apiserver/pkg/server/healthz"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
 	"k8s.io/client-go/tools/leaderelection"
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
 
@@ -59,8 +61,10 @@ import (
 	settingsv1alpha1 "github.com/kubernetes-incubator/service-catalog/pkg/apis/settings/v1alpha1"
 	servicecataloginformers "github.com/kubernetes-incubator/service-catalog/pkg/client/informers_generated/externalversions"
 	"github.com/kubernetes-incubator/service-catalog/pkg/controller"
+	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
 
 	"github.com/golang/glog"
+	opentracing "github.com/opentracing/opentracing-go"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 )
@@ -157,6 +161,12 @@ func Run(controllerManagerOptions *options.ControllerManagerServer) error {
 		return fmt.Errorf("failed to establish SecureServingOptions %v", err)
 	}
 
+	tracer, err := tracing.NewTracer(controllerManagerOptions.TracingExporter, controllerManagerOptions.TracingFile)
+	if err != nil {
+		return fmt.Errorf("failed to create the tracer: %v", err)
+	}
+	opentracing.SetGlobalTracer(tracer)
+
 	glog.V(4).Info("Starting http server and mux")
 	// Start http server and handlers
 	go func() {
@@ -386,11 +396,30 @@ func StartControllers(s *options.ControllerManagerServer,
 		s.OperationPollingMaximumBackoffDuration,
 		s.ClusterIDConfigMapName,
 		s.ClusterIDConfigMapNamespace,
+		s.AutoUpgradeInstances,
+		s.BrokerCircuitBreakerThreshold,
+		s.BrokerCircuitBreakerProbeInterval,
+		s.BindingRotationGracePeriod,
+		s.InstanceDriftCheckInterval,
 	)
 	if err != nil {
 		return err
 	}
 
+	resourceListers := metrics.ResourceListers{
+		ServiceInstances:      serviceCatalogSharedInformers.ServiceInstances().Lister(),
+		ServiceBindings:       serviceCatalogSharedInformers.ServiceBindings().Lister(),
+		ClusterServiceClasses: serviceCatalogSharedInformers.ClusterServiceClasses().Lister(),
+		ClusterServicePlans:   serviceCatalogSharedInformers.ClusterServicePlans().Lister(),
+	}
+	if utilfeature.DefaultFeatureGate.Enabled(scfeatures.NamespacedServiceBroker) {
+		resourceListers.ServiceClasses = serviceCatalogSharedInformers.ServiceClasses().Lister()
+		resourceListers.ServicePlans = serviceCatalogSharedInformers.ServicePlans().Lister()
+	}
+	if err := metrics.RegisterCollector(metrics.NewResourceCollector(resourceListers)); err != nil {
+		return err
+	}
+
 	glog.V(1).Info("Starting shared informers")
 	informerFactory.Start(stop)
 
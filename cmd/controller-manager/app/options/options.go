@@ -60,6 +60,9 @@ const (
 	defaultLeaderElectionNamespace                = "kube-system"
 	defaultReconciliationRetryDuration            = 7 * 24 * time.Hour
 	defaultOperationPollingMaximumBackoffDuration = 20 * time.Minute
+	defaultBrokerCircuitBreakerProbeInterval      = 1 * time.Minute
+	defaultBindingRotationGracePeriod             = 10 * time.Minute
+	defaultInstanceDriftCheckInterval             = 1 * time.Hour
 )
 
 var defaultOSBAPIPreferredVersion = osb.LatestAPIVersion().HeaderValue()
@@ -85,6 +88,9 @@ func NewControllerManagerServer() *ControllerManagerServer {
 			EnableContentionProfiling:              false,
 			ReconciliationRetryDuration:            defaultReconciliationRetryDuration,
 			OperationPollingMaximumBackoffDuration: defaultOperationPollingMaximumBackoffDuration,
+			BrokerCircuitBreakerProbeInterval:      defaultBrokerCircuitBreakerProbeInterval,
+			BindingRotationGracePeriod:             defaultBindingRotationGracePeriod,
+			InstanceDriftCheckInterval:             defaultInstanceDriftCheckInterval,
 			SecureServingOptions:                   genericoptions.NewSecureServingOptions(),
 		},
 	}
@@ -122,4 +128,11 @@ func (s *ControllerManagerServer) AddFlags(fs *pflag.FlagSet) {
 	utilfeature.DefaultFeatureGate.AddFlag(fs)
 	fs.StringVar(&s.ClusterIDConfigMapName, "cluster-id-configmap-name", controller.DefaultClusterIDConfigMapName, "k8s name for clusterid configmap")
 	fs.StringVar(&s.ClusterIDConfigMapNamespace, "cluster-id-configmap-namespace", controller.DefaultClusterIDConfigMapNamespace, "k8s namespace for clusterid configmap")
+	fs.BoolVar(&s.AutoUpgradeInstances, "auto-upgrade-instances", s.AutoUpgradeInstances, "Upgrade instances to the newest version of their plan as soon as the broker reports one in the maintenance info of the plan")
+	fs.IntVar(&s.BrokerCircuitBreakerThreshold, "broker-circuit-breaker-threshold", s.BrokerCircuitBreakerThreshold, "The number of consecutive failed requests to a broker after which requests to the broker are suspended until it answers a catalog request again. Zero disables the circuit breaker")
+	fs.DurationVar(&s.BrokerCircuitBreakerProbeInterval, "broker-circuit-breaker-probe-interval", s.BrokerCircuitBreakerProbeInterval, "The interval on which the catalog of a broker with suspended requests is requested to check whether the broker has recovered")
+	fs.DurationVar(&s.BindingRotationGracePeriod, "binding-rotation-grace-period", s.BindingRotationGracePeriod, "How long the previous credentials of a binding are kept at the broker after a rotation has written new credentials into its secret")
+	fs.DurationVar(&s.InstanceDriftCheckInterval, "instance-drift-check-interval", s.InstanceDriftCheckInterval, "The minimum interval between two requests fetching an instance from its broker to check whether the instance has drifted from its spec. Zero disables the drift checks")
+	fs.StringVar(&s.TracingExporter, "tracing-exporter", s.TracingExporter, "Where to export the spans of the reconciliations and of the requests to the brokers: stdout or file. Tracing is disabled when empty")
+	fs.StringVar(&s.TracingFile, "tracing-file", s.TracingFile, "The path of the file the file tracing exporter appends the spans to, as one JSON object per line")
 }
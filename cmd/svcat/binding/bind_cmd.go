@@ -38,6 +38,7 @@ type bindCmd struct {
 	params       interface{}
 	rawSecrets   []string
 	secrets      map[string]string
+	dryRun       bool
 }
 
 // NewBindCmd builds a "svcat bind" command
@@ -54,6 +55,7 @@ func NewBindCmd(cxt *command.Context) *cobra.Command {
   svcat bind wordpress-mysql-instance --name wordpress-mysql-binding --secret-name wordpress-mysql-secret
   svcat bind wordpress-mysql-instance --name wordpress-mysql-binding --external-id c8ca2fcc-4398-11e8-842f-0ed5f89f718b
   svcat bind wordpress-instance --params type=admin
+  svcat bind wordpress-instance --params type=admin --dry-run
   svcat bind wordpress-instance --params-json '{
 	"type": "admin",
 	"teams": [
@@ -90,6 +92,8 @@ func NewBindCmd(cxt *command.Context) *cobra.Command {
 		"Additional parameter, whose value is stored in a secret, to use when binding the instance, format: SECRET[KEY]")
 	cmd.Flags().StringVar(&bindCmd.jsonParams, "params-json", "",
 		"Additional parameters to use when binding the instance, provided as a JSON object. Cannot be combined with --param")
+	cmd.Flags().BoolVar(&bindCmd.dryRun, "dry-run", false,
+		"Validate the parameters against the plan's schema and print the request that would be sent to the broker, without binding the instance. Parameter values read from secrets are redacted")
 	bindCmd.AddWaitFlags(cmd)
 	return cmd
 }
@@ -127,9 +131,25 @@ func (c *bindCmd) Validate(args []string) error {
 }
 
 func (c *bindCmd) Run() error {
+	if c.dryRun {
+		return c.dryRunBind()
+	}
 	return c.bind()
 }
 
+func (c *bindCmd) dryRunBind() error {
+	preview, err := c.App.DryRunBind(c.Namespace, c.bindingName, c.externalID, c.instanceName, c.secretName, c.params, c.secrets, c.App.CurrentUser)
+	if err != nil {
+		return err
+	}
+
+	output.WriteBindPreview(c.Output, preview)
+	if len(preview.ValidationErrors) > 0 {
+		return fmt.Errorf("the parameters are not valid for the plan of instance '%s'", c.instanceName)
+	}
+	return nil
+}
+
 func (c *bindCmd) bind() error {
 	binding, err := c.App.Bind(c.Namespace, c.bindingName, c.externalID, c.instanceName, c.secretName, c.params, c.secrets)
 	if err != nil {
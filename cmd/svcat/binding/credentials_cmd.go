@@ -0,0 +1,128 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package binding
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/output"
+	"github.com/spf13/cobra"
+)
+
+type getCredentialsCmd struct {
+	*command.Namespaced
+	name         string
+	outputFormat string
+	exec         bool
+	execArgs     []string
+}
+
+// NewGetCredentialsCmd builds a "svcat get credentials" command
+func NewGetCredentialsCmd(cxt *command.Context) *cobra.Command {
+	getCmd := &getCredentialsCmd{Namespaced: command.NewNamespaced(cxt)}
+	cmd := &cobra.Command{
+		Use:     "credentials BINDING",
+		Aliases: []string{"credential", "creds"},
+		Short:   "Print the decoded credentials of a binding, or run a command with them",
+		Long: `Print the decoded credentials of a binding, as they are injected in the secret
+of the binding after applying its secret transforms.
+
+With --exec, the command after "--" runs with the credentials added to its
+environment instead.`,
+		Example: command.NormalizeExamples(`
+  svcat get credentials wordpress-mysql-binding
+  svcat get credentials wordpress-mysql-binding -o dotenv > .env
+  svcat get credentials wordpress-mysql-binding -o properties
+  svcat get credentials wordpress-mysql-binding --exec -- npm start
+`),
+		PreRunE: command.PreRunE(getCmd),
+		RunE:    command.RunE(getCmd),
+	}
+	cmd.Flags().StringVarP(&getCmd.outputFormat, "output", "o", output.FormatEnv,
+		"The output format to use. Valid options are env, dotenv, json or properties. If not present, defaults to env")
+	cmd.Flags().BoolVar(&getCmd.exec, "exec", false,
+		"Run the command passed after -- with the credentials as environment variables, instead of printing them")
+	getCmd.AddNamespaceFlags(cmd.Flags(), false)
+	return cmd
+}
+
+func (c *getCredentialsCmd) Validate(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("a binding name is required")
+	}
+	c.name = args[0]
+
+	if c.exec {
+		c.execArgs = args[1:]
+		if len(c.execArgs) == 0 {
+			return fmt.Errorf("a command to run is required with --exec")
+		}
+		return nil
+	}
+
+	c.outputFormat = strings.ToLower(c.outputFormat)
+	switch c.outputFormat {
+	case output.FormatEnv, output.FormatDotenv, output.FormatJSON, output.FormatProperties:
+		return nil
+	default:
+		return fmt.Errorf("invalid --output format %q, allowed values are: env, dotenv, json and properties", c.outputFormat)
+	}
+}
+
+func (c *getCredentialsCmd) Run() error {
+	credentials, err := c.App.RetrieveCredentials(c.Namespace, c.name)
+	if err != nil {
+		return err
+	}
+
+	if c.exec {
+		return c.execWithCredentials(credentials)
+	}
+
+	output.WriteCredentials(c.Output, c.outputFormat, credentials)
+	return nil
+}
+
+// execWithCredentials runs the command passed after -- with the credentials
+// added to the environment of svcat.
+func (c *getCredentialsCmd) execWithCredentials(credentials map[string]string) error {
+	keys := make([]string, 0, len(credentials))
+	for key := range credentials {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	env := os.Environ()
+	for _, key := range keys {
+		env = append(env, key+"="+credentials[key])
+	}
+
+	cmd := exec.Command(c.execArgs[0], c.execArgs[1:]...)
+	cmd.Env = env
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = c.Output
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("unable to run %s (%s)", c.execArgs[0], err)
+	}
+	return nil
+}
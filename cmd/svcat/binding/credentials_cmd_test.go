@@ -0,0 +1,65 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package binding
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/test"
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	svcatfake "github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/clientset/fake"
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+
+	_ "github.com/kubernetes-incubator/service-catalog/internal/test"
+)
+
+func TestGetCredentialsCommandExec(t *testing.T) {
+	const namespace = "default"
+	binding := &v1beta1.ServiceBinding{
+		ObjectMeta: v1.ObjectMeta{Namespace: namespace, Name: "mybinding"},
+		Spec:       v1beta1.ServiceBindingSpec{SecretName: "mysecret"},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: v1.ObjectMeta{Namespace: namespace, Name: "mysecret"},
+		Data:       map[string][]byte{"DB_PASSWORD": []byte("s3cret")},
+	}
+
+	fakeApp, _ := svcat.NewApp(k8sfake.NewSimpleClientset(secret), svcatfake.NewSimpleClientset(binding), namespace)
+	output := &bytes.Buffer{}
+	cxt := svcattest.NewContext(output, fakeApp)
+
+	cmd := &getCredentialsCmd{
+		Namespaced: command.NewNamespaced(cxt),
+		exec:       true,
+	}
+	cmd.Namespace = namespace
+	if err := cmd.Validate([]string{"mybinding", "printenv", "DB_PASSWORD"}); err != nil {
+		t.Fatalf("unexpected validation error: %v", err)
+	}
+
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("expected the command to succeed but it failed with %q", err)
+	}
+	if output.String() != "s3cret\n" {
+		t.Fatalf("expected the command to see the credentials in its environment, got %q", output.String())
+	}
+}
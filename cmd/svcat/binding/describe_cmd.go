@@ -26,13 +26,17 @@ import (
 
 type describeCmd struct {
 	*command.Namespaced
+	*command.Formatted
 	name        string
 	showSecrets bool
 }
 
 // NewDescribeCmd builds a "svcat describe binding" command
 func NewDescribeCmd(cxt *command.Context) *cobra.Command {
-	describeCmd := &describeCmd{Namespaced: command.NewNamespaced(cxt)}
+	describeCmd := &describeCmd{
+		Namespaced: command.NewNamespaced(cxt),
+		Formatted:  command.NewFormatted(),
+	}
 	cmd := &cobra.Command{
 		Use:     "binding NAME",
 		Aliases: []string{"bindings", "bnd"},
@@ -42,6 +46,7 @@ func NewDescribeCmd(cxt *command.Context) *cobra.Command {
 		RunE:    command.RunE(describeCmd),
 	}
 	describeCmd.AddNamespaceFlags(cmd.Flags(), false)
+	describeCmd.AddOutputFlags(cmd.Flags())
 	cmd.Flags().BoolVar(
 		&describeCmd.showSecrets,
 		"show-secrets",
@@ -70,10 +75,18 @@ func (c *describeCmd) describe() error {
 		return err
 	}
 
+	if c.OutputFormat != output.FormatTable {
+		output.WriteBinding(c.Output, c.OutputFormat, *binding)
+		return nil
+	}
+
 	output.WriteBindingDetails(c.Output, binding)
 
 	secret, err := c.App.RetrieveSecretByBinding(binding)
 	output.WriteAssociatedSecret(c.Output, secret, err, c.showSecrets)
 
+	timeline, err := c.App.RetrieveBindingTimeline(binding)
+	output.WriteTimeline(c.Output, timeline, err)
+
 	return nil
 }
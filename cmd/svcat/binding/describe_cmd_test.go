@@ -81,6 +81,7 @@ func TestDescribeCommand(t *testing.T) {
 			// Initialize the command arguments
 			cmd := &describeCmd{
 				Namespaced: command.NewNamespaced(cxt),
+				Formatted:  command.NewFormatted(),
 			}
 			cmd.Namespace = namespace
 			cmd.name = tc.bindingName
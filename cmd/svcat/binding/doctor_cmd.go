@@ -0,0 +1,76 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package binding
+
+import (
+	"fmt"
+
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/output"
+	"github.com/spf13/cobra"
+)
+
+type doctorCmd struct {
+	*command.Namespaced
+	*command.Formatted
+	name string
+}
+
+// NewDoctorCmd builds a "svcat doctor binding" command
+func NewDoctorCmd(cxt *command.Context) *cobra.Command {
+	doctorCmd := &doctorCmd{
+		Namespaced: command.NewNamespaced(cxt),
+		Formatted:  command.NewFormatted(),
+	}
+	cmd := &cobra.Command{
+		Use:     "binding NAME",
+		Aliases: []string{"bindings", "bnd"},
+		Short:   "Diagnose why a binding is not ready",
+		Long: `Check a binding, its secret, and the instance, class, plan and broker that
+it references, for the known failure modes reported by the controller. The
+problems found are listed most severe first, with the commands to run next.`,
+		Example: command.NormalizeExamples(`
+  svcat doctor binding wordpress-mysql-binding
+  svcat doctor binding wordpress-mysql-binding -n mynamespace -o json
+`),
+		PreRunE: command.PreRunE(doctorCmd),
+		RunE:    command.RunE(doctorCmd),
+	}
+	doctorCmd.AddNamespaceFlags(cmd.Flags(), false)
+	doctorCmd.AddOutputFlags(cmd.Flags())
+	return cmd
+}
+
+func (c *doctorCmd) Validate(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("a binding name is required")
+	}
+	c.name = args[0]
+
+	return nil
+}
+
+func (c *doctorCmd) Run() error {
+	diagnoses, err := c.App.DiagnoseBinding(c.Namespace, c.name)
+	if err != nil {
+		return err
+	}
+
+	resource := fmt.Sprintf("binding %s/%s", c.Namespace, c.name)
+	output.WriteDiagnoses(c.Output, c.OutputFormat, resource, diagnoses)
+	return nil
+}
@@ -17,14 +17,19 @@ limitations under the License.
 package binding
 
 import (
+	"fmt"
+
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/output"
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 type getCmd struct {
 	*command.Namespaced
 	*command.Formatted
+	*command.Watchable
 	name string
 }
 
@@ -33,6 +38,7 @@ func NewGetCmd(cxt *command.Context) *cobra.Command {
 	getCmd := &getCmd{
 		Namespaced: command.NewNamespaced(cxt),
 		Formatted:  command.NewFormatted(),
+		Watchable:  command.NewWatchable(),
 	}
 	cmd := &cobra.Command{
 		Use:     "bindings [NAME]",
@@ -41,6 +47,7 @@ func NewGetCmd(cxt *command.Context) *cobra.Command {
 		Example: command.NormalizeExamples(`
   svcat get bindings
   svcat get bindings --all-namespaces
+  svcat get bindings --watch
   svcat get binding wordpress-mysql-binding
   svcat get binding -n ci concourse-postgres-binding
 `),
@@ -50,12 +57,17 @@ func NewGetCmd(cxt *command.Context) *cobra.Command {
 
 	getCmd.AddNamespaceFlags(cmd.Flags(), true)
 	getCmd.AddOutputFlags(cmd.Flags())
+	getCmd.AddWatchFlag(cmd)
 	return cmd
 }
 
 func (c *getCmd) Validate(args []string) error {
 	if len(args) > 0 {
 		c.name = args[0]
+
+		if c.Watch {
+			return fmt.Errorf("watch is not supported when specifiying binding name")
+		}
 	}
 
 	return nil
@@ -76,7 +88,19 @@ func (c *getCmd) getAll() error {
 	}
 
 	output.WriteBindingList(c.Output, c.OutputFormat, bindings)
-	return nil
+	if !c.Watch {
+		return nil
+	}
+
+	w, err := c.App.WatchBindings(c.Namespace, bindings.ResourceVersion)
+	if err != nil {
+		return err
+	}
+	return c.WatchUntilInterrupted(w, func(event watch.Event) {
+		if binding, ok := event.Object.(*v1beta1.ServiceBinding); ok {
+			output.WriteBindingChange(c.Output, c.OutputFormat, event.Type, *binding)
+		}
+	})
 }
 
 func (c *getCmd) get() error {
@@ -28,7 +28,9 @@ import (
 	"github.com/kubernetes-incubator/service-catalog/pkg/svcat"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
 	k8sfake "k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
 
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/output"
 	_ "github.com/kubernetes-incubator/service-catalog/internal/test"
@@ -134,6 +136,7 @@ func TestGetCommand(t *testing.T) {
 			cmd := &getCmd{
 				Namespaced: command.NewNamespaced(cxt),
 				Formatted:  command.NewFormatted(),
+				Watchable:  command.NewWatchable(),
 			}
 			cmd.Namespace = namespace
 			cmd.name = tc.bindingName
@@ -157,3 +160,50 @@ func TestGetCommand(t *testing.T) {
 		})
 	}
 }
+
+func TestGetCommandWatch(t *testing.T) {
+	const namespace = "default"
+	binding := &v1beta1.ServiceBinding{
+		ObjectMeta: v1.ObjectMeta{
+			Namespace: namespace,
+			Name:      "mybinding",
+		},
+		Spec: v1beta1.ServiceBindingSpec{
+			ServiceInstanceRef: v1beta1.LocalObjectReference{Name: "myinstance"},
+		},
+	}
+
+	svcatClient := svcatfake.NewSimpleClientset()
+	fakeWatch := watch.NewFake()
+	svcatClient.PrependWatchReactor("servicebindings", clienttesting.DefaultWatchReactor(fakeWatch, nil))
+	go func() {
+		fakeWatch.Add(binding)
+		fakeWatch.Delete(binding)
+		fakeWatch.Stop()
+	}()
+
+	fakeApp, _ := svcat.NewApp(k8sfake.NewSimpleClientset(), svcatClient, namespace)
+	output := &bytes.Buffer{}
+	cxt := svcattest.NewContext(output, fakeApp)
+
+	cmd := &getCmd{
+		Namespaced: command.NewNamespaced(cxt),
+		Formatted:  command.NewFormatted(),
+		Watchable:  command.NewWatchable(),
+	}
+	cmd.Namespace = namespace
+	cmd.OutputFormat = "table"
+	cmd.Watch = true
+
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("expected the command to succeed but it failed with %q", err)
+	}
+
+	gotOutput := output.String()
+	if !strings.Contains(gotOutput, "mybinding") {
+		t.Fatalf("expected the watched binding to be printed, got:\n%s", gotOutput)
+	}
+	if !strings.Contains(gotOutput, "Deleted") {
+		t.Fatalf("expected the deletion of the binding to be printed, got:\n%s", gotOutput)
+	}
+}
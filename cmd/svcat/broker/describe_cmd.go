@@ -21,27 +21,38 @@ import (
 
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/output"
+	servicecatalog "github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
 	"github.com/spf13/cobra"
 )
 
 type describeCmd struct {
-	*command.Context
+	*command.Namespaced
+	*command.Scoped
+	*command.Formatted
 	name string
 }
 
 // NewDescribeCmd builds a "svcat describe broker" command
 func NewDescribeCmd(cxt *command.Context) *cobra.Command {
-	describeCmd := &describeCmd{Context: cxt}
+	describeCmd := &describeCmd{
+		Namespaced: command.NewNamespaced(cxt),
+		Scoped:     command.NewScoped(),
+		Formatted:  command.NewFormatted(),
+	}
 	cmd := &cobra.Command{
 		Use:     "broker NAME",
 		Aliases: []string{"brokers", "brk"},
 		Short:   "Show details of a specific broker",
 		Example: command.NormalizeExamples(`
   svcat describe broker asb
+  svcat describe broker asb --scope namespace --namespace dev
 `),
 		PreRunE: command.PreRunE(describeCmd),
 		RunE:    command.RunE(describeCmd),
 	}
+	describeCmd.AddNamespaceFlags(cmd.Flags(), false)
+	describeCmd.AddScopedFlags(cmd.Flags(), false)
+	describeCmd.AddOutputFlags(cmd.Flags())
 	return cmd
 }
 
@@ -59,11 +70,17 @@ func (c *describeCmd) Run() error {
 }
 
 func (c *describeCmd) Describe() error {
-	broker, err := c.App.RetrieveBroker(c.name)
+	scopeOpts := servicecatalog.ScopeOptions{Namespace: c.Namespace, Scope: c.Scope}
+	broker, err := c.App.RetrieveBrokerInScope(c.name, scopeOpts)
 	if err != nil {
 		return err
 	}
 
+	if c.OutputFormat != output.FormatTable {
+		output.WriteBroker(c.Output, c.OutputFormat, broker)
+		return nil
+	}
+
 	output.WriteBrokerDetails(c.Output, broker)
 	return nil
 }
@@ -21,6 +21,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/test"
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	svcatfake "github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/clientset/fake"
@@ -78,7 +79,9 @@ func TestDescribeCommand(t *testing.T) {
 
 			// Initialize the command arguments
 			cmd := &describeCmd{
-				Context: cxt,
+				Namespaced: command.NewNamespaced(cxt),
+				Scoped:     command.NewScoped(),
+				Formatted:  command.NewFormatted(),
 			}
 			cmd.name = tc.brokerName
 
@@ -0,0 +1,72 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package broker
+
+import (
+	"fmt"
+
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/output"
+	"github.com/spf13/cobra"
+)
+
+type doctorCmd struct {
+	*command.Context
+	*command.Formatted
+	name string
+}
+
+// NewDoctorCmd builds a "svcat doctor broker" command
+func NewDoctorCmd(cxt *command.Context) *cobra.Command {
+	doctorCmd := &doctorCmd{
+		Context:   cxt,
+		Formatted: command.NewFormatted(),
+	}
+	cmd := &cobra.Command{
+		Use:     "broker NAME",
+		Aliases: []string{"brokers", "brk"},
+		Short:   "Diagnose why a broker is not ready",
+		Long: `Check a broker for the known failure modes reported by the controller.
+The problems found are listed most severe first, with the commands to run next.`,
+		Example: command.NormalizeExamples(`
+  svcat doctor broker asb
+`),
+		PreRunE: command.PreRunE(doctorCmd),
+		RunE:    command.RunE(doctorCmd),
+	}
+	doctorCmd.AddOutputFlags(cmd.Flags())
+	return cmd
+}
+
+func (c *doctorCmd) Validate(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("a broker name is required")
+	}
+	c.name = args[0]
+
+	return nil
+}
+
+func (c *doctorCmd) Run() error {
+	diagnoses, err := c.App.DiagnoseBroker(c.name)
+	if err != nil {
+		return err
+	}
+
+	output.WriteDiagnoses(c.Output, c.OutputFormat, "broker "+c.name, diagnoses)
+	return nil
+}
@@ -92,6 +92,6 @@ func (c *getCmd) get() error {
 		return err
 	}
 
-	output.WriteBroker(c.Output, c.OutputFormat, *broker)
+	output.WriteBroker(c.Output, c.OutputFormat, broker)
 	return nil
 }
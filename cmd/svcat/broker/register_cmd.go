@@ -33,12 +33,16 @@ import (
 // RegisterCmd contains the information needed to register a broker
 type RegisterCmd struct {
 	*command.Namespaced
+	*command.Scoped
 	*command.Waitable
 
 	Context *command.Context
 
 	BasicSecret       string
+	BasicUsername     string
+	BasicPassword     string
 	BearerSecret      string
+	BearerToken       string
 	BrokerName        string
 	CAFile            string
 	ClassRestrictions []string
@@ -54,6 +58,7 @@ func NewRegisterCmd(cxt *command.Context) *cobra.Command {
 	registerCmd := &RegisterCmd{
 		Context:    cxt,
 		Namespaced: command.NewNamespaced(cxt),
+		Scoped:     command.NewScoped(),
 		Waitable:   command.NewWaitable(),
 	}
 	cmd := &cobra.Command{
@@ -61,6 +66,9 @@ func NewRegisterCmd(cxt *command.Context) *cobra.Command {
 		Short: "Registers a new broker with service catalog",
 		Example: command.NormalizeExamples(`
 		svcat register mysqlbroker --url http://mysqlbroker.com
+		svcat register mysqlbroker --url https://mysqlbroker.com --ca mysqlbroker-ca.pem --basic-username admin --basic-password s3cret
+		svcat register mysqlbroker --url http://mysqlbroker.com --scope namespace --namespace dev --bearer-secret mysqlbroker-token
+		svcat register mysqlbroker --url http://mysqlbroker.com --class-restrictions "spec.externalName in (mysql, mariadb)" --plan-restrictions "spec.free=true"
 		`),
 		PreRunE: command.PreRunE(registerCmd),
 		RunE:    command.RunE(registerCmd),
@@ -70,8 +78,14 @@ func NewRegisterCmd(cxt *command.Context) *cobra.Command {
 	cmd.MarkFlagRequired("url")
 	cmd.Flags().StringVar(&registerCmd.BasicSecret, "basic-secret", "",
 		"A secret containing basic auth (username/password) information to connect to the broker")
+	cmd.Flags().StringVar(&registerCmd.BasicUsername, "basic-username", "",
+		"The username to connect to the broker with basic auth, stored in a new secret named by --basic-secret or NAME-auth")
+	cmd.Flags().StringVar(&registerCmd.BasicPassword, "basic-password", "",
+		"The password to connect to the broker with basic auth, stored with --basic-username")
 	cmd.Flags().StringVar(&registerCmd.BearerSecret, "bearer-secret", "",
 		"A secret containing a bearer token to connect to the broker")
+	cmd.Flags().StringVar(&registerCmd.BearerToken, "bearer-token", "",
+		"The token to connect to the broker with bearer auth, stored in a new secret named by --bearer-secret or NAME-auth")
 	cmd.Flags().StringVar(&registerCmd.CAFile, "ca", "",
 		"A file containing the CA certificate to connect to the broker")
 	cmd.Flags().StringSliceVar(&registerCmd.ClassRestrictions, "class-restrictions", []string{},
@@ -85,6 +99,7 @@ func NewRegisterCmd(cxt *command.Context) *cobra.Command {
 	cmd.Flags().BoolVar(&registerCmd.SkipTLS, "skip-tls", false,
 		"Disables TLS certificate verification when communicating with this broker. This is strongly discouraged. You should use --ca instead.")
 	registerCmd.AddNamespaceFlags(cmd.Flags(), false)
+	registerCmd.AddScopedFlags(cmd.Flags(), false)
 	registerCmd.AddWaitFlags(cmd)
 
 	return cmd
@@ -97,9 +112,14 @@ func (c *RegisterCmd) Validate(args []string) error {
 	}
 	c.BrokerName = args[0]
 
-	if c.BasicSecret != "" && c.BearerSecret != "" {
+	basicAuth := c.BasicSecret != "" || c.BasicUsername != "" || c.BasicPassword != ""
+	bearerAuth := c.BearerSecret != "" || c.BearerToken != ""
+	if basicAuth && bearerAuth {
 		return fmt.Errorf("cannot use both basic auth and bearer auth")
 	}
+	if (c.BasicUsername == "") != (c.BasicPassword == "") {
+		return fmt.Errorf("--basic-username and --basic-password must be used together")
+	}
 
 	if c.CAFile != "" {
 		_, err := os.Stat(c.CAFile)
@@ -113,6 +133,12 @@ func (c *RegisterCmd) Validate(args []string) error {
 			return fmt.Errorf("invalid --relist-duration value, allowed values are: duration, manual")
 		}
 	}
+	if len(c.ClassRestrictions) > 0 || len(c.PlanRestrictions) > 0 {
+		err := servicecatalog.ValidateCatalogRestrictions(c.ClassRestrictions, c.PlanRestrictions, c.Scope)
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -120,11 +146,15 @@ func (c *RegisterCmd) Validate(args []string) error {
 func (c *RegisterCmd) Run() error {
 	opts := &servicecatalog.RegisterOptions{
 		BasicSecret:       c.BasicSecret,
+		BasicUsername:     c.BasicUsername,
+		BasicPassword:     c.BasicPassword,
 		BearerSecret:      c.BearerSecret,
+		BearerToken:       c.BearerToken,
 		CAFile:            c.CAFile,
 		ClassRestrictions: c.ClassRestrictions,
 		Namespace:         c.Namespace,
 		PlanRestrictions:  c.PlanRestrictions,
+		Scope:             c.Scope,
 		SkipTLS:           c.SkipTLS,
 	}
 	if c.RelistBehavior == "duration" {
@@ -141,9 +171,10 @@ func (c *RegisterCmd) Run() error {
 
 	if c.Wait {
 		fmt.Fprintln(c.Output, "Waiting for the broker to be registered...")
-		finalBroker, err := c.Context.App.WaitForBroker(c.BrokerName, c.Interval, c.Timeout)
+		scopeOpts := servicecatalog.ScopeOptions{Namespace: c.Namespace, Scope: c.Scope}
+		finalBroker, err := c.Context.App.WaitForBroker(c.BrokerName, scopeOpts, c.Interval, c.Timeout)
 		if err == nil {
-			broker = finalBroker.(*v1beta1.ClusterServiceBroker)
+			broker = finalBroker
 		}
 
 		output.WriteBrokerDetails(c.Output, broker)
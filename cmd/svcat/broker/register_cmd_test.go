@@ -57,6 +57,21 @@ var _ = Describe("Register Command", func() {
 			Expect(bearerSecretFlag).NotTo(BeNil())
 			Expect(bearerSecretFlag.Usage).To(ContainSubstring("A secret containing a bearer token to connect to the broker"))
 
+			basicUsernameFlag := cmd.Flags().Lookup("basic-username")
+			Expect(basicUsernameFlag).NotTo(BeNil())
+			Expect(basicUsernameFlag.Usage).To(ContainSubstring("stored in a new secret named by --basic-secret or NAME-auth"))
+
+			basicPasswordFlag := cmd.Flags().Lookup("basic-password")
+			Expect(basicPasswordFlag).NotTo(BeNil())
+
+			bearerTokenFlag := cmd.Flags().Lookup("bearer-token")
+			Expect(bearerTokenFlag).NotTo(BeNil())
+			Expect(bearerTokenFlag.Usage).To(ContainSubstring("stored in a new secret named by --bearer-secret or NAME-auth"))
+
+			scopeFlag := cmd.Flags().Lookup("scope")
+			Expect(scopeFlag).NotTo(BeNil())
+			Expect(scopeFlag.DefValue).To(Equal(servicecatalog.ClusterScope))
+
 			caFlag := cmd.Flags().Lookup("ca")
 			Expect(caFlag).NotTo(BeNil())
 			Expect(caFlag.Usage).To(ContainSubstring("A file containing the CA certificate to connect to the broker"))
@@ -121,6 +136,48 @@ var _ = Describe("Register Command", func() {
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("error finding CA file"))
 		})
+		It("errors if basic auth credentials and a bearer secret are provided", func() {
+			cmd := RegisterCmd{
+				BasicUsername: "admin",
+				BasicPassword: "s3cret",
+				BearerSecret:  "bearersecret",
+			}
+			err := cmd.Validate([]string{"bananabroker"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("cannot use both basic auth and bearer auth"))
+		})
+		It("errors if a basic auth username is provided without a password", func() {
+			cmd := RegisterCmd{
+				BasicUsername: "admin",
+			}
+			err := cmd.Validate([]string{"bananabroker"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("--basic-username and --basic-password must be used together"))
+		})
+		It("validates the catalog restrictions for the scope of the broker", func() {
+			cmd := RegisterCmd{
+				Scoped:            &command.Scoped{Scope: servicecatalog.ClusterScope},
+				ClassRestrictions: []string{"spec.externalName in (mysql, mariadb)"},
+				PlanRestrictions:  []string{"spec.free=true"},
+			}
+			err := cmd.Validate([]string{"bananabroker"})
+			Expect(err).NotTo(HaveOccurred())
+
+			cmd.ClassRestrictions = []string{"spec.externalName in (mysql"}
+			err = cmd.Validate([]string{"bananabroker"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid class restrictions"))
+
+			cmd.ClassRestrictions = nil
+			cmd.PlanRestrictions = []string{"spec.clusterServiceClass.name=foo"}
+			err = cmd.Validate([]string{"bananabroker"})
+			Expect(err).NotTo(HaveOccurred())
+
+			cmd.Scope = servicecatalog.NamespaceScope
+			err = cmd.Validate([]string{"bananabroker"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("\"spec.clusterServiceClass.name\" is not a supported property"))
+		})
 		It("only allows valid values for relist behavior", func() {
 			cmd := RegisterCmd{
 				RelistBehavior: "foobar",
@@ -217,6 +274,7 @@ var _ = Describe("Register Command", func() {
 				ClassRestrictions: classRestrictions,
 				Namespaced:        command.NewNamespaced(cxt),
 				PlanRestrictions:  planRestrictions,
+				Scoped:            command.NewScoped(),
 				RelistBehavior:    relistBehavior,
 				RelistDuration:    relistDuration,
 				SkipTLS:           skipTLS,
@@ -269,6 +327,7 @@ var _ = Describe("Register Command", func() {
 				BearerSecret: bearerSecret,
 				BrokerName:   brokerName,
 				Namespaced:   command.NewNamespaced(cxt),
+				Scoped:       command.NewScoped(),
 				URL:          brokerURL,
 				Waitable:     command.NewWaitable(),
 			}
@@ -291,6 +350,59 @@ var _ = Describe("Register Command", func() {
 			Expect(output).To(ContainSubstring(brokerName))
 			Expect(output).To(ContainSubstring(brokerURL))
 		})
+		It("Registers a namespaced broker with new basic auth credentials", func() {
+			namespacedBroker := &v1beta1.ServiceBroker{
+				ObjectMeta: v1.ObjectMeta{
+					Name:      brokerName,
+					Namespace: namespace,
+				},
+				Spec: v1beta1.ServiceBrokerSpec{
+					CommonServiceBrokerSpec: v1beta1.CommonServiceBrokerSpec{
+						URL: brokerURL,
+					},
+					AuthInfo: &v1beta1.ServiceBrokerAuthInfo{
+						Basic: &v1beta1.BasicAuthConfig{
+							SecretRef: &v1beta1.LocalObjectReference{Name: brokerName + "-auth"},
+						},
+					},
+				},
+			}
+			outputBuffer := &bytes.Buffer{}
+
+			fakeApp, _ := svcat.NewApp(nil, nil, namespace)
+			fakeSDK := new(servicecatalogfakes.FakeSvcatClient)
+			fakeSDK.RegisterReturns(namespacedBroker, nil)
+			fakeApp.SvcatClient = fakeSDK
+			cxt := svcattest.NewContext(outputBuffer, fakeApp)
+			cmd := RegisterCmd{
+				Context:       cxt,
+				BasicUsername: "admin",
+				BasicPassword: "s3cret",
+				BrokerName:    brokerName,
+				Namespaced:    command.NewNamespaced(cxt),
+				Scoped:        &command.Scoped{Scope: servicecatalog.NamespaceScope},
+				URL:           brokerURL,
+				Waitable:      command.NewWaitable(),
+			}
+			cmd.Namespaced.ApplyNamespaceFlags(&pflag.FlagSet{})
+			cmd.Waitable.ApplyWaitFlags()
+			err := cmd.Run()
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeSDK.RegisterCallCount()).To(Equal(1))
+			_, _, returnedOpts := fakeSDK.RegisterArgsForCall(0)
+			opts := servicecatalog.RegisterOptions{
+				BasicUsername: "admin",
+				BasicPassword: "s3cret",
+				Namespace:     namespace,
+				Scope:         servicecatalog.NamespaceScope,
+			}
+			Expect(*returnedOpts).To(Equal(opts))
+
+			output := outputBuffer.String()
+			Expect(output).To(ContainSubstring(namespace))
+			Expect(output).To(ContainSubstring("Basic (secret foobarbroker-auth)"))
+		})
 		It("Calls the SDK's WaitForBroker method with the passed in interval and timeout when Wait==true", func() {
 			interval := 1 * time.Second
 			timeout := 1 * time.Minute
@@ -307,6 +419,7 @@ var _ = Describe("Register Command", func() {
 				Context:    cxt,
 				BrokerName: brokerName,
 				Namespaced: command.NewNamespaced(cxt),
+				Scoped:     command.NewScoped(),
 				URL:        brokerURL,
 				Waitable:   command.NewWaitable(),
 			}
@@ -329,8 +442,9 @@ var _ = Describe("Register Command", func() {
 			Expect(*returnedOpts).To(Equal(opts))
 
 			Expect(fakeSDK.WaitForBrokerCallCount()).To(Equal(1))
-			waitName, waitInterval, waitTimeout := fakeSDK.WaitForBrokerArgsForCall(0)
+			waitName, waitScopeOpts, waitInterval, waitTimeout := fakeSDK.WaitForBrokerArgsForCall(0)
 			Expect(waitName).To(Equal(brokerName))
+			Expect(waitScopeOpts).To(Equal(servicecatalog.ScopeOptions{Namespace: namespace}))
 			Expect(waitInterval).To(Equal(interval))
 			Expect(*waitTimeout).To(Equal(timeout))
 
@@ -28,6 +28,7 @@ import (
 
 type describeCmd struct {
 	*command.Context
+	*command.Formatted
 	lookupByUUID bool
 	uuid         string
 	name         string
@@ -35,7 +36,10 @@ type describeCmd struct {
 
 // NewDescribeCmd builds a "svcat describe class" command
 func NewDescribeCmd(cxt *command.Context) *cobra.Command {
-	describeCmd := &describeCmd{Context: cxt}
+	describeCmd := &describeCmd{
+		Context:   cxt,
+		Formatted: command.NewFormatted(),
+	}
 	cmd := &cobra.Command{
 		Use:     "class NAME",
 		Aliases: []string{"classes", "cl"},
@@ -54,6 +58,7 @@ func NewDescribeCmd(cxt *command.Context) *cobra.Command {
 		false,
 		"Whether or not to get the class by UUID (the default is by name)",
 	)
+	describeCmd.AddOutputFlags(cmd.Flags())
 	return cmd
 }
 
@@ -87,6 +92,11 @@ func (c *describeCmd) describe() error {
 		return err
 	}
 
+	if c.OutputFormat != output.FormatTable {
+		output.WriteClass(c.Output, c.OutputFormat, *class)
+		return nil
+	}
+
 	output.WriteClassDetails(c.Output, class)
 
 	plans, err := c.App.RetrievePlans(servicecatalog.RetrievePlanOptions{Scope: servicecatalog.AllScope, ClassID: class.Name})
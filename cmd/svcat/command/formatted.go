@@ -17,7 +17,6 @@ limitations under the License.
 package command
 
 import (
-	"fmt"
 	"strings"
 
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/output"
@@ -46,19 +45,19 @@ func NewFormatted() *Formatted {
 // AddOutputFlags adds common output flags to a command that can have variable output formats.
 func (c *Formatted) AddOutputFlags(flags *pflag.FlagSet) {
 	flags.StringVarP(&c.OutputFormat, "output", "o", output.FormatTable,
-		"The output format to use. Valid options are table, json or yaml. If not present, defaults to table",
+		"The output format to use. Valid options are table, json, yaml, name, jsonpath=TEMPLATE, go-template=TEMPLATE or custom-columns=HEADER:JSONPATH,... If not present, defaults to table",
 	)
 }
 
 // ApplyFormatFlags persists the format-related flags:
 // * --output
 func (c *Formatted) ApplyFormatFlags(flags *pflag.FlagSet) error {
-	c.OutputFormat = strings.ToLower(c.OutputFormat)
-
-	switch c.OutputFormat {
-	case output.FormatTable, output.FormatJSON, output.FormatYAML:
-		return nil
-	default:
-		return fmt.Errorf("invalid --output format %q, allowed values are: table, json and yaml", c.OutputFormat)
+	// Only the name of the format is case insensitive, not its template
+	format, template := output.SplitFormat(c.OutputFormat)
+	c.OutputFormat = strings.ToLower(format)
+	if template != "" {
+		c.OutputFormat += "=" + template
 	}
+
+	return output.ValidateFormat(c.OutputFormat)
 }
@@ -51,15 +51,27 @@ func NewScoped() *Scoped {
 
 // AddScopedFlags adds the scope-related flags.
 // * --scope
+// The scope defaults to all scopes when allowAll is set, and to the cluster
+// level otherwise.
 func (c *Scoped) AddScopedFlags(flags *pflag.FlagSet, allowAll bool) {
 	c.allowAll = allowAll
 	if allowAll {
 		flags.StringVar(&c.rawScope, "scope", servicecatalog.AllScope, "Limit the results to a particular scope: cluster, namespace or all")
 	} else {
-		flags.StringVar(&c.rawScope, "scope", servicecatalog.NamespaceScope, "Limit the results to a particular scope: cluster, namespace")
+		flags.StringVar(&c.rawScope, "scope", servicecatalog.ClusterScope, "The scope of the resource: cluster or namespace")
 	}
 }
 
+// AddCreateScopedFlags adds the scope-related flags of a command that creates
+// a resource, and can also determine its scope from the resources it refers
+// to when all scopes are requested explicitly.
+// * --scope
+// The resource is created at the cluster level by default.
+func (c *Scoped) AddCreateScopedFlags(flags *pflag.FlagSet) {
+	c.allowAll = true
+	flags.StringVar(&c.rawScope, "scope", servicecatalog.ClusterScope, "The scope of the resource: cluster, namespace or all")
+}
+
 // ApplyScopedFlags persists the scope-related flags:
 // * --scope
 func (c *Scoped) ApplyScopedFlags(flags *pflag.FlagSet) error {
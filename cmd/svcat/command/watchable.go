@@ -0,0 +1,68 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// Watchable adds support to a command for the --watch flag.
+type Watchable struct {
+	Watch bool
+}
+
+// NewWatchable initializes a new watchable command.
+func NewWatchable() *Watchable {
+	return &Watchable{}
+}
+
+// AddWatchFlag adds the --watch flag.
+func (c *Watchable) AddWatchFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVarP(&c.Watch, "watch", "w", false,
+		"After listing the resources, watch for changes. Press Ctrl-C to stop watching.")
+}
+
+// WatchUntilInterrupted passes each change received from the watch to
+// handle, until the user presses Ctrl-C or the server closes the watch.
+func (c *Watchable) WatchUntilInterrupted(w watch.Interface, handle func(watch.Event)) error {
+	defer w.Stop()
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+
+	for {
+		select {
+		case <-interrupt:
+			return nil
+		case event, ok := <-w.ResultChan():
+			if !ok {
+				return nil
+			}
+			if event.Type == watch.Error {
+				return fmt.Errorf("watch failed (%s)", apierrors.FromObject(event.Object))
+			}
+			handle(event)
+		}
+	}
+}
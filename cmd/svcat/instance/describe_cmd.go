@@ -26,23 +26,29 @@ import (
 
 type describeCmd struct {
 	*command.Namespaced
+	*command.Formatted
 	name string
 }
 
 // NewDescribeCmd builds a "svcat describe instance" command
 func NewDescribeCmd(cxt *command.Context) *cobra.Command {
-	describeCmd := &describeCmd{Namespaced: command.NewNamespaced(cxt)}
+	describeCmd := &describeCmd{
+		Namespaced: command.NewNamespaced(cxt),
+		Formatted:  command.NewFormatted(),
+	}
 	cmd := &cobra.Command{
 		Use:     "instance NAME",
 		Aliases: []string{"instances", "inst"},
 		Short:   "Show details of a specific instance",
 		Example: command.NormalizeExamples(`
   svcat describe instance wordpress-mysql-instance
+  svcat describe instance wordpress-mysql-instance -o jsonpath='{.status.dashboardURL}'
 `),
 		PreRunE: command.PreRunE(describeCmd),
 		RunE:    command.RunE(describeCmd),
 	}
 	describeCmd.AddNamespaceFlags(cmd.Flags(), false)
+	describeCmd.AddOutputFlags(cmd.Flags())
 	return cmd
 }
 
@@ -65,6 +71,11 @@ func (c *describeCmd) describe() error {
 		return err
 	}
 
+	if c.OutputFormat != output.FormatTable {
+		output.WriteInstance(c.Output, c.OutputFormat, *instance)
+		return nil
+	}
+
 	output.WriteInstanceDetails(c.Output, instance)
 
 	bindings, err := c.App.RetrieveBindingsByInstance(instance)
@@ -73,5 +84,8 @@ func (c *describeCmd) describe() error {
 	}
 	output.WriteAssociatedBindings(c.Output, bindings)
 
+	timeline, err := c.App.RetrieveInstanceTimeline(instance)
+	output.WriteTimeline(c.Output, timeline, err)
+
 	return nil
 }
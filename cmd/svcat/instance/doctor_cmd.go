@@ -0,0 +1,76 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instance
+
+import (
+	"fmt"
+
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/output"
+	"github.com/spf13/cobra"
+)
+
+type doctorCmd struct {
+	*command.Namespaced
+	*command.Formatted
+	name string
+}
+
+// NewDoctorCmd builds a "svcat doctor instance" command
+func NewDoctorCmd(cxt *command.Context) *cobra.Command {
+	doctorCmd := &doctorCmd{
+		Namespaced: command.NewNamespaced(cxt),
+		Formatted:  command.NewFormatted(),
+	}
+	cmd := &cobra.Command{
+		Use:     "instance NAME",
+		Aliases: []string{"instances", "inst"},
+		Short:   "Diagnose why an instance is not ready",
+		Long: `Check an instance, and the class, plan and broker that it references, for
+the known failure modes reported by the controller. The problems found are
+listed most severe first, with the commands to run next.`,
+		Example: command.NormalizeExamples(`
+  svcat doctor instance wordpress-mysql-instance
+  svcat doctor instance wordpress-mysql-instance -n mynamespace -o json
+`),
+		PreRunE: command.PreRunE(doctorCmd),
+		RunE:    command.RunE(doctorCmd),
+	}
+	doctorCmd.AddNamespaceFlags(cmd.Flags(), false)
+	doctorCmd.AddOutputFlags(cmd.Flags())
+	return cmd
+}
+
+func (c *doctorCmd) Validate(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("an instance name is required")
+	}
+	c.name = args[0]
+
+	return nil
+}
+
+func (c *doctorCmd) Run() error {
+	diagnoses, err := c.App.DiagnoseInstance(c.Namespace, c.name)
+	if err != nil {
+		return err
+	}
+
+	resource := fmt.Sprintf("instance %s/%s", c.Namespace, c.name)
+	output.WriteDiagnoses(c.Output, c.OutputFormat, resource, diagnoses)
+	return nil
+}
@@ -21,7 +21,9 @@ import (
 
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/output"
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 type getCmd struct {
@@ -29,6 +31,7 @@ type getCmd struct {
 	*command.Formatted
 	*command.PlanFiltered
 	*command.ClassFiltered
+	*command.Watchable
 	name string
 }
 
@@ -39,6 +42,7 @@ func NewGetCmd(cxt *command.Context) *cobra.Command {
 		Formatted:     command.NewFormatted(),
 		ClassFiltered: command.NewClassFiltered(),
 		PlanFiltered:  command.NewPlanFiltered(),
+		Watchable:     command.NewWatchable(),
 	}
 	cmd := &cobra.Command{
 		Use:     "instances [NAME]",
@@ -49,6 +53,7 @@ func NewGetCmd(cxt *command.Context) *cobra.Command {
   svcat get instances --class redis
   svcat get instances --plan default
   svcat get instances --all-namespaces
+  svcat get instances --watch
   svcat get instance wordpress-mysql-instance
   svcat get instance -n ci concourse-postgres-instance
 `),
@@ -59,6 +64,7 @@ func NewGetCmd(cxt *command.Context) *cobra.Command {
 	getCmd.AddOutputFlags(cmd.Flags())
 	getCmd.AddClassFlag(cmd)
 	getCmd.AddPlanFlag(cmd)
+	getCmd.AddWatchFlag(cmd)
 
 	return cmd
 }
@@ -74,6 +80,10 @@ func (c *getCmd) Validate(args []string) error {
 		if c.PlanFilter != "" {
 			return fmt.Errorf("plan filter is not supported when specifiying instance name")
 		}
+
+		if c.Watch {
+			return fmt.Errorf("watch is not supported when specifiying instance name")
+		}
 	}
 
 	return nil
@@ -94,7 +104,19 @@ func (c *getCmd) getAll() error {
 	}
 
 	output.WriteInstanceList(c.Output, c.OutputFormat, instances)
-	return nil
+	if !c.Watch {
+		return nil
+	}
+
+	w, err := c.App.WatchInstances(c.Namespace, c.ClassFilter, c.PlanFilter, instances.ResourceVersion)
+	if err != nil {
+		return err
+	}
+	return c.WatchUntilInterrupted(w, func(event watch.Event) {
+		if instance, ok := event.Object.(*v1beta1.ServiceInstance); ok {
+			output.WriteInstanceChange(c.Output, c.OutputFormat, event.Type, *instance)
+		}
+	})
 }
 
 func (c *getCmd) get() error {
@@ -0,0 +1,75 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instance
+
+import (
+	"fmt"
+
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/output"
+	"github.com/spf13/cobra"
+)
+
+type historyCmd struct {
+	*command.Namespaced
+	*command.Formatted
+	name string
+}
+
+// NewHistoryCmd builds a "svcat history instance" command
+func NewHistoryCmd(cxt *command.Context) *cobra.Command {
+	historyCmd := &historyCmd{
+		Namespaced: command.NewNamespaced(cxt),
+		Formatted:  command.NewFormatted(),
+	}
+	cmd := &cobra.Command{
+		Use:     "instance NAME",
+		Aliases: []string{"instances", "inst"},
+		Short:   "List the provision, update and deprovision operations of an instance",
+		Long: `List the most recent provision, update and deprovision operations of an
+instance, with their start time, duration and result, followed by the operation
+in progress if there is one.`,
+		Example: command.NormalizeExamples(`
+  svcat history instance wordpress-mysql-instance
+  svcat history instance wordpress-mysql-instance -o yaml
+`),
+		PreRunE: command.PreRunE(historyCmd),
+		RunE:    command.RunE(historyCmd),
+	}
+	historyCmd.AddNamespaceFlags(cmd.Flags(), false)
+	historyCmd.AddOutputFlags(cmd.Flags())
+	return cmd
+}
+
+func (c *historyCmd) Validate(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("an instance name is required")
+	}
+	c.name = args[0]
+
+	return nil
+}
+
+func (c *historyCmd) Run() error {
+	instance, err := c.App.RetrieveInstance(c.Namespace, c.name)
+	if err != nil {
+		return err
+	}
+
+	output.WriteInstanceHistory(c.Output, c.OutputFormat, instance)
+	return nil
+}
@@ -27,6 +27,7 @@ import (
 
 type provisonCmd struct {
 	*command.Namespaced
+	*command.Scoped
 	*command.Waitable
 
 	instanceName string
@@ -38,12 +39,14 @@ type provisonCmd struct {
 	params       interface{}
 	rawSecrets   []string
 	secrets      map[string]string
+	dryRun       bool
 }
 
 // NewProvisionCmd builds a "svcat provision" command
 func NewProvisionCmd(cxt *command.Context) *cobra.Command {
 	provisionCmd := &provisonCmd{
 		Namespaced: command.NewNamespaced(cxt),
+		Scoped:     command.NewScoped(),
 		Waitable:   command.NewWaitable(),
 	}
 	cmd := &cobra.Command{
@@ -53,6 +56,8 @@ func NewProvisionCmd(cxt *command.Context) *cobra.Command {
   svcat provision wordpress-mysql-instance --class mysqldb --plan free -p location=eastus -p sslEnforcement=disabled
   svcat provision wordpress-mysql-instance --external-id a7c00676-4398-11e8-842f-0ed5f89f718b --class mysqldb --plan free
   svcat provision wordpress-mysql-instance --class mysqldb --plan free -s mysecret[dbparams]
+  svcat provision wordpress-mysql-instance --class mysqldb --plan free -p location=eastus --dry-run
+  svcat provision wordpress-mysql-instance --class mysqldb --plan free --scope namespace --namespace dev
   svcat provision secure-instance --class mysqldb --plan secureDB --params-json '{
     "encrypt" : true,
     "firewallRules" : [
@@ -68,6 +73,8 @@ func NewProvisionCmd(cxt *command.Context) *cobra.Command {
 		RunE:    command.RunE(provisionCmd),
 	}
 	provisionCmd.AddNamespaceFlags(cmd.Flags(), false)
+	provisionCmd.AddCreateScopedFlags(cmd.Flags())
+	cmd.Flags().Lookup("scope").Usage = "The scope of the class and plan: cluster, namespace or all. With all, the class is looked up in the namespace of the instance and at the cluster scope"
 	cmd.Flags().StringVar(&provisionCmd.externalID, "external-id", "",
 		"The ID of the instance for use with the OSB SB API (Optional)")
 	cmd.Flags().StringVar(&provisionCmd.className, "class", "",
@@ -82,6 +89,8 @@ func NewProvisionCmd(cxt *command.Context) *cobra.Command {
 		"Additional parameter, whose value is stored in a secret, to use when provisioning the service, format: SECRET[KEY]")
 	cmd.Flags().StringVar(&provisionCmd.jsonParams, "params-json", "",
 		"Additional parameters to use when provisioning the service, provided as a JSON object. Cannot be combined with --param")
+	cmd.Flags().BoolVar(&provisionCmd.dryRun, "dry-run", false,
+		"Validate the parameters against the plan's schema and print the request that would be sent to the broker, without provisioning the service. Parameter values read from secrets are redacted")
 	provisionCmd.AddWaitFlags(cmd)
 
 	return cmd
@@ -120,11 +129,28 @@ func (c *provisonCmd) Validate(args []string) error {
 }
 
 func (c *provisonCmd) Run() error {
+	if c.dryRun {
+		return c.DryRun()
+	}
 	return c.Provision()
 }
 
+// DryRun prints the provision request without creating the instance.
+func (c *provisonCmd) DryRun() error {
+	preview, err := c.App.DryRunProvision(c.Namespace, c.instanceName, c.externalID, c.className, c.planName, c.params, c.secrets, c.Scope, c.App.CurrentUser)
+	if err != nil {
+		return err
+	}
+
+	output.WriteProvisionPreview(c.Output, preview)
+	if len(preview.ValidationErrors) > 0 {
+		return fmt.Errorf("the parameters are not valid for plan '%s/%s'", c.className, c.planName)
+	}
+	return nil
+}
+
 func (c *provisonCmd) Provision() error {
-	instance, err := c.App.Provision(c.Namespace, c.instanceName, c.externalID, c.className, c.planName, c.params, c.secrets)
+	instance, err := c.App.Provision(c.Namespace, c.instanceName, c.externalID, c.className, c.planName, c.params, c.secrets, c.Scope)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,149 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instance
+
+import (
+	"fmt"
+
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/output"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/parameters"
+	"github.com/spf13/cobra"
+)
+
+type updateCmd struct {
+	*command.Namespaced
+	*command.Waitable
+
+	instanceName string
+	planName     string
+	rawParams    []string
+	jsonParams   string
+	params       interface{}
+	rawSecrets   []string
+	secrets      map[string]string
+}
+
+// NewUpdateCmd builds a "svcat update instance" command
+func NewUpdateCmd(cxt *command.Context) *cobra.Command {
+	updateCmd := &updateCmd{
+		Namespaced: command.NewNamespaced(cxt),
+		Waitable:   command.NewWaitable(),
+	}
+	cmd := &cobra.Command{
+		Use:   "instance NAME",
+		Short: "Change the plan or the parameters of an instance",
+		Long: `Update instance changes the plan of an instance, or replaces its parameters or
+the secrets its parameters are read from. The changes are printed before they
+are applied.`,
+		Example: command.NormalizeExamples(`
+  svcat update instance wordpress-mysql-instance --plan premium
+  svcat update instance wordpress-mysql-instance -p location=westus -p sslEnforcement=enabled
+  svcat update instance wordpress-mysql-instance -s mysecret[dbparams] --wait
+  svcat update instance wordpress-mysql-instance --params-json '{"encrypt": true}'
+`),
+		PreRunE: command.PreRunE(updateCmd),
+		RunE:    command.RunE(updateCmd),
+	}
+	updateCmd.AddNamespaceFlags(cmd.Flags(), false)
+	cmd.Flags().StringVar(&updateCmd.planName, "plan", "",
+		"The name of the plan to change the instance to. The class of the instance must allow plan changes")
+	cmd.Flags().StringSliceVarP(&updateCmd.rawParams, "param", "p", nil,
+		"Parameter that replaces the parameters of the instance, format: NAME=VALUE. Cannot be combined with --params-json, Sensitive information should be placed in a secret and specified with --secret")
+	cmd.Flags().StringSliceVarP(&updateCmd.rawSecrets, "secret", "s", nil,
+		"Parameter, whose value is stored in a secret, that replaces the parameters from secrets of the instance, format: SECRET[KEY]")
+	cmd.Flags().StringVar(&updateCmd.jsonParams, "params-json", "",
+		"Parameters that replace the parameters of the instance, provided as a JSON object. Cannot be combined with --param")
+	updateCmd.AddWaitFlags(cmd)
+
+	return cmd
+}
+
+func (c *updateCmd) Validate(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("an instance name is required")
+	}
+	c.instanceName = args[0]
+
+	if c.jsonParams != "" && len(c.rawParams) > 0 {
+		return fmt.Errorf("--params-json cannot be used with --param")
+	}
+
+	// Parameters that are not specified are left unchanged
+	if c.jsonParams != "" {
+		params, err := parameters.ParseVariableJSON(c.jsonParams)
+		if err != nil {
+			return fmt.Errorf("invalid --params-json value (%s)", err)
+		}
+		c.params = params
+	} else if len(c.rawParams) > 0 {
+		params, err := parameters.ParseVariableAssignments(c.rawParams)
+		if err != nil {
+			return fmt.Errorf("invalid --param value (%s)", err)
+		}
+		c.params = params
+	}
+
+	if len(c.rawSecrets) > 0 {
+		var err error
+		c.secrets, err = parameters.ParseKeyMaps(c.rawSecrets)
+		if err != nil {
+			return fmt.Errorf("invalid --secret value (%s)", err)
+		}
+	}
+
+	if c.planName == "" && c.params == nil && c.secrets == nil {
+		return fmt.Errorf("at least one of --plan, --param, --params-json or --secret is required")
+	}
+
+	return nil
+}
+
+func (c *updateCmd) Run() error {
+	return c.Update()
+}
+
+// Update prints the changes to the instance and applies them.
+func (c *updateCmd) Update() error {
+	instance, err := c.App.RetrieveInstance(c.Namespace, c.instanceName)
+	if err != nil {
+		return err
+	}
+	output.WriteInstanceChanges(c.Output, instance, c.planName, c.params, c.secrets)
+	fmt.Fprintln(c.Output)
+
+	instance, err = c.App.UpdateInstance(c.Namespace, c.instanceName, c.planName, c.params, c.secrets)
+	if err != nil {
+		return err
+	}
+
+	if c.Wait {
+		fmt.Fprintln(c.Output, "Waiting for the instance to be updated...")
+		finalInstance, err := c.App.WaitForInstance(instance.Namespace, instance.Name, c.Interval, c.Timeout)
+		if err == nil {
+			instance = finalInstance
+		}
+
+		// Always print the instance because the update did succeed,
+		// and just print any errors that occurred while polling
+		output.WriteInstanceDetails(c.Output, instance)
+		return err
+	}
+
+	output.WriteInstanceDetails(c.Output, instance)
+	return nil
+}
@@ -32,6 +32,8 @@ import (
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/completion"
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/instance"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/manifest"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/marketplace"
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/plan"
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/plugin"
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/versions"
@@ -91,7 +93,7 @@ func buildRootCommand(cxt *command.Context) *cobra.Command {
 
 			// Initialize the context if not already configured (by tests)
 			if cxt.App == nil {
-				k8sClient, svcatClient, namespace, err := getClients(opts.KubeConfig, opts.KubeContext)
+				k8sClient, svcatClient, namespace, restConfig, err := getClients(opts.KubeConfig, opts.KubeContext)
 				if err != nil {
 					return err
 				}
@@ -100,6 +102,7 @@ func buildRootCommand(cxt *command.Context) *cobra.Command {
 				if err != nil {
 					return err
 				}
+				app.CurrentUser = kube.GetUserInfo(restConfig)
 
 				cxt.App = app
 			}
@@ -118,6 +121,7 @@ func buildRootCommand(cxt *command.Context) *cobra.Command {
 	cmd.AddCommand(newCreateCmd(cxt))
 	cmd.AddCommand(newGetCmd(cxt))
 	cmd.AddCommand(newDescribeCmd(cxt))
+	cmd.AddCommand(marketplace.NewMarketplaceCmd(cxt))
 	cmd.AddCommand(broker.NewRegisterCmd(cxt))
 	cmd.AddCommand(broker.NewDeregisterCmd(cxt))
 	cmd.AddCommand(instance.NewProvisionCmd(cxt))
@@ -129,6 +133,11 @@ func buildRootCommand(cxt *command.Context) *cobra.Command {
 		cmd.AddCommand(newInstallCmd(cxt))
 	}
 	cmd.AddCommand(newTouchCmd(cxt))
+	cmd.AddCommand(newUpdateCmd(cxt))
+	cmd.AddCommand(newHistoryCmd(cxt))
+	cmd.AddCommand(newDoctorCmd(cxt))
+	cmd.AddCommand(manifest.NewExportCmd(cxt))
+	cmd.AddCommand(manifest.NewApplyCmd(cxt))
 	cmd.AddCommand(versions.NewVersionCmd(cxt))
 	cmd.AddCommand(newCompletionCmd(cxt))
 
@@ -162,6 +171,7 @@ func newGetCmd(cxt *command.Context) *cobra.Command {
 		Short: "List a resource, optionally filtered by name",
 	}
 	cmd.AddCommand(binding.NewGetCmd(cxt))
+	cmd.AddCommand(binding.NewGetCredentialsCmd(cxt))
 	cmd.AddCommand(broker.NewGetCmd(cxt))
 	cmd.AddCommand(class.NewGetCmd(cxt))
 	cmd.AddCommand(instance.NewGetCmd(cxt))
@@ -203,33 +213,61 @@ func newTouchCmd(cxt *command.Context) *cobra.Command {
 	return cmd
 }
 
+func newUpdateCmd(cxt *command.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update",
+		Short: "Update a resource",
+	}
+	cmd.AddCommand(instance.NewUpdateCmd(cxt))
+	return cmd
+}
+
+func newHistoryCmd(cxt *command.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "List the operations of a resource",
+	}
+	cmd.AddCommand(instance.NewHistoryCmd(cxt))
+	return cmd
+}
+
+func newDoctorCmd(cxt *command.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Diagnose why a resource is not ready",
+	}
+	cmd.AddCommand(binding.NewDoctorCmd(cxt))
+	cmd.AddCommand(broker.NewDoctorCmd(cxt))
+	cmd.AddCommand(instance.NewDoctorCmd(cxt))
+	return cmd
+}
+
 func newCompletionCmd(ctx *command.Context) *cobra.Command {
 	return completion.NewCompletionCmd(ctx)
 }
 
 // getClients loads api clients based on the plugin context if present, otherwise the specified kube config.
-func getClients(kubeConfig, kubeContext string) (k8sClient k8sclient.Interface, svcatClient svcatclient.Interface, namespaces string, err error) {
-	var restConfig *rest.Config
+func getClients(kubeConfig, kubeContext string) (k8sClient k8sclient.Interface, svcatClient svcatclient.Interface, namespaces string, restConfig *rest.Config, err error) {
 	var config clientcmd.ClientConfig
 
 	if plugin.IsPlugin() {
 		restConfig, config, err = pluginutils.InitClientAndConfig()
 		if err != nil {
-			return nil, nil, "", fmt.Errorf("could not get Kubernetes config from kubectl plugin context: %s", err)
+			return nil, nil, "", nil, fmt.Errorf("could not get Kubernetes config from kubectl plugin context: %s", err)
 		}
 	} else {
 		config = kube.GetConfig(kubeContext, kubeConfig)
 		restConfig, err = config.ClientConfig()
 		if err != nil {
-			return nil, nil, "", fmt.Errorf("could not get Kubernetes config for context %q: %s", kubeContext, err)
+			return nil, nil, "", nil, fmt.Errorf("could not get Kubernetes config for context %q: %s", kubeContext, err)
 		}
 	}
 
 	namespace, _, err := config.Namespace()
 	k8sClient, err = k8sclient.NewForConfig(restConfig)
 	if err != nil {
-		return nil, nil, "", err
+		return nil, nil, "", nil, err
 	}
 	svcatClient, err = svcatclient.NewForConfig(restConfig)
-	return k8sClient, svcatClient, namespace, nil
+	return k8sClient, svcatClient, namespace, restConfig, nil
 }
@@ -0,0 +1,132 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manifest
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/output"
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+	"github.com/spf13/cobra"
+)
+
+type applyCmd struct {
+	*command.Namespaced
+	filename    string
+	rawTimeout  string
+	timeout     *time.Duration
+	rawInterval string
+	interval    time.Duration
+}
+
+// NewApplyCmd builds a "svcat apply" command
+func NewApplyCmd(cxt *command.Context) *cobra.Command {
+	applyCmd := &applyCmd{Namespaced: command.NewNamespaced(cxt)}
+	cmd := &cobra.Command{
+		Use:   "apply -f FILENAME",
+		Short: "Create or update the instances and bindings of a manifest",
+		Long: `Create or update the instances and bindings of a manifest, such as one written
+by "svcat export", in a namespace.
+
+The instances are applied first, and the bindings are only applied once all the
+instances are ready. Existing instances are updated to the plan and parameters
+of the manifest, and existing bindings to its parameters. The rest of the spec
+of a binding cannot be updated, so it must match the manifest.`,
+		Example: command.NormalizeExamples(`
+  svcat apply -f dev.yaml --namespace preview-42
+  svcat apply -f dev.yaml --namespace preview-42 --timeout 30m
+`),
+		PreRunE: command.PreRunE(applyCmd),
+		RunE:    command.RunE(applyCmd),
+	}
+	cmd.Flags().StringVarP(&applyCmd.filename, "filename", "f", "",
+		"The manifest to apply, in yaml or json")
+	cmd.Flags().StringVar(&applyCmd.rawTimeout, "timeout", "5m",
+		"Timeout for the instances to become ready, specified in human readable format: 30s, 1m, 1h. Specify -1 to wait indefinitely.")
+	cmd.Flags().StringVar(&applyCmd.rawInterval, "interval", "1s",
+		"Poll interval for the instances to become ready, specified in human readable format: 30s, 1m, 1h")
+	applyCmd.AddNamespaceFlags(cmd.Flags(), false)
+	return cmd
+}
+
+func (c *applyCmd) Validate(args []string) error {
+	if c.filename == "" {
+		return fmt.Errorf("a manifest is required, specify it with --filename")
+	}
+
+	if c.rawTimeout != "-1" {
+		timeout, err := time.ParseDuration(c.rawTimeout)
+		if err != nil {
+			return fmt.Errorf("invalid --timeout value (%s)", err)
+		}
+		c.timeout = &timeout
+	}
+
+	interval, err := time.ParseDuration(c.rawInterval)
+	if err != nil {
+		return fmt.Errorf("invalid --interval value (%s)", err)
+	}
+	c.interval = interval
+
+	return nil
+}
+
+func (c *applyCmd) Run() error {
+	f, err := os.Open(c.filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	manifest, err := servicecatalog.ParseManifest(f)
+	if err != nil {
+		return err
+	}
+
+	for i := range manifest.Instances {
+		instance, result, err := c.App.ApplyInstance(c.Namespace, &manifest.Instances[i])
+		if err != nil {
+			return err
+		}
+		output.WriteApplyResult(c.Output, "instance", instance.Name, result)
+	}
+
+	if len(manifest.Instances) > 0 && len(manifest.Bindings) > 0 {
+		fmt.Fprintln(c.Output, "Waiting for the instances to be ready...")
+	}
+	for _, instance := range manifest.Instances {
+		finalInstance, err := c.App.WaitForInstance(c.Namespace, instance.Name, c.interval, c.timeout)
+		if err != nil {
+			return err
+		}
+		if !c.App.IsInstanceReady(finalInstance) {
+			return fmt.Errorf("instance '%s.%s' is not ready, the bindings were not applied", c.Namespace, instance.Name)
+		}
+	}
+
+	for i := range manifest.Bindings {
+		binding, result, err := c.App.ApplyBinding(c.Namespace, &manifest.Bindings[i])
+		if err != nil {
+			return err
+		}
+		output.WriteApplyResult(c.Output, "binding", binding.Name, result)
+	}
+	return nil
+}
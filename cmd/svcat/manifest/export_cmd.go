@@ -0,0 +1,76 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manifest
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/output"
+	"github.com/spf13/cobra"
+)
+
+type exportCmd struct {
+	*command.Namespaced
+	outputFormat string
+}
+
+// NewExportCmd builds a "svcat export" command
+func NewExportCmd(cxt *command.Context) *cobra.Command {
+	exportCmd := &exportCmd{Namespaced: command.NewNamespaced(cxt)}
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the instances and bindings of a namespace as a manifest",
+		Long: `Export the instances and bindings of a namespace as a manifest that can be
+applied to another namespace with "svcat apply".
+
+The status, the external IDs, the resolved references to classes and plans and
+the metadata generated by the server are not exported. Parameters from secrets
+are exported as references to the secrets, which must also exist in the
+namespace where the manifest is applied.`,
+		Example: command.NormalizeExamples(`
+  svcat export --namespace dev > dev.yaml
+  svcat export --namespace dev -o json
+`),
+		PreRunE: command.PreRunE(exportCmd),
+		RunE:    command.RunE(exportCmd),
+	}
+	cmd.Flags().StringVarP(&exportCmd.outputFormat, "output", "o", output.FormatYAML,
+		"The output format to use. Valid options are json or yaml. If not present, defaults to yaml")
+	exportCmd.AddNamespaceFlags(cmd.Flags(), false)
+	return cmd
+}
+
+func (c *exportCmd) Validate(args []string) error {
+	c.outputFormat = strings.ToLower(c.outputFormat)
+	switch c.outputFormat {
+	case output.FormatJSON, output.FormatYAML:
+		return nil
+	default:
+		return fmt.Errorf("invalid --output format %q, allowed values are: json and yaml", c.outputFormat)
+	}
+}
+
+func (c *exportCmd) Run() error {
+	manifest, err := c.App.ExportManifest(c.Namespace)
+	if err != nil {
+		return err
+	}
+
+	return output.WriteManifest(c.Output, c.outputFormat, manifest)
+}
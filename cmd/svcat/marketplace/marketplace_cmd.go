@@ -0,0 +1,91 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package marketplace
+
+import (
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/output"
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+	"github.com/spf13/cobra"
+)
+
+type marketplaceCmd struct {
+	*command.Namespaced
+	*command.Scoped
+	*command.Formatted
+	tags     []string
+	broker   string
+	freeOnly bool
+	search   string
+}
+
+// NewMarketplaceCmd builds a "svcat marketplace" command
+func NewMarketplaceCmd(cxt *command.Context) *cobra.Command {
+	marketplaceCmd := &marketplaceCmd{
+		Namespaced: command.NewNamespaced(cxt),
+		Scoped:     command.NewScoped(),
+		Formatted:  command.NewFormatted(),
+	}
+	cmd := &cobra.Command{
+		Use:     "marketplace",
+		Aliases: []string{"mp"},
+		Short:   "List the plans that can be provisioned, with their class and broker",
+		Example: command.NormalizeExamples(`
+  svcat marketplace
+  svcat marketplace --free
+  svcat marketplace --tag database --tag mysql
+  svcat marketplace --broker azure-broker --search backup
+  svcat marketplace --scope namespace --namespace dev
+`),
+		PreRunE: command.PreRunE(marketplaceCmd),
+		RunE:    command.RunE(marketplaceCmd),
+	}
+	cmd.Flags().StringSliceVar(&marketplaceCmd.tags, "tag", nil,
+		"If present, only list the classes that have the tag. May be repeated to require several tags")
+	cmd.Flags().StringVar(&marketplaceCmd.broker, "broker", "",
+		"If present, only list the classes offered by the broker")
+	cmd.Flags().BoolVar(&marketplaceCmd.freeOnly, "free", false,
+		"Only list the free plans")
+	cmd.Flags().StringVar(&marketplaceCmd.search, "search", "",
+		"If present, only list the classes and plans whose name, description or external metadata contain the keyword")
+	marketplaceCmd.AddOutputFlags(cmd.Flags())
+	marketplaceCmd.AddNamespaceFlags(cmd.Flags(), true)
+	marketplaceCmd.AddScopedFlags(cmd.Flags(), true)
+	return cmd
+}
+
+func (c *marketplaceCmd) Validate(args []string) error {
+	return nil
+}
+
+func (c *marketplaceCmd) Run() error {
+	opts := servicecatalog.MarketplaceOptions{
+		Namespace: c.Namespace,
+		Scope:     c.Scope,
+		Tags:      c.tags,
+		Broker:    c.broker,
+		FreeOnly:  c.freeOnly,
+		Search:    c.search,
+	}
+	offerings, err := c.App.RetrieveMarketplace(opts)
+	if err != nil {
+		return err
+	}
+
+	output.WriteMarketplace(c.Output, c.OutputFormat, offerings)
+	return nil
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"io"
 	"sort"
+	"strings"
 
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	svcatsdk "github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
@@ -46,41 +47,35 @@ func writeBindingListTable(w io.Writer, bindingList *v1beta1.ServiceBindingList)
 	})
 
 	for _, binding := range bindingList.Items {
-		t.Append([]string{
-			binding.Name,
-			binding.Namespace,
-			binding.Spec.ServiceInstanceRef.Name,
-			getBindingStatusShort(binding.Status),
-		})
+		t.Append(bindingListRow(binding, getBindingStatusShort(binding.Status)))
 	}
 	t.Render()
 }
 
+func bindingListRow(binding v1beta1.ServiceBinding, status string) []string {
+	return []string{
+		binding.Name,
+		binding.Namespace,
+		binding.Spec.ServiceInstanceRef.Name,
+		status,
+	}
+}
+
 // WriteBindingList prints a list of bindings in the specified output format.
 func WriteBindingList(w io.Writer, outputFormat string, bindingList *v1beta1.ServiceBindingList) {
-	switch outputFormat {
-	case FormatJSON:
-		writeJSON(w, bindingList)
-	case FormatYAML:
-		writeYAML(w, bindingList, 0)
-	case FormatTable:
+	writeFormatted(w, outputFormat, bindingList, func() {
 		writeBindingListTable(w, bindingList)
-	}
+	})
 }
 
 // WriteBinding prints a single bindings in the specified output format.
 func WriteBinding(w io.Writer, outputFormat string, binding v1beta1.ServiceBinding) {
-	switch outputFormat {
-	case FormatJSON:
-		writeJSON(w, binding)
-	case FormatYAML:
-		writeYAML(w, binding, 0)
-	case FormatTable:
+	writeFormatted(w, outputFormat, binding, func() {
 		l := v1beta1.ServiceBindingList{
 			Items: []v1beta1.ServiceBinding{binding},
 		}
 		writeBindingListTable(w, &l)
-	}
+	})
 }
 
 // WriteBindingDetails prints details for a single binding.
@@ -97,6 +92,44 @@ func WriteBindingDetails(w io.Writer, binding *v1beta1.ServiceBinding) {
 
 	writeParameters(w, binding.Spec.Parameters)
 	writeParametersFrom(w, binding.Spec.ParametersFrom)
+	writeBindingOutputs(w, binding.Spec.Outputs)
+}
+
+// writeBindingOutputs prints the Secrets and ConfigMaps that the credentials
+// of a binding are written to, in addition to its Secret.
+func writeBindingOutputs(w io.Writer, outputs []v1beta1.ServiceBindingOutput) {
+	if len(outputs) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w, "\nOutputs:")
+	t := NewListTable(w)
+	t.SetHeader([]string{
+		"Name",
+		"Kind",
+		"Type",
+		"Keys",
+	})
+	for _, output := range outputs {
+		keys := make([]string, 0, len(output.Keys))
+		for _, key := range output.Keys {
+			if key.TargetKey != "" && key.TargetKey != key.Key {
+				keys = append(keys, fmt.Sprintf("%s -> %s", key.Key, key.TargetKey))
+			} else {
+				keys = append(keys, key.Key)
+			}
+		}
+		if len(keys) == 0 {
+			keys = append(keys, "*")
+		}
+		t.Append([]string{
+			output.Name,
+			string(output.Kind),
+			output.SecretType,
+			strings.Join(keys, ", "),
+		})
+	}
+	t.Render()
 }
 
 // WriteAssociatedBindings prints a list of bindings associated with an instance.
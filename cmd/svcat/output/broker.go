@@ -17,7 +17,11 @@ limitations under the License.
 package output
 
 import (
+	"encoding/pem"
+	"fmt"
 	"io"
+	"strconv"
+	"strings"
 
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
@@ -61,37 +65,99 @@ func writeBrokerListTable(w io.Writer, brokers []servicecatalog.Broker) {
 
 // WriteBrokerList prints a list of brokers in the specified output format.
 func WriteBrokerList(w io.Writer, outputFormat string, brokers ...servicecatalog.Broker) {
-	switch outputFormat {
-	case FormatJSON:
-		writeJSON(w, brokers)
-	case FormatYAML:
-		writeYAML(w, brokers, 0)
-	case FormatTable:
+	writeFormatted(w, outputFormat, brokers, func() {
 		writeBrokerListTable(w, brokers)
-	}
+	})
 }
 
 // WriteBroker prints a broker in the specified output format.
-func WriteBroker(w io.Writer, outputFormat string, broker v1beta1.ClusterServiceBroker) {
-	switch outputFormat {
-	case FormatJSON:
-		writeJSON(w, broker)
-	case FormatYAML:
-		writeYAML(w, broker, 0)
-	case FormatTable:
-		writeBrokerListTable(w, []servicecatalog.Broker{&broker})
-	}
+func WriteBroker(w io.Writer, outputFormat string, broker servicecatalog.Broker) {
+	writeFormatted(w, outputFormat, broker, func() {
+		writeBrokerListTable(w, []servicecatalog.Broker{broker})
+	})
 }
 
 // WriteBrokerDetails prints details for a single broker.
 func WriteBrokerDetails(w io.Writer, broker servicecatalog.Broker) {
 	t := NewDetailsTable(w)
 
+	t.Append([]string{"Name:", broker.GetName()})
+	if ns := broker.GetNamespace(); ns != "" {
+		t.Append([]string{"Namespace:", ns})
+	}
+	spec := broker.GetSpec()
 	t.AppendBulk([][]string{
-		{"Name:", broker.GetName()},
 		{"URL:", broker.GetURL()},
 		{"Status:", getBrokerStatusFull(broker.GetStatus())},
+		{"Auth:", getBrokerAuth(broker)},
+		{"CA Bundle:", getBrokerCABundle(spec.CABundle)},
+		{"Skip TLS Verify:", strconv.FormatBool(spec.InsecureSkipTLSVerify)},
+		{"Relist:", getBrokerRelist(spec)},
 	})
+	if restrictions := spec.CatalogRestrictions; restrictions != nil {
+		t.AppendBulk([][]string{
+			{"Class Restrictions:", getBrokerRestrictions(restrictions.ServiceClass)},
+			{"Plan Restrictions:", getBrokerRestrictions(restrictions.ServicePlan)},
+		})
+	}
 
 	t.Render()
 }
+
+// getBrokerAuth describes the kind of auth of a broker, and the secret that
+// holds its credentials.
+func getBrokerAuth(broker servicecatalog.Broker) string {
+	switch b := broker.(type) {
+	case *v1beta1.ClusterServiceBroker:
+		auth := b.Spec.AuthInfo
+		switch {
+		case auth == nil:
+		case auth.Basic != nil && auth.Basic.SecretRef != nil:
+			return fmt.Sprintf("Basic (secret %s/%s)", auth.Basic.SecretRef.Namespace, auth.Basic.SecretRef.Name)
+		case auth.Bearer != nil && auth.Bearer.SecretRef != nil:
+			return fmt.Sprintf("Bearer (secret %s/%s)", auth.Bearer.SecretRef.Namespace, auth.Bearer.SecretRef.Name)
+		}
+	case *v1beta1.ServiceBroker:
+		auth := b.Spec.AuthInfo
+		switch {
+		case auth == nil:
+		case auth.Basic != nil && auth.Basic.SecretRef != nil:
+			return fmt.Sprintf("Basic (secret %s)", auth.Basic.SecretRef.Name)
+		case auth.Bearer != nil && auth.Bearer.SecretRef != nil:
+			return fmt.Sprintf("Bearer (secret %s)", auth.Bearer.SecretRef.Name)
+		}
+	}
+	return "None"
+}
+
+// getBrokerCABundle summarizes the CA bundle of a broker by the number of
+// certificates it holds.
+func getBrokerCABundle(caBundle []byte) string {
+	if len(caBundle) == 0 {
+		return "None"
+	}
+	count := 0
+	for block, rest := pem.Decode(caBundle); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type == "CERTIFICATE" {
+			count++
+		}
+	}
+	if count == 0 {
+		return fmt.Sprintf("%d bytes, no PEM certificate found", len(caBundle))
+	}
+	return fmt.Sprintf("%d certificate(s)", count)
+}
+
+func getBrokerRelist(spec v1beta1.CommonServiceBrokerSpec) string {
+	if spec.RelistBehavior == v1beta1.ServiceBrokerRelistBehaviorDuration && spec.RelistDuration != nil {
+		return fmt.Sprintf("%s, every %s", spec.RelistBehavior, spec.RelistDuration.Duration)
+	}
+	return string(spec.RelistBehavior)
+}
+
+func getBrokerRestrictions(restrictions []string) string {
+	if len(restrictions) == 0 {
+		return "None"
+	}
+	return strings.Join(restrictions, ", ")
+}
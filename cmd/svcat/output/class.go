@@ -54,26 +54,16 @@ func writeClassListTable(w io.Writer, classes []servicecatalog.Class) {
 
 // WriteClassList prints a list of classes in the specified output format.
 func WriteClassList(w io.Writer, outputFormat string, classes ...servicecatalog.Class) {
-	switch outputFormat {
-	case FormatJSON:
-		writeJSON(w, classes)
-	case FormatYAML:
-		writeYAML(w, classes, 0)
-	case FormatTable:
+	writeFormatted(w, outputFormat, classes, func() {
 		writeClassListTable(w, classes)
-	}
+	})
 }
 
 // WriteClass prints a single class in the specified output format.
 func WriteClass(w io.Writer, outputFormat string, class v1beta1.ClusterServiceClass) {
-	switch outputFormat {
-	case FormatJSON:
-		writeJSON(w, class)
-	case FormatYAML:
-		writeYAML(w, class, 0)
-	case FormatTable:
+	writeFormatted(w, outputFormat, class, func() {
 		writeClassListTable(w, []servicecatalog.Class{&class})
-	}
+	})
 }
 
 // WriteClassDetails prints details for a single class.
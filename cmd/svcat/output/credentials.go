@@ -0,0 +1,112 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// FormatEnv is the --output flag value to print credentials as shell
+	// export statements.
+	FormatEnv = "env"
+
+	// FormatDotenv is the --output flag value to print credentials as a
+	// .env file.
+	FormatDotenv = "dotenv"
+
+	// FormatProperties is the --output flag value to print credentials as a
+	// Java .properties file.
+	FormatProperties = "properties"
+)
+
+// WriteCredentials prints the credentials of a binding in the specified
+// output format, sorted by key.
+func WriteCredentials(w io.Writer, outputFormat string, credentials map[string]string) {
+	if outputFormat == FormatJSON {
+		writeJSON(w, credentials)
+		fmt.Fprintln(w)
+		return
+	}
+
+	keys := make([]string, 0, len(credentials))
+	for key := range credentials {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := credentials[key]
+		switch outputFormat {
+		case FormatEnv:
+			// Secret keys may contain '-' and '.', which shells do not accept
+			if len(validation.IsCIdentifier(key)) > 0 {
+				fmt.Fprintf(w, "# skipped %s, it is not a valid shell variable name\n", key)
+				continue
+			}
+			fmt.Fprintf(w, "export %s=%s\n", key, quoteShell(value))
+		case FormatDotenv:
+			fmt.Fprintf(w, "%s=%s\n", key, quoteDotenv(value))
+		case FormatProperties:
+			fmt.Fprintf(w, "%s=%s\n", escapeProperty(key, true), escapeProperty(value, false))
+		}
+	}
+}
+
+// quoteShell single-quotes a value, so that the shell does not expand it.
+func quoteShell(value string) string {
+	return "'" + strings.Replace(value, "'", `'"'"'`, -1) + "'"
+}
+
+// quoteDotenv double-quotes a value, escaping the characters that dotenv
+// parsers expand in double-quoted values.
+func quoteDotenv(value string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "$", `\$`)
+	return `"` + r.Replace(value) + `"`
+}
+
+// escapeProperty escapes a key or a value of a .properties file.
+func escapeProperty(s string, isKey bool) string {
+	var b strings.Builder
+	for i, c := range s {
+		switch c {
+		case '\\':
+			b.WriteString(`\\`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '=', ':', '#', '!', ' ':
+			// Only the first character of a value can be mistaken for a
+			// separator or a comment
+			if isKey || i == 0 {
+				b.WriteRune('\\')
+			}
+			b.WriteRune(c)
+		default:
+			b.WriteRune(c)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	_ "github.com/kubernetes-incubator/service-catalog/internal/test"
+)
+
+func TestWriteCredentials(t *testing.T) {
+	credentials := map[string]string{
+		"password":   "it's\n\"$ecret\"",
+		"key name":   " =value",
+		"broker-url": "http://broker:8080",
+	}
+
+	testcases := []struct {
+		name         string // Test name
+		outputFormat string // Format tested
+		output       string // Expected output
+	}{
+		{"env", FormatEnv,
+			"# skipped broker-url, it is not a valid shell variable name\n" +
+				"# skipped key name, it is not a valid shell variable name\n" +
+				"export password='it'\"'\"'s\n\"$ecret\"'\n"},
+		{"dotenv", FormatDotenv,
+			"broker-url=\"http://broker:8080\"\n" +
+				"key name=\" =value\"\n" +
+				"password=\"it's\\n\\\"\\$ecret\\\"\"\n"},
+		{"properties", FormatProperties,
+			"broker-url=http://broker:8080\n" +
+				"key\\ name=\\ =value\n" +
+				"password=it's\\n\"$ecret\"\n"},
+		{"json", FormatJSON,
+			"{\n" +
+				"   \"broker-url\": \"http://broker:8080\",\n" +
+				"   \"key name\": \" =value\",\n" +
+				"   \"password\": \"it's\\n\\\"$ecret\\\"\"\n" +
+				"}\n"},
+	}
+
+	for _, tc := range testcases {
+		output := &bytes.Buffer{}
+		WriteCredentials(output, tc.outputFormat, credentials)
+		if tc.output != output.String() {
+			t.Errorf("%v: Output mismatch: expected \"%v\", actual \"%v\"", tc.name, tc.output, output.String())
+		}
+	}
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	servicecatalog "github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// WriteInstanceChanges prints the changes that an update makes to the plan,
+// the parameters and the parameters from secrets of an instance. An empty
+// plan name and nil params or secrets leave them unchanged.
+func WriteInstanceChanges(w io.Writer, instance *v1beta1.ServiceInstance, planName string, params interface{}, secrets map[string]string) {
+	fmt.Fprintf(w, "Changes to instance %s/%s:\n", instance.Namespace, instance.Name)
+	changed := false
+
+	currentPlan := servicecatalog.CurrentPlanName(instance)
+	if planName != "" && planName != currentPlan {
+		fmt.Fprintf(w, "  Plan: %s -> %s\n", currentPlan, planName)
+		changed = true
+	}
+	if params != nil && writeParametersDiff(w, instance.Spec.Parameters, params) {
+		changed = true
+	}
+	if secrets != nil && writeParametersFromDiff(w, instance.Spec.ParametersFrom, secrets) {
+		changed = true
+	}
+
+	if !changed {
+		fmt.Fprintln(w, "  No changes")
+	}
+}
+
+// writeParametersDiff prints the parameters that are added, removed or
+// changed, and returns whether there are any.
+func writeParametersDiff(w io.Writer, current *runtime.RawExtension, params interface{}) bool {
+	before := map[string]interface{}{}
+	if current != nil && len(current.Raw) > 0 {
+		// Parameters that are not a json object are all shown as added
+		json.Unmarshal(current.Raw, &before)
+	}
+	after := map[string]interface{}{}
+	if raw, err := json.Marshal(params); err == nil {
+		json.Unmarshal(raw, &after)
+	}
+
+	keys := make([]string, 0, len(before)+len(after))
+	for k := range before {
+		keys = append(keys, k)
+	}
+	for k := range after {
+		if _, ok := before[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	var lines []string
+	for _, k := range keys {
+		oldValue, hadValue := before[k]
+		newValue, hasValue := after[k]
+		switch {
+		case !hasValue:
+			lines = append(lines, fmt.Sprintf("    - %s: %s", k, formatParameterValue(oldValue)))
+		case !hadValue:
+			lines = append(lines, fmt.Sprintf("    + %s: %s", k, formatParameterValue(newValue)))
+		case formatParameterValue(oldValue) != formatParameterValue(newValue):
+			lines = append(lines, fmt.Sprintf("    ~ %s: %s -> %s", k, formatParameterValue(oldValue), formatParameterValue(newValue)))
+		}
+	}
+	if len(lines) == 0 {
+		return false
+	}
+
+	fmt.Fprintln(w, "  Parameters:")
+	for _, line := range lines {
+		fmt.Fprintln(w, line)
+	}
+	return true
+}
+
+// writeParametersFromDiff prints the secrets that parameters are no longer
+// or newly read from, and returns whether there are any.
+func writeParametersFromDiff(w io.Writer, current []v1beta1.ParametersFromSource, secrets map[string]string) bool {
+	before := map[string]bool{}
+	for _, p := range current {
+		if p.SecretKeyRef != nil {
+			before[p.SecretKeyRef.Name+"."+p.SecretKeyRef.Key] = true
+		}
+	}
+	after := map[string]bool{}
+	for secret, key := range secrets {
+		after[secret+"."+key] = true
+	}
+
+	var removed, added []string
+	for ref := range before {
+		if !after[ref] {
+			removed = append(removed, ref)
+		}
+	}
+	for ref := range after {
+		if !before[ref] {
+			added = append(added, ref)
+		}
+	}
+	if len(removed) == 0 && len(added) == 0 {
+		return false
+	}
+	sort.Strings(removed)
+	sort.Strings(added)
+
+	fmt.Fprintln(w, "  Parameters From:")
+	for _, ref := range removed {
+		fmt.Fprintf(w, "    - Secret: %s\n", ref)
+	}
+	for _, ref := range added {
+		fmt.Fprintf(w, "    + Secret: %s\n", ref)
+	}
+	return true
+}
+
+// formatParameterValue formats the value of a parameter as json.
+func formatParameterValue(value interface{}) string {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(b)
+}
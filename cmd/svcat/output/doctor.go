@@ -0,0 +1,59 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+)
+
+// WriteDiagnoses prints the problems found on a resource and its parents,
+// most severe first, with the commands suggested for each of them.
+func WriteDiagnoses(w io.Writer, outputFormat string, resource string, diagnoses []servicecatalog.Diagnosis) {
+	if diagnoses == nil {
+		diagnoses = []servicecatalog.Diagnosis{}
+	}
+	writeFormatted(w, outputFormat, diagnoses, func() {
+		writeDiagnosesList(w, resource, diagnoses)
+	})
+}
+
+func writeDiagnosesList(w io.Writer, resource string, diagnoses []servicecatalog.Diagnosis) {
+	if len(diagnoses) == 0 {
+		fmt.Fprintf(w, "No problems found with %s\n", resource)
+		return
+	}
+
+	for i, d := range diagnoses {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "%d. [%s] %s\n", i+1, d.Severity, d.Resource)
+		fmt.Fprintf(w, "   %s\n", d.Problem)
+		if d.Reason != "" {
+			fmt.Fprintf(w, "   Reason: %s\n", d.Reason)
+		}
+		if len(d.Suggestions) > 0 {
+			fmt.Fprintln(w, "   Suggested commands:")
+			for _, s := range d.Suggestions {
+				fmt.Fprintf(w, "     %s\n", s)
+			}
+		}
+	}
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	_ "github.com/kubernetes-incubator/service-catalog/internal/test"
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+)
+
+func TestWriteDiagnoses(t *testing.T) {
+	diagnoses := []servicecatalog.Diagnosis{
+		{
+			Severity:    servicecatalog.SeverityError,
+			Resource:    "broker mybroker",
+			Reason:      "ErrorFetchingCatalog",
+			Problem:     "The catalog could not be fetched",
+			Suggestions: []string{"svcat describe broker mybroker", "svcat sync broker mybroker"},
+		},
+		{
+			Severity: servicecatalog.SeverityInfo,
+			Resource: "instance myns/myinstance",
+			Problem:  "Provision operation in progress",
+		},
+	}
+
+	testcases := []struct {
+		name         string                     // Test name
+		outputFormat string                     // Format tested
+		diagnoses    []servicecatalog.Diagnosis // Diagnoses printed
+		output       string                     // Expected output
+	}{
+		{"table", FormatTable, diagnoses,
+			"1. [Error] broker mybroker\n" +
+				"   The catalog could not be fetched\n" +
+				"   Reason: ErrorFetchingCatalog\n" +
+				"   Suggested commands:\n" +
+				"     svcat describe broker mybroker\n" +
+				"     svcat sync broker mybroker\n" +
+				"\n" +
+				"2. [Info] instance myns/myinstance\n" +
+				"   Provision operation in progress\n"},
+		{"no problems", FormatTable, nil,
+			"No problems found with instance myns/myinstance\n"},
+		{"severity by name", "jsonpath={[*].severity}", diagnoses,
+			"Error Info"},
+	}
+
+	for _, tc := range testcases {
+		output := &bytes.Buffer{}
+		WriteDiagnoses(output, tc.outputFormat, "instance myns/myinstance", tc.diagnoses)
+		if tc.output != output.String() {
+			t.Errorf("%v: Output mismatch: expected \"%v\", actual \"%v\"", tc.name, tc.output, output.String())
+		}
+	}
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	servicecatalog "github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+	osb "github.com/pmorie/go-open-service-broker-client/v2"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// WriteProvisionPreview prints the provision request of a dry-run provision
+// and any violation of the plan's schema.
+func WriteProvisionPreview(w io.Writer, preview *servicecatalog.ProvisionPreview) {
+	fmt.Fprintln(w, "Provision Request:")
+	writeRequest(w, preview.Request)
+	writeOriginatingIdentityNote(w, preview.Request.OriginatingIdentity)
+	writeValidationErrors(w, "instance create", preview.ValidationErrors)
+}
+
+// WriteBindPreview prints the bind request of a dry-run bind and any
+// violation of the plan's schema.
+func WriteBindPreview(w io.Writer, preview *servicecatalog.BindPreview) {
+	fmt.Fprintln(w, "Bind Request:")
+	writeRequest(w, preview.Request)
+	writeOriginatingIdentityNote(w, preview.Request.OriginatingIdentity)
+	writeValidationErrors(w, "binding create", preview.ValidationErrors)
+}
+
+// writeRequest prints an OSB request as json, without escaping the
+// redacted parameters.
+func writeRequest(w io.Writer, request interface{}) {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", strings.Repeat(" ", 3))
+	if err := enc.Encode(request); err != nil {
+		fmt.Fprintf(w, "err marshaling json: %v\n", err)
+	}
+}
+
+func writeOriginatingIdentityNote(w io.Writer, originatingIdentity *osb.OriginatingIdentity) {
+	if originatingIdentity == nil {
+		fmt.Fprintln(w, "\nThe originating identity is set by the API server and could not be determined from the current context.")
+	}
+}
+
+func writeValidationErrors(w io.Writer, schema string, errs field.ErrorList) {
+	if len(errs) == 0 {
+		fmt.Fprintf(w, "\nThe parameters satisfy the %s parameter schema of the plan.\n", schema)
+		return
+	}
+	fmt.Fprintf(w, "\nThe parameters do not satisfy the %s parameter schema of the plan:\n", schema)
+	for _, err := range errs {
+		fmt.Fprintf(w, "  %s\n", err.Error())
+	}
+}
@@ -19,6 +19,7 @@ package output
 import (
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"github.com/olekukonko/tablewriter"
@@ -50,6 +51,16 @@ func appendInstanceDashboardURL(status v1beta1.ServiceInstanceStatus, table *tab
 	}
 }
 
+func appendInstanceOperationRetry(status v1beta1.ServiceInstanceStatus, table *tablewriter.Table) {
+	if status.OperationRetry != nil {
+		retry := status.OperationRetry
+		table.AppendBulk([][]string{
+			{"Next Retry:", fmt.Sprintf("%s (after %d failed attempts)", retry.NextRetryTime.UTC(), retry.Attempts)},
+			{"Last Error:", retry.LastError},
+		})
+	}
+}
+
 func writeInstanceListTable(w io.Writer, instanceList *v1beta1.ServiceInstanceList) {
 	t := NewListTable(w)
 	t.SetHeader([]string{
@@ -61,43 +72,37 @@ func writeInstanceListTable(w io.Writer, instanceList *v1beta1.ServiceInstanceLi
 	})
 
 	for _, instance := range instanceList.Items {
-		t.Append([]string{
-			instance.Name,
-			instance.Namespace,
-			instance.Spec.GetSpecifiedClusterServiceClass(),
-			instance.Spec.GetSpecifiedClusterServicePlan(),
-			getInstanceStatusShort(instance.Status),
-		})
+		t.Append(instanceListRow(instance, getInstanceStatusShort(instance.Status)))
 	}
 
 	t.Render()
 }
 
+func instanceListRow(instance v1beta1.ServiceInstance, status string) []string {
+	return []string{
+		instance.Name,
+		instance.Namespace,
+		instance.Spec.GetSpecifiedClass(),
+		instance.Spec.GetSpecifiedPlan(),
+		status,
+	}
+}
+
 // WriteInstanceList prints a list of instances.
 func WriteInstanceList(w io.Writer, outputFormat string, instanceList *v1beta1.ServiceInstanceList) {
-	switch outputFormat {
-	case FormatJSON:
-		writeJSON(w, instanceList)
-	case FormatYAML:
-		writeYAML(w, instanceList, 0)
-	case FormatTable:
+	writeFormatted(w, outputFormat, instanceList, func() {
 		writeInstanceListTable(w, instanceList)
-	}
+	})
 }
 
 // WriteInstance prints a single instance
 func WriteInstance(w io.Writer, outputFormat string, instance v1beta1.ServiceInstance) {
-	switch outputFormat {
-	case FormatJSON:
-		writeJSON(w, instance)
-	case FormatYAML:
-		writeYAML(w, instance, 0)
-	case FormatTable:
+	writeFormatted(w, outputFormat, instance, func() {
 		p := v1beta1.ServiceInstanceList{
 			Items: []v1beta1.ServiceInstance{instance},
 		}
 		writeInstanceListTable(w, &p)
-	}
+	})
 }
 
 // WriteParentInstance prints identifying information for a parent instance.
@@ -144,13 +149,73 @@ func WriteInstanceDetails(w io.Writer, instance *v1beta1.ServiceInstance) {
 		{"Namespace:", instance.Namespace},
 		{"Status:", getInstanceStatusFull(instance.Status)},
 	})
+	appendInstanceOperationRetry(instance.Status, t)
 	appendInstanceDashboardURL(instance.Status, t)
 	t.AppendBulk([][]string{
-		{"Class:", instance.Spec.GetSpecifiedClusterServiceClass()},
-		{"Plan:", instance.Spec.GetSpecifiedClusterServicePlan()},
+		{"Class:", instance.Spec.GetSpecifiedClass()},
+		{"Plan:", instance.Spec.GetSpecifiedPlan()},
 	})
 	t.Render()
 
 	writeParameters(w, instance.Spec.Parameters)
 	writeParametersFrom(w, instance.Spec.ParametersFrom)
 }
+
+// WriteInstanceHistory prints the operations that were completed for an
+// instance, followed by the operation in progress if there is one.
+func WriteInstanceHistory(w io.Writer, outputFormat string, instance *v1beta1.ServiceInstance) {
+	writeFormatted(w, outputFormat, instance.Status.OperationHistory, func() {
+		writeInstanceHistoryTable(w, instance)
+	})
+}
+
+func writeInstanceHistoryTable(w io.Writer, instance *v1beta1.ServiceInstance) {
+	status := instance.Status
+	if len(status.OperationHistory) == 0 && status.CurrentOperation == "" {
+		fmt.Fprintln(w, "No operations recorded")
+		return
+	}
+
+	t := NewListTable(w)
+	t.SetHeader([]string{
+		"Operation",
+		"Started",
+		"Duration",
+		"Result",
+		"Reason",
+		"Message",
+	})
+	t.SetVariableColumn(6)
+	for _, record := range status.OperationHistory {
+		t.Append([]string{
+			string(record.Operation),
+			record.StartTime.UTC().String(),
+			record.CompletionTime.Sub(record.StartTime.Time).Round(time.Second).String(),
+			string(record.Result),
+			record.Reason,
+			record.Message,
+		})
+	}
+	if status.CurrentOperation != "" {
+		started, duration := "", ""
+		if status.OperationStartTime != nil {
+			started = status.OperationStartTime.UTC().String()
+			duration = time.Since(status.OperationStartTime.Time).Round(time.Second).String()
+		}
+		readyCond := v1beta1.ServiceInstanceCondition{}
+		for _, cond := range status.Conditions {
+			if cond.Type == v1beta1.ServiceInstanceConditionReady {
+				readyCond = cond
+			}
+		}
+		t.Append([]string{
+			string(status.CurrentOperation),
+			started,
+			duration,
+			"InProgress",
+			readyCond.Reason,
+			readyCond.Message,
+		})
+	}
+	t.Render()
+}
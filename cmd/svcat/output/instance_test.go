@@ -19,9 +19,11 @@ package output
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"github.com/olekukonko/tablewriter"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func Test_appendInstanceDashboardURL(t *testing.T) {
@@ -53,3 +55,35 @@ func Test_appendInstanceDashboardURL(t *testing.T) {
 		})
 	}
 }
+
+func Test_appendInstanceOperationRetry(t *testing.T) {
+	nextRetryTime := metav1.NewTime(time.Date(2018, time.January, 11, 21, 0, 15, 0, time.UTC))
+
+	tests := []struct {
+		name           string
+		status         v1beta1.ServiceInstanceStatus
+		expectedString string
+	}{
+		{"operationRetryOK", v1beta1.ServiceInstanceStatus{
+			OperationRetry: &v1beta1.ServiceInstanceOperationRetry{
+				Attempts:      3,
+				NextRetryTime: nextRetryTime,
+				LastError:     "connection refused",
+			},
+		}, "Next Retry:   2018-01-11 21:00:15 +0000 UTC (after 3 failed attempts)  \n  Last Error:   connection refused"},
+		{"operationRetryEmpty", v1beta1.ServiceInstanceStatus{}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stringBuilder strings.Builder
+			table := NewDetailsTable(&stringBuilder)
+			appendInstanceOperationRetry(tt.status, table)
+			table.Render()
+			actualString := strings.Trim(stringBuilder.String(), " \n")
+
+			if actualString != tt.expectedString {
+				t.Fatalf("%v failed; expected %q; got %q", tt.name, tt.expectedString, actualString)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+)
+
+// WriteManifest prints a manifest as a v1 List, in yaml or json.
+func WriteManifest(w io.Writer, outputFormat string, manifest *servicecatalog.Manifest) error {
+	list, err := manifest.List()
+	if err != nil {
+		return fmt.Errorf("unable to serialize the manifest (%s)", err)
+	}
+	writeFormatted(w, outputFormat, list, func() {})
+	return nil
+}
+
+// WriteApplyResult prints what applying a resource of a manifest did.
+func WriteApplyResult(w io.Writer, kind, name string, result servicecatalog.ApplyResult) {
+	fmt.Fprintf(w, "%s '%s' %s\n", kind, name, result)
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"io"
+	"strings"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+)
+
+func getOfferingCost(offering servicecatalog.Offering) string {
+	if offering.IsFree() {
+		return "free"
+	}
+	return "paid"
+}
+
+func getOfferingBindable(offering servicecatalog.Offering) string {
+	if offering.IsBindable() {
+		return "yes"
+	}
+	return "no"
+}
+
+// getOfferingDescription returns the description of the plan of an offering,
+// or of its class when the plan has none.
+func getOfferingDescription(offering servicecatalog.Offering) string {
+	if description := offering.Plan.GetDescription(); description != "" {
+		return description
+	}
+	return offering.Class.GetDescription()
+}
+
+func writeMarketplaceTable(w io.Writer, offerings []servicecatalog.Offering) {
+	t := NewListTable(w)
+	t.SetHeader([]string{
+		"Class",
+		"Plan",
+		"Namespace",
+		"Broker",
+		"Cost",
+		"Bindable",
+		"Tags",
+		"Description",
+	})
+	t.SetVariableColumn(8)
+
+	for _, offering := range offerings {
+		t.Append([]string{
+			offering.Class.GetExternalName(),
+			offering.Plan.GetExternalName(),
+			offering.Plan.GetNamespace(),
+			offering.Class.GetServiceBrokerName(),
+			getOfferingCost(offering),
+			getOfferingBindable(offering),
+			strings.Join(offering.Class.GetSpec().Tags, ", "),
+			getOfferingDescription(offering),
+		})
+	}
+
+	t.Render()
+}
+
+// WriteMarketplace prints the offerings of the marketplace in the specified
+// output format.
+func WriteMarketplace(w io.Writer, outputFormat string, offerings []servicecatalog.Offering) {
+	writeFormatted(w, outputFormat, offerings, func() {
+		writeMarketplaceTable(w, offerings)
+	})
+}
@@ -39,6 +39,22 @@ const (
 
 	// FormatYAML is the --output flag value for yaml output.
 	FormatYAML = "yaml"
+
+	// FormatName is the --output flag value to print the kind and the name of
+	// resources.
+	FormatName = "name"
+
+	// FormatJSONPath is the --output flag prefix for a JSONPath template:
+	// jsonpath=TEMPLATE.
+	FormatJSONPath = "jsonpath"
+
+	// FormatGoTemplate is the --output flag prefix for a Go template:
+	// go-template=TEMPLATE.
+	FormatGoTemplate = "go-template"
+
+	// FormatCustomColumns is the --output flag prefix for a table with custom
+	// columns: custom-columns=HEADER:JSONPATH,...
+	FormatCustomColumns = "custom-columns"
 )
 
 func formatStatusShort(condition string, conditionStatus v1beta1.ConditionStatus, reason string) string {
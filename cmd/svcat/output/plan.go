@@ -77,29 +77,18 @@ func WritePlanList(w io.Writer, outputFormat string, plans []servicecatalog.Plan
 	for _, class := range classes {
 		classNames[class.GetName()] = class.GetExternalName()
 	}
-	switch outputFormat {
-	case FormatJSON:
-		writeJSON(w, plans)
-	case FormatYAML:
-		writeYAML(w, plans, 0)
-	case FormatTable:
+	writeFormatted(w, outputFormat, plans, func() {
 		writePlanListTable(w, plans, classNames)
-	}
+	})
 }
 
 // WritePlan prints a single plan in the specified output format.
 func WritePlan(w io.Writer, outputFormat string, plan v1beta1.ClusterServicePlan, class v1beta1.ClusterServiceClass) {
-
-	switch outputFormat {
-	case FormatJSON:
-		writeJSON(w, plan)
-	case FormatYAML:
-		writeYAML(w, plan, 0)
-	case FormatTable:
+	writeFormatted(w, outputFormat, plan, func() {
 		classNames := map[string]string{}
 		classNames[class.Name] = class.Spec.ExternalName
 		writePlanListTable(w, []servicecatalog.Plan{&plan}, classNames)
-	}
+	})
 }
 
 // WriteAssociatedPlans prints a list of plans associated with a class.
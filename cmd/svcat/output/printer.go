@@ -0,0 +1,297 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/template"
+
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// printer prints resources, or lists of resources, with a template provided
+// by the user: jsonpath=TEMPLATE, go-template=TEMPLATE or
+// custom-columns=HEADER:JSONPATH,...
+type printer interface {
+	print(w io.Writer, obj interface{}) error
+}
+
+// ValidateFormat checks that an --output value is a supported format, and
+// that the template or the columns it holds can be parsed.
+func ValidateFormat(outputFormat string) error {
+	switch outputFormat {
+	case FormatTable, FormatJSON, FormatYAML, FormatName:
+		return nil
+	}
+	_, err := newPrinter(outputFormat)
+	return err
+}
+
+// SplitFormat splits an --output value into the name of the format and the
+// template or columns passed after '=', if any.
+func SplitFormat(outputFormat string) (string, string) {
+	parts := strings.SplitN(outputFormat, "=", 2)
+	if len(parts) == 1 {
+		return parts[0], ""
+	}
+	return parts[0], parts[1]
+}
+
+// writeFormatted prints a resource, or a list of resources, in the specified
+// output format. The table format is specific to each resource, so it is
+// printed by writeTable.
+func writeFormatted(w io.Writer, outputFormat string, obj interface{}, writeTable func()) {
+	var err error
+	switch outputFormat {
+	case FormatTable:
+		writeTable()
+	case FormatJSON:
+		writeJSON(w, obj)
+	case FormatYAML:
+		writeYAML(w, obj, 0)
+	case FormatName:
+		err = writeNames(w, obj)
+	default:
+		var p printer
+		p, err = newPrinter(outputFormat)
+		if err == nil {
+			err = p.print(w, obj)
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(w, "err printing %s: %v\n", outputFormat, err)
+	}
+}
+
+func newPrinter(outputFormat string) (printer, error) {
+	format, arg := SplitFormat(outputFormat)
+	switch format {
+	case FormatJSONPath:
+		return newJSONPathPrinter(arg)
+	case FormatGoTemplate:
+		return newGoTemplatePrinter(arg)
+	case FormatCustomColumns:
+		return newCustomColumnsPrinter(arg)
+	}
+	return nil, fmt.Errorf("invalid --output format %q, allowed values are: table, json, yaml, name, jsonpath=..., go-template=... and custom-columns=...", outputFormat)
+}
+
+type jsonPathPrinter struct {
+	path *jsonpath.JSONPath
+}
+
+func newJSONPathPrinter(text string) (*jsonPathPrinter, error) {
+	if text == "" {
+		return nil, fmt.Errorf("jsonpath template format specified but no template given")
+	}
+	path := jsonpath.New("output").AllowMissingKeys(true)
+	if err := path.Parse(text); err != nil {
+		return nil, fmt.Errorf("error parsing jsonpath %s (%s)", text, err)
+	}
+	return &jsonPathPrinter{path: path}, nil
+}
+
+func (p *jsonPathPrinter) print(w io.Writer, obj interface{}) error {
+	data, err := toData(obj)
+	if err != nil {
+		return err
+	}
+	return p.path.Execute(w, data)
+}
+
+type goTemplatePrinter struct {
+	template *template.Template
+}
+
+func newGoTemplatePrinter(text string) (*goTemplatePrinter, error) {
+	if text == "" {
+		return nil, fmt.Errorf("go-template format specified but no template given")
+	}
+	t, err := template.New("output").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing go-template %s (%s)", text, err)
+	}
+	return &goTemplatePrinter{template: t}, nil
+}
+
+func (p *goTemplatePrinter) print(w io.Writer, obj interface{}) error {
+	data, err := toData(obj)
+	if err != nil {
+		return err
+	}
+	return p.template.Execute(w, data)
+}
+
+type column struct {
+	header string
+	path   *jsonpath.JSONPath
+}
+
+type customColumnsPrinter struct {
+	columns []column
+}
+
+func newCustomColumnsPrinter(spec string) (*customColumnsPrinter, error) {
+	if spec == "" {
+		return nil, fmt.Errorf("custom-columns format specified but no custom columns given")
+	}
+	p := &customColumnsPrinter{}
+	for _, part := range strings.Split(spec, ",") {
+		colSpec := strings.SplitN(part, ":", 2)
+		if len(colSpec) != 2 || colSpec[0] == "" {
+			return nil, fmt.Errorf("unexpected custom-columns spec: %s, expected <header>:<json-path-expr>", part)
+		}
+		path := jsonpath.New(colSpec[0]).AllowMissingKeys(true)
+		if err := path.Parse(relaxedJSONPath(colSpec[1])); err != nil {
+			return nil, fmt.Errorf("error parsing the json path of column %s (%s)", colSpec[0], err)
+		}
+		p.columns = append(p.columns, column{header: colSpec[0], path: path})
+	}
+	return p, nil
+}
+
+// relaxedJSONPath accepts the json path of a column with or without the
+// surrounding braces and the leading dot, as kubectl does.
+func relaxedJSONPath(path string) string {
+	path = strings.TrimSuffix(strings.TrimPrefix(path, "{"), "}")
+	if !strings.HasPrefix(path, ".") {
+		path = "." + path
+	}
+	return "{" + path + "}"
+}
+
+func (p *customColumnsPrinter) print(w io.Writer, obj interface{}) error {
+	data, err := toData(obj)
+	if err != nil {
+		return err
+	}
+
+	t := NewListTable(w)
+	headers := make([]string, 0, len(p.columns))
+	for _, col := range p.columns {
+		headers = append(headers, col.header)
+	}
+	t.SetHeader(headers)
+
+	for _, item := range dataItems(data) {
+		row := make([]string, 0, len(p.columns))
+		for _, col := range p.columns {
+			value, err := findColumnValue(col.path, item)
+			if err != nil {
+				return err
+			}
+			row = append(row, value)
+		}
+		t.Append(row)
+	}
+	t.Render()
+	return nil
+}
+
+func findColumnValue(path *jsonpath.JSONPath, item interface{}) (string, error) {
+	results, err := path.FindResults(item)
+	if err != nil {
+		return "", err
+	}
+	var values []string
+	for _, result := range results {
+		for _, value := range result {
+			values = append(values, fmt.Sprint(value.Interface()))
+		}
+	}
+	if len(values) == 0 {
+		return "<none>", nil
+	}
+	return strings.Join(values, ","), nil
+}
+
+// writeNames prints the kind and the name of each resource, such as
+// serviceinstance/wordpress-mysql-instance.
+func writeNames(w io.Writer, obj interface{}) error {
+	for _, item := range objectItems(obj) {
+		data, err := toData(item)
+		if err != nil {
+			return err
+		}
+		kind := strings.ToLower(reflect.Indirect(reflect.ValueOf(item)).Type().Name())
+		var name string
+		if fields, ok := data.(map[string]interface{}); ok {
+			if metadata, ok := fields["metadata"].(map[string]interface{}); ok {
+				name, _ = metadata["name"].(string)
+			}
+		}
+		if name == "" {
+			return fmt.Errorf("%s does not have a name", kind)
+		}
+		fmt.Fprintf(w, "%s/%s\n", kind, name)
+	}
+	return nil
+}
+
+// objectItems returns the items of a list of resources, or the resource
+// itself when it is not a list.
+func objectItems(obj interface{}) []interface{} {
+	v := reflect.Indirect(reflect.ValueOf(obj))
+	if v.Kind() == reflect.Struct {
+		if items := v.FieldByName("Items"); items.IsValid() && items.Kind() == reflect.Slice {
+			v = items
+		}
+	}
+	if v.Kind() != reflect.Slice {
+		return []interface{}{obj}
+	}
+	items := make([]interface{}, 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		items = append(items, v.Index(i).Interface())
+	}
+	return items
+}
+
+// dataItems returns the items of a list of resources converted by toData,
+// or the resource itself when it is not a list.
+func dataItems(data interface{}) []interface{} {
+	switch d := data.(type) {
+	case []interface{}:
+		return d
+	case map[string]interface{}:
+		if items, ok := d["items"].([]interface{}); ok {
+			return items
+		}
+	}
+	return []interface{}{data}
+}
+
+// toData converts a resource to the generic maps and slices of its json
+// representation, so that templates use the json names of its fields.
+func toData(obj interface{}) (interface{}, error) {
+	j, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(j))
+	dec.UseNumber()
+	var data interface{}
+	if err := dec.Decode(&data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
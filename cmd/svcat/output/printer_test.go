@@ -0,0 +1,101 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	_ "github.com/kubernetes-incubator/service-catalog/internal/test"
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidateFormat(t *testing.T) {
+	testcases := []struct {
+		name         string // Test name
+		outputFormat string // Format tested
+		wantError    string // Expected error, empty when the format is valid
+	}{
+		{"Table", "table", ""},
+		{"Name", "name", ""},
+		{"JSONPath", "jsonpath={.metadata.name}", ""},
+		{"Go template", "go-template={{.metadata.name}}", ""},
+		{"Custom columns", "custom-columns=NAME:.metadata.name,UID:{.metadata.uid}", ""},
+		{"Unknown format", "xml", `invalid --output format "xml"`},
+		{"Missing jsonpath", "jsonpath", "no template given"},
+		{"Invalid jsonpath", "jsonpath={.metadata.name", "error parsing jsonpath"},
+		{"Invalid go template", "go-template={{.metadata.name}", "error parsing go-template"},
+		{"Column without header", "custom-columns=:.metadata.name", "unexpected custom-columns spec"},
+	}
+
+	for _, tc := range testcases {
+		err := ValidateFormat(tc.outputFormat)
+		if tc.wantError == "" {
+			if err != nil {
+				t.Errorf("%v: unexpected error: %v", tc.name, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tc.wantError) {
+			t.Errorf("%v: expected an error containing %q, got %v", tc.name, tc.wantError, err)
+		}
+	}
+}
+
+func TestWriteFormatted(t *testing.T) {
+	instances := &v1beta1.ServiceInstanceList{
+		Items: []v1beta1.ServiceInstance{
+			{ObjectMeta: v1.ObjectMeta{Name: "mysql", Namespace: "dev"}},
+			{ObjectMeta: v1.ObjectMeta{Name: "redis", Namespace: "prod"}},
+		},
+	}
+	brokers := []servicecatalog.Broker{
+		&v1beta1.ClusterServiceBroker{ObjectMeta: v1.ObjectMeta{Name: "global"}},
+		&v1beta1.ServiceBroker{ObjectMeta: v1.ObjectMeta{Name: "local", Namespace: "dev"}},
+	}
+	offerings := []servicecatalog.Offering{{}}
+
+	testcases := []struct {
+		name         string      // Test name
+		outputFormat string      // Format tested
+		obj          interface{} // Resource printed
+		output       string      // Expected output
+	}{
+		{"Names of a list", FormatName, instances, "serviceinstance/mysql\nserviceinstance/redis\n"},
+		{"Names of a slice", FormatName, brokers, "clusterservicebroker/global\nservicebroker/local\n"},
+		{"Names of a single resource", FormatName, instances.Items[0], "serviceinstance/mysql\n"},
+		{"Names of unnamed items", FormatName, offerings, "err printing name: offering does not have a name\n"},
+		{"JSONPath", "jsonpath={.items[*].metadata.name}", instances, "mysql redis"},
+		{"Go template", "go-template={{range .}}{{.metadata.name}};{{end}}", brokers, "global;local;"},
+		{"Custom columns with a relaxed path", "custom-columns=NAME:metadata.name,NAMESPACE:{.metadata.namespace}", brokers,
+			"   NAME    NAMESPACE  \n" +
+				"+--------+-----------+\n" +
+				"  global   <none>     \n" +
+				"  local    dev        \n"},
+	}
+
+	for _, tc := range testcases {
+		output := &bytes.Buffer{}
+		writeFormatted(output, tc.outputFormat, tc.obj, func() { t.Errorf("%v: unexpected table", tc.name) })
+		if tc.output != output.String() {
+			t.Errorf("%v: Output mismatch: expected \"%v\", actual \"%v\"", tc.name, tc.output, output.String())
+		}
+	}
+}
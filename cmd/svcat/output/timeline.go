@@ -0,0 +1,62 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+)
+
+// WriteTimeline prints the events and condition transitions of an instance
+// or a binding, from the oldest to the most recent.
+func WriteTimeline(w io.Writer, timeline []servicecatalog.TimelineEntry, err error) {
+	fmt.Fprintln(w, "\nTimeline:")
+	if err != nil {
+		// The timeline only helps troubleshooting, warn about it without
+		// blowing up the entire command.
+		fmt.Fprintf(w, "  %s\n", err.Error())
+		return
+	}
+	if len(timeline) == 0 {
+		fmt.Fprintln(w, "No events recorded")
+		return
+	}
+
+	t := NewListTable(w)
+	t.SetHeader([]string{
+		"Time",
+		"Type",
+		"Reason",
+		"Message",
+	})
+	t.SetVariableColumn(4)
+	for _, entry := range timeline {
+		reason := entry.Reason
+		if entry.Count > 1 {
+			reason = fmt.Sprintf("%s (x%d)", reason, entry.Count)
+		}
+		t.Append([]string{
+			entry.Time.UTC().String(),
+			entry.Type,
+			reason,
+			entry.Message,
+		})
+	}
+	t.Render()
+}
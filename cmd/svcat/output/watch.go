@@ -0,0 +1,75 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// WriteInstanceChange prints a change to an instance received while watching
+// the instances. Tables only print the row of the instance, below the list
+// printed before watching.
+func WriteInstanceChange(w io.Writer, outputFormat string, eventType watch.EventType, instance v1beta1.ServiceInstance) {
+	switch outputFormat {
+	case FormatTable:
+		status := getInstanceStatusShort(instance.Status)
+		if eventType == watch.Deleted {
+			status = "Deleted"
+		}
+		writeWatchRow(w, instanceListRow(instance, status))
+	default:
+		writeWatchObject(w, outputFormat, instance)
+	}
+}
+
+// WriteBindingChange prints a change to a binding received while watching
+// the bindings. Tables only print the row of the binding, below the list
+// printed before watching.
+func WriteBindingChange(w io.Writer, outputFormat string, eventType watch.EventType, binding v1beta1.ServiceBinding) {
+	switch outputFormat {
+	case FormatTable:
+		status := getBindingStatusShort(binding.Status)
+		if eventType == watch.Deleted {
+			status = "Deleted"
+		}
+		writeWatchRow(w, bindingListRow(binding, status))
+	default:
+		writeWatchObject(w, outputFormat, binding)
+	}
+}
+
+func writeWatchRow(w io.Writer, row []string) {
+	t := NewListTable(w)
+	t.Append(row)
+	t.Render()
+}
+
+// writeWatchObject separates each object from the previous output, as json
+// and yaml are printed without a trailing separator.
+func writeWatchObject(w io.Writer, outputFormat string, obj interface{}) {
+	switch outputFormat {
+	case FormatJSON:
+		fmt.Fprintln(w)
+	case FormatYAML:
+		fmt.Fprintln(w, "---")
+	}
+	writeFormatted(w, outputFormat, obj, func() {})
+}
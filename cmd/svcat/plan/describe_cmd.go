@@ -28,6 +28,7 @@ import (
 
 type describeCmd struct {
 	*command.Context
+	*command.Formatted
 	lookupByUUID bool
 	showSchemas  bool
 	uuid         string
@@ -36,7 +37,10 @@ type describeCmd struct {
 
 // NewDescribeCmd builds a "svcat describe plan" command
 func NewDescribeCmd(cxt *command.Context) *cobra.Command {
-	describeCmd := &describeCmd{Context: cxt}
+	describeCmd := &describeCmd{
+		Context:   cxt,
+		Formatted: command.NewFormatted(),
+	}
 	cmd := &cobra.Command{
 		Use:     "plan NAME",
 		Aliases: []string{"plans", "pl"},
@@ -62,6 +66,7 @@ func NewDescribeCmd(cxt *command.Context) *cobra.Command {
 		true,
 		"Whether or not to show instance and binding parameter schemas",
 	)
+	describeCmd.AddOutputFlags(cmd.Flags())
 	return cmd
 }
 
@@ -107,6 +112,11 @@ func (c *describeCmd) describe() error {
 		return err
 	}
 
+	if c.OutputFormat != output.FormatTable {
+		output.WritePlan(c.Output, c.OutputFormat, *plan, *class)
+		return nil
+	}
+
 	output.WritePlanDetails(c.Output, plan, class)
 
 	instances, err := c.App.RetrieveInstancesByPlan(plan)
@@ -158,6 +158,28 @@ func TestCommandValidation(t *testing.T) {
 		{"bind does not accept --param and --params-json",
 			`bind name --params-json '{}' --param k=v`,
 			"--params-json cannot be used with --param"},
+		{"update instance requires name", "update instance", "an instance name is required"},
+		{"history instance requires name", "history instance", "an instance name is required"},
+		{"update instance requires a change", "update instance name", "at least one of --plan, --param, --params-json or --secret is required"},
+		{"update instance does not accept --param and --params-json",
+			`update instance name --params-json '{}' --param k=v`,
+			"--params-json cannot be used with --param"},
+		{"get credentials requires name", "get credentials", "a binding name is required"},
+		{"get credentials --exec requires a command", "get credentials name --exec", "a command to run is required with --exec"},
+		{"get credentials requires a supported output format", "get credentials name -o yaml", "invalid --output format \"yaml\""},
+		{"doctor instance requires name", "doctor instance", "an instance name is required"},
+		{"doctor binding requires name", "doctor binding", "a binding name is required"},
+		{"doctor broker requires name", "doctor broker", "a broker name is required"},
+		{"register with basic username only", "register mybroker --url http://mybroker.com --basic-username admin", "--basic-username and --basic-password must be used together"},
+		{"register with basic and bearer auth", "register mybroker --url http://mybroker.com --basic-secret mysecret --bearer-token t0ken", "cannot use both basic auth and bearer auth"},
+		{"register with invalid scope", "register mybroker --url http://mybroker.com --scope all", "invalid --scope (all), allowed values are: cluster, namespace"},
+		{"get instance does not accept --watch", "get instance name --watch", "watch is not supported when specifiying instance name"},
+		{"get binding does not accept --watch", "get binding name --watch", "watch is not supported when specifiying binding name"},
+		{"apply requires a manifest", "apply", "a manifest is required"},
+		{"export only supports json and yaml", "export -o table", "invalid --output format \"table\""},
+		{"get requires a supported output format", "get instances -o xml", "invalid --output format \"xml\""},
+		{"jsonpath output requires a template", "get instances -o jsonpath=", "jsonpath template format specified but no template given"},
+		{"custom-columns output requires headers", "get instances -o custom-columns=.metadata.name", "unexpected custom-columns spec: .metadata.name"},
 		{"completion no shell specified", "completion", "Shell not specified"},
 		{"completion too many args", "completion arg0 arg1", "Too many arguments. Expected only the shell type"},
 		{"completion unsupported shell", "completion unsupportedShell", "Unsupported shell type \"unsupportedShell\""},
@@ -186,6 +208,7 @@ func TestCommandOutput(t *testing.T) {
 		{name: "get broker (json)", cmd: "get broker ups-broker -o json", golden: "output/get-broker.json"},
 		{name: "get broker (yaml)", cmd: "get broker ups-broker -o yaml", golden: "output/get-broker.yaml"},
 		{name: "describe broker", cmd: "describe broker ups-broker", golden: "output/describe-broker.txt"},
+		{name: "list all brokers (name)", cmd: "get brokers -o name", golden: "output/get-brokers-name.txt"},
 		{name: "register broker", cmd: "register ups-broker --url http://upsbroker.com", golden: "output/register-broker.txt"},
 		{name: "deregister broker", cmd: "deregister ups-broker", golden: "output/deregister-broker.txt"},
 
@@ -200,6 +223,10 @@ func TestCommandOutput(t *testing.T) {
 		{name: "describe class uuid", cmd: "describe class --uuid 4f6e6cf6-ffdd-425f-a2c7-3c9258ad2468", golden: "output/describe-class.txt"},
 		{name: "create class", cmd: "create class new-class --from user-provided-service", golden: "output/create-class.txt"},
 
+		{name: "list the marketplace", cmd: "marketplace", golden: "output/marketplace.txt"},
+		{name: "list the marketplace (json)", cmd: "marketplace -o json", golden: "output/marketplace.json"},
+		{name: "search the free plans of the marketplace", cmd: "marketplace --free --search another", golden: "output/marketplace-free-search.txt"},
+
 		{name: "list all plans", cmd: "get plans", golden: "output/get-plans.txt"},
 		{name: "list all plans (json)", cmd: "get plans -o json", golden: "output/get-plans.json"},
 		{name: "list all plans (yaml)", cmd: "get plans -o yaml", golden: "output/get-plans.yaml"},
@@ -229,21 +256,49 @@ func TestCommandOutput(t *testing.T) {
 		{name: "list all instances filtered by existing class", cmd: "get instances --all-namespaces --class user-provided-service", golden: "output/get-instances-all-namespaces-by-class.txt"},
 		{name: "list all instances filtered by not existing class", cmd: "get instances --all-namespaces --class wrong", golden: "output/get-instances-all-namespaces-by-wrong-class.txt"},
 		{name: "list all instances", cmd: "get instances --all-namespaces", golden: "output/get-instances-all-namespaces.txt"},
+		{name: "watch instances in a namespace", cmd: "get instances -n test-ns --watch", golden: "output/get-instances-watch.txt"},
+		{name: "watch instances in a namespace (yaml)", cmd: "get instances -n test-ns --watch -o yaml", golden: "output/get-instances-watch.yaml"},
+		{name: "watch all instances filtered by class", cmd: "get instances --all-namespaces --class user-provided-service --watch", golden: "output/get-instances-all-namespaces-by-class-watch.txt"},
 		{name: "get instance", cmd: "get instance ups-instance -n test-ns", golden: "output/get-instance.txt"},
 		{name: "get instance (json)", cmd: "get instance ups-instance -n test-ns -o json", golden: "output/get-instance.json"},
 		{name: "get instance (yaml)", cmd: "get instance ups-instance -n test-ns -o yaml", golden: "output/get-instance.yaml"},
 		{name: "describe instance", cmd: "describe instance ups-instance -n test-ns", golden: "output/describe-instance.txt"},
+		{name: "describe instance (jsonpath)", cmd: "describe instance ups-instance -n test-ns -o jsonpath={.status.conditions[0].reason}", golden: "output/describe-instance-jsonpath.txt"},
+		{name: "list all instances in a namespace (name)", cmd: "get instances -n test-ns -o name", golden: "output/get-instances-name.txt"},
+		{name: "list all instances (jsonpath)", cmd: `get instances --all-namespaces -o jsonpath={range.items[*]}{.metadata.namespace}/{.metadata.name}{"\n"}{end}`, golden: "output/get-instances-jsonpath.txt"},
+		{name: "list all instances (go-template)", cmd: `get instances --all-namespaces -o go-template={{range.items}}{{.metadata.name}}:{{.spec.clusterServicePlanExternalName}}{{"\n"}}{{end}}`, golden: "output/get-instances-go-template.txt"},
+		{name: "list all instances (custom-columns)", cmd: "get instances --all-namespaces -o custom-columns=NAME:.metadata.name,PLAN:.spec.clusterServicePlanExternalName,DASHBOARD:.status.dashboardURL", golden: "output/get-instances-custom-columns.txt"},
+		{name: "get instance (custom-columns)", cmd: "get instance ups-instance -n test-ns -o custom-columns=NAME:{.metadata.name},READY:{.status.conditions[?(@.type==\"Ready\")].status}", golden: "output/get-instance-custom-columns.txt"},
 		{name: "bind instance", cmd: "bind ups-instance --name ups-binding -n test-ns", golden: "output/bind-instance.txt"},
 		{name: "bind instance and wait", cmd: "bind ups-instance --name ups-binding -n test-ns --wait", golden: "output/bind-instance-and-wait.txt"},
+		{name: "bind instance (dry-run)", cmd: "bind ups-instance --name ups-binding -n test-ns --external-id 61a5d2b4-4415-4e22-a4b0-bbc1e4b2a09b -p type=admin -s ups-params[params] --dry-run", golden: "output/bind-instance-dry-run.txt"},
 		{name: "unbind instance", cmd: "unbind ups-instance -n test-ns", golden: "output/unbind-instance.txt"},
 		{name: "unbind instance and wait", cmd: "unbind ups-instance -n test-ns --wait", golden: "output/unbind-instance-and-wait.txt"},
 		{name: "provision instance", cmd: "provision ups-instance -n test-ns --class user-provided-service --plan default", golden: "output/provision-instance.txt"},
 		{name: "provision instance and wait", cmd: "provision ups-instance -n test-ns --class user-provided-service --plan default --wait", golden: "output/provision-instance-and-wait.txt"},
+		{name: "provision namespaced instance", cmd: "provision ups-instance -n default --class user-provided-service --plan user-provided-namespace-plan --scope namespace", golden: "output/provision-namespaced-instance.txt"},
+		{name: "provision instance (dry-run)", cmd: "provision ups-instance -n test-ns --class user-provided-service --plan default --external-id 7e2c42f3-6d94-4409-bb15-7610d60af544 -p location=eastus -s ups-params[params] --dry-run", golden: "output/provision-instance-dry-run.txt"},
 		{name: "deprovision instance", cmd: "deprovision ups-instance -n test-ns", golden: "output/deprovision-instance.txt"},
+		{name: "instance history", cmd: "history instance ups-instance -n test-ns", golden: "output/history-instance.txt"},
+		{name: "instance history (yaml)", cmd: "history instance ups-instance -n test-ns -o yaml", golden: "output/history-instance.yaml"},
+		{name: "update instance", cmd: "update instance ups-instance -n test-ns --plan premium -p param1=value2 -p param2=value3 -s ups-params[params]", golden: "output/update-instance.txt"},
+		{name: "update instance and wait", cmd: "update instance ups-instance -n test-ns --plan premium --wait", golden: "output/update-instance-and-wait.txt"},
+		{name: "export namespace", cmd: "export -n test-ns", golden: "output/export.yaml"},
+		{name: "export namespace (json)", cmd: "export -n test-ns -o json", golden: "output/export.json"},
+		{name: "apply manifest", cmd: "apply -f testdata/manifest.yaml -n test-ns", golden: "output/apply-manifest.txt"},
 		{name: "list all bindings in a namespace", cmd: "get bindings -n test-ns", golden: "output/get-bindings.txt"},
 		{name: "list all bindings in a namespace (json)", cmd: "get bindings -n test-ns -o json", golden: "output/get-bindings.json"},
 		{name: "list all bindings in a namespace (yaml)", cmd: "get bindings -n test-ns -o yaml", golden: "output/get-bindings.yaml"},
 		{name: "list all bindings", cmd: "get bindings --all-namespaces", golden: "output/get-bindings-all-namespaces.txt"},
+		{name: "watch bindings in a namespace", cmd: "get bindings -n test-ns --watch", golden: "output/get-bindings-watch.txt"},
+		{name: "get credentials", cmd: "get credentials ups-binding -n test-ns", golden: "output/get-credentials-env.txt"},
+		{name: "get credentials (dotenv)", cmd: "get credentials ups-binding -n test-ns -o dotenv", golden: "output/get-credentials-dotenv.txt"},
+		{name: "get credentials (json)", cmd: "get credentials ups-binding -n test-ns -o json", golden: "output/get-credentials.json"},
+		{name: "get credentials (properties)", cmd: "get credentials ups-binding -n test-ns -o properties", golden: "output/get-credentials.properties"},
+		{name: "doctor instance", cmd: "doctor instance ups-instance -n test-ns", golden: "output/doctor-instance.txt"},
+		{name: "doctor instance (json)", cmd: "doctor instance ups-instance -n test-ns -o json", golden: "output/doctor-instance.json"},
+		{name: "doctor binding", cmd: "doctor binding ups-binding -n test-ns", golden: "output/doctor-binding.txt"},
+		{name: "doctor broker", cmd: "doctor broker ups-broker", golden: "output/doctor-broker.txt"},
 		{name: "get binding", cmd: "get binding ups-binding -n test-ns", golden: "output/get-binding.txt"},
 		{name: "get binding (json)", cmd: "get binding ups-binding -n test-ns -o json", golden: "output/get-binding.json"},
 		{name: "get binding (yaml)", cmd: "get binding ups-binding -n test-ns -o yaml", golden: "output/get-binding.yaml"},
@@ -315,6 +370,9 @@ func TestNamespacedCommands(t *testing.T) {
 		{name: "deprovision with flag namespace", cmd: "deprovision NAME --namespace " + flagNS, wantNS: flagNS},
 		{name: "deprovision with context namespace", cmd: "deprovision NAME", wantNS: contextNS},
 
+		{name: "update instance with flag namespace", cmd: "update instance NAME --plan PLAN --namespace " + flagNS, wantNS: flagNS},
+		{name: "update instance with context namespace", cmd: "update instance NAME --plan PLAN", wantNS: contextNS},
+
 		{name: "bind with flag namespace", cmd: "bind NAME --namespace " + flagNS, wantNS: flagNS},
 		{name: "bind with context namespace", cmd: "bind NAME", wantNS: contextNS},
 
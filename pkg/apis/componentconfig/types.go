@@ -109,4 +109,40 @@ type ControllerManagerConfiguration struct {
 	ClusterIDConfigMapName string
 	// ClusterIDConfigMapNamespace is the k8s namespace that the clusterid configmap will be stored in.
 	ClusterIDConfigMapNamespace string
+
+	// AutoUpgradeInstances enables updating instances to the newest version
+	// of their plan whenever the broker reports one in the maintenance info
+	// of the plan.
+	AutoUpgradeInstances bool
+
+	// BrokerCircuitBreakerThreshold is the number of consecutive failed
+	// requests to a broker after which the controller stops sending requests
+	// to the broker until it answers a catalog request again. Zero disables
+	// the circuit breaker.
+	BrokerCircuitBreakerThreshold int
+
+	// BrokerCircuitBreakerProbeInterval is the interval on which the catalog
+	// of a broker whose circuit is open is requested to check whether the
+	// broker has recovered.
+	BrokerCircuitBreakerProbeInterval time.Duration
+
+	// BindingRotationGracePeriod is how long the previous credentials of a
+	// binding are kept at the broker after a rotation has written the new
+	// credentials into the secret of the binding, so that the workloads
+	// using them have time to pick up the new ones.
+	BindingRotationGracePeriod time.Duration
+
+	// InstanceDriftCheckInterval is the minimum interval between two
+	// requests fetching an instance from its broker to check whether the
+	// instance has drifted from its spec. Zero disables the drift checks.
+	InstanceDriftCheckInterval time.Duration
+
+	// TracingExporter is where the spans of the reconciliations and of the
+	// requests to the brokers are exported: "stdout", "file", or empty to
+	// disable tracing.
+	TracingExporter string
+
+	// TracingFile is the path of the file the spans are written to by the
+	// "file" tracing exporter.
+	TracingFile string
 }
@@ -105,6 +105,12 @@ type CommonServiceBrokerSpec struct {
 	// CatalogRestrictions is a set of restrictions on which of a broker's services
 	// and plans have resources created for them.
 	CatalogRestrictions *CatalogRestrictions
+
+	// RequestLimits limits the rate and the concurrency of the requests that
+	// the controller sends to the broker. Requests are not limited when
+	// RequestLimits is unset.
+	// +optional
+	RequestLimits *BrokerRequestLimits
 }
 
 // CatalogRestrictions is a set of restrictions on which of a broker's services
@@ -160,6 +166,25 @@ type CatalogRestrictions struct {
 	ServicePlan []string
 }
 
+// BrokerRequestLimits is a set of limits on the requests sent to a broker.
+type BrokerRequestLimits struct {
+	// QPS is the maximum average number of requests per second sent to the
+	// broker. Zero means that the rate of requests is not limited.
+	// +optional
+	QPS int32
+
+	// Burst is the maximum number of requests sent to the broker at once when
+	// the rate of requests is limited. Defaults to QPS.
+	// +optional
+	Burst int32
+
+	// MaxInFlightRequests is the maximum number of requests sent to the broker
+	// that may await a response at the same time. Zero means that the number
+	// of requests is not limited.
+	// +optional
+	MaxInFlightRequests int32
+}
+
 // ClusterServiceBrokerSpec represents a description of a Broker.
 type ClusterServiceBrokerSpec struct {
 	CommonServiceBrokerSpec
@@ -333,6 +358,10 @@ const (
 	// ServiceBrokerConditionFailed represents information about a final failure
 	// that should not be retried.
 	ServiceBrokerConditionFailed ServiceBrokerConditionType = "Failed"
+
+	// ServiceBrokerConditionDegraded represents the fact that requests to a
+	// given broker are suspended because too many of them failed.
+	ServiceBrokerConditionDegraded ServiceBrokerConditionType = "Degraded"
 )
 
 // ConditionStatus represents a condition's status.
@@ -445,6 +474,13 @@ type CommonServiceClassSpec struct {
 	// its endpoint is supported for all plans.
 	BindingRetrievable bool
 
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// InstancesRetrievable indicates whether fetching a service instance via
+	// a GET on its endpoint is supported for all plans.
+	InstancesRetrievable bool
+
 	// PlanUpdatable indicates whether instances provisioned from this
 	// ServiceClass may change ServicePlans after being provisioned.
 	PlanUpdatable bool
@@ -582,6 +618,24 @@ type CommonServicePlanSpec struct {
 	// the instance are merged with these defaults, with instance-defined
 	// parameters taking precedence over defaults.
 	DefaultProvisionParameters *runtime.RawExtension
+
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// MaintenanceInfo is the version of the plan reported by the broker.
+	// Instances provisioned from an earlier version of the plan can be
+	// upgraded to this version.
+	MaintenanceInfo *MaintenanceInfo
+}
+
+// MaintenanceInfo describes the version of a plan, which brokers change to
+// indicate that instances of the plan should be upgraded.
+type MaintenanceInfo struct {
+	// Version is the version of the plan, as a semantic version.
+	Version string
+
+	// Description describes the changes of this version of the plan.
+	Description string
 }
 
 // ClusterServicePlanSpec represents details about the ClusterServicePlan
@@ -891,6 +945,14 @@ type ServiceInstanceStatus struct {
 	// DefaultProvisionParameters are the default parameters applied to this
 	// instance.
 	DefaultProvisionParameters *runtime.RawExtension
+
+	// OperationRetry is the backoff state of a Provision or Update operation
+	// that failed with an error which will be retried.
+	OperationRetry *ServiceInstanceOperationRetry
+
+	// OperationHistory records the most recent operations that were completed
+	// for the instance, oldest first. At most 10 operations are kept.
+	OperationHistory []ServiceInstanceOperationRecord
 }
 
 // ServiceInstanceCondition contains condition information about an Instance.
@@ -929,6 +991,15 @@ const (
 	// ServiceInstanceConditionOrphanMitigation represents information about an
 	// orphan mitigation that is required after failed provisioning.
 	ServiceInstanceConditionOrphanMitigation ServiceInstanceConditionType = "OrphanMitigation"
+
+	// ServiceInstanceConditionDrifted represents information about whether the
+	// plan and parameters reported by the broker for a ServiceInstance differ
+	// from the ones last sent to the broker.
+	ServiceInstanceConditionDrifted ServiceInstanceConditionType = "Drifted"
+
+	// ServiceInstanceConditionUpgradeAvailable represents information about
+	// whether a newer version of the plan of a ServiceInstance is available.
+	ServiceInstanceConditionUpgradeAvailable ServiceInstanceConditionType = "UpgradeAvailable"
 )
 
 // ServiceInstanceOperation represents a type of operation the controller can
@@ -947,6 +1018,64 @@ const (
 	ServiceInstanceOperationDeprovision ServiceInstanceOperation = "Deprovision"
 )
 
+// ServiceInstanceOperationRetry is the state of the exponential backoff
+// between attempts of an operation on a ServiceInstance.
+type ServiceInstanceOperationRetry struct {
+	// Generation is the 'Generation' of the serviceInstanceSpec that the
+	// operation was attempted for. The backoff is reset when the generation
+	// changes.
+	Generation int64
+
+	// Attempts is the number of attempts of the operation that failed.
+	Attempts int32
+
+	// NextRetryTime is the earliest time at which the operation will be
+	// attempted again.
+	NextRetryTime metav1.Time
+
+	// LastError is the error returned by the last attempt of the operation.
+	LastError string
+}
+
+// ServiceInstanceOperationResult is the outcome of an operation on a
+// ServiceInstance.
+type ServiceInstanceOperationResult string
+
+const (
+	// ServiceInstanceOperationSucceeded indicates that the operation
+	// succeeded.
+	ServiceInstanceOperationSucceeded ServiceInstanceOperationResult = "Succeeded"
+	// ServiceInstanceOperationFailed indicates that the operation failed
+	// with an error that will not be retried.
+	ServiceInstanceOperationFailed ServiceInstanceOperationResult = "Failed"
+	// ServiceInstanceOperationSuperseded indicates that the operation was
+	// abandoned to start another operation, because the spec of the instance
+	// changed or the instance was deleted before the operation completed.
+	ServiceInstanceOperationSuperseded ServiceInstanceOperationResult = "Superseded"
+)
+
+// ServiceInstanceOperationRecord describes a completed operation on a
+// ServiceInstance.
+type ServiceInstanceOperationRecord struct {
+	// Operation is the operation that was performed.
+	Operation ServiceInstanceOperation
+
+	// StartTime is the time at which the operation started.
+	StartTime metav1.Time
+
+	// CompletionTime is the time at which the operation completed.
+	CompletionTime metav1.Time
+
+	// Result is the outcome of the operation.
+	Result ServiceInstanceOperationResult
+
+	// Reason is a brief machine-readable explanation of the result.
+	Reason string
+
+	// Message is a human-readable description of the result.
+	Message string
+}
+
 // ServiceInstancePropertiesState is the state of a ServiceInstance that
 // the ServiceBroker knows about.
 type ServiceInstancePropertiesState struct {
@@ -978,6 +1107,10 @@ type ServiceInstancePropertiesState struct {
 
 	// UserInfo is information about the user that made the request.
 	UserInfo *UserInfo
+
+	// MaintenanceInfo is the version of the plan that the broker knows this
+	// ServiceInstance to run.
+	MaintenanceInfo *MaintenanceInfo
 }
 
 // ServiceInstanceDeprovisionStatus is the status of deprovisioning a
@@ -1042,8 +1175,9 @@ type ServiceBinding struct {
 // ServiceBindingSpec represents the desired state of a
 // ServiceBinding.
 //
-// The spec field cannot be changed after a ServiceBinding is
-// created.  Changes submitted to the spec field will be ignored.
+// Only the parameters and the rotation requests in the spec field can be
+// changed after a ServiceBinding is created. Changes submitted to the rest of
+// the spec field will be ignored.
 type ServiceBindingSpec struct {
 	// ServiceInstanceRef is the reference to the Instance this ServiceBinding is to.
 	//
@@ -1079,9 +1213,16 @@ type ServiceBindingSpec struct {
 	// by the broker before they are inserted into the Secret
 	SecretTransforms []SecretTransform
 
+	// List of additional Secrets and ConfigMaps that the credentials, after
+	// the SecretTransforms are applied, are written to
+	// +optional
+	Outputs []ServiceBindingOutput
+
 	// ExternalID is the identity of this object for use with the OSB API.
 	//
-	// Immutable.
+	// Immutable, except that the API server replaces it with a new identity
+	// when RotationRequests is incremented, or when the parameters of a bound
+	// ServiceBinding are changed.
 	ExternalID string
 
 	// Currently, this field is ALPHA: it may change or disappear at any time
@@ -1092,6 +1233,14 @@ type ServiceBindingSpec struct {
 	// settable by the end-user. User-provided values for this field are not saved.
 	// +optional
 	UserInfo *UserInfo
+
+	// RotationRequests is a strictly increasing, non-negative integer counter
+	// that can be manually incremented by a user to rotate the credentials of
+	// the ServiceBinding. The controller binds again with a new ExternalID,
+	// writes the new credentials into the Secret, and unbinds the previous
+	// credentials once they have been retired for the rotation grace period.
+	// +optional
+	RotationRequests int64
 }
 
 // ServiceBindingStatus represents the current status of a ServiceBinding.
@@ -1141,6 +1290,25 @@ type ServiceBindingStatus struct {
 
 	// UnbindStatus describes what has been done to unbind a ServiceBinding
 	UnbindStatus ServiceBindingUnbindStatus
+
+	// RetiredBindings are the previous bindings with the broker whose
+	// credentials were replaced by a rotation and that still have to be
+	// unbound.
+	RetiredBindings []RetiredServiceBinding
+}
+
+// RetiredServiceBinding is a previous binding with the broker of a
+// ServiceBinding whose credentials were rotated.
+type RetiredServiceBinding struct {
+	// ExternalID is the identity of the previous binding for use with the
+	// OSB API.
+	ExternalID string
+
+	// RetiredTime is the time at which the credentials of the previous
+	// binding were replaced in the Secret. It is not set while the new
+	// credentials are being bound, and the previous binding is unbound once
+	// the rotation grace period has elapsed since this time.
+	RetiredTime *metav1.Time
 }
 
 // ServiceBindingCondition condition information for a ServiceBinding.
@@ -1194,6 +1362,31 @@ const (
 	FinalizerServiceCatalog string = "kubernetes-incubator/service-catalog"
 )
 
+// These are annotations of a ServiceBinding that restart the workloads using
+// its credentials when they change.
+const (
+	// ServiceBindingRestartWorkloadsAnnotation lists the workloads to restart
+	// when the credentials of the ServiceBinding change, as a comma-separated
+	// list of <kind>/<name> in the namespace of the ServiceBinding, where kind
+	// is deployment, statefulset or daemonset. For example:
+	//     deployment/web,statefulset/worker
+	ServiceBindingRestartWorkloadsAnnotation string = "servicecatalog.k8s.io/restart-workloads"
+	// ServiceBindingRestartWorkloadsSelectorAnnotation is a label selector of
+	// the Deployments, StatefulSets and DaemonSets in the namespace of the
+	// ServiceBinding to restart when its credentials change.
+	ServiceBindingRestartWorkloadsSelectorAnnotation string = "servicecatalog.k8s.io/restart-workloads-selector"
+	// RestartAllowedServiceBindingsAnnotation is set on a workload to allow
+	// the ServiceBindings it lists, as a comma-separated list of names in the
+	// namespace of the workload, to restart it. A workload that does not list
+	// a ServiceBinding is not restarted by it, as the ServiceBinding could
+	// otherwise be used to restart workloads its author cannot update.
+	RestartAllowedServiceBindingsAnnotation string = "servicecatalog.k8s.io/restart-allowed-bindings"
+	// CredentialsChecksumAnnotation is set on the pod template of the
+	// restarted workloads to the checksum of the credentials that were
+	// written, which rolls out new pods.
+	CredentialsChecksumAnnotation string = "servicecatalog.k8s.io/credentials-checksum"
+)
+
 // ServiceBindingPropertiesState is the state of a
 // ServiceBinding that the ServiceBroker knows about.
 type ServiceBindingPropertiesState struct {
@@ -1272,10 +1465,15 @@ type ClusterObjectReference struct {
 // SecretTransform is a single transformation of the credentials returned
 // from the broker
 type SecretTransform struct {
-	RenameKey   *RenameKeyTransform
-	AddKey      *AddKeyTransform
-	AddKeysFrom *AddKeysFromTransform
-	RemoveKey   *RemoveKeyTransform
+	RenameKey    *RenameKeyTransform
+	AddKey       *AddKeyTransform
+	AddKeysFrom  *AddKeysFromTransform
+	RemoveKey    *RemoveKeyTransform
+	Template     *TemplateTransform
+	Flatten      *FlattenTransform
+	Base64Decode *Base64DecodeTransform
+	Base64Encode *Base64EncodeTransform
+	OnlyKeys     *OnlyKeysTransform
 }
 
 // RenameKeyTransform specifies that one of the credentials keys returned
@@ -1305,3 +1503,66 @@ type AddKeysFromTransform struct {
 type RemoveKeyTransform struct {
 	Key string
 }
+
+// TemplateTransform specifies that Service Catalog should add an entry to
+// the Secret associated with the ServiceBinding, whose value is the result of
+// a Go template executed with the credentials.
+type TemplateTransform struct {
+	Key      string
+	Template string
+}
+
+// FlattenTransform specifies that nested JSON credentials should be
+// replaced with one entry per nested value.
+type FlattenTransform struct {
+	Key       string
+	Separator string
+}
+
+// Base64DecodeTransform specifies that the value of one of the credentials
+// keys should be decoded from base64.
+type Base64DecodeTransform struct {
+	Key string
+}
+
+// Base64EncodeTransform specifies that the value of one of the credentials
+// keys should be encoded in base64.
+type Base64EncodeTransform struct {
+	Key string
+}
+
+// OnlyKeysTransform specifies that only some of the credentials keys should
+// be included in the credentials Secret.
+type OnlyKeysTransform struct {
+	Keys []string
+}
+
+// ServiceBindingOutputKind is the kind of object that an output of a
+// ServiceBinding writes the credentials to.
+type ServiceBindingOutputKind string
+
+const (
+	// ServiceBindingOutputKindSecret writes the credentials to a Secret.
+	ServiceBindingOutputKindSecret ServiceBindingOutputKind = "Secret"
+
+	// ServiceBindingOutputKindConfigMap writes the credentials to a
+	// ConfigMap. It should only be used for non-sensitive values.
+	ServiceBindingOutputKindConfigMap ServiceBindingOutputKind = "ConfigMap"
+)
+
+// ServiceBindingOutput specifies an additional Secret or ConfigMap that
+// Service Catalog should write some of the credentials of a ServiceBinding
+// to.
+type ServiceBindingOutput struct {
+	Name       string
+	Kind       ServiceBindingOutputKind
+	SecretType string
+	Keys       []ServiceBindingOutputKey
+}
+
+// ServiceBindingOutputKey selects a credentials key to write to an output of
+// a ServiceBinding.
+type ServiceBindingOutputKey struct {
+	Key       string
+	TargetKey string
+}
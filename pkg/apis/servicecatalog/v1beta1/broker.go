@@ -55,3 +55,13 @@ func (b *ClusterServiceBroker) GetStatus() CommonServiceBrokerStatus {
 func (b *ServiceBroker) GetStatus() CommonServiceBrokerStatus {
 	return b.Status.CommonServiceBrokerStatus
 }
+
+// GetSpec returns the spec that is common to brokers of both scopes.
+func (b *ClusterServiceBroker) GetSpec() CommonServiceBrokerSpec {
+	return b.Spec.CommonServiceBrokerSpec
+}
+
+// GetSpec returns the spec that is common to brokers of both scopes.
+func (b *ServiceBroker) GetSpec() CommonServiceBrokerSpec {
+	return b.Spec.CommonServiceBrokerSpec
+}
@@ -55,3 +55,23 @@ func (c *ClusterServiceClass) GetDescription() string {
 func (c *ServiceClass) GetDescription() string {
 	return c.Spec.Description
 }
+
+// GetServiceBrokerName returns the name of the broker that offers the class.
+func (c *ClusterServiceClass) GetServiceBrokerName() string {
+	return c.Spec.ClusterServiceBrokerName
+}
+
+// GetServiceBrokerName returns the name of the broker that offers the class.
+func (c *ServiceClass) GetServiceBrokerName() string {
+	return c.Spec.ServiceBrokerName
+}
+
+// GetSpec returns the spec that is common to classes of both scopes.
+func (c *ClusterServiceClass) GetSpec() CommonServiceClassSpec {
+	return c.Spec.CommonServiceClassSpec
+}
+
+// GetSpec returns the spec that is common to classes of both scopes.
+func (c *ServiceClass) GetSpec() CommonServiceClassSpec {
+	return c.Spec.CommonServiceClassSpec
+}
@@ -65,3 +65,13 @@ func (p *ClusterServicePlan) GetClassID() string {
 func (p *ServicePlan) GetClassID() string {
 	return p.Spec.ServiceClassRef.Name
 }
+
+// GetSpec returns the spec that is common to plans of both scopes.
+func (p *ClusterServicePlan) GetSpec() CommonServicePlanSpec {
+	return p.Spec.CommonServicePlanSpec
+}
+
+// GetSpec returns the spec that is common to plans of both scopes.
+func (p *ServicePlan) GetSpec() CommonServicePlanSpec {
+	return p.Spec.CommonServicePlanSpec
+}
@@ -131,6 +131,24 @@ func (pr PlanReference) GetSpecifiedServicePlan() string {
 	return ""
 }
 
+// GetSpecifiedClass returns the user-specified class value from either the
+// cluster-scoped or the namespaced class fields.
+func (pr PlanReference) GetSpecifiedClass() string {
+	if pr.ClusterServiceClassSpecified() {
+		return pr.GetSpecifiedClusterServiceClass()
+	}
+	return pr.GetSpecifiedServiceClass()
+}
+
+// GetSpecifiedPlan returns the user-specified plan value from either the
+// cluster-scoped or the namespaced plan fields.
+func (pr PlanReference) GetSpecifiedPlan() string {
+	if pr.ClusterServicePlanSpecified() {
+		return pr.GetSpecifiedClusterServicePlan()
+	}
+	return pr.GetSpecifiedServicePlan()
+}
+
 // GetClusterServiceClassFilterFieldName returns the appropriate field name for filtering
 // a list of service catalog classes by the PlanReference.
 func (pr PlanReference) GetClusterServiceClassFilterFieldName() string {
@@ -126,6 +126,12 @@ type CommonServiceBrokerSpec struct {
 	// and plans have resources created for them.
 	// +optional
 	CatalogRestrictions *CatalogRestrictions `json:"catalogRestrictions,omitempty"`
+
+	// RequestLimits limits the rate and the concurrency of the requests that
+	// the controller sends to the broker. Requests are not limited when
+	// RequestLimits is unset.
+	// +optional
+	RequestLimits *BrokerRequestLimits `json:"requestLimits,omitempty"`
 }
 
 // CatalogRestrictions is a set of restrictions on which of a broker's services
@@ -181,6 +187,25 @@ type CatalogRestrictions struct {
 	ServicePlan []string `json:"servicePlan,omitempty"`
 }
 
+// BrokerRequestLimits is a set of limits on the requests sent to a broker.
+type BrokerRequestLimits struct {
+	// QPS is the maximum average number of requests per second sent to the
+	// broker. Zero means that the rate of requests is not limited.
+	// +optional
+	QPS int32 `json:"qps,omitempty"`
+
+	// Burst is the maximum number of requests sent to the broker at once when
+	// the rate of requests is limited. Defaults to QPS.
+	// +optional
+	Burst int32 `json:"burst,omitempty"`
+
+	// MaxInFlightRequests is the maximum number of requests sent to the broker
+	// that may await a response at the same time. Zero means that the number
+	// of requests is not limited.
+	// +optional
+	MaxInFlightRequests int32 `json:"maxInFlightRequests,omitempty"`
+}
+
 // ClusterServiceBrokerSpec represents a description of a Broker.
 type ClusterServiceBrokerSpec struct {
 	CommonServiceBrokerSpec `json:",inline"`
@@ -354,6 +379,10 @@ const (
 	// ServiceBrokerConditionFailed represents information about a final failure
 	// that should not be retried.
 	ServiceBrokerConditionFailed ServiceBrokerConditionType = "Failed"
+
+	// ServiceBrokerConditionDegraded represents the fact that requests to a
+	// given broker are suspended because too many of them failed.
+	ServiceBrokerConditionDegraded ServiceBrokerConditionType = "Degraded"
 )
 
 // ConditionStatus represents a condition's status.
@@ -485,6 +514,13 @@ type CommonServiceClassSpec struct {
 	// its endpoint is supported for all plans.
 	BindingRetrievable bool `json:"bindingRetrievable"`
 
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// InstancesRetrievable indicates whether fetching a service instance via
+	// a GET on its endpoint is supported for all plans.
+	InstancesRetrievable bool `json:"instancesRetrievable,omitempty"`
+
 	// PlanUpdatable indicates whether instances provisioned from this
 	// ServiceClass may change ServicePlans after being
 	// provisioned.
@@ -640,6 +676,24 @@ type CommonServicePlanSpec struct {
 	// the instance are merged with these defaults, with instance-defined
 	// parameters taking precedence over defaults.
 	DefaultProvisionParameters *runtime.RawExtension `json:"defaultProvisionParameters,omitempty"`
+
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// MaintenanceInfo is the version of the plan reported by the broker.
+	// Instances provisioned from an earlier version of the plan can be
+	// upgraded to this version.
+	MaintenanceInfo *MaintenanceInfo `json:"maintenanceInfo,omitempty"`
+}
+
+// MaintenanceInfo describes the version of a plan, which brokers change to
+// indicate that instances of the plan should be upgraded.
+type MaintenanceInfo struct {
+	// Version is the version of the plan, as a semantic version.
+	Version string `json:"version"`
+
+	// Description describes the changes of this version of the plan.
+	Description string `json:"description,omitempty"`
 }
 
 // ClusterServicePlanSpec represents details about a ClusterServicePlan.
@@ -980,6 +1034,14 @@ type ServiceInstanceStatus struct {
 	// DefaultProvisionParameters are the default parameters applied to this
 	// instance.
 	DefaultProvisionParameters *runtime.RawExtension `json:"defaultProvisionParameters,omitempty"`
+
+	// OperationRetry is the backoff state of a Provision or Update operation
+	// that failed with an error which will be retried.
+	OperationRetry *ServiceInstanceOperationRetry `json:"operationRetry,omitempty"`
+
+	// OperationHistory records the most recent operations that were completed
+	// for the instance, oldest first. At most 10 operations are kept.
+	OperationHistory []ServiceInstanceOperationRecord `json:"operationHistory,omitempty"`
 }
 
 // ServiceInstanceCondition contains condition information about an Instance.
@@ -1018,6 +1080,15 @@ const (
 	// ServiceInstanceConditionOrphanMitigation represents information about an
 	// orphan mitigation that is required after failed provisioning.
 	ServiceInstanceConditionOrphanMitigation ServiceInstanceConditionType = "OrphanMitigation"
+
+	// ServiceInstanceConditionDrifted represents information about whether the
+	// plan and parameters reported by the broker for a ServiceInstance differ
+	// from the ones last sent to the broker.
+	ServiceInstanceConditionDrifted ServiceInstanceConditionType = "Drifted"
+
+	// ServiceInstanceConditionUpgradeAvailable represents information about
+	// whether a newer version of the plan of a ServiceInstance is available.
+	ServiceInstanceConditionUpgradeAvailable ServiceInstanceConditionType = "UpgradeAvailable"
 )
 
 // ServiceInstanceOperation represents a type of operation the controller can
@@ -1036,6 +1107,64 @@ const (
 	ServiceInstanceOperationDeprovision ServiceInstanceOperation = "Deprovision"
 )
 
+// ServiceInstanceOperationRetry is the state of the exponential backoff
+// between attempts of an operation on a ServiceInstance.
+type ServiceInstanceOperationRetry struct {
+	// Generation is the 'Generation' of the serviceInstanceSpec that the
+	// operation was attempted for. The backoff is reset when the generation
+	// changes.
+	Generation int64 `json:"generation"`
+
+	// Attempts is the number of attempts of the operation that failed.
+	Attempts int32 `json:"attempts"`
+
+	// NextRetryTime is the earliest time at which the operation will be
+	// attempted again.
+	NextRetryTime metav1.Time `json:"nextRetryTime"`
+
+	// LastError is the error returned by the last attempt of the operation.
+	LastError string `json:"lastError,omitempty"`
+}
+
+// ServiceInstanceOperationResult is the outcome of an operation on a
+// ServiceInstance.
+type ServiceInstanceOperationResult string
+
+const (
+	// ServiceInstanceOperationSucceeded indicates that the operation
+	// succeeded.
+	ServiceInstanceOperationSucceeded ServiceInstanceOperationResult = "Succeeded"
+	// ServiceInstanceOperationFailed indicates that the operation failed
+	// with an error that will not be retried.
+	ServiceInstanceOperationFailed ServiceInstanceOperationResult = "Failed"
+	// ServiceInstanceOperationSuperseded indicates that the operation was
+	// abandoned to start another operation, because the spec of the instance
+	// changed or the instance was deleted before the operation completed.
+	ServiceInstanceOperationSuperseded ServiceInstanceOperationResult = "Superseded"
+)
+
+// ServiceInstanceOperationRecord describes a completed operation on a
+// ServiceInstance.
+type ServiceInstanceOperationRecord struct {
+	// Operation is the operation that was performed.
+	Operation ServiceInstanceOperation `json:"operation"`
+
+	// StartTime is the time at which the operation started.
+	StartTime metav1.Time `json:"startTime"`
+
+	// CompletionTime is the time at which the operation completed.
+	CompletionTime metav1.Time `json:"completionTime"`
+
+	// Result is the outcome of the operation.
+	Result ServiceInstanceOperationResult `json:"result"`
+
+	// Reason is a brief machine-readable explanation of the result.
+	Reason string `json:"reason,omitempty"`
+
+	// Message is a human-readable description of the result.
+	Message string `json:"message,omitempty"`
+}
+
 // ServiceInstancePropertiesState is the state of a ServiceInstance that
 // the ClusterServiceBroker knows about.
 type ServiceInstancePropertiesState struct {
@@ -1067,6 +1196,10 @@ type ServiceInstancePropertiesState struct {
 
 	// UserInfo is information about the user that made the request.
 	UserInfo *UserInfo `json:"userInfo,omitempty"`
+
+	// MaintenanceInfo is the version of the plan that the broker knows this
+	// ServiceInstance to run.
+	MaintenanceInfo *MaintenanceInfo `json:"maintenanceInfo,omitempty"`
 }
 
 // ServiceInstanceDeprovisionStatus is the status of deprovisioning a
@@ -1141,8 +1274,9 @@ type ServiceBinding struct {
 // ServiceBindingSpec represents the desired state of a
 // ServiceBinding.
 //
-// The spec field cannot be changed after a ServiceBinding is
-// created.  Changes submitted to the spec field will be ignored.
+// Only the parameters and the rotation requests in the spec field can be
+// changed after a ServiceBinding is created. Changes submitted to the rest of
+// the spec field will be ignored.
 type ServiceBindingSpec struct {
 	// ServiceInstanceRef is the reference to the Instance this ServiceBinding is to.
 	//
@@ -1178,10 +1312,21 @@ type ServiceBindingSpec struct {
 	// associated with the ServiceBinding before they are inserted into the Secret.
 	SecretTransforms []SecretTransform `json:"secretTransforms,omitempty"`
 
-	// ExternalID is the identity of this object for use with the OSB API.
+	// List of additional Secrets and ConfigMaps in the ServiceBinding's
+	// namespace that the credentials, after the SecretTransforms are applied,
+	// are written to. The Secret named by SecretName always holds all of the
+	// credentials.
 	//
 	// Immutable.
 	// +optional
+	Outputs []ServiceBindingOutput `json:"outputs,omitempty"`
+
+	// ExternalID is the identity of this object for use with the OSB API.
+	//
+	// Immutable, except that the API server replaces it with a new identity
+	// when RotationRequests is incremented, or when the parameters of a bound
+	// ServiceBinding are changed.
+	// +optional
 	ExternalID string `json:"externalID"`
 
 	// Currently, this field is ALPHA: it may change or disappear at any time
@@ -1192,6 +1337,14 @@ type ServiceBindingSpec struct {
 	// settable by the end-user. User-provided values for this field are not saved.
 	// +optional
 	UserInfo *UserInfo `json:"userInfo,omitempty"`
+
+	// RotationRequests is a strictly increasing, non-negative integer counter
+	// that can be manually incremented by a user to rotate the credentials of
+	// the ServiceBinding. The controller binds again with a new ExternalID,
+	// writes the new credentials into the Secret, and unbinds the previous
+	// credentials once they have been retired for the rotation grace period.
+	// +optional
+	RotationRequests int64 `json:"rotationRequests"`
 }
 
 // ServiceBindingStatus represents the current status of a ServiceBinding.
@@ -1241,6 +1394,25 @@ type ServiceBindingStatus struct {
 
 	// UnbindStatus describes what has been done to unbind the ServiceBinding.
 	UnbindStatus ServiceBindingUnbindStatus `json:"unbindStatus"`
+
+	// RetiredBindings are the previous bindings with the broker whose
+	// credentials were replaced by a rotation and that still have to be
+	// unbound.
+	RetiredBindings []RetiredServiceBinding `json:"retiredBindings,omitempty"`
+}
+
+// RetiredServiceBinding is a previous binding with the broker of a
+// ServiceBinding whose credentials were rotated.
+type RetiredServiceBinding struct {
+	// ExternalID is the identity of the previous binding for use with the
+	// OSB API.
+	ExternalID string `json:"externalID"`
+
+	// RetiredTime is the time at which the credentials of the previous
+	// binding were replaced in the Secret. It is not set while the new
+	// credentials are being bound, and the previous binding is unbound once
+	// the rotation grace period has elapsed since this time.
+	RetiredTime *metav1.Time `json:"retiredTime,omitempty"`
 }
 
 // ServiceBindingCondition condition information for a ServiceBinding.
@@ -1315,6 +1487,31 @@ const (
 	FinalizerServiceCatalog string = "kubernetes-incubator/service-catalog"
 )
 
+// These are annotations of a ServiceBinding that restart the workloads using
+// its credentials when they change.
+const (
+	// ServiceBindingRestartWorkloadsAnnotation lists the workloads to restart
+	// when the credentials of the ServiceBinding change, as a comma-separated
+	// list of <kind>/<name> in the namespace of the ServiceBinding, where kind
+	// is deployment, statefulset or daemonset. For example:
+	//     deployment/web,statefulset/worker
+	ServiceBindingRestartWorkloadsAnnotation string = "servicecatalog.k8s.io/restart-workloads"
+	// ServiceBindingRestartWorkloadsSelectorAnnotation is a label selector of
+	// the Deployments, StatefulSets and DaemonSets in the namespace of the
+	// ServiceBinding to restart when its credentials change.
+	ServiceBindingRestartWorkloadsSelectorAnnotation string = "servicecatalog.k8s.io/restart-workloads-selector"
+	// RestartAllowedServiceBindingsAnnotation is set on a workload to allow
+	// the ServiceBindings it lists, as a comma-separated list of names in the
+	// namespace of the workload, to restart it. A workload that does not list
+	// a ServiceBinding is not restarted by it, as the ServiceBinding could
+	// otherwise be used to restart workloads its author cannot update.
+	RestartAllowedServiceBindingsAnnotation string = "servicecatalog.k8s.io/restart-allowed-bindings"
+	// CredentialsChecksumAnnotation is set on the pod template of the
+	// restarted workloads to the checksum of the credentials that were
+	// written, which rolls out new pods.
+	CredentialsChecksumAnnotation string = "servicecatalog.k8s.io/credentials-checksum"
+)
+
 // ServiceBindingPropertiesState is the state of a
 // ServiceBinding that the ClusterServiceBroker knows about.
 type ServiceBindingPropertiesState struct {
@@ -1411,6 +1608,21 @@ type SecretTransform struct {
 	AddKeysFrom *AddKeysFromTransform `json:"addKeysFrom,omitempty"`
 	// RemoveKey represents a transform that removes a credentials Secret entry
 	RemoveKey *RemoveKeyTransform `json:"removeKey,omitempty"`
+	// Template represents a transform that adds a key whose value is built
+	// from the credentials with a Go template
+	Template *TemplateTransform `json:"template,omitempty"`
+	// Flatten represents a transform that replaces nested JSON credentials
+	// with one entry per nested value
+	Flatten *FlattenTransform `json:"flatten,omitempty"`
+	// Base64Decode represents a transform that decodes the base64 value of
+	// a credentials Secret entry
+	Base64Decode *Base64DecodeTransform `json:"base64Decode,omitempty"`
+	// Base64Encode represents a transform that encodes the value of a
+	// credentials Secret entry in base64
+	Base64Encode *Base64EncodeTransform `json:"base64Encode,omitempty"`
+	// OnlyKeys represents a transform that removes all the credentials
+	// Secret entries but the listed ones
+	OnlyKeys *OnlyKeysTransform `json:"onlyKeys,omitempty"`
 }
 
 // RenameKeyTransform specifies that one of the credentials keys returned
@@ -1471,3 +1683,123 @@ type RemoveKeyTransform struct {
 	// The key to remove from the Secret
 	Key string `json:"key"`
 }
+
+// TemplateTransform specifies that Service Catalog should add an entry to
+// the Secret associated with the ServiceBinding, whose value is the result of
+// a Go template executed with the credentials.
+// For example, given the following credentials:
+//     {"host": "db", "port": 5432, "database": "orders"}
+// and the following TemplateTransform:
+//     {"key": "JDBC_URL", "template": "jdbc:postgresql://{{.host}}:{{.port}}/{{.database}}"}
+// the following entry will appear in the Secret:
+//     "JDBC_URL": "jdbc:postgresql://db:5432/orders"
+// Referencing a key that is not in the credentials is an error.
+type TemplateTransform struct {
+	// The name of the key to add
+	Key string `json:"key"`
+	// The Go template that builds the value to add to the Secret under the
+	// specified key
+	Template string `json:"template"`
+}
+
+// FlattenTransform specifies that nested JSON credentials should be
+// replaced with one entry per nested value, named after the path to the
+// value.
+// For example, given the following credentials entry:
+//     "db": {"host": "db", "ports": [5432, 5433]}
+// and the following FlattenTransform:
+//     {"key": "db"}
+// the following entries will appear in the Secret:
+//     "db_host": "db"
+//     "db_ports_0": "5432"
+//     "db_ports_1": "5433"
+type FlattenTransform struct {
+	// The key of the nested credentials to flatten. All of the nested
+	// credentials are flattened when empty.
+	// +optional
+	Key string `json:"key,omitempty"`
+	// The separator between the names of nested keys. Defaults to "_".
+	// +optional
+	Separator string `json:"separator,omitempty"`
+}
+
+// Base64DecodeTransform specifies that the value of one of the credentials
+// keys is base64 encoded, and should be decoded before it is stored in the
+// Secret.
+type Base64DecodeTransform struct {
+	// The key whose value to decode
+	Key string `json:"key"`
+}
+
+// Base64EncodeTransform specifies that the value of one of the credentials
+// keys should be encoded in base64 before it is stored in the Secret.
+type Base64EncodeTransform struct {
+	// The key whose value to encode
+	Key string `json:"key"`
+}
+
+// OnlyKeysTransform specifies that only some of the credentials keys should
+// be included in the credentials Secret.
+// For example, given the following OnlyKeysTransform:
+//     {"keys": ["uri", "username", "password"]}
+// all of the entries but "uri", "username" and "password" will be removed
+// from the Secret. The listed keys that are not in the credentials are
+// ignored.
+type OnlyKeysTransform struct {
+	// The keys to keep in the Secret
+	Keys []string `json:"keys"`
+}
+
+// ServiceBindingOutputKind is the kind of object that an output of a
+// ServiceBinding writes the credentials to.
+type ServiceBindingOutputKind string
+
+const (
+	// ServiceBindingOutputKindSecret writes the credentials to a Secret.
+	ServiceBindingOutputKindSecret ServiceBindingOutputKind = "Secret"
+
+	// ServiceBindingOutputKindConfigMap writes the credentials to a
+	// ConfigMap. It should only be used for non-sensitive values.
+	ServiceBindingOutputKindConfigMap ServiceBindingOutputKind = "ConfigMap"
+)
+
+// ServiceBindingOutput specifies an additional Secret or ConfigMap that
+// Service Catalog should write some of the credentials of a ServiceBinding
+// to. The object is owned by the ServiceBinding, and is deleted along with
+// the credentials Secret.
+// For example, given the following credentials:
+//     {"uri": "mysql://db:3306", "tls.crt": "...", "tls.key": "..."}
+// and the following outputs:
+//     {"name": "db-config", "kind": "ConfigMap", "keys": [{"key": "uri", "targetKey": "DB_URI"}]}
+//     {"name": "db-tls", "kind": "Secret", "secretType": "kubernetes.io/tls",
+//      "keys": [{"key": "tls.crt"}, {"key": "tls.key"}]}
+// the ConfigMap "db-config" will hold the entry "DB_URI", and the TLS Secret
+// "db-tls" will hold the certificate and its key.
+type ServiceBindingOutput struct {
+	// Name of the Secret or ConfigMap to write the credentials to. It must be
+	// different from the SecretName of the ServiceBinding.
+	Name string `json:"name"`
+	// Kind of the object to write the credentials to, either Secret or
+	// ConfigMap.
+	Kind ServiceBindingOutputKind `json:"kind"`
+	// SecretType is the type of the Secret, one of Opaque, kubernetes.io/tls
+	// and kubernetes.io/dockerconfigjson. Defaults to Opaque. It must not be
+	// set for a ConfigMap.
+	// +optional
+	SecretType string `json:"secretType,omitempty"`
+	// Keys selects the credentials keys to write to the object. All of the
+	// credentials are written to a Secret that selects no key. The keys are
+	// required for a ConfigMap.
+	// +optional
+	Keys []ServiceBindingOutputKey `json:"keys,omitempty"`
+}
+
+// ServiceBindingOutputKey selects a credentials key to write to an output of
+// a ServiceBinding.
+type ServiceBindingOutputKey struct {
+	// The credentials key, after the SecretTransforms are applied
+	Key string `json:"key"`
+	// The key to write the value under in the object. Defaults to Key.
+	// +optional
+	TargetKey string `json:"targetKey,omitempty"`
+}
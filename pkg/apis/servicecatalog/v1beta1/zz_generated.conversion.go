@@ -41,10 +41,16 @@ func RegisterConversions(scheme *runtime.Scheme) error {
 		Convert_servicecatalog_AddKeyTransform_To_v1beta1_AddKeyTransform,
 		Convert_v1beta1_AddKeysFromTransform_To_servicecatalog_AddKeysFromTransform,
 		Convert_servicecatalog_AddKeysFromTransform_To_v1beta1_AddKeysFromTransform,
+		Convert_v1beta1_Base64DecodeTransform_To_servicecatalog_Base64DecodeTransform,
+		Convert_servicecatalog_Base64DecodeTransform_To_v1beta1_Base64DecodeTransform,
+		Convert_v1beta1_Base64EncodeTransform_To_servicecatalog_Base64EncodeTransform,
+		Convert_servicecatalog_Base64EncodeTransform_To_v1beta1_Base64EncodeTransform,
 		Convert_v1beta1_BasicAuthConfig_To_servicecatalog_BasicAuthConfig,
 		Convert_servicecatalog_BasicAuthConfig_To_v1beta1_BasicAuthConfig,
 		Convert_v1beta1_BearerTokenAuthConfig_To_servicecatalog_BearerTokenAuthConfig,
 		Convert_servicecatalog_BearerTokenAuthConfig_To_v1beta1_BearerTokenAuthConfig,
+		Convert_v1beta1_BrokerRequestLimits_To_servicecatalog_BrokerRequestLimits,
+		Convert_servicecatalog_BrokerRequestLimits_To_v1beta1_BrokerRequestLimits,
 		Convert_v1beta1_CatalogRestrictions_To_servicecatalog_CatalogRestrictions,
 		Convert_servicecatalog_CatalogRestrictions_To_v1beta1_CatalogRestrictions,
 		Convert_v1beta1_ClusterBasicAuthConfig_To_servicecatalog_ClusterBasicAuthConfig,
@@ -91,10 +97,16 @@ func RegisterConversions(scheme *runtime.Scheme) error {
 		Convert_servicecatalog_CommonServicePlanSpec_To_v1beta1_CommonServicePlanSpec,
 		Convert_v1beta1_CommonServicePlanStatus_To_servicecatalog_CommonServicePlanStatus,
 		Convert_servicecatalog_CommonServicePlanStatus_To_v1beta1_CommonServicePlanStatus,
+		Convert_v1beta1_FlattenTransform_To_servicecatalog_FlattenTransform,
+		Convert_servicecatalog_FlattenTransform_To_v1beta1_FlattenTransform,
 		Convert_v1beta1_LocalObjectReference_To_servicecatalog_LocalObjectReference,
 		Convert_servicecatalog_LocalObjectReference_To_v1beta1_LocalObjectReference,
+		Convert_v1beta1_MaintenanceInfo_To_servicecatalog_MaintenanceInfo,
+		Convert_servicecatalog_MaintenanceInfo_To_v1beta1_MaintenanceInfo,
 		Convert_v1beta1_ObjectReference_To_servicecatalog_ObjectReference,
 		Convert_servicecatalog_ObjectReference_To_v1beta1_ObjectReference,
+		Convert_v1beta1_OnlyKeysTransform_To_servicecatalog_OnlyKeysTransform,
+		Convert_servicecatalog_OnlyKeysTransform_To_v1beta1_OnlyKeysTransform,
 		Convert_v1beta1_ParametersFromSource_To_servicecatalog_ParametersFromSource,
 		Convert_servicecatalog_ParametersFromSource_To_v1beta1_ParametersFromSource,
 		Convert_v1beta1_PlanReference_To_servicecatalog_PlanReference,
@@ -103,6 +115,8 @@ func RegisterConversions(scheme *runtime.Scheme) error {
 		Convert_servicecatalog_RemoveKeyTransform_To_v1beta1_RemoveKeyTransform,
 		Convert_v1beta1_RenameKeyTransform_To_servicecatalog_RenameKeyTransform,
 		Convert_servicecatalog_RenameKeyTransform_To_v1beta1_RenameKeyTransform,
+		Convert_v1beta1_RetiredServiceBinding_To_servicecatalog_RetiredServiceBinding,
+		Convert_servicecatalog_RetiredServiceBinding_To_v1beta1_RetiredServiceBinding,
 		Convert_v1beta1_SecretKeyReference_To_servicecatalog_SecretKeyReference,
 		Convert_servicecatalog_SecretKeyReference_To_v1beta1_SecretKeyReference,
 		Convert_v1beta1_SecretTransform_To_servicecatalog_SecretTransform,
@@ -113,6 +127,10 @@ func RegisterConversions(scheme *runtime.Scheme) error {
 		Convert_servicecatalog_ServiceBindingCondition_To_v1beta1_ServiceBindingCondition,
 		Convert_v1beta1_ServiceBindingList_To_servicecatalog_ServiceBindingList,
 		Convert_servicecatalog_ServiceBindingList_To_v1beta1_ServiceBindingList,
+		Convert_v1beta1_ServiceBindingOutput_To_servicecatalog_ServiceBindingOutput,
+		Convert_servicecatalog_ServiceBindingOutput_To_v1beta1_ServiceBindingOutput,
+		Convert_v1beta1_ServiceBindingOutputKey_To_servicecatalog_ServiceBindingOutputKey,
+		Convert_servicecatalog_ServiceBindingOutputKey_To_v1beta1_ServiceBindingOutputKey,
 		Convert_v1beta1_ServiceBindingPropertiesState_To_servicecatalog_ServiceBindingPropertiesState,
 		Convert_servicecatalog_ServiceBindingPropertiesState_To_v1beta1_ServiceBindingPropertiesState,
 		Convert_v1beta1_ServiceBindingSpec_To_servicecatalog_ServiceBindingSpec,
@@ -145,6 +163,10 @@ func RegisterConversions(scheme *runtime.Scheme) error {
 		Convert_servicecatalog_ServiceInstanceCondition_To_v1beta1_ServiceInstanceCondition,
 		Convert_v1beta1_ServiceInstanceList_To_servicecatalog_ServiceInstanceList,
 		Convert_servicecatalog_ServiceInstanceList_To_v1beta1_ServiceInstanceList,
+		Convert_v1beta1_ServiceInstanceOperationRecord_To_servicecatalog_ServiceInstanceOperationRecord,
+		Convert_servicecatalog_ServiceInstanceOperationRecord_To_v1beta1_ServiceInstanceOperationRecord,
+		Convert_v1beta1_ServiceInstanceOperationRetry_To_servicecatalog_ServiceInstanceOperationRetry,
+		Convert_servicecatalog_ServiceInstanceOperationRetry_To_v1beta1_ServiceInstanceOperationRetry,
 		Convert_v1beta1_ServiceInstancePropertiesState_To_servicecatalog_ServiceInstancePropertiesState,
 		Convert_servicecatalog_ServiceInstancePropertiesState_To_v1beta1_ServiceInstancePropertiesState,
 		Convert_v1beta1_ServiceInstanceSpec_To_servicecatalog_ServiceInstanceSpec,
@@ -159,6 +181,8 @@ func RegisterConversions(scheme *runtime.Scheme) error {
 		Convert_servicecatalog_ServicePlanSpec_To_v1beta1_ServicePlanSpec,
 		Convert_v1beta1_ServicePlanStatus_To_servicecatalog_ServicePlanStatus,
 		Convert_servicecatalog_ServicePlanStatus_To_v1beta1_ServicePlanStatus,
+		Convert_v1beta1_TemplateTransform_To_servicecatalog_TemplateTransform,
+		Convert_servicecatalog_TemplateTransform_To_v1beta1_TemplateTransform,
 		Convert_v1beta1_UserInfo_To_servicecatalog_UserInfo,
 		Convert_servicecatalog_UserInfo_To_v1beta1_UserInfo,
 	)
@@ -210,6 +234,46 @@ func Convert_servicecatalog_AddKeysFromTransform_To_v1beta1_AddKeysFromTransform
 	return autoConvert_servicecatalog_AddKeysFromTransform_To_v1beta1_AddKeysFromTransform(in, out, s)
 }
 
+func autoConvert_v1beta1_Base64DecodeTransform_To_servicecatalog_Base64DecodeTransform(in *Base64DecodeTransform, out *servicecatalog.Base64DecodeTransform, s conversion.Scope) error {
+	out.Key = in.Key
+	return nil
+}
+
+// Convert_v1beta1_Base64DecodeTransform_To_servicecatalog_Base64DecodeTransform is an autogenerated conversion function.
+func Convert_v1beta1_Base64DecodeTransform_To_servicecatalog_Base64DecodeTransform(in *Base64DecodeTransform, out *servicecatalog.Base64DecodeTransform, s conversion.Scope) error {
+	return autoConvert_v1beta1_Base64DecodeTransform_To_servicecatalog_Base64DecodeTransform(in, out, s)
+}
+
+func autoConvert_servicecatalog_Base64DecodeTransform_To_v1beta1_Base64DecodeTransform(in *servicecatalog.Base64DecodeTransform, out *Base64DecodeTransform, s conversion.Scope) error {
+	out.Key = in.Key
+	return nil
+}
+
+// Convert_servicecatalog_Base64DecodeTransform_To_v1beta1_Base64DecodeTransform is an autogenerated conversion function.
+func Convert_servicecatalog_Base64DecodeTransform_To_v1beta1_Base64DecodeTransform(in *servicecatalog.Base64DecodeTransform, out *Base64DecodeTransform, s conversion.Scope) error {
+	return autoConvert_servicecatalog_Base64DecodeTransform_To_v1beta1_Base64DecodeTransform(in, out, s)
+}
+
+func autoConvert_v1beta1_Base64EncodeTransform_To_servicecatalog_Base64EncodeTransform(in *Base64EncodeTransform, out *servicecatalog.Base64EncodeTransform, s conversion.Scope) error {
+	out.Key = in.Key
+	return nil
+}
+
+// Convert_v1beta1_Base64EncodeTransform_To_servicecatalog_Base64EncodeTransform is an autogenerated conversion function.
+func Convert_v1beta1_Base64EncodeTransform_To_servicecatalog_Base64EncodeTransform(in *Base64EncodeTransform, out *servicecatalog.Base64EncodeTransform, s conversion.Scope) error {
+	return autoConvert_v1beta1_Base64EncodeTransform_To_servicecatalog_Base64EncodeTransform(in, out, s)
+}
+
+func autoConvert_servicecatalog_Base64EncodeTransform_To_v1beta1_Base64EncodeTransform(in *servicecatalog.Base64EncodeTransform, out *Base64EncodeTransform, s conversion.Scope) error {
+	out.Key = in.Key
+	return nil
+}
+
+// Convert_servicecatalog_Base64EncodeTransform_To_v1beta1_Base64EncodeTransform is an autogenerated conversion function.
+func Convert_servicecatalog_Base64EncodeTransform_To_v1beta1_Base64EncodeTransform(in *servicecatalog.Base64EncodeTransform, out *Base64EncodeTransform, s conversion.Scope) error {
+	return autoConvert_servicecatalog_Base64EncodeTransform_To_v1beta1_Base64EncodeTransform(in, out, s)
+}
+
 func autoConvert_v1beta1_BasicAuthConfig_To_servicecatalog_BasicAuthConfig(in *BasicAuthConfig, out *servicecatalog.BasicAuthConfig, s conversion.Scope) error {
 	out.SecretRef = (*servicecatalog.LocalObjectReference)(unsafe.Pointer(in.SecretRef))
 	return nil
@@ -250,6 +314,30 @@ func Convert_servicecatalog_BearerTokenAuthConfig_To_v1beta1_BearerTokenAuthConf
 	return autoConvert_servicecatalog_BearerTokenAuthConfig_To_v1beta1_BearerTokenAuthConfig(in, out, s)
 }
 
+func autoConvert_v1beta1_BrokerRequestLimits_To_servicecatalog_BrokerRequestLimits(in *BrokerRequestLimits, out *servicecatalog.BrokerRequestLimits, s conversion.Scope) error {
+	out.QPS = in.QPS
+	out.Burst = in.Burst
+	out.MaxInFlightRequests = in.MaxInFlightRequests
+	return nil
+}
+
+// Convert_v1beta1_BrokerRequestLimits_To_servicecatalog_BrokerRequestLimits is an autogenerated conversion function.
+func Convert_v1beta1_BrokerRequestLimits_To_servicecatalog_BrokerRequestLimits(in *BrokerRequestLimits, out *servicecatalog.BrokerRequestLimits, s conversion.Scope) error {
+	return autoConvert_v1beta1_BrokerRequestLimits_To_servicecatalog_BrokerRequestLimits(in, out, s)
+}
+
+func autoConvert_servicecatalog_BrokerRequestLimits_To_v1beta1_BrokerRequestLimits(in *servicecatalog.BrokerRequestLimits, out *BrokerRequestLimits, s conversion.Scope) error {
+	out.QPS = in.QPS
+	out.Burst = in.Burst
+	out.MaxInFlightRequests = in.MaxInFlightRequests
+	return nil
+}
+
+// Convert_servicecatalog_BrokerRequestLimits_To_v1beta1_BrokerRequestLimits is an autogenerated conversion function.
+func Convert_servicecatalog_BrokerRequestLimits_To_v1beta1_BrokerRequestLimits(in *servicecatalog.BrokerRequestLimits, out *BrokerRequestLimits, s conversion.Scope) error {
+	return autoConvert_servicecatalog_BrokerRequestLimits_To_v1beta1_BrokerRequestLimits(in, out, s)
+}
+
 func autoConvert_v1beta1_CatalogRestrictions_To_servicecatalog_CatalogRestrictions(in *CatalogRestrictions, out *servicecatalog.CatalogRestrictions, s conversion.Scope) error {
 	out.ServiceClass = *(*[]string)(unsafe.Pointer(&in.ServiceClass))
 	out.ServicePlan = *(*[]string)(unsafe.Pointer(&in.ServicePlan))
@@ -680,6 +768,7 @@ func autoConvert_v1beta1_CommonServiceBrokerSpec_To_servicecatalog_CommonService
 	out.RelistDuration = (*v1.Duration)(unsafe.Pointer(in.RelistDuration))
 	out.RelistRequests = in.RelistRequests
 	out.CatalogRestrictions = (*servicecatalog.CatalogRestrictions)(unsafe.Pointer(in.CatalogRestrictions))
+	out.RequestLimits = (*servicecatalog.BrokerRequestLimits)(unsafe.Pointer(in.RequestLimits))
 	return nil
 }
 
@@ -696,6 +785,7 @@ func autoConvert_servicecatalog_CommonServiceBrokerSpec_To_v1beta1_CommonService
 	out.RelistDuration = (*v1.Duration)(unsafe.Pointer(in.RelistDuration))
 	out.RelistRequests = in.RelistRequests
 	out.CatalogRestrictions = (*CatalogRestrictions)(unsafe.Pointer(in.CatalogRestrictions))
+	out.RequestLimits = (*BrokerRequestLimits)(unsafe.Pointer(in.RequestLimits))
 	return nil
 }
 
@@ -736,6 +826,7 @@ func autoConvert_v1beta1_CommonServiceClassSpec_To_servicecatalog_CommonServiceC
 	out.Description = in.Description
 	out.Bindable = in.Bindable
 	out.BindingRetrievable = in.BindingRetrievable
+	out.InstancesRetrievable = in.InstancesRetrievable
 	out.PlanUpdatable = in.PlanUpdatable
 	out.ExternalMetadata = (*runtime.RawExtension)(unsafe.Pointer(in.ExternalMetadata))
 	out.Tags = *(*[]string)(unsafe.Pointer(&in.Tags))
@@ -754,6 +845,7 @@ func autoConvert_servicecatalog_CommonServiceClassSpec_To_v1beta1_CommonServiceC
 	out.Description = in.Description
 	out.Bindable = in.Bindable
 	out.BindingRetrievable = in.BindingRetrievable
+	out.InstancesRetrievable = in.InstancesRetrievable
 	out.PlanUpdatable = in.PlanUpdatable
 	out.ExternalMetadata = (*runtime.RawExtension)(unsafe.Pointer(in.ExternalMetadata))
 	out.Tags = *(*[]string)(unsafe.Pointer(&in.Tags))
@@ -798,6 +890,7 @@ func autoConvert_v1beta1_CommonServicePlanSpec_To_servicecatalog_CommonServicePl
 	out.ServiceBindingCreateParameterSchema = (*runtime.RawExtension)(unsafe.Pointer(in.ServiceBindingCreateParameterSchema))
 	out.ServiceBindingCreateResponseSchema = (*runtime.RawExtension)(unsafe.Pointer(in.ServiceBindingCreateResponseSchema))
 	out.DefaultProvisionParameters = (*runtime.RawExtension)(unsafe.Pointer(in.DefaultProvisionParameters))
+	out.MaintenanceInfo = (*servicecatalog.MaintenanceInfo)(unsafe.Pointer(in.MaintenanceInfo))
 	return nil
 }
 
@@ -818,6 +911,7 @@ func autoConvert_servicecatalog_CommonServicePlanSpec_To_v1beta1_CommonServicePl
 	out.ServiceBindingCreateParameterSchema = (*runtime.RawExtension)(unsafe.Pointer(in.ServiceBindingCreateParameterSchema))
 	out.ServiceBindingCreateResponseSchema = (*runtime.RawExtension)(unsafe.Pointer(in.ServiceBindingCreateResponseSchema))
 	out.DefaultProvisionParameters = (*runtime.RawExtension)(unsafe.Pointer(in.DefaultProvisionParameters))
+	out.MaintenanceInfo = (*MaintenanceInfo)(unsafe.Pointer(in.MaintenanceInfo))
 	return nil
 }
 
@@ -846,6 +940,28 @@ func Convert_servicecatalog_CommonServicePlanStatus_To_v1beta1_CommonServicePlan
 	return autoConvert_servicecatalog_CommonServicePlanStatus_To_v1beta1_CommonServicePlanStatus(in, out, s)
 }
 
+func autoConvert_v1beta1_FlattenTransform_To_servicecatalog_FlattenTransform(in *FlattenTransform, out *servicecatalog.FlattenTransform, s conversion.Scope) error {
+	out.Key = in.Key
+	out.Separator = in.Separator
+	return nil
+}
+
+// Convert_v1beta1_FlattenTransform_To_servicecatalog_FlattenTransform is an autogenerated conversion function.
+func Convert_v1beta1_FlattenTransform_To_servicecatalog_FlattenTransform(in *FlattenTransform, out *servicecatalog.FlattenTransform, s conversion.Scope) error {
+	return autoConvert_v1beta1_FlattenTransform_To_servicecatalog_FlattenTransform(in, out, s)
+}
+
+func autoConvert_servicecatalog_FlattenTransform_To_v1beta1_FlattenTransform(in *servicecatalog.FlattenTransform, out *FlattenTransform, s conversion.Scope) error {
+	out.Key = in.Key
+	out.Separator = in.Separator
+	return nil
+}
+
+// Convert_servicecatalog_FlattenTransform_To_v1beta1_FlattenTransform is an autogenerated conversion function.
+func Convert_servicecatalog_FlattenTransform_To_v1beta1_FlattenTransform(in *servicecatalog.FlattenTransform, out *FlattenTransform, s conversion.Scope) error {
+	return autoConvert_servicecatalog_FlattenTransform_To_v1beta1_FlattenTransform(in, out, s)
+}
+
 func autoConvert_v1beta1_LocalObjectReference_To_servicecatalog_LocalObjectReference(in *LocalObjectReference, out *servicecatalog.LocalObjectReference, s conversion.Scope) error {
 	out.Name = in.Name
 	return nil
@@ -866,6 +982,28 @@ func Convert_servicecatalog_LocalObjectReference_To_v1beta1_LocalObjectReference
 	return autoConvert_servicecatalog_LocalObjectReference_To_v1beta1_LocalObjectReference(in, out, s)
 }
 
+func autoConvert_v1beta1_MaintenanceInfo_To_servicecatalog_MaintenanceInfo(in *MaintenanceInfo, out *servicecatalog.MaintenanceInfo, s conversion.Scope) error {
+	out.Version = in.Version
+	out.Description = in.Description
+	return nil
+}
+
+// Convert_v1beta1_MaintenanceInfo_To_servicecatalog_MaintenanceInfo is an autogenerated conversion function.
+func Convert_v1beta1_MaintenanceInfo_To_servicecatalog_MaintenanceInfo(in *MaintenanceInfo, out *servicecatalog.MaintenanceInfo, s conversion.Scope) error {
+	return autoConvert_v1beta1_MaintenanceInfo_To_servicecatalog_MaintenanceInfo(in, out, s)
+}
+
+func autoConvert_servicecatalog_MaintenanceInfo_To_v1beta1_MaintenanceInfo(in *servicecatalog.MaintenanceInfo, out *MaintenanceInfo, s conversion.Scope) error {
+	out.Version = in.Version
+	out.Description = in.Description
+	return nil
+}
+
+// Convert_servicecatalog_MaintenanceInfo_To_v1beta1_MaintenanceInfo is an autogenerated conversion function.
+func Convert_servicecatalog_MaintenanceInfo_To_v1beta1_MaintenanceInfo(in *servicecatalog.MaintenanceInfo, out *MaintenanceInfo, s conversion.Scope) error {
+	return autoConvert_servicecatalog_MaintenanceInfo_To_v1beta1_MaintenanceInfo(in, out, s)
+}
+
 func autoConvert_v1beta1_ObjectReference_To_servicecatalog_ObjectReference(in *ObjectReference, out *servicecatalog.ObjectReference, s conversion.Scope) error {
 	out.Namespace = in.Namespace
 	out.Name = in.Name
@@ -888,6 +1026,26 @@ func Convert_servicecatalog_ObjectReference_To_v1beta1_ObjectReference(in *servi
 	return autoConvert_servicecatalog_ObjectReference_To_v1beta1_ObjectReference(in, out, s)
 }
 
+func autoConvert_v1beta1_OnlyKeysTransform_To_servicecatalog_OnlyKeysTransform(in *OnlyKeysTransform, out *servicecatalog.OnlyKeysTransform, s conversion.Scope) error {
+	out.Keys = *(*[]string)(unsafe.Pointer(&in.Keys))
+	return nil
+}
+
+// Convert_v1beta1_OnlyKeysTransform_To_servicecatalog_OnlyKeysTransform is an autogenerated conversion function.
+func Convert_v1beta1_OnlyKeysTransform_To_servicecatalog_OnlyKeysTransform(in *OnlyKeysTransform, out *servicecatalog.OnlyKeysTransform, s conversion.Scope) error {
+	return autoConvert_v1beta1_OnlyKeysTransform_To_servicecatalog_OnlyKeysTransform(in, out, s)
+}
+
+func autoConvert_servicecatalog_OnlyKeysTransform_To_v1beta1_OnlyKeysTransform(in *servicecatalog.OnlyKeysTransform, out *OnlyKeysTransform, s conversion.Scope) error {
+	out.Keys = *(*[]string)(unsafe.Pointer(&in.Keys))
+	return nil
+}
+
+// Convert_servicecatalog_OnlyKeysTransform_To_v1beta1_OnlyKeysTransform is an autogenerated conversion function.
+func Convert_servicecatalog_OnlyKeysTransform_To_v1beta1_OnlyKeysTransform(in *servicecatalog.OnlyKeysTransform, out *OnlyKeysTransform, s conversion.Scope) error {
+	return autoConvert_servicecatalog_OnlyKeysTransform_To_v1beta1_OnlyKeysTransform(in, out, s)
+}
+
 func autoConvert_v1beta1_ParametersFromSource_To_servicecatalog_ParametersFromSource(in *ParametersFromSource, out *servicecatalog.ParametersFromSource, s conversion.Scope) error {
 	out.SecretKeyRef = (*servicecatalog.SecretKeyReference)(unsafe.Pointer(in.SecretKeyRef))
 	return nil
@@ -992,6 +1150,28 @@ func Convert_servicecatalog_RenameKeyTransform_To_v1beta1_RenameKeyTransform(in
 	return autoConvert_servicecatalog_RenameKeyTransform_To_v1beta1_RenameKeyTransform(in, out, s)
 }
 
+func autoConvert_v1beta1_RetiredServiceBinding_To_servicecatalog_RetiredServiceBinding(in *RetiredServiceBinding, out *servicecatalog.RetiredServiceBinding, s conversion.Scope) error {
+	out.ExternalID = in.ExternalID
+	out.RetiredTime = (*v1.Time)(unsafe.Pointer(in.RetiredTime))
+	return nil
+}
+
+// Convert_v1beta1_RetiredServiceBinding_To_servicecatalog_RetiredServiceBinding is an autogenerated conversion function.
+func Convert_v1beta1_RetiredServiceBinding_To_servicecatalog_RetiredServiceBinding(in *RetiredServiceBinding, out *servicecatalog.RetiredServiceBinding, s conversion.Scope) error {
+	return autoConvert_v1beta1_RetiredServiceBinding_To_servicecatalog_RetiredServiceBinding(in, out, s)
+}
+
+func autoConvert_servicecatalog_RetiredServiceBinding_To_v1beta1_RetiredServiceBinding(in *servicecatalog.RetiredServiceBinding, out *RetiredServiceBinding, s conversion.Scope) error {
+	out.ExternalID = in.ExternalID
+	out.RetiredTime = (*v1.Time)(unsafe.Pointer(in.RetiredTime))
+	return nil
+}
+
+// Convert_servicecatalog_RetiredServiceBinding_To_v1beta1_RetiredServiceBinding is an autogenerated conversion function.
+func Convert_servicecatalog_RetiredServiceBinding_To_v1beta1_RetiredServiceBinding(in *servicecatalog.RetiredServiceBinding, out *RetiredServiceBinding, s conversion.Scope) error {
+	return autoConvert_servicecatalog_RetiredServiceBinding_To_v1beta1_RetiredServiceBinding(in, out, s)
+}
+
 func autoConvert_v1beta1_SecretKeyReference_To_servicecatalog_SecretKeyReference(in *SecretKeyReference, out *servicecatalog.SecretKeyReference, s conversion.Scope) error {
 	out.Name = in.Name
 	out.Key = in.Key
@@ -1019,6 +1199,11 @@ func autoConvert_v1beta1_SecretTransform_To_servicecatalog_SecretTransform(in *S
 	out.AddKey = (*servicecatalog.AddKeyTransform)(unsafe.Pointer(in.AddKey))
 	out.AddKeysFrom = (*servicecatalog.AddKeysFromTransform)(unsafe.Pointer(in.AddKeysFrom))
 	out.RemoveKey = (*servicecatalog.RemoveKeyTransform)(unsafe.Pointer(in.RemoveKey))
+	out.Template = (*servicecatalog.TemplateTransform)(unsafe.Pointer(in.Template))
+	out.Flatten = (*servicecatalog.FlattenTransform)(unsafe.Pointer(in.Flatten))
+	out.Base64Decode = (*servicecatalog.Base64DecodeTransform)(unsafe.Pointer(in.Base64Decode))
+	out.Base64Encode = (*servicecatalog.Base64EncodeTransform)(unsafe.Pointer(in.Base64Encode))
+	out.OnlyKeys = (*servicecatalog.OnlyKeysTransform)(unsafe.Pointer(in.OnlyKeys))
 	return nil
 }
 
@@ -1032,6 +1217,11 @@ func autoConvert_servicecatalog_SecretTransform_To_v1beta1_SecretTransform(in *s
 	out.AddKey = (*AddKeyTransform)(unsafe.Pointer(in.AddKey))
 	out.AddKeysFrom = (*AddKeysFromTransform)(unsafe.Pointer(in.AddKeysFrom))
 	out.RemoveKey = (*RemoveKeyTransform)(unsafe.Pointer(in.RemoveKey))
+	out.Template = (*TemplateTransform)(unsafe.Pointer(in.Template))
+	out.Flatten = (*FlattenTransform)(unsafe.Pointer(in.Flatten))
+	out.Base64Decode = (*Base64DecodeTransform)(unsafe.Pointer(in.Base64Decode))
+	out.Base64Encode = (*Base64EncodeTransform)(unsafe.Pointer(in.Base64Encode))
+	out.OnlyKeys = (*OnlyKeysTransform)(unsafe.Pointer(in.OnlyKeys))
 	return nil
 }
 
@@ -1122,6 +1312,54 @@ func Convert_servicecatalog_ServiceBindingList_To_v1beta1_ServiceBindingList(in
 	return autoConvert_servicecatalog_ServiceBindingList_To_v1beta1_ServiceBindingList(in, out, s)
 }
 
+func autoConvert_v1beta1_ServiceBindingOutput_To_servicecatalog_ServiceBindingOutput(in *ServiceBindingOutput, out *servicecatalog.ServiceBindingOutput, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Kind = servicecatalog.ServiceBindingOutputKind(in.Kind)
+	out.SecretType = in.SecretType
+	out.Keys = *(*[]servicecatalog.ServiceBindingOutputKey)(unsafe.Pointer(&in.Keys))
+	return nil
+}
+
+// Convert_v1beta1_ServiceBindingOutput_To_servicecatalog_ServiceBindingOutput is an autogenerated conversion function.
+func Convert_v1beta1_ServiceBindingOutput_To_servicecatalog_ServiceBindingOutput(in *ServiceBindingOutput, out *servicecatalog.ServiceBindingOutput, s conversion.Scope) error {
+	return autoConvert_v1beta1_ServiceBindingOutput_To_servicecatalog_ServiceBindingOutput(in, out, s)
+}
+
+func autoConvert_servicecatalog_ServiceBindingOutput_To_v1beta1_ServiceBindingOutput(in *servicecatalog.ServiceBindingOutput, out *ServiceBindingOutput, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Kind = ServiceBindingOutputKind(in.Kind)
+	out.SecretType = in.SecretType
+	out.Keys = *(*[]ServiceBindingOutputKey)(unsafe.Pointer(&in.Keys))
+	return nil
+}
+
+// Convert_servicecatalog_ServiceBindingOutput_To_v1beta1_ServiceBindingOutput is an autogenerated conversion function.
+func Convert_servicecatalog_ServiceBindingOutput_To_v1beta1_ServiceBindingOutput(in *servicecatalog.ServiceBindingOutput, out *ServiceBindingOutput, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ServiceBindingOutput_To_v1beta1_ServiceBindingOutput(in, out, s)
+}
+
+func autoConvert_v1beta1_ServiceBindingOutputKey_To_servicecatalog_ServiceBindingOutputKey(in *ServiceBindingOutputKey, out *servicecatalog.ServiceBindingOutputKey, s conversion.Scope) error {
+	out.Key = in.Key
+	out.TargetKey = in.TargetKey
+	return nil
+}
+
+// Convert_v1beta1_ServiceBindingOutputKey_To_servicecatalog_ServiceBindingOutputKey is an autogenerated conversion function.
+func Convert_v1beta1_ServiceBindingOutputKey_To_servicecatalog_ServiceBindingOutputKey(in *ServiceBindingOutputKey, out *servicecatalog.ServiceBindingOutputKey, s conversion.Scope) error {
+	return autoConvert_v1beta1_ServiceBindingOutputKey_To_servicecatalog_ServiceBindingOutputKey(in, out, s)
+}
+
+func autoConvert_servicecatalog_ServiceBindingOutputKey_To_v1beta1_ServiceBindingOutputKey(in *servicecatalog.ServiceBindingOutputKey, out *ServiceBindingOutputKey, s conversion.Scope) error {
+	out.Key = in.Key
+	out.TargetKey = in.TargetKey
+	return nil
+}
+
+// Convert_servicecatalog_ServiceBindingOutputKey_To_v1beta1_ServiceBindingOutputKey is an autogenerated conversion function.
+func Convert_servicecatalog_ServiceBindingOutputKey_To_v1beta1_ServiceBindingOutputKey(in *servicecatalog.ServiceBindingOutputKey, out *ServiceBindingOutputKey, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ServiceBindingOutputKey_To_v1beta1_ServiceBindingOutputKey(in, out, s)
+}
+
 func autoConvert_v1beta1_ServiceBindingPropertiesState_To_servicecatalog_ServiceBindingPropertiesState(in *ServiceBindingPropertiesState, out *servicecatalog.ServiceBindingPropertiesState, s conversion.Scope) error {
 	out.Parameters = (*runtime.RawExtension)(unsafe.Pointer(in.Parameters))
 	out.ParametersChecksum = in.ParametersChecksum
@@ -1154,8 +1392,10 @@ func autoConvert_v1beta1_ServiceBindingSpec_To_servicecatalog_ServiceBindingSpec
 	out.ParametersFrom = *(*[]servicecatalog.ParametersFromSource)(unsafe.Pointer(&in.ParametersFrom))
 	out.SecretName = in.SecretName
 	out.SecretTransforms = *(*[]servicecatalog.SecretTransform)(unsafe.Pointer(&in.SecretTransforms))
+	out.Outputs = *(*[]servicecatalog.ServiceBindingOutput)(unsafe.Pointer(&in.Outputs))
 	out.ExternalID = in.ExternalID
 	out.UserInfo = (*servicecatalog.UserInfo)(unsafe.Pointer(in.UserInfo))
+	out.RotationRequests = in.RotationRequests
 	return nil
 }
 
@@ -1172,8 +1412,10 @@ func autoConvert_servicecatalog_ServiceBindingSpec_To_v1beta1_ServiceBindingSpec
 	out.ParametersFrom = *(*[]ParametersFromSource)(unsafe.Pointer(&in.ParametersFrom))
 	out.SecretName = in.SecretName
 	out.SecretTransforms = *(*[]SecretTransform)(unsafe.Pointer(&in.SecretTransforms))
+	out.Outputs = *(*[]ServiceBindingOutput)(unsafe.Pointer(&in.Outputs))
 	out.ExternalID = in.ExternalID
 	out.UserInfo = (*UserInfo)(unsafe.Pointer(in.UserInfo))
+	out.RotationRequests = in.RotationRequests
 	return nil
 }
 
@@ -1193,6 +1435,7 @@ func autoConvert_v1beta1_ServiceBindingStatus_To_servicecatalog_ServiceBindingSt
 	out.ExternalProperties = (*servicecatalog.ServiceBindingPropertiesState)(unsafe.Pointer(in.ExternalProperties))
 	out.OrphanMitigationInProgress = in.OrphanMitigationInProgress
 	out.UnbindStatus = servicecatalog.ServiceBindingUnbindStatus(in.UnbindStatus)
+	out.RetiredBindings = *(*[]servicecatalog.RetiredServiceBinding)(unsafe.Pointer(&in.RetiredBindings))
 	return nil
 }
 
@@ -1212,6 +1455,7 @@ func autoConvert_servicecatalog_ServiceBindingStatus_To_v1beta1_ServiceBindingSt
 	out.ExternalProperties = (*ServiceBindingPropertiesState)(unsafe.Pointer(in.ExternalProperties))
 	out.OrphanMitigationInProgress = in.OrphanMitigationInProgress
 	out.UnbindStatus = ServiceBindingUnbindStatus(in.UnbindStatus)
+	out.RetiredBindings = *(*[]RetiredServiceBinding)(unsafe.Pointer(&in.RetiredBindings))
 	return nil
 }
 
@@ -1560,6 +1804,62 @@ func Convert_servicecatalog_ServiceInstanceList_To_v1beta1_ServiceInstanceList(i
 	return autoConvert_servicecatalog_ServiceInstanceList_To_v1beta1_ServiceInstanceList(in, out, s)
 }
 
+func autoConvert_v1beta1_ServiceInstanceOperationRecord_To_servicecatalog_ServiceInstanceOperationRecord(in *ServiceInstanceOperationRecord, out *servicecatalog.ServiceInstanceOperationRecord, s conversion.Scope) error {
+	out.Operation = servicecatalog.ServiceInstanceOperation(in.Operation)
+	out.StartTime = in.StartTime
+	out.CompletionTime = in.CompletionTime
+	out.Result = servicecatalog.ServiceInstanceOperationResult(in.Result)
+	out.Reason = in.Reason
+	out.Message = in.Message
+	return nil
+}
+
+// Convert_v1beta1_ServiceInstanceOperationRecord_To_servicecatalog_ServiceInstanceOperationRecord is an autogenerated conversion function.
+func Convert_v1beta1_ServiceInstanceOperationRecord_To_servicecatalog_ServiceInstanceOperationRecord(in *ServiceInstanceOperationRecord, out *servicecatalog.ServiceInstanceOperationRecord, s conversion.Scope) error {
+	return autoConvert_v1beta1_ServiceInstanceOperationRecord_To_servicecatalog_ServiceInstanceOperationRecord(in, out, s)
+}
+
+func autoConvert_servicecatalog_ServiceInstanceOperationRecord_To_v1beta1_ServiceInstanceOperationRecord(in *servicecatalog.ServiceInstanceOperationRecord, out *ServiceInstanceOperationRecord, s conversion.Scope) error {
+	out.Operation = ServiceInstanceOperation(in.Operation)
+	out.StartTime = in.StartTime
+	out.CompletionTime = in.CompletionTime
+	out.Result = ServiceInstanceOperationResult(in.Result)
+	out.Reason = in.Reason
+	out.Message = in.Message
+	return nil
+}
+
+// Convert_servicecatalog_ServiceInstanceOperationRecord_To_v1beta1_ServiceInstanceOperationRecord is an autogenerated conversion function.
+func Convert_servicecatalog_ServiceInstanceOperationRecord_To_v1beta1_ServiceInstanceOperationRecord(in *servicecatalog.ServiceInstanceOperationRecord, out *ServiceInstanceOperationRecord, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ServiceInstanceOperationRecord_To_v1beta1_ServiceInstanceOperationRecord(in, out, s)
+}
+
+func autoConvert_v1beta1_ServiceInstanceOperationRetry_To_servicecatalog_ServiceInstanceOperationRetry(in *ServiceInstanceOperationRetry, out *servicecatalog.ServiceInstanceOperationRetry, s conversion.Scope) error {
+	out.Generation = in.Generation
+	out.Attempts = in.Attempts
+	out.NextRetryTime = in.NextRetryTime
+	out.LastError = in.LastError
+	return nil
+}
+
+// Convert_v1beta1_ServiceInstanceOperationRetry_To_servicecatalog_ServiceInstanceOperationRetry is an autogenerated conversion function.
+func Convert_v1beta1_ServiceInstanceOperationRetry_To_servicecatalog_ServiceInstanceOperationRetry(in *ServiceInstanceOperationRetry, out *servicecatalog.ServiceInstanceOperationRetry, s conversion.Scope) error {
+	return autoConvert_v1beta1_ServiceInstanceOperationRetry_To_servicecatalog_ServiceInstanceOperationRetry(in, out, s)
+}
+
+func autoConvert_servicecatalog_ServiceInstanceOperationRetry_To_v1beta1_ServiceInstanceOperationRetry(in *servicecatalog.ServiceInstanceOperationRetry, out *ServiceInstanceOperationRetry, s conversion.Scope) error {
+	out.Generation = in.Generation
+	out.Attempts = in.Attempts
+	out.NextRetryTime = in.NextRetryTime
+	out.LastError = in.LastError
+	return nil
+}
+
+// Convert_servicecatalog_ServiceInstanceOperationRetry_To_v1beta1_ServiceInstanceOperationRetry is an autogenerated conversion function.
+func Convert_servicecatalog_ServiceInstanceOperationRetry_To_v1beta1_ServiceInstanceOperationRetry(in *servicecatalog.ServiceInstanceOperationRetry, out *ServiceInstanceOperationRetry, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ServiceInstanceOperationRetry_To_v1beta1_ServiceInstanceOperationRetry(in, out, s)
+}
+
 func autoConvert_v1beta1_ServiceInstancePropertiesState_To_servicecatalog_ServiceInstancePropertiesState(in *ServiceInstancePropertiesState, out *servicecatalog.ServiceInstancePropertiesState, s conversion.Scope) error {
 	out.ClusterServicePlanExternalName = in.ClusterServicePlanExternalName
 	out.ClusterServicePlanExternalID = in.ClusterServicePlanExternalID
@@ -1568,6 +1868,7 @@ func autoConvert_v1beta1_ServiceInstancePropertiesState_To_servicecatalog_Servic
 	out.Parameters = (*runtime.RawExtension)(unsafe.Pointer(in.Parameters))
 	out.ParametersChecksum = in.ParametersChecksum
 	out.UserInfo = (*servicecatalog.UserInfo)(unsafe.Pointer(in.UserInfo))
+	out.MaintenanceInfo = (*servicecatalog.MaintenanceInfo)(unsafe.Pointer(in.MaintenanceInfo))
 	return nil
 }
 
@@ -1584,6 +1885,7 @@ func autoConvert_servicecatalog_ServiceInstancePropertiesState_To_v1beta1_Servic
 	out.Parameters = (*runtime.RawExtension)(unsafe.Pointer(in.Parameters))
 	out.ParametersChecksum = in.ParametersChecksum
 	out.UserInfo = (*UserInfo)(unsafe.Pointer(in.UserInfo))
+	out.MaintenanceInfo = (*MaintenanceInfo)(unsafe.Pointer(in.MaintenanceInfo))
 	return nil
 }
 
@@ -1649,6 +1951,8 @@ func autoConvert_v1beta1_ServiceInstanceStatus_To_servicecatalog_ServiceInstance
 	out.ProvisionStatus = servicecatalog.ServiceInstanceProvisionStatus(in.ProvisionStatus)
 	out.DeprovisionStatus = servicecatalog.ServiceInstanceDeprovisionStatus(in.DeprovisionStatus)
 	out.DefaultProvisionParameters = (*runtime.RawExtension)(unsafe.Pointer(in.DefaultProvisionParameters))
+	out.OperationRetry = (*servicecatalog.ServiceInstanceOperationRetry)(unsafe.Pointer(in.OperationRetry))
+	out.OperationHistory = *(*[]servicecatalog.ServiceInstanceOperationRecord)(unsafe.Pointer(&in.OperationHistory))
 	return nil
 }
 
@@ -1672,6 +1976,8 @@ func autoConvert_servicecatalog_ServiceInstanceStatus_To_v1beta1_ServiceInstance
 	out.ProvisionStatus = ServiceInstanceProvisionStatus(in.ProvisionStatus)
 	out.DeprovisionStatus = ServiceInstanceDeprovisionStatus(in.DeprovisionStatus)
 	out.DefaultProvisionParameters = (*runtime.RawExtension)(unsafe.Pointer(in.DefaultProvisionParameters))
+	out.OperationRetry = (*ServiceInstanceOperationRetry)(unsafe.Pointer(in.OperationRetry))
+	out.OperationHistory = *(*[]ServiceInstanceOperationRecord)(unsafe.Pointer(&in.OperationHistory))
 	return nil
 }
 
@@ -1790,6 +2096,28 @@ func Convert_servicecatalog_ServicePlanStatus_To_v1beta1_ServicePlanStatus(in *s
 	return autoConvert_servicecatalog_ServicePlanStatus_To_v1beta1_ServicePlanStatus(in, out, s)
 }
 
+func autoConvert_v1beta1_TemplateTransform_To_servicecatalog_TemplateTransform(in *TemplateTransform, out *servicecatalog.TemplateTransform, s conversion.Scope) error {
+	out.Key = in.Key
+	out.Template = in.Template
+	return nil
+}
+
+// Convert_v1beta1_TemplateTransform_To_servicecatalog_TemplateTransform is an autogenerated conversion function.
+func Convert_v1beta1_TemplateTransform_To_servicecatalog_TemplateTransform(in *TemplateTransform, out *servicecatalog.TemplateTransform, s conversion.Scope) error {
+	return autoConvert_v1beta1_TemplateTransform_To_servicecatalog_TemplateTransform(in, out, s)
+}
+
+func autoConvert_servicecatalog_TemplateTransform_To_v1beta1_TemplateTransform(in *servicecatalog.TemplateTransform, out *TemplateTransform, s conversion.Scope) error {
+	out.Key = in.Key
+	out.Template = in.Template
+	return nil
+}
+
+// Convert_servicecatalog_TemplateTransform_To_v1beta1_TemplateTransform is an autogenerated conversion function.
+func Convert_servicecatalog_TemplateTransform_To_v1beta1_TemplateTransform(in *servicecatalog.TemplateTransform, out *TemplateTransform, s conversion.Scope) error {
+	return autoConvert_servicecatalog_TemplateTransform_To_v1beta1_TemplateTransform(in, out, s)
+}
+
 func autoConvert_v1beta1_UserInfo_To_servicecatalog_UserInfo(in *UserInfo, out *servicecatalog.UserInfo, s conversion.Scope) error {
 	out.Username = in.Username
 	out.UID = in.UID
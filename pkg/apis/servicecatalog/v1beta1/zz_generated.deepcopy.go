@@ -89,6 +89,38 @@ func (in *AddKeysFromTransform) DeepCopy() *AddKeysFromTransform {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Base64DecodeTransform) DeepCopyInto(out *Base64DecodeTransform) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Base64DecodeTransform.
+func (in *Base64DecodeTransform) DeepCopy() *Base64DecodeTransform {
+	if in == nil {
+		return nil
+	}
+	out := new(Base64DecodeTransform)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Base64EncodeTransform) DeepCopyInto(out *Base64EncodeTransform) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Base64EncodeTransform.
+func (in *Base64EncodeTransform) DeepCopy() *Base64EncodeTransform {
+	if in == nil {
+		return nil
+	}
+	out := new(Base64EncodeTransform)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BasicAuthConfig) DeepCopyInto(out *BasicAuthConfig) {
 	*out = *in
@@ -139,6 +171,22 @@ func (in *BearerTokenAuthConfig) DeepCopy() *BearerTokenAuthConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BrokerRequestLimits) DeepCopyInto(out *BrokerRequestLimits) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrokerRequestLimits.
+func (in *BrokerRequestLimits) DeepCopy() *BrokerRequestLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(BrokerRequestLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CatalogRestrictions) DeepCopyInto(out *CatalogRestrictions) {
 	*out = *in
@@ -586,6 +634,15 @@ func (in *CommonServiceBrokerSpec) DeepCopyInto(out *CommonServiceBrokerSpec) {
 			(*in).DeepCopyInto(*out)
 		}
 	}
+	if in.RequestLimits != nil {
+		in, out := &in.RequestLimits, &out.RequestLimits
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(BrokerRequestLimits)
+			**out = **in
+		}
+	}
 	return
 }
 
@@ -755,6 +812,15 @@ func (in *CommonServicePlanSpec) DeepCopyInto(out *CommonServicePlanSpec) {
 			(*in).DeepCopyInto(*out)
 		}
 	}
+	if in.MaintenanceInfo != nil {
+		in, out := &in.MaintenanceInfo, &out.MaintenanceInfo
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(MaintenanceInfo)
+			**out = **in
+		}
+	}
 	return
 }
 
@@ -804,6 +870,22 @@ func (in ExtraValue) DeepCopy() ExtraValue {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlattenTransform) DeepCopyInto(out *FlattenTransform) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlattenTransform.
+func (in *FlattenTransform) DeepCopy() *FlattenTransform {
+	if in == nil {
+		return nil
+	}
+	out := new(FlattenTransform)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LocalObjectReference) DeepCopyInto(out *LocalObjectReference) {
 	*out = *in
@@ -820,6 +902,22 @@ func (in *LocalObjectReference) DeepCopy() *LocalObjectReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceInfo) DeepCopyInto(out *MaintenanceInfo) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceInfo.
+func (in *MaintenanceInfo) DeepCopy() *MaintenanceInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObjectReference) DeepCopyInto(out *ObjectReference) {
 	*out = *in
@@ -836,6 +934,27 @@ func (in *ObjectReference) DeepCopy() *ObjectReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OnlyKeysTransform) DeepCopyInto(out *OnlyKeysTransform) {
+	*out = *in
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OnlyKeysTransform.
+func (in *OnlyKeysTransform) DeepCopy() *OnlyKeysTransform {
+	if in == nil {
+		return nil
+	}
+	out := new(OnlyKeysTransform)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ParametersFromSource) DeepCopyInto(out *ParametersFromSource) {
 	*out = *in
@@ -909,6 +1028,30 @@ func (in *RenameKeyTransform) DeepCopy() *RenameKeyTransform {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetiredServiceBinding) DeepCopyInto(out *RetiredServiceBinding) {
+	*out = *in
+	if in.RetiredTime != nil {
+		in, out := &in.RetiredTime, &out.RetiredTime
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = (*in).DeepCopy()
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetiredServiceBinding.
+func (in *RetiredServiceBinding) DeepCopy() *RetiredServiceBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(RetiredServiceBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretKeyReference) DeepCopyInto(out *SecretKeyReference) {
 	*out = *in
@@ -964,6 +1107,51 @@ func (in *SecretTransform) DeepCopyInto(out *SecretTransform) {
 			**out = **in
 		}
 	}
+	if in.Template != nil {
+		in, out := &in.Template, &out.Template
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(TemplateTransform)
+			**out = **in
+		}
+	}
+	if in.Flatten != nil {
+		in, out := &in.Flatten, &out.Flatten
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(FlattenTransform)
+			**out = **in
+		}
+	}
+	if in.Base64Decode != nil {
+		in, out := &in.Base64Decode, &out.Base64Decode
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(Base64DecodeTransform)
+			**out = **in
+		}
+	}
+	if in.Base64Encode != nil {
+		in, out := &in.Base64Encode, &out.Base64Encode
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(Base64EncodeTransform)
+			**out = **in
+		}
+	}
+	if in.OnlyKeys != nil {
+		in, out := &in.OnlyKeys, &out.OnlyKeys
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(OnlyKeysTransform)
+			(*in).DeepCopyInto(*out)
+		}
+	}
 	return
 }
 
@@ -1055,6 +1243,43 @@ func (in *ServiceBindingList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceBindingOutput) DeepCopyInto(out *ServiceBindingOutput) {
+	*out = *in
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = make([]ServiceBindingOutputKey, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceBindingOutput.
+func (in *ServiceBindingOutput) DeepCopy() *ServiceBindingOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceBindingOutput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceBindingOutputKey) DeepCopyInto(out *ServiceBindingOutputKey) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceBindingOutputKey.
+func (in *ServiceBindingOutputKey) DeepCopy() *ServiceBindingOutputKey {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceBindingOutputKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceBindingPropertiesState) DeepCopyInto(out *ServiceBindingPropertiesState) {
 	*out = *in
@@ -1116,6 +1341,13 @@ func (in *ServiceBindingSpec) DeepCopyInto(out *ServiceBindingSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make([]ServiceBindingOutput, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.UserInfo != nil {
 		in, out := &in.UserInfo, &out.UserInfo
 		if *in == nil {
@@ -1183,6 +1415,13 @@ func (in *ServiceBindingStatus) DeepCopyInto(out *ServiceBindingStatus) {
 			(*in).DeepCopyInto(*out)
 		}
 	}
+	if in.RetiredBindings != nil {
+		in, out := &in.RetiredBindings, &out.RetiredBindings
+		*out = make([]RetiredServiceBinding, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -1524,6 +1763,41 @@ func (in *ServiceInstanceList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceInstanceOperationRecord) DeepCopyInto(out *ServiceInstanceOperationRecord) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	in.CompletionTime.DeepCopyInto(&out.CompletionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceInstanceOperationRecord.
+func (in *ServiceInstanceOperationRecord) DeepCopy() *ServiceInstanceOperationRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceInstanceOperationRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceInstanceOperationRetry) DeepCopyInto(out *ServiceInstanceOperationRetry) {
+	*out = *in
+	in.NextRetryTime.DeepCopyInto(&out.NextRetryTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceInstanceOperationRetry.
+func (in *ServiceInstanceOperationRetry) DeepCopy() *ServiceInstanceOperationRetry {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceInstanceOperationRetry)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceInstancePropertiesState) DeepCopyInto(out *ServiceInstancePropertiesState) {
 	*out = *in
@@ -1545,6 +1819,15 @@ func (in *ServiceInstancePropertiesState) DeepCopyInto(out *ServiceInstancePrope
 			(*in).DeepCopyInto(*out)
 		}
 	}
+	if in.MaintenanceInfo != nil {
+		in, out := &in.MaintenanceInfo, &out.MaintenanceInfo
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(MaintenanceInfo)
+			**out = **in
+		}
+	}
 	return
 }
 
@@ -1699,6 +1982,22 @@ func (in *ServiceInstanceStatus) DeepCopyInto(out *ServiceInstanceStatus) {
 			(*in).DeepCopyInto(*out)
 		}
 	}
+	if in.OperationRetry != nil {
+		in, out := &in.OperationRetry, &out.OperationRetry
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(ServiceInstanceOperationRetry)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.OperationHistory != nil {
+		in, out := &in.OperationHistory, &out.OperationHistory
+		*out = make([]ServiceInstanceOperationRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -1808,6 +2107,22 @@ func (in *ServicePlanStatus) DeepCopy() *ServicePlanStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateTransform) DeepCopyInto(out *TemplateTransform) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateTransform.
+func (in *TemplateTransform) DeepCopy() *TemplateTransform {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateTransform)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UserInfo) DeepCopyInto(out *UserInfo) {
 	*out = *in
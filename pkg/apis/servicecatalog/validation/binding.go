@@ -17,10 +17,16 @@ limitations under the License.
 package validation
 
 import (
+	"fmt"
+	"strings"
+	"text/template"
+
 	"github.com/ghodss/yaml"
 	sc "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
 	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
 	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/labels"
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
 )
@@ -28,6 +34,14 @@ import (
 // validateServiceBindingName is the validation function for ServiceBinding names.
 var validateServiceBindingName = apivalidation.NameIsDNSSubdomain
 
+// validServiceBindingRestartWorkloadKinds are the kinds of the workloads that
+// can be restarted when the credentials of a binding change.
+var validServiceBindingRestartWorkloadKinds = map[string]bool{
+	"deployment":  true,
+	"statefulset": true,
+	"daemonset":   true,
+}
+
 var validServiceBindingOperations = map[sc.ServiceBindingOperation]bool{
 	sc.ServiceBindingOperation(""):   true,
 	sc.ServiceBindingOperationBind:   true,
@@ -61,6 +75,26 @@ var validServiceBindingUnbindStatusValues = func() []string {
 	return validValues
 }()
 
+// requiredServiceBindingOutputSecretKeys holds the types of Secret that a
+// ServiceBinding can write its credentials to, and the keys that a Secret of
+// each type must hold.
+var requiredServiceBindingOutputSecretKeys = map[string][]string{
+	"Opaque":                         nil,
+	"kubernetes.io/tls":              {"tls.crt", "tls.key"},
+	"kubernetes.io/dockerconfigjson": {".dockerconfigjson"},
+}
+
+var validServiceBindingOutputSecretTypeValues = []string{
+	"Opaque",
+	"kubernetes.io/tls",
+	"kubernetes.io/dockerconfigjson",
+}
+
+var validServiceBindingOutputKindValues = []string{
+	string(sc.ServiceBindingOutputKindSecret),
+	string(sc.ServiceBindingOutputKindConfigMap),
+}
+
 // ValidateServiceBinding validates a ServiceBinding and returns a list of errors.
 func ValidateServiceBinding(binding *sc.ServiceBinding) field.ErrorList {
 	return internalValidateServiceBinding(binding, true)
@@ -71,6 +105,7 @@ func internalValidateServiceBinding(binding *sc.ServiceBinding, create bool) fie
 	allErrs = append(allErrs, apivalidation.ValidateObjectMeta(&binding.ObjectMeta, true, /*namespace*/
 		validateServiceBindingName,
 		field.NewPath("metadata"))...)
+	allErrs = append(allErrs, validateServiceBindingRestartWorkloads(binding.Annotations, field.NewPath("metadata", "annotations"))...)
 	allErrs = append(allErrs, validateServiceBindingSpec(&binding.Spec, field.NewPath("spec"), create)...)
 	if create {
 		allErrs = append(allErrs, validateServiceBindingCreate(binding)...)
@@ -80,6 +115,38 @@ func internalValidateServiceBinding(binding *sc.ServiceBinding, create bool) fie
 	return allErrs
 }
 
+func validateServiceBindingRestartWorkloads(annotations map[string]string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if workloads, ok := annotations[sc.ServiceBindingRestartWorkloadsAnnotation]; ok {
+		workloadsPath := fldPath.Key(sc.ServiceBindingRestartWorkloadsAnnotation)
+		for _, workload := range strings.Split(workloads, ",") {
+			parts := strings.Split(strings.TrimSpace(workload), "/")
+			if len(parts) != 2 {
+				allErrs = append(allErrs, field.Invalid(workloadsPath, workloads, fmt.Sprintf("%q must be of the form <kind>/<name>", workload)))
+				continue
+			}
+			if !validServiceBindingRestartWorkloadKinds[parts[0]] {
+				allErrs = append(allErrs, field.Invalid(workloadsPath, workloads, fmt.Sprintf("%q must be one of deployment, statefulset or daemonset", parts[0])))
+			}
+			for _, msg := range apivalidation.NameIsDNSSubdomain(parts[1], false /* prefix */) {
+				allErrs = append(allErrs, field.Invalid(workloadsPath, workloads, fmt.Sprintf("%q: %s", parts[1], msg)))
+			}
+		}
+	}
+
+	if selector, ok := annotations[sc.ServiceBindingRestartWorkloadsSelectorAnnotation]; ok {
+		selectorPath := fldPath.Key(sc.ServiceBindingRestartWorkloadsSelectorAnnotation)
+		if _, err := labels.Parse(selector); err != nil {
+			allErrs = append(allErrs, field.Invalid(selectorPath, selector, err.Error()))
+		} else if strings.TrimSpace(selector) == "" {
+			allErrs = append(allErrs, field.Invalid(selectorPath, selector, "the selector must not select every workload"))
+		}
+	}
+
+	return allErrs
+}
+
 func validateServiceBindingSpec(spec *sc.ServiceBindingSpec, fldPath *field.Path, create bool) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -95,6 +162,161 @@ func validateServiceBindingSpec(spec *sc.ServiceBindingSpec, fldPath *field.Path
 		allErrs = append(allErrs, validateParametersFromSource(spec.ParametersFrom, fldPath)...)
 	}
 
+	allErrs = append(allErrs, apivalidation.ValidateNonnegativeField(spec.RotationRequests, fldPath.Child("rotationRequests"))...)
+
+	allErrs = append(allErrs, validateSecretTransforms(spec.SecretTransforms, fldPath.Child("secretTransforms"))...)
+
+	allErrs = append(allErrs, validateServiceBindingOutputs(spec.Outputs, spec.SecretName, fldPath.Child("outputs"))...)
+
+	return allErrs
+}
+
+func validateSecretTransforms(transforms []sc.SecretTransform, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for i, transform := range transforms {
+		transformPath := fldPath.Index(i)
+
+		members := 0
+		for _, set := range []bool{
+			transform.RenameKey != nil,
+			transform.AddKey != nil,
+			transform.AddKeysFrom != nil,
+			transform.RemoveKey != nil,
+			transform.Template != nil,
+			transform.Flatten != nil,
+			transform.Base64Decode != nil,
+			transform.Base64Encode != nil,
+			transform.OnlyKeys != nil,
+		} {
+			if set {
+				members++
+			}
+		}
+		if members == 0 {
+			allErrs = append(allErrs, field.Required(transformPath, "a transform must be specified"))
+		} else if members > 1 {
+			allErrs = append(allErrs, field.Forbidden(transformPath, "only one transform may be specified"))
+		}
+
+		if t := transform.Template; t != nil {
+			templatePath := transformPath.Child("template")
+			allErrs = append(allErrs, validateSecretTransformKey(t.Key, templatePath.Child("key"))...)
+			if t.Template == "" {
+				allErrs = append(allErrs, field.Required(templatePath.Child("template"), "template is required"))
+			} else if _, err := template.New("template").Parse(t.Template); err != nil {
+				allErrs = append(allErrs, field.Invalid(templatePath.Child("template"), t.Template, err.Error()))
+			}
+		}
+
+		if t := transform.Flatten; t != nil && t.Separator != "" {
+			// The separator ends up in the flattened keys.
+			for _, msg := range utilvalidation.IsConfigMapKey("a" + t.Separator + "b") {
+				allErrs = append(allErrs, field.Invalid(transformPath.Child("flatten", "separator"), t.Separator, msg))
+			}
+		}
+
+		if t := transform.Base64Decode; t != nil {
+			allErrs = append(allErrs, validateSecretTransformKey(t.Key, transformPath.Child("base64Decode", "key"))...)
+		}
+
+		if t := transform.Base64Encode; t != nil {
+			allErrs = append(allErrs, validateSecretTransformKey(t.Key, transformPath.Child("base64Encode", "key"))...)
+		}
+
+		if t := transform.OnlyKeys; t != nil {
+			keysPath := transformPath.Child("onlyKeys", "keys")
+			if len(t.Keys) == 0 {
+				allErrs = append(allErrs, field.Required(keysPath, "at least one key is required"))
+			}
+			for j, key := range t.Keys {
+				allErrs = append(allErrs, validateSecretTransformKey(key, keysPath.Index(j))...)
+			}
+		}
+	}
+
+	return allErrs
+}
+
+func validateSecretTransformKey(key string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if key == "" {
+		return append(allErrs, field.Required(fldPath, "key is required"))
+	}
+	for _, msg := range utilvalidation.IsConfigMapKey(key) {
+		allErrs = append(allErrs, field.Invalid(fldPath, key, msg))
+	}
+	return allErrs
+}
+
+func validateServiceBindingOutputs(outputs []sc.ServiceBindingOutput, secretName string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	names := map[sc.ServiceBindingOutputKind]map[string]bool{}
+	for i, output := range outputs {
+		outputPath := fldPath.Index(i)
+
+		for _, msg := range apivalidation.NameIsDNSSubdomain(output.Name, false /* prefix */) {
+			allErrs = append(allErrs, field.Invalid(outputPath.Child("name"), output.Name, msg))
+		}
+
+		switch output.Kind {
+		case sc.ServiceBindingOutputKindSecret:
+			if output.Name == secretName {
+				allErrs = append(allErrs, field.Invalid(outputPath.Child("name"), output.Name, "name must be different from secretName"))
+			}
+			if _, ok := requiredServiceBindingOutputSecretKeys[output.SecretType]; output.SecretType != "" && !ok {
+				allErrs = append(allErrs, field.NotSupported(outputPath.Child("secretType"), output.SecretType, validServiceBindingOutputSecretTypeValues))
+			}
+		case sc.ServiceBindingOutputKindConfigMap:
+			if output.SecretType != "" {
+				allErrs = append(allErrs, field.Forbidden(outputPath.Child("secretType"), "secretType must not be set for a ConfigMap"))
+			}
+			if len(output.Keys) == 0 {
+				allErrs = append(allErrs, field.Required(outputPath.Child("keys"), "the keys written to a ConfigMap must be selected explicitly"))
+			}
+		default:
+			allErrs = append(allErrs, field.NotSupported(outputPath.Child("kind"), output.Kind, validServiceBindingOutputKindValues))
+		}
+
+		if names[output.Kind] == nil {
+			names[output.Kind] = map[string]bool{}
+		}
+		if names[output.Kind][output.Name] {
+			allErrs = append(allErrs, field.Duplicate(outputPath.Child("name"), output.Name))
+		}
+		names[output.Kind][output.Name] = true
+
+		targetKeys := map[string]bool{}
+		for j, key := range output.Keys {
+			keyPath := outputPath.Child("keys").Index(j)
+			if key.Key == "" {
+				allErrs = append(allErrs, field.Required(keyPath.Child("key"), "key is required"))
+			}
+			targetKey := key.TargetKey
+			if targetKey == "" {
+				targetKey = key.Key
+			}
+			for _, msg := range utilvalidation.IsConfigMapKey(targetKey) {
+				allErrs = append(allErrs, field.Invalid(keyPath.Child("targetKey"), targetKey, msg))
+			}
+			if targetKeys[targetKey] {
+				allErrs = append(allErrs, field.Duplicate(keyPath.Child("targetKey"), targetKey))
+			}
+			targetKeys[targetKey] = true
+		}
+
+		// The keys required by the type of the Secret can only be checked
+		// here when they are selected explicitly.
+		if len(output.Keys) > 0 {
+			for _, required := range requiredServiceBindingOutputSecretKeys[output.SecretType] {
+				if !targetKeys[required] {
+					allErrs = append(allErrs, field.Required(outputPath.Child("keys"), fmt.Sprintf("a Secret of type %q must hold the key %q", output.SecretType, required)))
+				}
+			}
+		}
+	}
+
 	return allErrs
 }
 
@@ -148,6 +370,12 @@ func validateServiceBindingStatus(status *sc.ServiceBindingStatus, fldPath *fiel
 		allErrs = append(allErrs, validateServiceBindingPropertiesState(status.ExternalProperties, fldPath.Child("externalProperties"), create)...)
 	}
 
+	for i, retired := range status.RetiredBindings {
+		if retired.ExternalID == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("retiredBindings").Index(i).Child("externalID"), "externalID is required"))
+		}
+	}
+
 	if create {
 		if status.UnbindStatus != sc.ServiceBindingUnbindStatusNotRequired {
 			allErrs = append(allErrs, field.Invalid(fldPath.Child("unbindStatus"), status.UnbindStatus, `unbindStatus must be "NotRequired" on create`))
@@ -236,6 +464,11 @@ func ValidateServiceBindingUpdate(new *sc.ServiceBinding, old *sc.ServiceBinding
 	allErrs := field.ErrorList{}
 	allErrs = append(allErrs, internalValidateServiceBindingUpdateAllowed(new, old)...)
 	allErrs = append(allErrs, internalValidateServiceBinding(new, false)...)
+
+	if new.Spec.RotationRequests < old.Spec.RotationRequests {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("rotationRequests"), new.Spec.RotationRequests, "new rotationRequests value must not be less than the old one"))
+	}
+
 	return allErrs
 }
 
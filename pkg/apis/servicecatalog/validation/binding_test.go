@@ -564,6 +564,352 @@ func TestValidateServiceBinding(t *testing.T) {
 			}(),
 			valid: true,
 		},
+		{
+			name: "negative rotationRequests",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.RotationRequests = -1
+				return b
+			}(),
+			valid: false,
+		},
+		{
+			name: "retired binding",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Status.RetiredBindings = []servicecatalog.RetiredServiceBinding{{ExternalID: "old-id"}}
+				return b
+			}(),
+			valid: true,
+		},
+		{
+			name: "retired binding without externalID",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Status.RetiredBindings = []servicecatalog.RetiredServiceBinding{{}}
+				return b
+			}(),
+			valid: false,
+		},
+		{
+			name: "valid outputs",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.Outputs = []servicecatalog.ServiceBindingOutput{
+					{Name: "test-config", Kind: servicecatalog.ServiceBindingOutputKindConfigMap, Keys: []servicecatalog.ServiceBindingOutputKey{{Key: "uri", TargetKey: "DB_URI"}}},
+					{Name: "test-config", Kind: servicecatalog.ServiceBindingOutputKindSecret},
+					{Name: "test-tls", Kind: servicecatalog.ServiceBindingOutputKindSecret, SecretType: "kubernetes.io/tls", Keys: []servicecatalog.ServiceBindingOutputKey{{Key: "cert", TargetKey: "tls.crt"}, {Key: "tls.key"}}},
+				}
+				return b
+			}(),
+			valid: true,
+		},
+		{
+			name: "output with invalid name",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.Outputs = []servicecatalog.ServiceBindingOutput{
+					{Name: "Test_Config", Kind: servicecatalog.ServiceBindingOutputKindConfigMap, Keys: []servicecatalog.ServiceBindingOutputKey{{Key: "uri"}}},
+				}
+				return b
+			}(),
+			valid: false,
+		},
+		{
+			name: "output with unsupported kind",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.Outputs = []servicecatalog.ServiceBindingOutput{
+					{Name: "test-config", Kind: "Pod"},
+				}
+				return b
+			}(),
+			valid: false,
+		},
+		{
+			name: "output secret named after secretName",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.Outputs = []servicecatalog.ServiceBindingOutput{
+					{Name: "test-secret", Kind: servicecatalog.ServiceBindingOutputKindSecret},
+				}
+				return b
+			}(),
+			valid: false,
+		},
+		{
+			name: "duplicate outputs",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.Outputs = []servicecatalog.ServiceBindingOutput{
+					{Name: "test-config", Kind: servicecatalog.ServiceBindingOutputKindConfigMap, Keys: []servicecatalog.ServiceBindingOutputKey{{Key: "uri"}}},
+					{Name: "test-config", Kind: servicecatalog.ServiceBindingOutputKindConfigMap, Keys: []servicecatalog.ServiceBindingOutputKey{{Key: "port"}}},
+				}
+				return b
+			}(),
+			valid: false,
+		},
+		{
+			name: "output with unsupported secretType",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.Outputs = []servicecatalog.ServiceBindingOutput{
+					{Name: "test-token", Kind: servicecatalog.ServiceBindingOutputKindSecret, SecretType: "kubernetes.io/service-account-token"},
+				}
+				return b
+			}(),
+			valid: false,
+		},
+		{
+			name: "configmap output with secretType",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.Outputs = []servicecatalog.ServiceBindingOutput{
+					{Name: "test-config", Kind: servicecatalog.ServiceBindingOutputKindConfigMap, SecretType: "Opaque", Keys: []servicecatalog.ServiceBindingOutputKey{{Key: "uri"}}},
+				}
+				return b
+			}(),
+			valid: false,
+		},
+		{
+			name: "configmap output without keys",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.Outputs = []servicecatalog.ServiceBindingOutput{
+					{Name: "test-config", Kind: servicecatalog.ServiceBindingOutputKindConfigMap},
+				}
+				return b
+			}(),
+			valid: false,
+		},
+		{
+			name: "output key without key",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.Outputs = []servicecatalog.ServiceBindingOutput{
+					{Name: "test-config", Kind: servicecatalog.ServiceBindingOutputKindConfigMap, Keys: []servicecatalog.ServiceBindingOutputKey{{TargetKey: "DB_URI"}}},
+				}
+				return b
+			}(),
+			valid: false,
+		},
+		{
+			name: "output key with invalid targetKey",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.Outputs = []servicecatalog.ServiceBindingOutput{
+					{Name: "test-config", Kind: servicecatalog.ServiceBindingOutputKindConfigMap, Keys: []servicecatalog.ServiceBindingOutputKey{{Key: "uri", TargetKey: "db/uri"}}},
+				}
+				return b
+			}(),
+			valid: false,
+		},
+		{
+			name: "output keys with duplicate targetKey",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.Outputs = []servicecatalog.ServiceBindingOutput{
+					{Name: "test-config", Kind: servicecatalog.ServiceBindingOutputKindConfigMap, Keys: []servicecatalog.ServiceBindingOutputKey{{Key: "uri", TargetKey: "url"}, {Key: "url"}}},
+				}
+				return b
+			}(),
+			valid: false,
+		},
+		{
+			name: "tls output missing a required key",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.Outputs = []servicecatalog.ServiceBindingOutput{
+					{Name: "test-tls", Kind: servicecatalog.ServiceBindingOutputKindSecret, SecretType: "kubernetes.io/tls", Keys: []servicecatalog.ServiceBindingOutputKey{{Key: "tls.crt"}}},
+				}
+				return b
+			}(),
+			valid: false,
+		},
+		{
+			name: "valid secret transforms",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.SecretTransforms = []servicecatalog.SecretTransform{
+					{Flatten: &servicecatalog.FlattenTransform{Key: "credentials", Separator: "."}},
+					{Base64Decode: &servicecatalog.Base64DecodeTransform{Key: "ca"}},
+					{Base64Encode: &servicecatalog.Base64EncodeTransform{Key: "password"}},
+					{Template: &servicecatalog.TemplateTransform{Key: "uri", Template: "postgres://{{ .username }}:{{ .password }}@{{ .host }}"}},
+					{OnlyKeys: &servicecatalog.OnlyKeysTransform{Keys: []string{"uri", "ca"}}},
+				}
+				return b
+			}(),
+			valid: true,
+		},
+		{
+			name: "empty secret transform",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.SecretTransforms = []servicecatalog.SecretTransform{
+					{},
+				}
+				return b
+			}(),
+			valid: false,
+		},
+		{
+			name: "secret transform with several transforms",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.SecretTransforms = []servicecatalog.SecretTransform{
+					{
+						RenameKey: &servicecatalog.RenameKeyTransform{From: "a", To: "b"},
+						RemoveKey: &servicecatalog.RemoveKeyTransform{Key: "c"},
+					},
+				}
+				return b
+			}(),
+			valid: false,
+		},
+		{
+			name: "template transform without key",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.SecretTransforms = []servicecatalog.SecretTransform{
+					{Template: &servicecatalog.TemplateTransform{Template: "{{ .host }}"}},
+				}
+				return b
+			}(),
+			valid: false,
+		},
+		{
+			name: "template transform with malformed template",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.SecretTransforms = []servicecatalog.SecretTransform{
+					{Template: &servicecatalog.TemplateTransform{Key: "uri", Template: "{{ .host "}},
+				}
+				return b
+			}(),
+			valid: false,
+		},
+		{
+			name: "flatten transform with invalid separator",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.SecretTransforms = []servicecatalog.SecretTransform{
+					{Flatten: &servicecatalog.FlattenTransform{Separator: "/"}},
+				}
+				return b
+			}(),
+			valid: false,
+		},
+		{
+			name: "base64Decode transform without key",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.SecretTransforms = []servicecatalog.SecretTransform{
+					{Base64Decode: &servicecatalog.Base64DecodeTransform{}},
+				}
+				return b
+			}(),
+			valid: false,
+		},
+		{
+			name: "base64Encode transform with invalid key",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.SecretTransforms = []servicecatalog.SecretTransform{
+					{Base64Encode: &servicecatalog.Base64EncodeTransform{Key: "a b"}},
+				}
+				return b
+			}(),
+			valid: false,
+		},
+		{
+			name: "onlyKeys transform without keys",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.SecretTransforms = []servicecatalog.SecretTransform{
+					{OnlyKeys: &servicecatalog.OnlyKeysTransform{}},
+				}
+				return b
+			}(),
+			valid: false,
+		},
+		{
+			name: "onlyKeys transform with empty key",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.SecretTransforms = []servicecatalog.SecretTransform{
+					{OnlyKeys: &servicecatalog.OnlyKeysTransform{Keys: []string{"uri", ""}}},
+				}
+				return b
+			}(),
+			valid: false,
+		},
+		{
+			name: "valid restart workloads",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Annotations = map[string]string{
+					servicecatalog.ServiceBindingRestartWorkloadsAnnotation:         "deployment/web, statefulset/worker,daemonset/agent",
+					servicecatalog.ServiceBindingRestartWorkloadsSelectorAnnotation: "app=web",
+				}
+				return b
+			}(),
+			valid: true,
+		},
+		{
+			name: "restart workload without kind",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Annotations = map[string]string{
+					servicecatalog.ServiceBindingRestartWorkloadsAnnotation: "web",
+				}
+				return b
+			}(),
+			valid: false,
+		},
+		{
+			name: "restart workload with unsupported kind",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Annotations = map[string]string{
+					servicecatalog.ServiceBindingRestartWorkloadsAnnotation: "replicaset/web",
+				}
+				return b
+			}(),
+			valid: false,
+		},
+		{
+			name: "restart workload with invalid name",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Annotations = map[string]string{
+					servicecatalog.ServiceBindingRestartWorkloadsAnnotation: "deployment/Web_App",
+				}
+				return b
+			}(),
+			valid: false,
+		},
+		{
+			name: "malformed restart workloads selector",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Annotations = map[string]string{
+					servicecatalog.ServiceBindingRestartWorkloadsSelectorAnnotation: "app in (web",
+				}
+				return b
+			}(),
+			valid: false,
+		},
+		{
+			name: "empty restart workloads selector",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Annotations = map[string]string{
+					servicecatalog.ServiceBindingRestartWorkloadsSelectorAnnotation: "",
+				}
+				return b
+			}(),
+			valid: false,
+		},
 	}
 
 	for _, tc := range cases {
@@ -639,3 +985,52 @@ func TestInternalValidateServiceBindingUpdateAllowed(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateServiceBindingUpdateRotationRequests(t *testing.T) {
+	cases := []struct {
+		name                string
+		oldRotationRequests int64
+		newRotationRequests int64
+		valid               bool
+	}{
+		{
+			name:                "unchanged",
+			oldRotationRequests: 1,
+			newRotationRequests: 1,
+			valid:               true,
+		},
+		{
+			name:                "incremented",
+			oldRotationRequests: 1,
+			newRotationRequests: 2,
+			valid:               true,
+		},
+		{
+			name:                "decremented",
+			oldRotationRequests: 2,
+			newRotationRequests: 1,
+			valid:               false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			oldBinding := validServiceBinding()
+			oldBinding.Generation = 1
+			oldBinding.Status.ReconciledGeneration = 1
+			oldBinding.Spec.RotationRequests = tc.oldRotationRequests
+
+			newBinding := validServiceBinding()
+			newBinding.Generation = 2
+			newBinding.Status.ReconciledGeneration = 1
+			newBinding.Spec.RotationRequests = tc.newRotationRequests
+
+			errs := ValidateServiceBindingUpdate(newBinding, oldBinding)
+			if len(errs) != 0 && tc.valid {
+				t.Errorf("unexpected error: %v", errs)
+			} else if len(errs) == 0 && !tc.valid {
+				t.Error("unexpected success")
+			}
+		})
+	}
+}
@@ -208,6 +208,10 @@ func validateCommonServiceBrokerSpec(spec *sc.CommonServiceBrokerSpec, fldPath *
 		}
 	}
 
+	if spec.RequestLimits != nil {
+		commonErrs = append(commonErrs, validateBrokerRequestLimits(spec.RequestLimits, fldPath.Child("requestLimits"))...)
+	}
+
 	if spec.CatalogRestrictions != nil && len(spec.CatalogRestrictions.ServiceClass) > 0 {
 		// confirm that the restrictions can turn into a predicate.
 		_, err := filter.CreatePredicate(spec.CatalogRestrictions.ServiceClass)
@@ -250,6 +254,24 @@ func validateCommonServiceBrokerSpec(spec *sc.CommonServiceBrokerSpec, fldPath *
 	return commonErrs
 }
 
+func validateBrokerRequestLimits(limits *sc.BrokerRequestLimits, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if limits.QPS < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("qps"), limits.QPS, "qps must not be negative"))
+	}
+	if limits.Burst < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("burst"), limits.Burst, "burst must not be negative"))
+	} else if limits.Burst > 0 && limits.QPS == 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("burst"), limits.Burst, "burst requires qps to be set"))
+	}
+	if limits.MaxInFlightRequests < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxInFlightRequests"), limits.MaxInFlightRequests, "maxInFlightRequests must not be negative"))
+	}
+
+	return allErrs
+}
+
 // ValidateClusterServiceBrokerUpdate checks that when changing from an older broker to a newer broker is okay ?
 func ValidateClusterServiceBrokerUpdate(new *sc.ClusterServiceBroker, old *sc.ClusterServiceBroker) field.ErrorList {
 	allErrs := validateCommonServiceBrokerUpdate(&new.Spec.CommonServiceBrokerSpec, &old.Spec.CommonServiceBrokerSpec)
@@ -0,0 +1,267 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	osb "github.com/pmorie/go-open-service-broker-client/v2"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-incubator/service-catalog/pkg/osbclient"
+)
+
+const (
+	errorBrokerUnavailableReason string = "BrokerUnavailable"
+	brokerDegradedReason         string = "BrokerDegraded"
+	brokerRecoveredReason        string = "BrokerRecovered"
+
+	// brokerCircuitBreakerWindow is the number of recent requests to a broker
+	// that the failure rate of the broker is computed over.
+	brokerCircuitBreakerWindow = 20
+)
+
+// brokerCircuitBreaker tracks the outcome of the requests sent to a broker.
+// The circuit opens after too many consecutive requests failed, and stays
+// open until the broker answers a catalog request again.
+type brokerCircuitBreaker struct {
+	mutex         sync.Mutex
+	threshold     int
+	probeInterval time.Duration
+
+	consecutiveFailures int
+	// results is a ring of the outcomes of the latest requests, true for a
+	// failed request.
+	results       [brokerCircuitBreakerWindow]bool
+	resultCount   int
+	nextResult    int
+	open          bool
+	nextProbeTime time.Time
+}
+
+// isBrokerFailure returns whether the given outcome of a request indicates
+// that the broker is unhealthy: the broker could not be reached, timed out or
+// responded with a server error.
+func isBrokerFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	if httpErr, ok := osb.IsHTTPError(err); ok {
+		return httpErr.StatusCode >= 500
+	}
+	_, ok := err.(*url.Error)
+	return ok
+}
+
+// record records the outcome of a request, and returns true when the request
+// caused the circuit to open.
+func (b *brokerCircuitBreaker) record(err error) bool {
+	failed := isBrokerFailure(err)
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.results[b.nextResult] = failed
+	b.nextResult = (b.nextResult + 1) % brokerCircuitBreakerWindow
+	if b.resultCount < brokerCircuitBreakerWindow {
+		b.resultCount++
+	}
+	if !failed {
+		b.consecutiveFailures = 0
+		return false
+	}
+	b.consecutiveFailures++
+	if b.open || b.consecutiveFailures < b.threshold {
+		return false
+	}
+	b.open = true
+	b.nextProbeTime = time.Now().Add(b.probeInterval)
+	return true
+}
+
+func (b *brokerCircuitBreaker) isOpen() bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.open
+}
+
+// timeUntilProbe returns how long to wait before probing the broker again.
+func (b *brokerCircuitBreaker) timeUntilProbe(now time.Time) time.Duration {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.nextProbeTime.Sub(now)
+}
+
+// probeFailed schedules the next probe after a failed probe.
+func (b *brokerCircuitBreaker) probeFailed(now time.Time) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.nextProbeTime = now.Add(b.probeInterval)
+}
+
+// close closes the circuit after a successful probe.
+func (b *brokerCircuitBreaker) close() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.open = false
+	b.consecutiveFailures = 0
+	b.results = [brokerCircuitBreakerWindow]bool{}
+	b.resultCount = 0
+	b.nextResult = 0
+}
+
+// failureRate returns the percentage of failed requests among the latest
+// requests to the broker.
+func (b *brokerCircuitBreaker) failureRate() int {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if b.resultCount == 0 {
+		return 0
+	}
+	failures := 0
+	for i := 0; i < b.resultCount; i++ {
+		if b.results[i] {
+			failures++
+		}
+	}
+	return failures * 100 / b.resultCount
+}
+
+// degradedMessage describes why the circuit of the broker is open.
+func (b *brokerCircuitBreaker) degradedMessage() string {
+	rate := b.failureRate()
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return fmt.Sprintf(
+		"%d consecutive requests to the broker failed (%d%% of the last %d requests); requests to the broker are suspended until it answers a catalog request",
+		b.consecutiveFailures, rate, b.resultCount,
+	)
+}
+
+// brokerCircuitBreakers holds the circuit breakers of the brokers, so that
+// the outcome of requests is tracked across reconciliations.
+type brokerCircuitBreakers struct {
+	mutex sync.Mutex
+	// threshold is the number of consecutive failed requests that opens the
+	// circuit of a broker; zero disables the circuit breakers.
+	threshold     int
+	probeInterval time.Duration
+	breakers      map[string]*brokerCircuitBreaker // Key is namespace/name, or name for cluster brokers
+}
+
+// get returns the circuit breaker of a broker, or nil when the circuit
+// breakers are disabled.
+func (b *brokerCircuitBreakers) get(brokerMeta metav1.ObjectMeta) *brokerCircuitBreaker {
+	if b.threshold <= 0 {
+		return nil
+	}
+	key := brokerKey(brokerMeta)
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	breaker, ok := b.breakers[key]
+	if !ok {
+		breaker = &brokerCircuitBreaker{
+			threshold:     b.threshold,
+			probeInterval: b.probeInterval,
+		}
+		b.breakers[key] = breaker
+	}
+	return breaker
+}
+
+// remove drops the circuit breaker of a deleted broker.
+func (b *brokerCircuitBreakers) remove(brokerMeta metav1.ObjectMeta) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	delete(b.breakers, brokerKey(brokerMeta))
+}
+
+func newBrokerUnavailableError(brokerMeta metav1.ObjectMeta) error {
+	return &operationError{
+		reason: errorBrokerUnavailableReason,
+		message: fmt.Sprintf(
+			"Requests to broker %q are suspended because too many of them failed; waiting for the broker to recover",
+			brokerKey(brokerMeta),
+		),
+	}
+}
+
+// isBrokerUnavailableError returns whether the given error was returned
+// because the circuit of a broker is open.
+func isBrokerUnavailableError(err error) bool {
+	opErr, ok := err.(*operationError)
+	return ok && opErr.reason == errorBrokerUnavailableReason
+}
+
+// isServiceBrokerDegraded returns whether the given broker status has a
+// Degraded condition with status true.
+func isServiceBrokerDegraded(status *v1beta1.CommonServiceBrokerStatus) bool {
+	for _, cond := range status.Conditions {
+		if cond.Type == v1beta1.ServiceBrokerConditionDegraded {
+			return cond.Status == v1beta1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// brokerCircuitOpened queues the broker whose circuit opened, so that its
+// Degraded condition gets set and the broker gets probed.
+func (c *controller) brokerCircuitOpened(brokerMeta metav1.ObjectMeta) {
+	key := brokerKey(brokerMeta)
+	glog.Warningf("Suspending requests to broker %q because too many of them failed", key)
+	if brokerMeta.Namespace == "" {
+		c.clusterServiceBrokerQueue.Add(key)
+	} else {
+		c.serviceBrokerQueue.Add(key)
+	}
+}
+
+// probeBroker requests the catalog of a broker whose circuit is open once
+// the probe interval has elapsed. It returns true when the broker recovered
+// and its circuit was closed. When the probe is not due yet, or when the
+// broker did not answer, the broker is queued for the next probe.
+func (c *controller) probeBroker(breaker *brokerCircuitBreaker, brokerMeta metav1.ObjectMeta, queue func(time.Duration), newClient func() (osbclient.Client, error)) bool {
+	now := time.Now()
+	if wait := breaker.timeUntilProbe(now); wait > 0 {
+		queue(wait)
+		return false
+	}
+
+	brokerClient, err := newClient()
+	if err == nil {
+		_, err = brokerClient.GetCatalog()
+	}
+	if err != nil {
+		glog.V(4).Infof("Broker %q is still unavailable: %v", brokerKey(brokerMeta), err)
+		breaker.probeFailed(now)
+		queue(breaker.probeInterval)
+		return false
+	}
+
+	glog.Infof("Resuming requests to broker %q", brokerKey(brokerMeta))
+	breaker.close()
+	return true
+}
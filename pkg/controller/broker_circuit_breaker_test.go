@@ -0,0 +1,245 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	osb "github.com/pmorie/go-open-service-broker-client/v2"
+	fakeosb "github.com/pmorie/go-open-service-broker-client/v2/fake"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+)
+
+func TestIsBrokerFailure(t *testing.T) {
+	cases := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "success", err: nil, expected: false},
+		{name: "server error", err: osb.HTTPStatusCodeError{StatusCode: 503}, expected: true},
+		{name: "client error", err: osb.HTTPStatusCodeError{StatusCode: 409}, expected: false},
+		{name: "transport error", err: &url.Error{Op: "Get", URL: "https://broker", Err: errors.New("connection refused")}, expected: true},
+		{name: "other error", err: errors.New("unexpected end of JSON input"), expected: false},
+	}
+	for _, tc := range cases {
+		if e, a := tc.expected, isBrokerFailure(tc.err); e != a {
+			t.Errorf("%v: expected %v, got %v", tc.name, e, a)
+		}
+	}
+}
+
+func TestBrokerCircuitBreakerRecord(t *testing.T) {
+	breaker := &brokerCircuitBreaker{threshold: 3, probeInterval: time.Minute}
+	failure := osb.HTTPStatusCodeError{StatusCode: 500}
+
+	breaker.record(failure)
+	breaker.record(failure)
+	breaker.record(nil)
+	if breaker.record(failure) || breaker.record(failure) {
+		t.Fatal("expected a successful request to reset the consecutive failures")
+	}
+	if !breaker.record(failure) {
+		t.Fatal("expected the circuit to open after 3 consecutive failures")
+	}
+	if !breaker.isOpen() {
+		t.Fatal("expected the circuit to be open")
+	}
+	if breaker.record(failure) {
+		t.Fatal("expected an open circuit not to open again")
+	}
+	if e, a := 85, breaker.failureRate(); e != a {
+		t.Fatalf("unexpected failure rate: expected %v, got %v", e, a)
+	}
+	if wait := breaker.timeUntilProbe(time.Now()); wait <= 0 || wait > time.Minute {
+		t.Fatalf("expected the probe to be scheduled after the probe interval, got %v", wait)
+	}
+
+	breaker.close()
+	if breaker.isOpen() {
+		t.Fatal("expected the circuit to be closed")
+	}
+	if e, a := 0, breaker.failureRate(); e != a {
+		t.Fatalf("unexpected failure rate after closing the circuit: expected %v, got %v", e, a)
+	}
+}
+
+func TestBrokerCircuitBreakersGet(t *testing.T) {
+	breakers := brokerCircuitBreakers{breakers: make(map[string]*brokerCircuitBreaker)}
+	broker := metav1.ObjectMeta{Name: "test-broker"}
+	if breakers.get(broker) != nil {
+		t.Fatal("expected no circuit breaker when the circuit breakers are disabled")
+	}
+
+	breakers.threshold = 5
+	breaker := breakers.get(broker)
+	if breaker == nil {
+		t.Fatal("expected a circuit breaker")
+	}
+	if breakers.get(broker) != breaker {
+		t.Fatal("expected the circuit breaker to be shared by the clients of a broker")
+	}
+	if breakers.get(metav1.ObjectMeta{Name: "test-broker", Namespace: "test-ns"}) == breaker {
+		t.Fatal("expected brokers in different namespaces to have different circuit breakers")
+	}
+
+	breakers.remove(broker)
+	if breakers.get(broker) == breaker {
+		t.Fatal("expected the circuit breaker of the deleted broker to be removed")
+	}
+}
+
+// TestReconcileClusterServiceBrokerCircuit tests that a broker whose circuit
+// opened gets a Degraded condition and is probed until it recovers.
+func TestReconcileClusterServiceBrokerCircuit(t *testing.T) {
+	_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, _ := newTestController(t, fakeosb.FakeClientConfiguration{
+		CatalogReaction: &fakeosb.CatalogReaction{
+			Error: osb.HTTPStatusCodeError{StatusCode: 500},
+		},
+	})
+	testController.brokerCircuitBreakers.threshold = 2
+	testController.brokerCircuitBreakers.probeInterval = time.Minute
+
+	broker := getTestClusterServiceBroker()
+	clientConfig := NewClientConfigurationForBroker(broker.ObjectMeta, &broker.Spec.CommonServiceBrokerSpec, nil)
+	brokerClient, err := testController.newBrokerClient(broker.ObjectMeta, &broker.Spec.CommonServiceBrokerSpec, clientConfig, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := brokerClient.GetCatalog(); err == nil {
+			t.Fatal("expected the catalog request to fail")
+		}
+	}
+	if e, a := 1, testController.clusterServiceBrokerQueue.Len(); e != a {
+		t.Fatalf("expected the broker to be queued when its circuit opens: expected %v queued items, got %v", e, a)
+	}
+	if _, err := brokerClient.GetCatalog(); !isBrokerUnavailableError(err) {
+		t.Fatalf("expected requests to be suspended, got %v", err)
+	}
+	if _, err := testController.newBrokerClient(broker.ObjectMeta, &broker.Spec.CommonServiceBrokerSpec, clientConfig, nil); !isBrokerUnavailableError(err) {
+		t.Fatalf("expected no client to be created while requests are suspended, got %v", err)
+	}
+	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 2)
+
+	// The broker gets the Degraded condition.
+	if err := reconcileClusterServiceBroker(t, testController, broker); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 2)
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	broker = assertUpdateStatus(t, actions[0], broker).(*v1beta1.ClusterServiceBroker)
+	assertClusterServiceBrokerCondition(t, broker, v1beta1.ServiceBrokerConditionDegraded, v1beta1.ConditionTrue)
+	if e, a := "2 consecutive requests to the broker failed (100% of the last 2 requests)", broker.Status.Conditions[0].Message; !strings.Contains(a, e) {
+		t.Fatalf("unexpected Degraded message: expected to contain %q, got %q", e, a)
+	}
+
+	// The broker is not probed before the probe interval has elapsed.
+	fakeCatalogClient.ClearActions()
+	if err := reconcileClusterServiceBroker(t, testController, broker); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 2)
+	assertNumberOfActions(t, fakeCatalogClient.Actions(), 0)
+
+	// A failed probe keeps the requests suspended.
+	breaker := testController.brokerCircuitBreakers.get(broker.ObjectMeta)
+	breaker.nextProbeTime = time.Now().Add(-time.Second)
+	if err := reconcileClusterServiceBroker(t, testController, broker); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 3)
+	assertNumberOfActions(t, fakeCatalogClient.Actions(), 0)
+	if !breaker.isOpen() {
+		t.Fatal("expected the circuit to stay open after a failed probe")
+	}
+
+	// A successful probe resumes the requests.
+	fakeClusterServiceBrokerClient.CatalogReaction = &fakeosb.CatalogReaction{
+		Response: &osb.CatalogResponse{},
+	}
+	breaker.nextProbeTime = time.Now().Add(-time.Second)
+	if err := reconcileClusterServiceBroker(t, testController, broker); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 4)
+	actions = fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	broker = assertUpdateStatus(t, actions[0], broker).(*v1beta1.ClusterServiceBroker)
+	assertClusterServiceBrokerCondition(t, broker, v1beta1.ServiceBrokerConditionDegraded, v1beta1.ConditionFalse)
+	if breaker.isOpen() {
+		t.Fatal("expected the circuit to be closed after a successful probe")
+	}
+}
+
+// TestReconcileServiceInstanceBrokerUnavailable tests that an instance of a
+// broker whose requests are suspended is parked instead of being retried.
+func TestReconcileServiceInstanceBrokerUnavailable(t *testing.T) {
+	_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, noFakeActions())
+	testController.brokerCircuitBreakers.threshold = 1
+	testController.brokerCircuitBreakers.probeInterval = time.Minute
+
+	broker := getTestClusterServiceBroker()
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(broker)
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+	instance := getTestServiceInstanceWithClusterRefs()
+
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("Reconcile not expected to fail : %v", err)
+	}
+	instance = assertServiceInstanceProvisionInProgressIsTheOnlyCatalogClientAction(t, fakeCatalogClient, instance)
+	fakeCatalogClient.ClearActions()
+
+	testController.brokerCircuitBreakers.get(broker.ObjectMeta).record(osb.HTTPStatusCodeError{StatusCode: 500})
+
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("Reconcile not expected to fail : %v", err)
+	}
+	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 0)
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	instance = assertUpdateStatus(t, actions[0], instance).(*v1beta1.ServiceInstance)
+	assertServiceInstanceReadyFalse(t, instance, errorBrokerUnavailableReason)
+
+	events := getRecordedEvents(testController)
+	expectedEvent := warningEventBuilder(errorBrokerUnavailableReason).msg("Requests to broker \"test-clusterservicebroker\" are suspended").String()
+	if len(events) != 1 || !strings.HasPrefix(events[0], expectedEvent) {
+		t.Fatalf("unexpected events: expected an event starting with %q, got %v", expectedEvent, events)
+	}
+
+	// The parked instance is not updated again while the requests are
+	// suspended.
+	fakeCatalogClient.ClearActions()
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("Reconcile not expected to fail : %v", err)
+	}
+	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 0)
+	assertNumberOfActions(t, fakeCatalogClient.Actions(), 0)
+	if events := getRecordedEvents(testController); len(events) != 0 {
+		t.Fatalf("unexpected events: %v", events)
+	}
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	opentracing "github.com/opentracing/opentracing-go"
+	osb "github.com/pmorie/go-open-service-broker-client/v2"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-incubator/service-catalog/pkg/osbclient"
+)
+
+// newBrokerClient creates a client for a broker with the given client
+// configuration, which enforces the request limits of the broker and feeds
+// its circuit breaker. The requests of the client are traced under the given
+// parent span, if any. An operationError with the BrokerUnavailable reason is
+// returned while the circuit of the broker is open, and one with the
+// BrokerRequestsThrottled reason when the request limits of the broker do not
+// allow sending a request.
+func (c *controller) newBrokerClient(brokerMeta metav1.ObjectMeta, commonSpec *v1beta1.CommonServiceBrokerSpec, clientConfig *osb.ClientConfiguration, parentSpan opentracing.Span) (osbclient.Client, error) {
+	breaker := c.brokerCircuitBreakers.get(brokerMeta)
+	if breaker != nil && breaker.isOpen() {
+		return nil, newBrokerUnavailableError(brokerMeta)
+	}
+	brokerClient, err := c.brokerClientCreateFunc(clientConfig)
+	if err != nil {
+		return nil, err
+	}
+	if contextClient, ok := brokerClient.(osbclient.ContextClient); ok && parentSpan != nil {
+		brokerClient = contextClient.WithContext(opentracing.ContextWithSpan(context.Background(), parentSpan))
+	}
+	limiter := c.brokerRequestLimiters.get(brokerMeta, commonSpec.RequestLimits)
+	if limiter == nil && breaker == nil {
+		return brokerClient, nil
+	}
+	if limiter != nil && !limiter.reserve() {
+		return nil, newBrokerRequestsThrottledError(brokerMeta)
+	}
+	return &guardedBrokerClient{
+		Client:     brokerClient,
+		brokerMeta: brokerMeta,
+		limiter:    limiter,
+		reserved:   limiter != nil,
+		breaker:    breaker,
+		onOpen:     c.brokerCircuitOpened,
+	}, nil
+}
+
+// guardedBrokerClient is an OSB client that checks the request limits of the
+// broker before each request and records the outcome of each request in the
+// circuit breaker of the broker.
+type guardedBrokerClient struct {
+	osbclient.Client
+	brokerMeta metav1.ObjectMeta
+	limiter    *brokerRequestLimiter // nil when the requests are not limited
+	reserved   bool                  // whether the rate limit of the next request was already taken
+	breaker    *brokerCircuitBreaker // nil when the circuit breaker is disabled
+	onOpen     func(brokerMeta metav1.ObjectMeta)
+}
+
+var _ osbclient.Client = &guardedBrokerClient{}
+
+// begin is called before each request. It returns an error instead of
+// letting the request through when the circuit of the broker is open, or when
+// the request limits of the broker are exceeded.
+func (gc *guardedBrokerClient) begin() error {
+	if gc.breaker != nil && gc.breaker.isOpen() {
+		return newBrokerUnavailableError(gc.brokerMeta)
+	}
+	if gc.limiter != nil {
+		reserved := gc.reserved
+		gc.reserved = false
+		if !gc.limiter.tryAcquire(reserved) {
+			return newBrokerRequestsThrottledError(gc.brokerMeta)
+		}
+	}
+	return nil
+}
+
+// end is called with the outcome of each request that begin let through.
+func (gc *guardedBrokerClient) end(err error) {
+	if gc.limiter != nil {
+		gc.limiter.release()
+	}
+	if gc.breaker != nil && gc.breaker.record(err) {
+		gc.onOpen(gc.brokerMeta)
+	}
+}
+
+func (gc *guardedBrokerClient) GetCatalog() (*osbclient.CatalogResponse, error) {
+	if err := gc.begin(); err != nil {
+		return nil, err
+	}
+	response, err := gc.Client.GetCatalog()
+	gc.end(err)
+	return response, err
+}
+
+func (gc *guardedBrokerClient) ProvisionInstance(r *osbclient.ProvisionRequest) (*osb.ProvisionResponse, error) {
+	if err := gc.begin(); err != nil {
+		return nil, err
+	}
+	response, err := gc.Client.ProvisionInstance(r)
+	gc.end(err)
+	return response, err
+}
+
+func (gc *guardedBrokerClient) UpdateInstance(r *osbclient.UpdateInstanceRequest) (*osb.UpdateInstanceResponse, error) {
+	if err := gc.begin(); err != nil {
+		return nil, err
+	}
+	response, err := gc.Client.UpdateInstance(r)
+	gc.end(err)
+	return response, err
+}
+
+func (gc *guardedBrokerClient) DeprovisionInstance(r *osb.DeprovisionRequest) (*osb.DeprovisionResponse, error) {
+	if err := gc.begin(); err != nil {
+		return nil, err
+	}
+	response, err := gc.Client.DeprovisionInstance(r)
+	gc.end(err)
+	return response, err
+}
+
+func (gc *guardedBrokerClient) PollLastOperation(r *osb.LastOperationRequest) (*osb.LastOperationResponse, error) {
+	if err := gc.begin(); err != nil {
+		return nil, err
+	}
+	response, err := gc.Client.PollLastOperation(r)
+	gc.end(err)
+	return response, err
+}
+
+func (gc *guardedBrokerClient) PollBindingLastOperation(r *osb.BindingLastOperationRequest) (*osb.LastOperationResponse, error) {
+	if err := gc.begin(); err != nil {
+		return nil, err
+	}
+	response, err := gc.Client.PollBindingLastOperation(r)
+	gc.end(err)
+	return response, err
+}
+
+func (gc *guardedBrokerClient) Bind(r *osb.BindRequest) (*osb.BindResponse, error) {
+	if err := gc.begin(); err != nil {
+		return nil, err
+	}
+	response, err := gc.Client.Bind(r)
+	gc.end(err)
+	return response, err
+}
+
+func (gc *guardedBrokerClient) Unbind(r *osb.UnbindRequest) (*osb.UnbindResponse, error) {
+	if err := gc.begin(); err != nil {
+		return nil, err
+	}
+	response, err := gc.Client.Unbind(r)
+	gc.end(err)
+	return response, err
+}
+
+func (gc *guardedBrokerClient) GetBinding(r *osb.GetBindingRequest) (*osb.GetBindingResponse, error) {
+	if err := gc.begin(); err != nil {
+		return nil, err
+	}
+	response, err := gc.Client.GetBinding(r)
+	gc.end(err)
+	return response, err
+}
+
+func (gc *guardedBrokerClient) GetInstance(r *osbclient.GetInstanceRequest) (*osbclient.GetInstanceResponse, error) {
+	if err := gc.begin(); err != nil {
+		return nil, err
+	}
+	response, err := gc.Client.GetInstance(r)
+	gc.end(err)
+	return response, err
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/flowcontrol"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-incubator/service-catalog/pkg/metrics"
+)
+
+const (
+	errorBrokerRequestsThrottledReason string = "BrokerRequestsThrottled"
+
+	// brokerRequestsThrottledRetryDelay is the delay after which a resource
+	// is reconciled again when the request limits of its broker did not
+	// allow sending a request.
+	brokerRequestsThrottledRetryDelay = 1 * time.Second
+)
+
+// brokerRequestLimiter enforces the request limits of a broker across all
+// the clients created for the broker. The limiter never blocks: a request
+// that exceeds the limits is refused, and the resource it was sent for is
+// reconciled again later, so that a slow broker does not hold the workers.
+type brokerRequestLimiter struct {
+	brokerKey   string
+	limits      v1beta1.BrokerRequestLimits
+	rateLimiter flowcontrol.RateLimiter // nil when the rate is not limited
+	inFlight    chan struct{}           // nil when the concurrency is not limited
+}
+
+func newBrokerRequestLimiter(brokerKey string, limits v1beta1.BrokerRequestLimits) *brokerRequestLimiter {
+	l := &brokerRequestLimiter{
+		brokerKey: brokerKey,
+		limits:    limits,
+	}
+	if limits.QPS > 0 {
+		burst := limits.Burst
+		if burst <= 0 {
+			burst = limits.QPS
+		}
+		l.rateLimiter = flowcontrol.NewTokenBucketRateLimiter(float32(limits.QPS), int(burst))
+	}
+	if limits.MaxInFlightRequests > 0 {
+		l.inFlight = make(chan struct{}, limits.MaxInFlightRequests)
+	}
+	return l
+}
+
+// reserve takes a token from the rate limiter for the first request of a
+// new client, and checks that an in-flight slot is free. It returns false
+// when the limits of the broker do not allow sending a request.
+func (l *brokerRequestLimiter) reserve() bool {
+	if l.inFlight != nil && len(l.inFlight) == cap(l.inFlight) {
+		return l.throttled()
+	}
+	if l.rateLimiter != nil && !l.rateLimiter.TryAccept() {
+		return l.throttled()
+	}
+	return true
+}
+
+// tryAcquire takes an in-flight slot and, unless the request was already
+// reserved, a token from the rate limiter. It returns false when the limits
+// of the broker do not allow sending the request; otherwise it must be
+// followed by a call to release once the broker has responded.
+func (l *brokerRequestLimiter) tryAcquire(reserved bool) bool {
+	if l.inFlight != nil {
+		select {
+		case l.inFlight <- struct{}{}:
+		default:
+			return l.throttled()
+		}
+	}
+	if !reserved && l.rateLimiter != nil && !l.rateLimiter.TryAccept() {
+		l.release()
+		return l.throttled()
+	}
+	return true
+}
+
+// throttled records that a request is delayed by the limits of the broker.
+func (l *brokerRequestLimiter) throttled() bool {
+	metrics.OSBRequestQueueWaitSeconds.WithLabelValues(l.brokerKey).Observe(brokerRequestsThrottledRetryDelay.Seconds())
+	return false
+}
+
+// release frees the in-flight slot taken by tryAcquire.
+func (l *brokerRequestLimiter) release() {
+	if l.inFlight != nil {
+		<-l.inFlight
+	}
+}
+
+// brokerRequestLimiters holds the request limiters of the brokers with
+// request limits, so that the limits are enforced across reconciliations.
+type brokerRequestLimiters struct {
+	mutex    sync.Mutex
+	limiters map[string]*brokerRequestLimiter // Key is namespace/name, or name for cluster brokers
+}
+
+// get returns the request limiter for the given limits of a broker. The
+// limiter of the broker is replaced when its limits change, and removed when
+// the broker has no limits, in which case nil is returned.
+func (b *brokerRequestLimiters) get(brokerMeta metav1.ObjectMeta, limits *v1beta1.BrokerRequestLimits) *brokerRequestLimiter {
+	key := brokerKey(brokerMeta)
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if limits == nil || (limits.QPS <= 0 && limits.MaxInFlightRequests <= 0) {
+		delete(b.limiters, key)
+		return nil
+	}
+	limiter, ok := b.limiters[key]
+	if !ok || limiter.limits != *limits {
+		glog.V(4).Infof("Limiting requests to broker %q: %+v", key, *limits)
+		limiter = newBrokerRequestLimiter(key, *limits)
+		b.limiters[key] = limiter
+	}
+	return limiter
+}
+
+// remove drops the request limiter of a deleted broker.
+func (b *brokerRequestLimiters) remove(brokerMeta metav1.ObjectMeta) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	delete(b.limiters, brokerKey(brokerMeta))
+}
+
+func brokerKey(brokerMeta metav1.ObjectMeta) string {
+	if brokerMeta.Namespace == "" {
+		return brokerMeta.Name
+	}
+	return brokerMeta.Namespace + "/" + brokerMeta.Name
+}
+
+func newBrokerRequestsThrottledError(brokerMeta metav1.ObjectMeta) error {
+	return &operationError{
+		reason: errorBrokerRequestsThrottledReason,
+		message: fmt.Sprintf(
+			"The request limits of broker %q do not allow sending more requests for now; the request will be retried",
+			brokerKey(brokerMeta),
+		),
+	}
+}
+
+// isBrokerRequestsThrottledError returns whether the given error was returned
+// because the request limits of a broker did not allow sending a request.
+func isBrokerRequestsThrottledError(err error) bool {
+	opErr, ok := err.(*operationError)
+	return ok && opErr.reason == errorBrokerRequestsThrottledReason
+}
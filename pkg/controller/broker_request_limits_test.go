@@ -0,0 +1,192 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	osb "github.com/pmorie/go-open-service-broker-client/v2"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-incubator/service-catalog/pkg/osbclient"
+	fakeosbclient "github.com/kubernetes-incubator/service-catalog/pkg/osbclient/fake"
+)
+
+// blockingCatalogClient is an OSB client whose GetCatalog calls block until
+// they are released.
+type blockingCatalogClient struct {
+	osbclient.Client
+	started chan struct{}
+	release chan struct{}
+}
+
+func (bc *blockingCatalogClient) GetCatalog() (*osbclient.CatalogResponse, error) {
+	bc.started <- struct{}{}
+	<-bc.release
+	return &osbclient.CatalogResponse{}, nil
+}
+
+func TestNewBrokerClientWithoutRequestLimits(t *testing.T) {
+	_, _, fakeClusterServiceBrokerClient, testController, _ := newTestController(t, noFakeActions())
+
+	broker := getTestClusterServiceBroker()
+	clientConfig := NewClientConfigurationForBroker(broker.ObjectMeta, &broker.Spec.CommonServiceBrokerSpec, nil)
+	brokerClient, err := testController.newBrokerClient(broker.ObjectMeta, &broker.Spec.CommonServiceBrokerSpec, clientConfig, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if brokerClient != fakeClusterServiceBrokerClient {
+		t.Fatalf("expected the client of a broker without request limits not to be wrapped, got %T", brokerClient)
+	}
+}
+
+func TestBrokerRequestLimitersGet(t *testing.T) {
+	limiters := brokerRequestLimiters{limiters: make(map[string]*brokerRequestLimiter)}
+	broker := metav1.ObjectMeta{Name: "test-broker"}
+	namespacedBroker := metav1.ObjectMeta{Name: "test-broker", Namespace: "test-ns"}
+	limits := &v1beta1.BrokerRequestLimits{QPS: 10, MaxInFlightRequests: 2}
+
+	limiter := limiters.get(broker, limits)
+	if limiter == nil {
+		t.Fatal("expected a limiter for a broker with request limits")
+	}
+	if limiters.get(broker, limits.DeepCopy()) != limiter {
+		t.Fatal("expected the limiter to be shared by the clients of a broker")
+	}
+	if limiters.get(namespacedBroker, limits) == limiter {
+		t.Fatal("expected brokers in different namespaces to have different limiters")
+	}
+	if limiters.get(broker, &v1beta1.BrokerRequestLimits{QPS: 5}) == limiter {
+		t.Fatal("expected the limiter to be replaced when the limits change")
+	}
+	if limiters.get(broker, &v1beta1.BrokerRequestLimits{}) != nil {
+		t.Fatal("expected no limiter for empty limits")
+	}
+	if _, ok := limiters.limiters[brokerKey(broker)]; ok {
+		t.Fatal("expected the limiter to be removed when the limits are unset")
+	}
+
+	limiters.remove(namespacedBroker)
+	if len(limiters.limiters) != 0 {
+		t.Fatalf("expected the limiter of the deleted broker to be removed, got %v", limiters.limiters)
+	}
+}
+
+func TestBrokerRequestLimiterBurst(t *testing.T) {
+	limiter := newBrokerRequestLimiter("test-broker", v1beta1.BrokerRequestLimits{QPS: 2})
+	for i := 0; i < 2; i++ {
+		if !limiter.rateLimiter.TryAccept() {
+			t.Fatalf("expected request %d to be accepted within the default burst", i+1)
+		}
+	}
+	if limiter.rateLimiter.TryAccept() {
+		t.Fatal("expected a request above the burst to be delayed")
+	}
+}
+
+func TestGuardedBrokerClientMaxInFlightRequests(t *testing.T) {
+	_, _, _, testController, _ := newTestController(t, noFakeActions())
+	blockingClient := &blockingCatalogClient{
+		Client:  fakeosbclient.NewFakeClient(noFakeActions()),
+		started: make(chan struct{}, 1),
+		release: make(chan struct{}),
+	}
+	testController.brokerClientCreateFunc = func(_ *osb.ClientConfiguration) (osbclient.Client, error) {
+		return blockingClient, nil
+	}
+
+	broker := getTestClusterServiceBroker()
+	broker.Spec.RequestLimits = &v1beta1.BrokerRequestLimits{MaxInFlightRequests: 1}
+	clientConfig := NewClientConfigurationForBroker(broker.ObjectMeta, &broker.Spec.CommonServiceBrokerSpec, nil)
+
+	brokerClient, err := testController.newBrokerClient(broker.ObjectMeta, &broker.Spec.CommonServiceBrokerSpec, clientConfig, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	otherClient, err := testController.newBrokerClient(broker.ObjectMeta, &broker.Spec.CommonServiceBrokerSpec, clientConfig, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		brokerClient.GetCatalog()
+		close(done)
+	}()
+	<-blockingClient.started
+
+	// The requests above the limit are refused instead of waiting.
+	if _, err := testController.newBrokerClient(broker.ObjectMeta, &broker.Spec.CommonServiceBrokerSpec, clientConfig, nil); !isBrokerRequestsThrottledError(err) {
+		t.Fatalf("expected a throttled error creating a client, got %v", err)
+	}
+	if _, err := otherClient.GetCatalog(); !isBrokerRequestsThrottledError(err) {
+		t.Fatalf("expected a throttled error sending a request, got %v", err)
+	}
+
+	blockingClient.release <- struct{}{}
+	<-done
+	if _, err := testController.newBrokerClient(broker.ObjectMeta, &broker.Spec.CommonServiceBrokerSpec, clientConfig, nil); err != nil {
+		t.Fatalf("expected a client once the request is answered, got %v", err)
+	}
+}
+
+func TestGuardedBrokerClientQPS(t *testing.T) {
+	_, _, _, testController, _ := newTestController(t, noFakeActions())
+
+	broker := getTestClusterServiceBroker()
+	broker.Spec.RequestLimits = &v1beta1.BrokerRequestLimits{QPS: 1}
+	clientConfig := NewClientConfigurationForBroker(broker.ObjectMeta, &broker.Spec.CommonServiceBrokerSpec, nil)
+
+	brokerClient, err := testController.newBrokerClient(broker.ObjectMeta, &broker.Spec.CommonServiceBrokerSpec, clientConfig, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The first request uses the token taken when the client was created.
+	if _, err := brokerClient.GetCatalog(); isBrokerRequestsThrottledError(err) {
+		t.Fatalf("expected the first request to be sent, got %v", err)
+	}
+	if _, err := brokerClient.GetCatalog(); !isBrokerRequestsThrottledError(err) {
+		t.Fatalf("expected a throttled error above the rate limit, got %v", err)
+	}
+	if _, err := testController.newBrokerClient(broker.ObjectMeta, &broker.Spec.CommonServiceBrokerSpec, clientConfig, nil); !isBrokerRequestsThrottledError(err) {
+		t.Fatalf("expected a throttled error creating a client above the rate limit, got %v", err)
+	}
+}
+
+func TestReconcileServiceInstanceBrokerRequestsThrottled(t *testing.T) {
+	_, fakeCatalogClient, _, testController, sharedInformers := newTestController(t, noFakeActions())
+	broker := getTestClusterServiceBroker()
+	broker.Spec.RequestLimits = &v1beta1.BrokerRequestLimits{QPS: 1}
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(broker)
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+	// Use the only token of the broker.
+	if _, err := testController.newBrokerClient(broker.ObjectMeta, &broker.Spec.CommonServiceBrokerSpec, NewClientConfigurationForBroker(broker.ObjectMeta, &broker.Spec.CommonServiceBrokerSpec, nil), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	instance := getTestServiceInstanceWithClusterRefs()
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("expected the throttled instance to be requeued without error, got %v", err)
+	}
+	if actions := fakeCatalogClient.Actions(); len(actions) != 0 {
+		t.Fatalf("expected the status of the throttled instance not to be updated, got %v", actions)
+	}
+}
@@ -24,11 +24,13 @@ import (
 	"time"
 
 	"github.com/golang/glog"
+	opentracing "github.com/opentracing/opentracing-go"
 	osb "github.com/pmorie/go-open-service-broker-client/v2"
 
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	runtimeutil "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/uuid"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -46,6 +48,7 @@ import (
 	listers "github.com/kubernetes-incubator/service-catalog/pkg/client/listers_generated/servicecatalog/v1beta1"
 	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
 	"github.com/kubernetes-incubator/service-catalog/pkg/filter"
+	"github.com/kubernetes-incubator/service-catalog/pkg/osbclient"
 	"github.com/kubernetes-incubator/service-catalog/pkg/pretty"
 )
 
@@ -80,7 +83,7 @@ func NewController(
 	bindingInformer informers.ServiceBindingInformer,
 	clusterServicePlanInformer informers.ClusterServicePlanInformer,
 	servicePlanInformer informers.ServicePlanInformer,
-	brokerClientCreateFunc osb.CreateFunc,
+	brokerClientCreateFunc osbclient.CreateFunc,
 	brokerRelistInterval time.Duration,
 	osbAPIPreferredVersion string,
 	recorder record.EventRecorder,
@@ -88,6 +91,11 @@ func NewController(
 	operationPollingMaximumBackoffDuration time.Duration,
 	clusterIDConfigMapName string,
 	clusterIDConfigMapNamespace string,
+	autoUpgradeInstances bool,
+	brokerCircuitBreakerThreshold int,
+	brokerCircuitBreakerProbeInterval time.Duration,
+	bindingRotationGracePeriod time.Duration,
+	instanceDriftCheckInterval time.Duration,
 ) (Controller, error) {
 	controller := &controller{
 		kubeClient:                  kubeClient,
@@ -109,6 +117,8 @@ func NewController(
 		bindingPollingQueue:         workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(pollingStartInterval, operationPollingMaximumBackoffDuration), "binding-poller"),
 		clusterIDConfigMapName:      clusterIDConfigMapName,
 		clusterIDConfigMapNamespace: clusterIDConfigMapNamespace,
+		autoUpgradeInstances:        autoUpgradeInstances,
+		bindingRotationGracePeriod:  bindingRotationGracePeriod,
 	}
 
 	controller.clusterServiceBrokerLister = clusterServiceBrokerInformer.Lister()
@@ -138,6 +148,7 @@ func NewController(
 		UpdateFunc: controller.instanceUpdate,
 		DeleteFunc: controller.instanceDelete,
 	})
+	instanceInformer.Informer().AddEventHandler(instanceOperationMetricsHandler)
 
 	controller.bindingLister = bindingInformer.Lister()
 	bindingInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
@@ -145,6 +156,7 @@ func NewController(
 		UpdateFunc: controller.bindingUpdate,
 		DeleteFunc: controller.bindingDelete,
 	})
+	bindingInformer.Informer().AddEventHandler(bindingOperationMetricsHandler)
 
 	if utilfeature.DefaultFeatureGate.Enabled(scfeatures.NamespacedServiceBroker) {
 		controller.serviceBrokerLister = serviceBrokerInformer.Lister()
@@ -166,8 +178,13 @@ func NewController(
 			DeleteFunc: controller.servicePlanDelete,
 		})
 	}
-	controller.instanceOperationRetryQueue.instances = make(map[string]backoffEntry)
-	controller.instanceOperationRetryQueue.rateLimiter = workqueue.NewItemExponentialFailureRateLimiter(minBrokerOperationRetryDelay, maxBrokerOperationRetryDelay)
+	controller.brokerRequestLimiters.limiters = make(map[string]*brokerRequestLimiter)
+	controller.brokerCircuitBreakers.threshold = brokerCircuitBreakerThreshold
+	controller.brokerCircuitBreakers.probeInterval = brokerCircuitBreakerProbeInterval
+	controller.brokerCircuitBreakers.breakers = make(map[string]*brokerCircuitBreaker)
+	controller.reconcileSpans.spans = make(map[string]opentracing.Span)
+	controller.instanceDriftChecks.interval = instanceDriftCheckInterval
+	controller.instanceDriftChecks.lastChecks = make(map[types.UID]time.Time)
 	return controller, nil
 }
 
@@ -184,7 +201,7 @@ type Controller interface {
 type controller struct {
 	kubeClient                  kubernetes.Interface
 	serviceCatalogClient        servicecatalogclientset.ServicecatalogV1beta1Interface
-	brokerClientCreateFunc      osb.CreateFunc
+	brokerClientCreateFunc      osbclient.CreateFunc
 	clusterServiceBrokerLister  listers.ClusterServiceBrokerLister
 	serviceBrokerLister         listers.ServiceBrokerLister
 	clusterServiceClassLister   listers.ClusterServiceClassLister
@@ -213,6 +230,13 @@ type controller struct {
 	// clusterIDConfigMapNamespace is the k8s namespace that the
 	// clusterid configmap will be stored in.
 	clusterIDConfigMapNamespace string
+	// autoUpgradeInstances indicates whether instances are updated to new
+	// versions of their plans as soon as brokers report them.
+	autoUpgradeInstances bool
+	// bindingRotationGracePeriod is how long the previous credentials of a
+	// rotated binding are kept at the broker after the new credentials have
+	// been written into the secret.
+	bindingRotationGracePeriod time.Duration
 	// clusterID holds the current value. If a configmap to hold
 	// this value does not exist, it will be created with this
 	// value. If there is a configmap with a different value, it
@@ -221,8 +245,17 @@ type controller struct {
 	// clusterIDLock protects access to clusterID between the
 	// monitor writing the value from the configmap, and any
 	// readers passing the clusterID to a broker.
-	clusterIDLock               sync.RWMutex
-	instanceOperationRetryQueue instanceOperationBackoff
+	clusterIDLock sync.RWMutex
+	// brokerRequestLimiters enforces the request limits of the brokers.
+	brokerRequestLimiters brokerRequestLimiters
+	// brokerCircuitBreakers suspends the requests to brokers that failed
+	// too many requests in a row.
+	brokerCircuitBreakers brokerCircuitBreakers
+	// reconcileSpans holds the spans of the reconciliations in progress.
+	reconcileSpans reconcileSpans
+	// instanceDriftChecks limits how often the instances are fetched from
+	// their brokers to check for drift.
+	instanceDriftChecks instanceDriftChecks
 }
 
 // Run runs the controller until the given stop channel can be read from.
@@ -258,10 +291,6 @@ func (c *controller) Run(workers int, stopCh <-chan struct{}) {
 	// simple polling based worker
 	c.createConfigMapMonitorWorker(stopCh, &waitGroup)
 
-	// create a task that runs periodically to purge expired
-	// instance operation retry entries
-	c.createPurgeExpiredRetryEntriesWorker(stopCh, &waitGroup)
-
 	<-stopCh
 	glog.Info("Shutting down service-catalog controller")
 
@@ -302,16 +331,6 @@ func (c *controller) createConfigMapMonitorWorker(stopCh <-chan struct{}, waitGr
 	}()
 }
 
-// createPurgeExpiredRetryEntriesWorker creates a task that runs periodically to
-// remove old entries from the instance retry queue
-func (c *controller) createPurgeExpiredRetryEntriesWorker(stopCh <-chan struct{}, waitGroup *sync.WaitGroup) {
-	waitGroup.Add(1)
-	go func() {
-		wait.Until(c.purgeExpiredRetryEntries, 2*maxBrokerOperationRetryDelay, stopCh)
-		waitGroup.Done()
-	}()
-}
-
 func (c *controller) monitorConfigMap() {
 	// Cannot wait for the informer to push something into a queue.
 	// What we're waiting on may never exist without us configuring
@@ -411,7 +430,7 @@ func (e *operationError) Error() string { return e.message }
 // The ClusterServicePlan returned will be nil if the ClusterServicePlanRef
 // is nil. This will happen when deleting a ServiceInstance that previously
 // had an update to a non-existent plan.
-func (c *controller) getClusterServiceClassPlanAndClusterServiceBroker(instance *v1beta1.ServiceInstance) (*v1beta1.ClusterServiceClass, *v1beta1.ClusterServicePlan, string, osb.Client, error) {
+func (c *controller) getClusterServiceClassPlanAndClusterServiceBroker(instance *v1beta1.ServiceInstance) (*v1beta1.ClusterServiceClass, *v1beta1.ClusterServicePlan, string, osbclient.Client, error) {
 	serviceClass, brokerName, brokerClient, err := c.getClusterServiceClassAndClusterServiceBroker(instance)
 	if err != nil {
 		return nil, nil, "", nil, err
@@ -434,7 +453,7 @@ func (c *controller) getClusterServiceClassPlanAndClusterServiceBroker(instance
 	return serviceClass, servicePlan, brokerName, brokerClient, nil
 }
 
-func (c *controller) getServiceClassPlanAndServiceBroker(instance *v1beta1.ServiceInstance) (*v1beta1.ServiceClass, *v1beta1.ServicePlan, string, osb.Client, error) {
+func (c *controller) getServiceClassPlanAndServiceBroker(instance *v1beta1.ServiceInstance) (*v1beta1.ServiceClass, *v1beta1.ServicePlan, string, osbclient.Client, error) {
 	serviceClass, brokerName, brokerClient, err := c.getServiceClassAndServiceBroker(instance)
 	if err != nil {
 		return nil, nil, "", nil, err
@@ -460,7 +479,7 @@ func (c *controller) getServiceClassPlanAndServiceBroker(instance *v1beta1.Servi
 // getClusterServiceClassAndClusterServiceBroker is a sequence of operations that's done in couple of
 // places so this method fetches the Service Class and creates
 // a brokerClient to use for that method given an ServiceInstance.
-func (c *controller) getClusterServiceClassAndClusterServiceBroker(instance *v1beta1.ServiceInstance) (*v1beta1.ClusterServiceClass, string, osb.Client, error) {
+func (c *controller) getClusterServiceClassAndClusterServiceBroker(instance *v1beta1.ServiceInstance) (*v1beta1.ClusterServiceClass, string, osbclient.Client, error) {
 	pcb := pretty.NewInstanceContextBuilder(instance)
 	serviceClass, err := c.clusterServiceClassLister.Get(instance.Spec.ClusterServiceClassRef.Name)
 	if err != nil {
@@ -498,7 +517,7 @@ func (c *controller) getClusterServiceClassAndClusterServiceBroker(instance *v1b
 
 	clientConfig := NewClientConfigurationForBroker(broker.ObjectMeta, &broker.Spec.CommonServiceBrokerSpec, authConfig)
 	glog.V(4).Info(pcb.Messagef("Creating client for ClusterServiceBroker %v, URL: %v", broker.Name, broker.Spec.URL))
-	brokerClient, err := c.brokerClientCreateFunc(clientConfig)
+	brokerClient, err := c.newBrokerClient(broker.ObjectMeta, &broker.Spec.CommonServiceBrokerSpec, clientConfig, c.reconcileSpans.get(pretty.ServiceInstance, instance.Namespace, instance.Name))
 	if err != nil {
 		return nil, "", nil, err
 	}
@@ -509,7 +528,7 @@ func (c *controller) getClusterServiceClassAndClusterServiceBroker(instance *v1b
 // getServiceClassAndServiceBroker is a sequence of operations that's done in couple of
 // places so this method fetches the Service Class and creates
 // a brokerClient to use for that method given a ServiceInstance.
-func (c *controller) getServiceClassAndServiceBroker(instance *v1beta1.ServiceInstance) (*v1beta1.ServiceClass, string, osb.Client, error) {
+func (c *controller) getServiceClassAndServiceBroker(instance *v1beta1.ServiceInstance) (*v1beta1.ServiceClass, string, osbclient.Client, error) {
 	pcb := pretty.NewContextBuilder(pretty.ServiceInstance, instance.Namespace, instance.Name, "")
 	serviceClass, err := c.serviceClassLister.ServiceClasses(instance.Namespace).Get(instance.Spec.ServiceClassRef.Name)
 	if err != nil {
@@ -547,7 +566,7 @@ func (c *controller) getServiceClassAndServiceBroker(instance *v1beta1.ServiceIn
 
 	clientConfig := NewClientConfigurationForBroker(broker.ObjectMeta, &broker.Spec.CommonServiceBrokerSpec, authConfig)
 	glog.V(4).Info(pcb.Messagef("Creating client for ServiceBroker %v, URL: %v", broker.Name, broker.Spec.URL))
-	brokerClient, err := c.brokerClientCreateFunc(clientConfig)
+	brokerClient, err := c.newBrokerClient(broker.ObjectMeta, &broker.Spec.CommonServiceBrokerSpec, clientConfig, c.reconcileSpans.get(pretty.ServiceInstance, instance.Namespace, instance.Name))
 	if err != nil {
 		return nil, "", nil, err
 	}
@@ -559,7 +578,7 @@ func (c *controller) getServiceClassAndServiceBroker(instance *v1beta1.ServiceIn
 // done to validate service plan, service class exist, and handles creating
 // a brokerclient to use for a given ServiceInstance.
 // Sets ClusterServiceClassRef and/or ClusterServicePlanRef if they haven't been already set.
-func (c *controller) getClusterServiceClassPlanAndClusterServiceBrokerForServiceBinding(instance *v1beta1.ServiceInstance, binding *v1beta1.ServiceBinding) (*v1beta1.ClusterServiceClass, *v1beta1.ClusterServicePlan, string, osb.Client, error) {
+func (c *controller) getClusterServiceClassPlanAndClusterServiceBrokerForServiceBinding(instance *v1beta1.ServiceInstance, binding *v1beta1.ServiceBinding) (*v1beta1.ClusterServiceClass, *v1beta1.ClusterServicePlan, string, osbclient.Client, error) {
 	serviceClass, serviceBrokerName, osbClient, err := c.getClusterServiceClassAndClusterServiceBrokerForServiceBinding(instance, binding)
 	if err != nil {
 		return nil, nil, "", nil, err
@@ -572,7 +591,7 @@ func (c *controller) getClusterServiceClassPlanAndClusterServiceBrokerForService
 	return serviceClass, servicePlan, serviceBrokerName, osbClient, nil
 }
 
-func (c *controller) getClusterServiceClassAndClusterServiceBrokerForServiceBinding(instance *v1beta1.ServiceInstance, binding *v1beta1.ServiceBinding) (*v1beta1.ClusterServiceClass, string, osb.Client, error) {
+func (c *controller) getClusterServiceClassAndClusterServiceBrokerForServiceBinding(instance *v1beta1.ServiceInstance, binding *v1beta1.ServiceBinding) (*v1beta1.ClusterServiceClass, string, osbclient.Client, error) {
 	serviceClass, err := c.getClusterServiceClassForServiceBinding(instance, binding)
 	if err != nil {
 		return nil, "", nil, err
@@ -655,9 +674,9 @@ func (c *controller) getClusterServiceBrokerForServiceBinding(instance *v1beta1.
 	return broker, nil
 }
 
-func (c *controller) getBrokerClientForServiceBinding(instance *v1beta1.ServiceInstance, binding *v1beta1.ServiceBinding) (osb.Client, error) {
+func (c *controller) getBrokerClientForServiceBinding(instance *v1beta1.ServiceInstance, binding *v1beta1.ServiceBinding) (osbclient.Client, error) {
 
-	var brokerClient osb.Client
+	var brokerClient osbclient.Client
 
 	if instance.Spec.ClusterServiceClassSpecified() {
 
@@ -690,7 +709,7 @@ func (c *controller) getBrokerClientForServiceBinding(instance *v1beta1.ServiceI
 		clientConfig := NewClientConfigurationForBroker(broker.ObjectMeta, &broker.Spec.CommonServiceBrokerSpec, authConfig)
 
 		glog.V(4).Infof("Creating client for ClusterServiceBroker %v, URL: %v", broker.Name, broker.Spec.URL)
-		brokerClient, err = c.brokerClientCreateFunc(clientConfig)
+		brokerClient, err = c.newBrokerClient(broker.ObjectMeta, &broker.Spec.CommonServiceBrokerSpec, clientConfig, c.reconcileSpans.get(pretty.ServiceBinding, binding.Namespace, binding.Name))
 		if err != nil {
 			return nil, err
 		}
@@ -726,7 +745,7 @@ func (c *controller) getBrokerClientForServiceBinding(instance *v1beta1.ServiceI
 		clientConfig := NewClientConfigurationForBroker(broker.ObjectMeta, &broker.Spec.CommonServiceBrokerSpec, authConfig)
 
 		glog.V(4).Infof("Creating client for ClusterServiceBroker %v, URL: %v", broker.Name, broker.Spec.URL)
-		brokerClient, err = c.brokerClientCreateFunc(clientConfig)
+		brokerClient, err = c.newBrokerClient(broker.ObjectMeta, &broker.Spec.CommonServiceBrokerSpec, clientConfig, c.reconcileSpans.get(pretty.ServiceBinding, binding.Namespace, binding.Name))
 		if err != nil {
 			return nil, err
 		}
@@ -845,7 +864,7 @@ func getBearerConfig(secret *corev1.Secret) (*osb.BearerConfig, error) {
 // into an array of ServiceClasses and an array of ServicePlans and filters
 // these through the restrictions provided. The ServiceClasses and
 // ServicePlans returned by this method are named in K8S with the OSB ID.
-func convertAndFilterCatalogToNamespacedTypes(namespace string, in *osb.CatalogResponse, restrictions *v1beta1.CatalogRestrictions) ([]*v1beta1.ServiceClass, []*v1beta1.ServicePlan, error) {
+func convertAndFilterCatalogToNamespacedTypes(namespace string, in *osbclient.CatalogResponse, restrictions *v1beta1.CatalogRestrictions) ([]*v1beta1.ServiceClass, []*v1beta1.ServicePlan, error) {
 	var predicate filter.Predicate
 	var err error
 	if restrictions != nil && len(restrictions.ServiceClass) > 0 {
@@ -877,6 +896,7 @@ func convertAndFilterCatalogToNamespacedTypes(namespace string, in *osb.CatalogR
 		if utilfeature.DefaultFeatureGate.Enabled(scfeatures.AsyncBindingOperations) {
 			serviceClass.Spec.BindingRetrievable = svc.BindingsRetrievable
 		}
+		serviceClass.Spec.InstancesRetrievable = svc.InstancesRetrievable
 
 		if svc.Metadata != nil {
 			metadata, err := json.Marshal(svc.Metadata)
@@ -917,7 +937,7 @@ func convertAndFilterCatalogToNamespacedTypes(namespace string, in *osb.CatalogR
 // ClusterServiceClasses and an array of ClusterServicePlans and filters these
 // through the restrictions provided. The ClusterServiceClasses and
 // ClusterServicePlans returned by this method are named in K8S with the OSB ID.
-func convertAndFilterCatalog(in *osb.CatalogResponse, restrictions *v1beta1.CatalogRestrictions) ([]*v1beta1.ClusterServiceClass, []*v1beta1.ClusterServicePlan, error) {
+func convertAndFilterCatalog(in *osbclient.CatalogResponse, restrictions *v1beta1.CatalogRestrictions) ([]*v1beta1.ClusterServiceClass, []*v1beta1.ClusterServicePlan, error) {
 	var predicate filter.Predicate
 	var err error
 	if restrictions != nil && len(restrictions.ServiceClass) > 0 {
@@ -949,6 +969,7 @@ func convertAndFilterCatalog(in *osb.CatalogResponse, restrictions *v1beta1.Cata
 		if utilfeature.DefaultFeatureGate.Enabled(scfeatures.AsyncBindingOperations) {
 			serviceClass.Spec.BindingRetrievable = svc.BindingsRetrievable
 		}
+		serviceClass.Spec.InstancesRetrievable = svc.InstancesRetrievable
 
 		if svc.Metadata != nil {
 			metadata, err := json.Marshal(svc.Metadata)
@@ -1046,7 +1067,7 @@ func filterServicePlans(restrictions *v1beta1.CatalogRestrictions, servicePlans
 	return accepted, rejected, nil
 }
 
-func convertServicePlans(namespace string, plans []osb.Plan, serviceClassID string) ([]*v1beta1.ServicePlan, error) {
+func convertServicePlans(namespace string, plans []osbclient.Plan, serviceClassID string) ([]*v1beta1.ServicePlan, error) {
 	if 0 == len(plans) {
 		return nil, fmt.Errorf("ServiceClass (K8S: %q) must have at least one plan", serviceClassID)
 	}
@@ -1075,7 +1096,7 @@ func convertServicePlans(namespace string, plans []osb.Plan, serviceClassID stri
 	return servicePlans, nil
 }
 
-func convertCommonServicePlan(plan osb.Plan, commonServicePlanSpec *v1beta1.CommonServicePlanSpec) error {
+func convertCommonServicePlan(plan osbclient.Plan, commonServicePlanSpec *v1beta1.CommonServicePlanSpec) error {
 	if plan.Bindable != nil {
 		b := plan.Bindable
 		commonServicePlanSpec.Bindable = b
@@ -1135,10 +1156,17 @@ func convertCommonServicePlan(plan osb.Plan, commonServicePlanSpec *v1beta1.Comm
 			}
 		}
 	}
+
+	if plan.MaintenanceInfo != nil {
+		commonServicePlanSpec.MaintenanceInfo = &v1beta1.MaintenanceInfo{
+			Version:     plan.MaintenanceInfo.Version,
+			Description: plan.MaintenanceInfo.Description,
+		}
+	}
 	return nil
 }
 
-func convertClusterServicePlans(plans []osb.Plan, serviceClassID string) ([]*v1beta1.ClusterServicePlan, error) {
+func convertClusterServicePlans(plans []osbclient.Plan, serviceClassID string) ([]*v1beta1.ClusterServicePlan, error) {
 	if 0 == len(plans) {
 		return nil, fmt.Errorf("ClusterServiceClass (K8S: %q) must have at least one plan", serviceClassID)
 	}
@@ -1216,6 +1244,13 @@ func convertClusterServicePlans(plans []osb.Plan, serviceClassID string) ([]*v1b
 				}
 			}
 		}
+
+		if plan.MaintenanceInfo != nil {
+			servicePlans[i].Spec.MaintenanceInfo = &v1beta1.MaintenanceInfo{
+				Version:     plan.MaintenanceInfo.Version,
+				Description: plan.MaintenanceInfo.Description,
+			}
+		}
 	}
 	return servicePlans, nil
 }
@@ -1232,6 +1267,18 @@ func isServiceInstanceConditionTrue(instance *v1beta1.ServiceInstance, condition
 	return false
 }
 
+// hasServiceInstanceConditionReason returns whether the given instance has a
+// given condition with the given reason.
+func hasServiceInstanceConditionReason(instance *v1beta1.ServiceInstance, conditionType v1beta1.ServiceInstanceConditionType, reason string) bool {
+	for _, cond := range instance.Status.Conditions {
+		if cond.Type == conditionType {
+			return cond.Reason == reason
+		}
+	}
+
+	return false
+}
+
 // isServiceInstanceReady returns whether the given instance has a ready condition
 // with status true.
 func isServiceInstanceReady(instance *v1beta1.ServiceInstance) bool {
@@ -1331,7 +1378,7 @@ func (c *controller) setClusterID(id string) {
 // done to validate service plan, service class exist, and handles creating
 // a brokerclient to use for a given ServiceInstance.
 // Sets ServiceClassRef and/or ServicePlanRef if they haven't been already set.
-func (c *controller) getServiceClassPlanAndServiceBrokerForServiceBinding(instance *v1beta1.ServiceInstance, binding *v1beta1.ServiceBinding) (*v1beta1.ServiceClass, *v1beta1.ServicePlan, string, osb.Client, error) {
+func (c *controller) getServiceClassPlanAndServiceBrokerForServiceBinding(instance *v1beta1.ServiceInstance, binding *v1beta1.ServiceBinding) (*v1beta1.ServiceClass, *v1beta1.ServicePlan, string, osbclient.Client, error) {
 	serviceClass, serviceBrokerName, osbClient, err := c.getServiceClassAndServiceBrokerForServiceBinding(instance, binding)
 	if err != nil {
 		return nil, nil, "", nil, err
@@ -1344,7 +1391,7 @@ func (c *controller) getServiceClassPlanAndServiceBrokerForServiceBinding(instan
 	return serviceClass, servicePlan, serviceBrokerName, osbClient, nil
 }
 
-func (c *controller) getServiceClassAndServiceBrokerForServiceBinding(instance *v1beta1.ServiceInstance, binding *v1beta1.ServiceBinding) (*v1beta1.ServiceClass, string, osb.Client, error) {
+func (c *controller) getServiceClassAndServiceBrokerForServiceBinding(instance *v1beta1.ServiceInstance, binding *v1beta1.ServiceBinding) (*v1beta1.ServiceClass, string, osbclient.Client, error) {
 	serviceClass, err := c.getServiceClassForServiceBinding(instance, binding)
 	if err != nil {
 		return nil, "", nil, err
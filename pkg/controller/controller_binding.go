@@ -18,8 +18,19 @@ package controller
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+	"unicode/utf8"
 
 	"github.com/golang/glog"
 	osb "github.com/pmorie/go-open-service-broker-client/v2"
@@ -27,11 +38,14 @@ import (
 
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
+	"github.com/kubernetes-incubator/service-catalog/pkg/osbclient"
 	"github.com/kubernetes-incubator/service-catalog/pkg/pretty"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/jsonpath"
@@ -49,10 +63,15 @@ const (
 	errorServiceBindingOrphanMitigation       string = "ServiceBindingNeedsOrphanMitigation"
 	errorFetchingBindingFailedReason          string = "FetchingBindingFailed"
 	errorAsyncOpTimeoutReason                 string = "AsyncOperationTimeout"
+	errorUnbindRetiredBindingReason           string = "UnbindRetiredCredentialsFailed"
+	errorRestartingWorkloadsReason            string = "ErrorRestartingWorkloads"
 
 	successInjectedBindResultReason  string = "InjectedBindResult"
 	successInjectedBindResultMessage string = "Injected bind result"
 	successUnboundReason             string = "UnboundSuccessfully"
+	successRotatedCredentialsReason  string = "RotatedCredentials"
+	successUnboundRetiredReason      string = "UnboundRetiredCredentials"
+	successRestartedWorkloadReason   string = "RestartedWorkload"
 	asyncBindingReason               string = "Binding"
 	asyncBindingMessage              string = "The binding is being created asynchronously"
 	asyncUnbindingReason             string = "Unbinding"
@@ -63,6 +82,10 @@ const (
 	unbindingInFlightMessage         string = "Unbind request for ServiceBinding in-flight to Broker"
 )
 
+// defaultFlattenSeparator is the separator between the names of nested keys
+// used by a FlattenTransform that does not specify one.
+const defaultFlattenSeparator = "_"
+
 // bindingControllerKind contains the schema.GroupVersionKind for this controller type.
 var bindingControllerKind = v1beta1.SchemeGroupVersion.WithKind("ServiceBinding")
 
@@ -91,6 +114,15 @@ func (c *controller) bindingAdd(obj interface{}) {
 	c.bindingQueue.Add(key)
 }
 
+func (c *controller) bindingAddAfter(obj interface{}, d time.Duration) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		glog.Errorf("Couldn't get key for object %+v: %v", obj, err)
+		return
+	}
+	c.bindingQueue.AddAfter(key, d)
+}
+
 func (c *controller) bindingUpdate(oldObj, newObj interface{}) {
 	// Bindings with ongoing asynchronous operations will be manually added
 	// to the polling queue by the reconciler. They should be ignored here in
@@ -127,7 +159,10 @@ func (c *controller) reconcileServiceBindingKey(key string) error {
 		return err
 	}
 
-	return c.reconcileServiceBinding(binding)
+	span := c.reconcileSpans.start(pretty.ServiceBinding, namespace, name)
+	err = c.reconcileServiceBinding(binding)
+	c.reconcileSpans.finish(pretty.ServiceBinding, namespace, name, span, err)
+	return err
 }
 
 func isServiceBindingFailed(binding *v1beta1.ServiceBinding) bool {
@@ -139,6 +174,17 @@ func isServiceBindingFailed(binding *v1beta1.ServiceBinding) bool {
 	return false
 }
 
+// hasServiceBindingConditionReason returns whether the given binding has a
+// given condition with the given reason.
+func hasServiceBindingConditionReason(binding *v1beta1.ServiceBinding, conditionType v1beta1.ServiceBindingConditionType, reason string) bool {
+	for _, condition := range binding.Status.Conditions {
+		if condition.Type == conditionType {
+			return condition.Reason == reason
+		}
+	}
+	return false
+}
+
 // getReconciliationActionForServiceBinding gets the action the reconciler
 // should be taking on the given binding.
 func getReconciliationActionForServiceBinding(binding *v1beta1.ServiceBinding) ReconciliationAction {
@@ -177,13 +223,19 @@ func (c *controller) reconcileServiceBinding(binding *v1beta1.ServiceBinding) er
 func (c *controller) reconcileServiceBindingAdd(binding *v1beta1.ServiceBinding) error {
 	pcb := pretty.NewBindingContextBuilder(binding)
 
-	if isServiceBindingFailed(binding) {
-		glog.V(4).Info(pcb.Message("not processing event; status showed that it has failed"))
-		return nil
+	// The previous credentials of a rotated binding are cleaned up even if a
+	// later rotation failed.
+	if binding.Status.ReconciledGeneration == binding.Generation && len(binding.Status.RetiredBindings) > 0 {
+		return c.reconcileServiceBindingRetiredBindings(binding)
 	}
 
+	// A failed binding is processed again once its spec is changed.
 	if binding.Status.ReconciledGeneration == binding.Generation {
-		glog.V(4).Info(pcb.Message("Not processing event; reconciled generation showed there is no work to do"))
+		if isServiceBindingFailed(binding) {
+			glog.V(4).Info(pcb.Message("not processing event; status showed that it has failed"))
+		} else {
+			glog.V(4).Info(pcb.Message("Not processing event; reconciled generation showed there is no work to do"))
+		}
 		return nil
 	}
 
@@ -199,7 +251,7 @@ func (c *controller) reconcileServiceBindingAdd(binding *v1beta1.ServiceBinding)
 	}
 
 	var prettyName string
-	var brokerClient osb.Client
+	var brokerClient osbclient.Client
 	var request *osb.BindRequest
 	var inProgressProperties *v1beta1.ServiceBindingPropertiesState
 
@@ -368,14 +420,20 @@ func (c *controller) reconcileServiceBindingDelete(binding *v1beta1.ServiceBindi
 
 	binding = binding.DeepCopy()
 
-	// If unbinding succeeded or is not needed, then clear out the finalizers
-	if binding.Status.UnbindStatus == v1beta1.ServiceBindingUnbindStatusNotRequired ||
-		binding.Status.UnbindStatus == v1beta1.ServiceBindingUnbindStatusSucceeded {
+	unbindNotRequired := binding.Status.UnbindStatus == v1beta1.ServiceBindingUnbindStatusNotRequired ||
+		binding.Status.UnbindStatus == v1beta1.ServiceBindingUnbindStatusSucceeded
 
+	// If unbinding succeeded or is not needed, and no previous credentials
+	// remain to be unbound, then clear out the finalizers
+	if unbindNotRequired && len(binding.Status.RetiredBindings) == 0 {
 		return c.processServiceBindingGracefulDeletionSuccess(binding)
 	}
 
-	if err := c.ejectServiceBinding(binding); err != nil {
+	// The secret still holds the credentials that a failed rotation was
+	// meant to replace, so it is kept while mitigating the orphan.
+	if binding.DeletionTimestamp == nil && isServiceBindingRotationInProgress(binding) {
+		glog.V(4).Info(pcb.Message("Not deleting the secret of a binding whose credentials are being rotated"))
+	} else if err := c.ejectServiceBinding(binding); err != nil {
 		msg := fmt.Sprintf(`Error ejecting binding. Error deleting secret: %s`, err)
 		readyCond := newServiceBindingReadyCondition(v1beta1.ConditionFalse, errorEjectingBindReason, msg)
 		return c.processServiceBindingOperationError(binding, readyCond)
@@ -418,7 +476,7 @@ func (c *controller) reconcileServiceBindingDelete(binding *v1beta1.ServiceBindi
 		return c.processServiceBindingOperationError(binding, readyCond)
 	}
 
-	var brokerClient osb.Client
+	var brokerClient osbclient.Client
 	var prettyBrokerName string
 
 	if instance.Spec.ClusterServiceClassSpecified() {
@@ -456,6 +514,20 @@ func (c *controller) reconcileServiceBindingDelete(binding *v1beta1.ServiceBindi
 		prettyBrokerName = pretty.FromServiceInstanceOfServiceClassAtBrokerName(instance, serviceClass, brokerName)
 	}
 
+	if binding.DeletionTimestamp != nil && len(binding.Status.RetiredBindings) > 0 {
+		// Unbind the previous credentials right away, without waiting for
+		// the end of their grace period.
+		if _, err := c.unbindRetiredServiceBindings(binding, instance, brokerClient, true); err != nil {
+			if _, updateErr := c.updateServiceBindingStatus(binding); updateErr != nil {
+				return updateErr
+			}
+			return err
+		}
+		if unbindNotRequired {
+			return c.processServiceBindingGracefulDeletionSuccess(binding)
+		}
+	}
+
 	request, err := c.prepareUnbindRequest(binding, instance)
 	if err != nil {
 		return c.handleServiceBindingReconciliationError(binding, err)
@@ -534,55 +606,192 @@ func (c *controller) injectServiceBinding(binding *v1beta1.ServiceBinding, crede
 		}
 	}
 
-	// Creating/updating the Secret
+	// The outputs are written before the Secret of the binding, so that the
+	// Secret only changes once all of the credentials are written, and the
+	// workloads are restarted on the next retry if one of the outputs fails.
+	for _, output := range binding.Spec.Outputs {
+		data, err := selectServiceBindingOutputData(output, secretData)
+		if err != nil {
+			return err
+		}
+		glog.V(5).Info(pcb.Messagef(`Creating/updating %s "%s/%s" with %d keys`,
+			output.Kind, binding.Namespace, output.Name, len(data),
+		))
+		switch output.Kind {
+		case v1beta1.ServiceBindingOutputKindSecret:
+			secretType := corev1.SecretType(output.SecretType)
+			if secretType == "" {
+				secretType = corev1.SecretTypeOpaque
+			}
+			_, err = c.createOrUpdateServiceBindingSecret(binding, output.Name, secretType, data)
+		case v1beta1.ServiceBindingOutputKindConfigMap:
+			err = c.createOrUpdateServiceBindingConfigMap(binding, output.Name, data)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	changed, err := c.createOrUpdateServiceBindingSecret(binding, binding.Spec.SecretName, "", secretData)
+	if err != nil {
+		return err
+	}
+
+	if changed {
+		c.restartServiceBindingWorkloads(binding, credentialsChecksum(secretData))
+	}
+
+	return nil
+}
+
+// selectServiceBindingOutputData returns the entries of the serialized
+// credentials that the given output of a binding holds.
+func selectServiceBindingOutputData(output v1beta1.ServiceBindingOutput, secretData map[string][]byte) (map[string][]byte, error) {
+	if len(output.Keys) == 0 {
+		// A ConfigMap is readable by anyone who can read the ConfigMaps of
+		// the namespace, so it never receives all of the credentials.
+		if output.Kind == v1beta1.ServiceBindingOutputKindConfigMap {
+			return nil, fmt.Errorf(`No credential keys are selected for ConfigMap %q`, output.Name)
+		}
+		return secretData, nil
+	}
+	data := make(map[string][]byte, len(output.Keys))
+	for _, key := range output.Keys {
+		value, ok := secretData[key.Key]
+		if !ok {
+			return nil, fmt.Errorf(`Credential key %q written to %s %q was not found in the credentials`, key.Key, output.Kind, output.Name)
+		}
+		targetKey := key.TargetKey
+		if targetKey == "" {
+			targetKey = key.Key
+		}
+		data[targetKey] = value
+	}
+	return data, nil
+}
+
+// createOrUpdateServiceBindingSecret writes the given data to the Secret of
+// the given name in the namespace of the binding, and reports whether the
+// content of the Secret changed. An existing Secret is only updated if it is
+// controlled by the binding. An empty secretType leaves the type of the Secret
+// to the API server.
+func (c *controller) createOrUpdateServiceBindingSecret(binding *v1beta1.ServiceBinding, name string, secretType corev1.SecretType, secretData map[string][]byte) (bool, error) {
 	secretClient := c.kubeClient.CoreV1().Secrets(binding.Namespace)
-	existingSecret, err := secretClient.Get(binding.Spec.SecretName, metav1.GetOptions{})
+	existingSecret, err := secretClient.Get(name, metav1.GetOptions{})
 	if err == nil {
 		// Update existing secret
 		if !metav1.IsControlledBy(existingSecret, binding) {
 			controllerRef := metav1.GetControllerOf(existingSecret)
-			return fmt.Errorf(`Secret "%s/%s" is not owned by ServiceBinding, controllerRef: %v`, binding.Namespace, existingSecret.Name, controllerRef)
+			return false, fmt.Errorf(`Secret "%s/%s" is not owned by ServiceBinding, controllerRef: %v`, binding.Namespace, existingSecret.Name, controllerRef)
 		}
+		if secretType != "" && existingSecret.Type != secretType {
+			return false, fmt.Errorf(`Secret "%s/%s" has type %q instead of %q`, binding.Namespace, existingSecret.Name, existingSecret.Type, secretType)
+		}
+		changed := credentialsChecksum(existingSecret.Data) != credentialsChecksum(secretData)
 		existingSecret.Data = secretData
 		_, err = secretClient.Update(existingSecret)
 		if err != nil {
 			if apierrors.IsConflict(err) {
 				// Conflicting update detected, try again later
-				return fmt.Errorf(`Conflicting Secret "%s/%s" update detected`, binding.Namespace, existingSecret.Name)
+				return false, fmt.Errorf(`Conflicting Secret "%s/%s" update detected`, binding.Namespace, existingSecret.Name)
 			}
-			return fmt.Errorf(`Unexpected error updating Secret "%s/%s": %v`, binding.Namespace, existingSecret.Name, err)
+			return false, fmt.Errorf(`Unexpected error updating Secret "%s/%s": %v`, binding.Namespace, existingSecret.Name, err)
 		}
-	} else {
-		if !apierrors.IsNotFound(err) {
-			// Terminal error
-			return fmt.Errorf(`Unexpected error getting Secret "%s/%s": %v`, binding.Namespace, existingSecret.Name, err)
-		}
-		err = nil
-
-		// Create new secret
-		secret := &corev1.Secret{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      binding.Spec.SecretName,
-				Namespace: binding.Namespace,
-				OwnerReferences: []metav1.OwnerReference{
-					*metav1.NewControllerRef(binding, bindingControllerKind),
-				},
+		return changed, nil
+	}
+
+	if !apierrors.IsNotFound(err) {
+		// Terminal error
+		return false, fmt.Errorf(`Unexpected error getting Secret "%s/%s": %v`, binding.Namespace, name, err)
+	}
+
+	// Create new secret
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: binding.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(binding, bindingControllerKind),
 			},
-			Data: secretData,
+		},
+		Type: secretType,
+		Data: secretData,
+	}
+	_, err = secretClient.Create(secret)
+	if err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			// Concurrent controller has created secret under the same name,
+			// Update the secret at the next retry iteration
+			return false, fmt.Errorf(`Conflicting Secret "%s/%s" creation detected`, binding.Namespace, secret.Name)
+		}
+		// Terminal error
+		return false, fmt.Errorf(`Unexpected error creating Secret "%s/%s": %v`, binding.Namespace, secret.Name, err)
+	}
+
+	return true, nil
+}
+
+// createOrUpdateServiceBindingConfigMap writes the given data to the
+// ConfigMap of the given name in the namespace of the binding. Values that are
+// not valid UTF-8 are written as binary data. An existing ConfigMap is only
+// updated if it is controlled by the binding.
+func (c *controller) createOrUpdateServiceBindingConfigMap(binding *v1beta1.ServiceBinding, name string, data map[string][]byte) error {
+	configMapData := make(map[string]string)
+	var configMapBinaryData map[string][]byte
+	for k, v := range data {
+		if utf8.Valid(v) {
+			configMapData[k] = string(v)
+			continue
+		}
+		if configMapBinaryData == nil {
+			configMapBinaryData = make(map[string][]byte)
+		}
+		configMapBinaryData[k] = v
+	}
+
+	configMapClient := c.kubeClient.CoreV1().ConfigMaps(binding.Namespace)
+	existingConfigMap, err := configMapClient.Get(name, metav1.GetOptions{})
+	if err == nil {
+		if !metav1.IsControlledBy(existingConfigMap, binding) {
+			controllerRef := metav1.GetControllerOf(existingConfigMap)
+			return fmt.Errorf(`ConfigMap "%s/%s" is not owned by ServiceBinding, controllerRef: %v`, binding.Namespace, existingConfigMap.Name, controllerRef)
 		}
-		_, err = secretClient.Create(secret)
+		existingConfigMap.Data = configMapData
+		existingConfigMap.BinaryData = configMapBinaryData
+		_, err = configMapClient.Update(existingConfigMap)
 		if err != nil {
-			if apierrors.IsAlreadyExists(err) {
-				// Concurrent controller has created secret under the same name,
-				// Update the secret at the next retry iteration
-				return fmt.Errorf(`Conflicting Secret "%s/%s" creation detected`, binding.Namespace, secret.Name)
+			if apierrors.IsConflict(err) {
+				return fmt.Errorf(`Conflicting ConfigMap "%s/%s" update detected`, binding.Namespace, existingConfigMap.Name)
 			}
-			// Terminal error
-			return fmt.Errorf(`Unexpected error creating Secret "%s/%s": %v`, binding.Namespace, secret.Name, err)
+			return fmt.Errorf(`Unexpected error updating ConfigMap "%s/%s": %v`, binding.Namespace, existingConfigMap.Name, err)
 		}
+		return nil
 	}
 
-	return err
+	if !apierrors.IsNotFound(err) {
+		return fmt.Errorf(`Unexpected error getting ConfigMap "%s/%s": %v`, binding.Namespace, name, err)
+	}
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: binding.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(binding, bindingControllerKind),
+			},
+		},
+		Data:       configMapData,
+		BinaryData: configMapBinaryData,
+	}
+	_, err = configMapClient.Create(configMap)
+	if err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf(`Conflicting ConfigMap "%s/%s" creation detected`, binding.Namespace, configMap.Name)
+		}
+		return fmt.Errorf(`Unexpected error creating ConfigMap "%s/%s": %v`, binding.Namespace, configMap.Name, err)
+	}
+
+	return nil
 }
 
 func (c *controller) transformCredentials(transforms []v1beta1.SecretTransform, credentials map[string]interface{}) error {
@@ -620,11 +829,130 @@ func (c *controller) transformCredentials(transforms []v1beta1.SecretTransform,
 			}
 		case t.RemoveKey != nil:
 			delete(credentials, t.RemoveKey.Key)
+		case t.Template != nil:
+			value, err := executeTemplate(t.Template.Template, credentials)
+			if err != nil {
+				return fmt.Errorf("template for key %q: %v", t.Template.Key, err)
+			}
+			credentials[t.Template.Key] = value
+		case t.Flatten != nil:
+			separator := t.Flatten.Separator
+			if separator == "" {
+				separator = defaultFlattenSeparator
+			}
+			// Flatten into a separate map, so that the keys added do not
+			// overwrite the credentials that are not flattened.
+			flattened := make(map[string]interface{})
+			var flattenedKeys []string
+			for k, v := range credentials {
+				if t.Flatten.Key != "" && k != t.Flatten.Key {
+					continue
+				}
+				switch v.(type) {
+				case map[string]interface{}, []interface{}:
+					if err := flattenCredential(k, separator, v, flattened); err != nil {
+						return err
+					}
+					flattenedKeys = append(flattenedKeys, k)
+				}
+			}
+			for _, k := range flattenedKeys {
+				delete(credentials, k)
+			}
+			for k, v := range flattened {
+				if _, ok := credentials[k]; ok {
+					return fmt.Errorf("flattened key %q conflicts with an existing key", k)
+				}
+				credentials[k] = v
+			}
+		case t.Base64Decode != nil:
+			value, ok := credentials[t.Base64Decode.Key]
+			if !ok {
+				continue
+			}
+			var encoded string
+			switch v := value.(type) {
+			case string:
+				encoded = v
+			case []byte:
+				encoded = string(v)
+			default:
+				return fmt.Errorf("value of key %q is not a base64 encoded string", t.Base64Decode.Key)
+			}
+			decoded, err := base64.StdEncoding.DecodeString(encoded)
+			if err != nil {
+				return fmt.Errorf("value of key %q is not a base64 encoded string: %v", t.Base64Decode.Key, err)
+			}
+			credentials[t.Base64Decode.Key] = decoded
+		case t.Base64Encode != nil:
+			value, ok := credentials[t.Base64Encode.Key]
+			if !ok {
+				continue
+			}
+			data, err := serialize(value)
+			if err != nil {
+				return fmt.Errorf("unable to serialize value for key %q: %v", t.Base64Encode.Key, err)
+			}
+			credentials[t.Base64Encode.Key] = base64.StdEncoding.EncodeToString(data)
+		case t.OnlyKeys != nil:
+			keys := sets.NewString(t.OnlyKeys.Keys...)
+			for k := range credentials {
+				if !keys.Has(k) {
+					delete(credentials, k)
+				}
+			}
 		}
 	}
 	return nil
 }
 
+// executeTemplate executes the given Go template with the credentials, whose
+// binary values are exposed to the template as strings.
+func executeTemplate(text string, credentials map[string]interface{}) (string, error) {
+	tmpl, err := template.New("template").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	data := make(map[string]interface{}, len(credentials))
+	for k, v := range credentials {
+		if b, ok := v.([]byte); ok {
+			v = string(b)
+		}
+		data[k] = v
+	}
+	buf := new(bytes.Buffer)
+	if err := tmpl.Execute(buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// flattenCredential adds an entry to the credentials for each of the values
+// nested in value, named after the path from prefix to the value.
+// It returns an error when two values are named the same.
+func flattenCredential(prefix, separator string, value interface{}, credentials map[string]interface{}) error {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for k, nested := range v {
+			if err := flattenCredential(prefix+separator+k, separator, nested, credentials); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for i, nested := range v {
+			if err := flattenCredential(prefix+separator+strconv.Itoa(i), separator, nested, credentials); err != nil {
+				return err
+			}
+		}
+	default:
+		if _, ok := credentials[prefix]; ok {
+			return fmt.Errorf("flattened key %q conflicts with another flattened key", prefix)
+		}
+		credentials[prefix] = value
+	}
+	return nil
+}
+
 func evaluateJSONPath(jsonPath string, credentials map[string]interface{}) (string, error) {
 	j := jsonpath.New("expression")
 	buf := new(bytes.Buffer)
@@ -637,6 +965,186 @@ func evaluateJSONPath(jsonPath string, credentials map[string]interface{}) (stri
 	return buf.String(), nil
 }
 
+// credentialsChecksum returns the checksum of the serialized credentials of
+// a binding.
+func credentialsChecksum(data map[string][]byte) string {
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	h := sha256.New()
+	for _, k := range keys {
+		h.Write([]byte(k))
+		h.Write([]byte{0})
+		h.Write(data[k])
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// serviceBindingWorkload is a workload whose pods use the credentials of a
+// binding.
+type serviceBindingWorkload struct {
+	kind        string
+	name        string
+	annotations map[string]string
+}
+
+// allowsRestartBy returns whether the workload allows the given binding to
+// restart it, by listing the binding in its
+// RestartAllowedServiceBindingsAnnotation.
+func (w serviceBindingWorkload) allowsRestartBy(binding *v1beta1.ServiceBinding) bool {
+	for _, name := range strings.Split(w.annotations[v1beta1.RestartAllowedServiceBindingsAnnotation], ",") {
+		if strings.TrimSpace(name) == binding.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// restartServiceBindingWorkloads sets the checksum of the credentials on the
+// pod template of the workloads selected by the annotations of the binding
+// that allow the binding to restart them, which rolls out new pods with the
+// new credentials. The credentials are already written, so errors are
+// reported as events of the binding rather than failing it.
+func (c *controller) restartServiceBindingWorkloads(binding *v1beta1.ServiceBinding, checksum string) {
+	pcb := pretty.NewBindingContextBuilder(binding)
+
+	workloads, err := c.listServiceBindingWorkloads(binding)
+	if err != nil {
+		msg := fmt.Sprintf("Error listing the workloads to restart: %s", err)
+		glog.Warning(pcb.Message(msg))
+		c.recorder.Event(binding, corev1.EventTypeWarning, errorRestartingWorkloadsReason, msg)
+	}
+	if len(workloads) == 0 {
+		return
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"annotations": map[string]string{
+						v1beta1.CredentialsChecksumAnnotation: checksum,
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		glog.Error(pcb.Messagef("Error building the patch of the workloads to restart: %s", err))
+		return
+	}
+
+	apps := c.kubeClient.AppsV1()
+	for _, w := range workloads {
+		if !w.allowsRestartBy(binding) {
+			msg := fmt.Sprintf(`Not restarting %s "%s/%s": its %q annotation does not list the binding`, w.kind, binding.Namespace, w.name, v1beta1.RestartAllowedServiceBindingsAnnotation)
+			glog.Warning(pcb.Message(msg))
+			c.recorder.Event(binding, corev1.EventTypeWarning, errorRestartingWorkloadsReason, msg)
+			continue
+		}
+		glog.V(4).Info(pcb.Messagef(`Restarting %s "%s/%s" with the new credentials`, w.kind, binding.Namespace, w.name))
+		switch w.kind {
+		case "deployment":
+			_, err = apps.Deployments(binding.Namespace).Patch(w.name, types.StrategicMergePatchType, patch)
+		case "statefulset":
+			_, err = apps.StatefulSets(binding.Namespace).Patch(w.name, types.StrategicMergePatchType, patch)
+		case "daemonset":
+			_, err = apps.DaemonSets(binding.Namespace).Patch(w.name, types.StrategicMergePatchType, patch)
+		}
+		if err != nil {
+			msg := fmt.Sprintf(`Error restarting %s "%s/%s": %s`, w.kind, binding.Namespace, w.name, err)
+			glog.Warning(pcb.Message(msg))
+			c.recorder.Event(binding, corev1.EventTypeWarning, errorRestartingWorkloadsReason, msg)
+			continue
+		}
+		msg := fmt.Sprintf(`Restarted %s "%s/%s" with the new credentials`, w.kind, binding.Namespace, w.name)
+		c.recorder.Event(binding, corev1.EventTypeNormal, successRestartedWorkloadReason, msg)
+	}
+}
+
+// listServiceBindingWorkloads returns the workloads listed by the
+// ServiceBindingRestartWorkloadsAnnotation of the binding and the ones
+// selected by its ServiceBindingRestartWorkloadsSelectorAnnotation, without
+// duplicates. The workloads that could be found are returned along with any
+// error.
+func (c *controller) listServiceBindingWorkloads(binding *v1beta1.ServiceBinding) ([]serviceBindingWorkload, error) {
+	var workloads []serviceBindingWorkload
+	var errs []error
+	seen := make(map[string]bool)
+	add := func(kind string, meta metav1.ObjectMeta) {
+		key := kind + "/" + meta.Name
+		if !seen[key] {
+			seen[key] = true
+			workloads = append(workloads, serviceBindingWorkload{kind: kind, name: meta.Name, annotations: meta.Annotations})
+		}
+	}
+
+	apps := c.kubeClient.AppsV1()
+	if list, ok := binding.Annotations[v1beta1.ServiceBindingRestartWorkloadsAnnotation]; ok {
+		for _, workload := range strings.Split(list, ",") {
+			parts := strings.Split(strings.TrimSpace(workload), "/")
+			if len(parts) != 2 {
+				errs = append(errs, fmt.Errorf("%q must be of the form <kind>/<name>", workload))
+				continue
+			}
+			kind, name := parts[0], parts[1]
+			switch kind {
+			case "deployment":
+				if d, err := apps.Deployments(binding.Namespace).Get(name, metav1.GetOptions{}); err != nil {
+					errs = append(errs, err)
+				} else {
+					add(kind, d.ObjectMeta)
+				}
+			case "statefulset":
+				if s, err := apps.StatefulSets(binding.Namespace).Get(name, metav1.GetOptions{}); err != nil {
+					errs = append(errs, err)
+				} else {
+					add(kind, s.ObjectMeta)
+				}
+			case "daemonset":
+				if d, err := apps.DaemonSets(binding.Namespace).Get(name, metav1.GetOptions{}); err != nil {
+					errs = append(errs, err)
+				} else {
+					add(kind, d.ObjectMeta)
+				}
+			default:
+				errs = append(errs, fmt.Errorf("unsupported kind %q", kind))
+			}
+		}
+	}
+
+	if selector, ok := binding.Annotations[v1beta1.ServiceBindingRestartWorkloadsSelectorAnnotation]; ok {
+		options := metav1.ListOptions{LabelSelector: selector}
+		if deployments, err := apps.Deployments(binding.Namespace).List(options); err != nil {
+			errs = append(errs, err)
+		} else {
+			for _, d := range deployments.Items {
+				add("deployment", d.ObjectMeta)
+			}
+		}
+		if statefulSets, err := apps.StatefulSets(binding.Namespace).List(options); err != nil {
+			errs = append(errs, err)
+		} else {
+			for _, s := range statefulSets.Items {
+				add("statefulset", s.ObjectMeta)
+			}
+		}
+		if daemonSets, err := apps.DaemonSets(binding.Namespace).List(options); err != nil {
+			errs = append(errs, err)
+		} else {
+			for _, d := range daemonSets.Items {
+				add("daemonset", d.ObjectMeta)
+			}
+		}
+	}
+
+	return workloads, utilerrors.NewAggregate(errs)
+}
+
 func (c *controller) ejectServiceBinding(binding *v1beta1.ServiceBinding) error {
 	var err error
 	pcb := pretty.NewBindingContextBuilder(binding)
@@ -648,9 +1156,192 @@ func (c *controller) ejectServiceBinding(binding *v1beta1.ServiceBinding) error
 		return err
 	}
 
+	// The outputs are only deleted if the binding controls them, as a
+	// binding does not write to an object it does not control.
+	for _, output := range binding.Spec.Outputs {
+		glog.V(5).Info(pcb.Messagef(`Deleting %s "%s/%s"`,
+			output.Kind, binding.Namespace, output.Name,
+		))
+		var obj metav1.Object
+		var deleteFunc func(string, *metav1.DeleteOptions) error
+		switch output.Kind {
+		case v1beta1.ServiceBindingOutputKindSecret:
+			secretClient := c.kubeClient.CoreV1().Secrets(binding.Namespace)
+			obj, err = secretClient.Get(output.Name, metav1.GetOptions{})
+			deleteFunc = secretClient.Delete
+		case v1beta1.ServiceBindingOutputKindConfigMap:
+			configMapClient := c.kubeClient.CoreV1().ConfigMaps(binding.Namespace)
+			obj, err = configMapClient.Get(output.Name, metav1.GetOptions{})
+			deleteFunc = configMapClient.Delete
+		default:
+			continue
+		}
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if !metav1.IsControlledBy(obj, binding) {
+			glog.V(4).Info(pcb.Messagef(`Not deleting %s "%s/%s" because it is not owned by the ServiceBinding`,
+				output.Kind, binding.Namespace, output.Name,
+			))
+			continue
+		}
+		if err := deleteFunc(output.Name, &metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isServiceBindingRotationInProgress returns whether the credentials of the
+// given binding are being rotated, that is whether a previous binding has
+// been retired but its credentials have not been replaced in the secret yet.
+func isServiceBindingRotationInProgress(binding *v1beta1.ServiceBinding) bool {
+	for _, retired := range binding.Status.RetiredBindings {
+		if retired.RetiredTime == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// retireServiceBindingCredentials records that the previous credentials of
+// a binding whose rotation is in progress have been replaced in the secret,
+// which starts their grace period. It returns whether any credentials were
+// retired.
+func retireServiceBindingCredentials(binding *v1beta1.ServiceBinding) bool {
+	retired := false
+	now := metav1.Now()
+	for i := range binding.Status.RetiredBindings {
+		if binding.Status.RetiredBindings[i].RetiredTime == nil {
+			binding.Status.RetiredBindings[i].RetiredTime = &now
+			retired = true
+		}
+	}
+	return retired
+}
+
+// reconcileServiceBindingRetiredBindings unbinds the previous bindings of a
+// ServiceBinding whose credentials were rotated, once the rotation grace
+// period has elapsed since they were replaced in the secret. The binding is
+// queued again for the previous bindings whose grace period has not elapsed
+// yet.
+func (c *controller) reconcileServiceBindingRetiredBindings(binding *v1beta1.ServiceBinding) error {
+	pcb := pretty.NewBindingContextBuilder(binding)
+
+	if wait := c.nextRetiredServiceBindingUnbind(binding); wait > 0 {
+		glog.V(4).Info(pcb.Messagef("Waiting %v to unbind the retired credentials", wait))
+		c.bindingAddAfter(binding, wait)
+		return nil
+	} else if wait < 0 {
+		glog.V(4).Info(pcb.Message("Not processing event; the credentials are being rotated"))
+		return nil
+	}
+
+	glog.V(4).Info(pcb.Message("Unbinding retired credentials"))
+
+	binding = binding.DeepCopy()
+
+	instance, err := c.instanceLister.ServiceInstances(binding.Namespace).Get(binding.Spec.ServiceInstanceRef.Name)
+	if err != nil {
+		return fmt.Errorf(`References a non-existent %s "%s/%s"`, pretty.ServiceInstance, binding.Namespace, binding.Spec.ServiceInstanceRef.Name)
+	}
+	if instance.Status.AsyncOpInProgress {
+		return fmt.Errorf(`Cannot unbind the retired credentials of %s while it has an ongoing asynchronous operation`, pretty.ServiceInstanceName(instance))
+	}
+
+	brokerClient, err := c.getBrokerClientForServiceBinding(instance, binding)
+	if err != nil {
+		return err
+	}
+
+	wait, unbindErr := c.unbindRetiredServiceBindings(binding, instance, brokerClient, false)
+	if _, err := c.updateServiceBindingStatus(binding); err != nil {
+		return err
+	}
+	if unbindErr != nil {
+		return unbindErr
+	}
+	if wait > 0 {
+		c.bindingAddAfter(binding, wait)
+	}
 	return nil
 }
 
+// nextRetiredServiceBindingUnbind returns how long until the grace period of
+// the next retired binding of the given binding elapses. It returns zero if
+// a retired binding is due to be unbound, and a negative duration if none of
+// them can be unbound yet because their credentials are still in use.
+func (c *controller) nextRetiredServiceBindingUnbind(binding *v1beta1.ServiceBinding) time.Duration {
+	next := time.Duration(-1)
+	for _, retired := range binding.Status.RetiredBindings {
+		if retired.RetiredTime == nil {
+			continue
+		}
+		wait := retired.RetiredTime.Add(c.bindingRotationGracePeriod).Sub(time.Now())
+		if wait <= 0 {
+			return 0
+		}
+		if next < 0 || wait < next {
+			next = wait
+		}
+	}
+	return next
+}
+
+// unbindRetiredServiceBindings sends an unbind request to the broker for each
+// retired binding of the given binding whose grace period has elapsed, or for
+// all of them if force is true, and removes the ones that were unbound from
+// the status of the binding. It returns how long until the grace period of
+// the next remaining retired binding elapses, and the errors of the unbind
+// requests that failed. The status is *not* recorded in the registry.
+func (c *controller) unbindRetiredServiceBindings(binding *v1beta1.ServiceBinding, instance *v1beta1.ServiceInstance, brokerClient osbclient.Client, force bool) (time.Duration, error) {
+	pcb := pretty.NewBindingContextBuilder(binding)
+
+	if instance.Status.ExternalProperties == nil {
+		return 0, fmt.Errorf("The plan of %s has not been set yet", pretty.ServiceInstanceName(instance))
+	}
+
+	var remaining []v1beta1.RetiredServiceBinding
+	var errs []error
+	for _, retired := range binding.Status.RetiredBindings {
+		if !force && (retired.RetiredTime == nil || time.Now().Before(retired.RetiredTime.Add(c.bindingRotationGracePeriod))) {
+			remaining = append(remaining, retired)
+			continue
+		}
+
+		retiredBinding := binding.DeepCopy()
+		retiredBinding.Spec.ExternalID = retired.ExternalID
+		request, err := c.prepareUnbindRequest(retiredBinding, instance)
+		if err != nil {
+			remaining = append(remaining, retired)
+			errs = append(errs, err)
+			continue
+		}
+		// The previous credentials are unbound in the background of the
+		// binding, so there is no operation to poll.
+		request.AcceptsIncomplete = false
+
+		if _, err := brokerClient.Unbind(request); err != nil {
+			msg := fmt.Sprintf("Error unbinding the retired credentials %q: %s", retired.ExternalID, err)
+			c.recorder.Event(binding, corev1.EventTypeWarning, errorUnbindRetiredBindingReason, msg)
+			remaining = append(remaining, retired)
+			errs = append(errs, errors.New(msg))
+			continue
+		}
+
+		msg := fmt.Sprintf("Unbound the retired credentials %q", retired.ExternalID)
+		glog.V(4).Info(pcb.Message(msg))
+		c.recorder.Event(binding, corev1.EventTypeNormal, successUnboundRetiredReason, msg)
+	}
+	binding.Status.RetiredBindings = remaining
+
+	return c.nextRetiredServiceBindingUnbind(binding), utilerrors.NewAggregate(errs)
+}
+
 // setServiceBindingCondition sets a single condition on a ServiceBinding's
 // status: if the condition already exists in the status, it is mutated; if the
 // condition does not already exist in the status, it is added. Other
@@ -725,6 +1416,28 @@ func setServiceBindingConditionInternal(toUpdate *v1beta1.ServiceBinding,
 	toUpdate.Status.Conditions = append(toUpdate.Status.Conditions, newCondition)
 }
 
+// removeServiceBindingCondition removes a condition of a given type from a
+// binding's status if it exists.
+func removeServiceBindingCondition(toUpdate *v1beta1.ServiceBinding,
+	conditionType v1beta1.ServiceBindingConditionType) {
+	pcb := pretty.NewBindingContextBuilder(toUpdate)
+	glog.V(5).Info(pcb.Messagef(
+		"Removing condition %q", conditionType,
+	))
+
+	newStatusConditions := make([]v1beta1.ServiceBindingCondition, 0, len(toUpdate.Status.Conditions))
+	for _, cond := range toUpdate.Status.Conditions {
+		if cond.Type == conditionType {
+			glog.V(5).Info(pcb.Messagef("Found existing condition %q: %q; removing it",
+				conditionType, cond.Status,
+			))
+			continue
+		}
+		newStatusConditions = append(newStatusConditions, cond)
+	}
+	toUpdate.Status.Conditions = newStatusConditions
+}
+
 func (c *controller) updateServiceBindingStatus(toUpdate *v1beta1.ServiceBinding) (*v1beta1.ServiceBinding, error) {
 	pcb := pretty.NewBindingContextBuilder(toUpdate)
 	glog.V(4).Info(pcb.Message("Updating status"))
@@ -796,6 +1509,7 @@ func (c *controller) recordStartOfServiceBindingOperation(
 		reason = bindingInFlightReason
 		message = bindingInFlightMessage
 		toUpdate.Status.UnbindStatus = v1beta1.ServiceBindingUnbindStatusRequired
+		removeServiceBindingCondition(toUpdate, v1beta1.ServiceBindingConditionFailed)
 	case v1beta1.ServiceBindingOperationUnbind:
 		reason = unbindingInFlightReason
 		message = unbindingInFlightMessage
@@ -1410,12 +2124,17 @@ func (c *controller) processBindSuccess(binding *v1beta1.ServiceBinding) error {
 	currentReconciledGeneration := binding.Status.ReconciledGeneration
 	clearServiceBindingCurrentOperation(binding)
 	rollbackBindingReconciledGenerationOnDeletion(binding, currentReconciledGeneration)
+	rotated := retireServiceBindingCredentials(binding)
 
 	if _, err := c.updateServiceBindingStatus(binding); err != nil {
 		return err
 	}
 
 	c.recorder.Event(binding, corev1.EventTypeNormal, successInjectedBindResultReason, successInjectedBindResultMessage)
+	if rotated {
+		msg := fmt.Sprintf("Rotated the credentials; the previous credentials will be unbound in %v", c.bindingRotationGracePeriod)
+		c.recorder.Event(binding, corev1.EventTypeNormal, successRotatedCredentialsReason, msg)
+	}
 	return nil
 }
 
@@ -1474,11 +2193,39 @@ func (c *controller) processBindAsyncResponse(binding *v1beta1.ServiceBinding, r
 func (c *controller) handleServiceBindingReconciliationError(binding *v1beta1.ServiceBinding, err error) error {
 	if resourceErr, ok := err.(*operationError); ok {
 		readyCond := newServiceBindingReadyCondition(v1beta1.ConditionFalse, resourceErr.reason, resourceErr.message)
+		switch resourceErr.reason {
+		case errorBrokerUnavailableReason:
+			return c.processServiceBindingBrokerUnavailable(binding, readyCond)
+		case errorBrokerRequestsThrottledReason:
+			pcb := pretty.NewBindingContextBuilder(binding)
+			glog.V(4).Info(pcb.Message(resourceErr.message))
+			c.bindingAddAfter(binding, brokerRequestsThrottledRetryDelay)
+			return nil
+		}
 		return c.processServiceBindingOperationError(binding, readyCond)
 	}
 	return err
 }
 
+// processServiceBindingBrokerUnavailable parks a ServiceBinding whose broker
+// is not sent requests anymore because too many of them failed. The binding
+// is queued again after the broker probe interval instead of being retried
+// with backoff.
+func (c *controller) processServiceBindingBrokerUnavailable(binding *v1beta1.ServiceBinding, readyCond *v1beta1.ServiceBindingCondition) error {
+	if !hasServiceBindingConditionReason(binding, readyCond.Type, readyCond.Reason) {
+		c.recorder.Event(binding, corev1.EventTypeWarning, readyCond.Reason, readyCond.Message)
+		setServiceBindingCondition(binding, readyCond.Type, readyCond.Status, readyCond.Reason, readyCond.Message)
+		if _, err := c.updateServiceBindingStatus(binding); err != nil {
+			return err
+		}
+	}
+
+	pcb := pretty.NewBindingContextBuilder(binding)
+	glog.V(4).Info(pcb.Message(readyCond.Message))
+	c.bindingAddAfter(binding, c.brokerCircuitBreakers.probeInterval)
+	return nil
+}
+
 // processServiceBindingGracefulDeletionSuccess handles the logging and
 // updating of a ServiceBinding that has successfully finished graceful
 // deletion.
@@ -1511,7 +2258,11 @@ func (c *controller) processUnbindSuccess(binding *v1beta1.ServiceBinding) error
 
 	setServiceBindingCondition(binding, v1beta1.ServiceBindingConditionReady, v1beta1.ConditionFalse, reason, msg)
 	clearServiceBindingCurrentOperation(binding)
-	binding.Status.ExternalProperties = nil
+	// The previous credentials of a binding whose rotation failed are still
+	// bound, with the properties the broker last accepted.
+	if !mitigatingOrphan || !isServiceBindingRotationInProgress(binding) {
+		binding.Status.ExternalProperties = nil
+	}
 	binding.Status.UnbindStatus = v1beta1.ServiceBindingUnbindStatusSucceeded
 
 	if mitigatingOrphan {
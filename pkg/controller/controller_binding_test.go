@@ -32,6 +32,7 @@ import (
 	sctestutil "github.com/kubernetes-incubator/service-catalog/test/util"
 	osb "github.com/pmorie/go-open-service-broker-client/v2"
 	fakeosb "github.com/pmorie/go-open-service-broker-client/v2/fake"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -3890,6 +3891,762 @@ func TestPollServiceBinding(t *testing.T) {
 	}
 }
 
+// TestReconcileServiceBindingRotation tests reconcileServiceBinding to ensure
+// a binding whose credentials are rotated binds again with its new
+// ExternalID, updates the credentials in the existing secret and retires the
+// previous binding.
+func TestReconcileServiceBindingRotation(t *testing.T) {
+	fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		BindReaction: &fakeosb.BindReaction{
+			Response: &osb.BindResponse{
+				Credentials: map[string]interface{}{
+					"password": "new",
+				},
+			},
+		},
+	})
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+	sharedInformers.ServiceInstances().Informer().GetStore().Add(getTestServiceInstanceWithStatus(v1beta1.ConditionTrue))
+
+	startTime := metav1.NewTime(time.Now().Add(-1 * time.Minute))
+	binding := &v1beta1.ServiceBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       testServiceBindingName,
+			Namespace:  testNamespace,
+			UID:        "binding-uid",
+			Finalizers: []string{v1beta1.FinalizerServiceCatalog},
+			Generation: 2,
+		},
+		Spec: v1beta1.ServiceBindingSpec{
+			ServiceInstanceRef: v1beta1.LocalObjectReference{Name: testServiceInstanceName},
+			ExternalID:         "rotated-binding-id",
+			SecretName:         testServiceBindingSecretName,
+			RotationRequests:   1,
+		},
+		Status: v1beta1.ServiceBindingStatus{
+			ReconciledGeneration: 1,
+			CurrentOperation:     v1beta1.ServiceBindingOperationBind,
+			OperationStartTime:   &startTime,
+			InProgressProperties: &v1beta1.ServiceBindingPropertiesState{},
+			ExternalProperties:   &v1beta1.ServiceBindingPropertiesState{},
+			UnbindStatus:         v1beta1.ServiceBindingUnbindStatusRequired,
+			RetiredBindings:      []v1beta1.RetiredServiceBinding{{ExternalID: testServiceBindingGUID}},
+		},
+	}
+
+	addGetNamespaceReaction(fakeKubeClient)
+	addGetSecretReaction(fakeKubeClient, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            testServiceBindingSecretName,
+			Namespace:       testNamespace,
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(binding, bindingControllerKind)},
+		},
+		Data: map[string][]byte{"password": []byte("old")},
+	})
+
+	if err := reconcileServiceBinding(t, testController, binding); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	brokerActions := fakeClusterServiceBrokerClient.Actions()
+	assertNumberOfBrokerActions(t, brokerActions, 1)
+	assertBind(t, brokerActions[0], &osb.BindRequest{
+		BindingID:  "rotated-binding-id",
+		InstanceID: testServiceInstanceGUID,
+		ServiceID:  testClusterServiceClassGUID,
+		PlanID:     testClusterServicePlanGUID,
+		AppGUID:    strPtr(testNamespaceGUID),
+		BindResource: &osb.BindResource{
+			AppGUID: strPtr(testNamespaceGUID),
+		},
+		Context: testContext,
+	})
+
+	// The secret is updated in place rather than deleted and created again.
+	kubeActions := fakeKubeClient.Actions()
+	assertNumberOfActions(t, kubeActions, 3)
+	assertActionEquals(t, kubeActions[0], "get", "namespaces")
+	assertActionEquals(t, kubeActions[1], "get", "secrets")
+	assertActionEquals(t, kubeActions[2], "update", "secrets")
+	updatedSecret := kubeActions[2].(clientgotesting.UpdateAction).GetObject().(*corev1.Secret)
+	if e, a := "new", string(updatedSecret.Data["password"]); e != a {
+		t.Fatalf("Unexpected password in the updated secret: %s", expectedGot(e, a))
+	}
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	updatedServiceBinding := assertUpdateStatus(t, actions[0], binding).(*v1beta1.ServiceBinding)
+	assertServiceBindingReadyTrue(t, updatedServiceBinding)
+	if e, a := 1, len(updatedServiceBinding.Status.RetiredBindings); e != a {
+		t.Fatalf("Unexpected number of retired bindings: %s", expectedGot(e, a))
+	}
+	retired := updatedServiceBinding.Status.RetiredBindings[0]
+	if e, a := testServiceBindingGUID, retired.ExternalID; e != a {
+		t.Fatalf("Unexpected retired binding: %s", expectedGot(e, a))
+	}
+	if retired.RetiredTime == nil {
+		t.Fatal("Expected the retired binding to have a retired time")
+	}
+
+	events := getRecordedEvents(testController)
+	expectedEvents := []string{
+		normalEventBuilder(successInjectedBindResultReason).msg(successInjectedBindResultMessage).String(),
+		normalEventBuilder(successRotatedCredentialsReason).msg("Rotated the credentials; the previous credentials will be unbound in 10m0s").String(),
+	}
+	if err := checkEvents(events, expectedEvents); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestReconcileServiceBindingUpdateParameters tests reconcileServiceBinding
+// to ensure changing the parameters of a bound binding binds again with the
+// new parameters, and keeps the properties known by the broker in sync.
+func TestReconcileServiceBindingUpdateParameters(t *testing.T) {
+	fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		BindReaction: &fakeosb.BindReaction{
+			Response: &osb.BindResponse{
+				Credentials: map[string]interface{}{
+					"role": "read-only",
+				},
+			},
+		},
+	})
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+	sharedInformers.ServiceInstances().Informer().GetStore().Add(getTestServiceInstanceWithStatus(v1beta1.ConditionTrue))
+
+	oldParameters := map[string]interface{}{"role": "read-write"}
+	newParameters := map[string]interface{}{"role": "read-only"}
+	b, err := json.Marshal(newParameters)
+	if err != nil {
+		t.Fatalf("Failed to marshal parameters %v : %v", newParameters, err)
+	}
+
+	binding := &v1beta1.ServiceBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       testServiceBindingName,
+			Namespace:  testNamespace,
+			UID:        "binding-uid",
+			Finalizers: []string{v1beta1.FinalizerServiceCatalog},
+			Generation: 2,
+		},
+		Spec: v1beta1.ServiceBindingSpec{
+			ServiceInstanceRef: v1beta1.LocalObjectReference{Name: testServiceInstanceName},
+			ExternalID:         "updated-binding-id",
+			SecretName:         testServiceBindingSecretName,
+			Parameters:         &runtime.RawExtension{Raw: b},
+		},
+		Status: v1beta1.ServiceBindingStatus{
+			ReconciledGeneration: 1,
+			ExternalProperties: &v1beta1.ServiceBindingPropertiesState{
+				Parameters:         &runtime.RawExtension{Raw: []byte(`{"role":"read-write"}`)},
+				ParametersChecksum: generateChecksumOfParametersOrFail(t, oldParameters),
+			},
+			UnbindStatus:    v1beta1.ServiceBindingUnbindStatusRequired,
+			RetiredBindings: []v1beta1.RetiredServiceBinding{{ExternalID: testServiceBindingGUID}},
+		},
+	}
+	setServiceBindingCondition(binding, v1beta1.ServiceBindingConditionReady, v1beta1.ConditionTrue, successInjectedBindResultReason, successInjectedBindResultMessage)
+
+	addGetNamespaceReaction(fakeKubeClient)
+	addGetSecretReaction(fakeKubeClient, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            testServiceBindingSecretName,
+			Namespace:       testNamespace,
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(binding, bindingControllerKind)},
+		},
+		Data: map[string][]byte{"role": []byte("read-write")},
+	})
+
+	if err := reconcileServiceBinding(t, testController, binding); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The properties of the previous bind are kept until the broker accepts
+	// the new parameters.
+	expectedParametersChecksum := generateChecksumOfParametersOrFail(t, newParameters)
+	binding = assertServiceBindingOperationInProgressWithParametersIsTheOnlyCatalogAction(t, fakeCatalogClient, binding, v1beta1.ServiceBindingOperationBind, newParameters, expectedParametersChecksum)
+	fakeCatalogClient.ClearActions()
+	fakeKubeClient.ClearActions()
+
+	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 0)
+
+	if err := reconcileServiceBinding(t, testController, binding); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	brokerActions := fakeClusterServiceBrokerClient.Actions()
+	assertNumberOfBrokerActions(t, brokerActions, 1)
+	assertBind(t, brokerActions[0], &osb.BindRequest{
+		BindingID:  "updated-binding-id",
+		InstanceID: testServiceInstanceGUID,
+		ServiceID:  testClusterServiceClassGUID,
+		PlanID:     testClusterServicePlanGUID,
+		AppGUID:    strPtr(testNamespaceGUID),
+		Parameters: newParameters,
+		BindResource: &osb.BindResource{
+			AppGUID: strPtr(testNamespaceGUID),
+		},
+		Context: testContext,
+	})
+
+	kubeActions := fakeKubeClient.Actions()
+	assertNumberOfActions(t, kubeActions, 3)
+	assertActionEquals(t, kubeActions[0], "get", "namespaces")
+	assertActionEquals(t, kubeActions[1], "get", "secrets")
+	assertActionEquals(t, kubeActions[2], "update", "secrets")
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	updatedServiceBinding := assertUpdateStatus(t, actions[0], binding).(*v1beta1.ServiceBinding)
+	assertServiceBindingOperationSuccessWithParameters(t, updatedServiceBinding, v1beta1.ServiceBindingOperationBind, newParameters, expectedParametersChecksum, binding)
+	if e, a := 1, len(updatedServiceBinding.Status.RetiredBindings); e != a {
+		t.Fatalf("Unexpected number of retired bindings: %s", expectedGot(e, a))
+	}
+	if updatedServiceBinding.Status.RetiredBindings[0].RetiredTime == nil {
+		t.Fatal("Expected the retired binding to have a retired time")
+	}
+}
+
+// TestReconcileServiceBindingFailedWithSpecChange tests
+// reconcileServiceBinding to ensure a failed binding is bound again once its
+// spec is changed.
+func TestReconcileServiceBindingFailedWithSpecChange(t *testing.T) {
+	fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, noFakeActions())
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ServiceInstances().Informer().GetStore().Add(getTestServiceInstanceWithStatus(v1beta1.ConditionTrue))
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+	binding := getTestServiceBindingWithFailedStatus()
+	binding.Generation = 2
+
+	if err := reconcileServiceBinding(t, testController, binding); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	updatedServiceBinding := assertServiceBindingBindInProgressIsTheOnlyCatalogAction(t, fakeCatalogClient, binding)
+	for _, condition := range updatedServiceBinding.Status.Conditions {
+		if condition.Type == v1beta1.ServiceBindingConditionFailed {
+			t.Fatalf("Expected the Failed condition to be removed, got %+v", condition)
+		}
+	}
+
+	assertGetNamespaceAction(t, fakeKubeClient.Actions())
+	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 0)
+}
+
+// TestReconcileServiceBindingRetiredBindings tests reconcileServiceBinding to
+// ensure the previous bindings of a rotated binding are unbound once their
+// grace period has elapsed.
+func TestReconcileServiceBindingRetiredBindings(t *testing.T) {
+	retiredTime := func(d time.Duration) *metav1.Time {
+		t := metav1.NewTime(time.Now().Add(d))
+		return &t
+	}
+
+	cases := []struct {
+		name             string
+		retired          []v1beta1.RetiredServiceBinding
+		unbindReaction   *fakeosb.UnbindReaction
+		expectedUnbinds  []string
+		expectedRetired  []string
+		expectStatus     bool
+		expectedEvent    string
+		expectedErrorMsg string
+	}{
+		{
+			name:    "grace period not elapsed",
+			retired: []v1beta1.RetiredServiceBinding{{ExternalID: "old-id", RetiredTime: retiredTime(-1 * time.Minute)}},
+		},
+		{
+			name:    "rotation in progress",
+			retired: []v1beta1.RetiredServiceBinding{{ExternalID: "old-id"}},
+		},
+		{
+			name: "grace period elapsed",
+			retired: []v1beta1.RetiredServiceBinding{
+				{ExternalID: "old-id", RetiredTime: retiredTime(-1 * time.Hour)},
+				{ExternalID: "newer-id", RetiredTime: retiredTime(-1 * time.Minute)},
+			},
+			unbindReaction:  &fakeosb.UnbindReaction{Response: &osb.UnbindResponse{}},
+			expectedUnbinds: []string{"old-id"},
+			expectedRetired: []string{"newer-id"},
+			expectStatus:    true,
+			expectedEvent:   normalEventBuilder(successUnboundRetiredReason).msg(`Unbound the retired credentials "old-id"`).String(),
+		},
+		{
+			name:    "unbind failure",
+			retired: []v1beta1.RetiredServiceBinding{{ExternalID: "old-id", RetiredTime: retiredTime(-1 * time.Hour)}},
+			unbindReaction: &fakeosb.UnbindReaction{
+				Error: errors.New("fake unbind failure"),
+			},
+			expectedUnbinds:  []string{"old-id"},
+			expectedRetired:  []string{"old-id"},
+			expectStatus:     true,
+			expectedEvent:    warningEventBuilder(errorUnbindRetiredBindingReason).msg(`Error unbinding the retired credentials "old-id": fake unbind failure`).String(),
+			expectedErrorMsg: "fake unbind failure",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+				UnbindReaction: tc.unbindReaction,
+			})
+
+			sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+			sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+			sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+			sharedInformers.ServiceInstances().Informer().GetStore().Add(getTestServiceInstanceWithRefsAndExternalProperties())
+
+			binding := getTestServiceBinding()
+			binding.Status.ReconciledGeneration = binding.Generation
+			binding.Status.RetiredBindings = tc.retired
+
+			err := reconcileServiceBinding(t, testController, binding)
+			if tc.expectedErrorMsg == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			} else if tc.expectedErrorMsg != "" && (err == nil || !strings.Contains(err.Error(), tc.expectedErrorMsg)) {
+				t.Fatalf("unexpected error: %s", expectedGot(tc.expectedErrorMsg, err))
+			}
+
+			brokerActions := fakeClusterServiceBrokerClient.Actions()
+			assertNumberOfBrokerActions(t, brokerActions, len(tc.expectedUnbinds))
+			for i, id := range tc.expectedUnbinds {
+				assertUnbind(t, brokerActions[i], &osb.UnbindRequest{
+					BindingID:  id,
+					InstanceID: testServiceInstanceGUID,
+					ServiceID:  testClusterServiceClassGUID,
+					PlanID:     testClusterServicePlanGUID,
+				})
+			}
+
+			actions := fakeCatalogClient.Actions()
+			if !tc.expectStatus {
+				assertNumberOfActions(t, actions, 0)
+				return
+			}
+			assertNumberOfActions(t, actions, 1)
+			updatedServiceBinding := assertUpdateStatus(t, actions[0], binding).(*v1beta1.ServiceBinding)
+			var retired []string
+			for _, r := range updatedServiceBinding.Status.RetiredBindings {
+				retired = append(retired, r.ExternalID)
+			}
+			if e, a := tc.expectedRetired, retired; !reflect.DeepEqual(e, a) {
+				t.Fatalf("Unexpected retired bindings: %s", expectedGot(e, a))
+			}
+
+			events := getRecordedEvents(testController)
+			if err := checkEvents(events, []string{tc.expectedEvent}); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+// TestReconcileServiceBindingDeleteWithRetiredBindings tests
+// reconcileServiceBinding to ensure deleting a rotated binding unbinds its
+// previous bindings without waiting for their grace period.
+func TestReconcileServiceBindingDeleteWithRetiredBindings(t *testing.T) {
+	fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		UnbindReaction: &fakeosb.UnbindReaction{
+			Response: &osb.UnbindResponse{},
+		},
+	})
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+	sharedInformers.ServiceInstances().Informer().GetStore().Add(getTestServiceInstanceWithRefsAndExternalProperties())
+
+	retiredTime := metav1.Now()
+	startTime := metav1.Now()
+	binding := getTestServiceBinding()
+	binding.DeletionTimestamp = &metav1.Time{}
+	binding.Generation = 2
+	binding.Spec.ExternalID = "rotated-binding-id"
+	binding.Spec.SecretName = testServiceBindingSecretName
+	binding.Status.ReconciledGeneration = 1
+	binding.Status.CurrentOperation = v1beta1.ServiceBindingOperationUnbind
+	binding.Status.OperationStartTime = &startTime
+	binding.Status.RetiredBindings = []v1beta1.RetiredServiceBinding{{ExternalID: testServiceBindingGUID, RetiredTime: &retiredTime}}
+
+	if err := reconcileServiceBinding(t, testController, binding); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assertDeleteSecretAction(t, fakeKubeClient.Actions(), binding.Spec.SecretName)
+
+	brokerActions := fakeClusterServiceBrokerClient.Actions()
+	assertNumberOfBrokerActions(t, brokerActions, 2)
+	for i, id := range []string{testServiceBindingGUID, "rotated-binding-id"} {
+		assertUnbind(t, brokerActions[i], &osb.UnbindRequest{
+			BindingID:  id,
+			InstanceID: testServiceInstanceGUID,
+			ServiceID:  testClusterServiceClassGUID,
+			PlanID:     testClusterServicePlanGUID,
+		})
+	}
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	updatedServiceBinding := assertUpdateStatus(t, actions[0], binding).(*v1beta1.ServiceBinding)
+	if e, a := 0, len(updatedServiceBinding.Status.RetiredBindings); e != a {
+		t.Fatalf("Unexpected number of retired bindings: %s", expectedGot(e, a))
+	}
+}
+
+// TestReconcileServiceBindingOrphanMitigationDuringRotation tests
+// reconcileServiceBinding to ensure the secret holding the credentials that
+// a failed rotation was meant to replace is kept while mitigating the orphan.
+func TestReconcileServiceBindingOrphanMitigationDuringRotation(t *testing.T) {
+	fakeKubeClient, _, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		UnbindReaction: &fakeosb.UnbindReaction{
+			Response: &osb.UnbindResponse{},
+		},
+	})
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+	sharedInformers.ServiceInstances().Informer().GetStore().Add(getTestServiceInstanceWithRefsAndExternalProperties())
+
+	binding := getTestServiceBinding()
+	binding.Spec.ExternalID = "rotated-binding-id"
+	binding.Spec.SecretName = testServiceBindingSecretName
+	binding.Status.CurrentOperation = v1beta1.ServiceBindingOperationBind
+	binding.Status.OrphanMitigationInProgress = true
+	binding.Status.RetiredBindings = []v1beta1.RetiredServiceBinding{{ExternalID: testServiceBindingGUID}}
+
+	if err := reconcileServiceBinding(t, testController, binding); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assertNumberOfActions(t, fakeKubeClient.Actions(), 0)
+
+	brokerActions := fakeClusterServiceBrokerClient.Actions()
+	assertNumberOfBrokerActions(t, brokerActions, 1)
+	assertUnbind(t, brokerActions[0], &osb.UnbindRequest{
+		BindingID:  "rotated-binding-id",
+		InstanceID: testServiceInstanceGUID,
+		ServiceID:  testClusterServiceClassGUID,
+		PlanID:     testClusterServicePlanGUID,
+	})
+}
+
+// TestInjectServiceBindingOutputs tests injectServiceBinding to ensure the
+// credentials are written to the outputs of a binding, owned by the binding.
+func TestInjectServiceBindingOutputs(t *testing.T) {
+	binding := getTestServiceBinding()
+	binding.UID = "binding-uid"
+	binding.Spec.SecretName = testServiceBindingSecretName
+	binding.Spec.SecretTransforms = []v1beta1.SecretTransform{
+		{RenameKey: &v1beta1.RenameKeyTransform{From: "cert", To: "tls.crt"}},
+	}
+	binding.Spec.Outputs = []v1beta1.ServiceBindingOutput{
+		{
+			Name: "db-config",
+			Kind: v1beta1.ServiceBindingOutputKindConfigMap,
+			Keys: []v1beta1.ServiceBindingOutputKey{{Key: "uri", TargetKey: "DB_URI"}, {Key: "port"}},
+		},
+		{
+			Name:       "db-tls",
+			Kind:       v1beta1.ServiceBindingOutputKindSecret,
+			SecretType: string(corev1.SecretTypeTLS),
+			Keys:       []v1beta1.ServiceBindingOutputKey{{Key: "tls.crt"}, {Key: "tls.key"}},
+		},
+	}
+
+	fakeKubeClient := clientgofake.NewSimpleClientset()
+	_, _, _, testController, _ := newTestController(t, noFakeActions())
+	testController.kubeClient = fakeKubeClient
+
+	credentials := map[string]interface{}{
+		"uri":     "mysql://db:3306",
+		"port":    3306,
+		"cert":    "certificate",
+		"tls.key": "key",
+	}
+	if err := testController.injectServiceBinding(binding, credentials); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	kubeActions := fakeKubeClient.Actions()
+	assertNumberOfActions(t, kubeActions, 6)
+	assertActionEquals(t, kubeActions[0], "get", "configmaps")
+	assertActionEquals(t, kubeActions[1], "create", "configmaps")
+	assertActionEquals(t, kubeActions[2], "get", "secrets")
+	assertActionEquals(t, kubeActions[3], "create", "secrets")
+	assertActionEquals(t, kubeActions[4], "get", "secrets")
+	assertActionEquals(t, kubeActions[5], "create", "secrets")
+
+	secret := kubeActions[5].(clientgotesting.CreateAction).GetObject().(*corev1.Secret)
+	if e, a := 4, len(secret.Data); e != a {
+		t.Fatalf("Unexpected number of keys in the credentials Secret: %s", expectedGot(e, a))
+	}
+
+	configMap := kubeActions[1].(clientgotesting.CreateAction).GetObject().(*corev1.ConfigMap)
+	if e, a := "db-config", configMap.Name; e != a {
+		t.Fatalf("Unexpected name of the ConfigMap: %s", expectedGot(e, a))
+	}
+	if e, a := map[string]string{"DB_URI": "mysql://db:3306", "port": "3306"}, configMap.Data; !reflect.DeepEqual(e, a) {
+		t.Fatalf("Unexpected data in the ConfigMap: %s", expectedGot(e, a))
+	}
+	if !metav1.IsControlledBy(configMap, binding) {
+		t.Fatalf("Expected the ConfigMap to be controlled by the binding, got %+v", configMap.OwnerReferences)
+	}
+
+	tlsSecret := kubeActions[3].(clientgotesting.CreateAction).GetObject().(*corev1.Secret)
+	if e, a := "db-tls", tlsSecret.Name; e != a {
+		t.Fatalf("Unexpected name of the TLS Secret: %s", expectedGot(e, a))
+	}
+	if e, a := corev1.SecretTypeTLS, tlsSecret.Type; e != a {
+		t.Fatalf("Unexpected type of the TLS Secret: %s", expectedGot(e, a))
+	}
+	if e, a := map[string][]byte{"tls.crt": []byte("certificate"), "tls.key": []byte("key")}, tlsSecret.Data; !reflect.DeepEqual(e, a) {
+		t.Fatalf("Unexpected data in the TLS Secret: %s", expectedGot(e, a))
+	}
+	if !metav1.IsControlledBy(tlsSecret, binding) {
+		t.Fatalf("Expected the TLS Secret to be controlled by the binding, got %+v", tlsSecret.OwnerReferences)
+	}
+}
+
+// TestInjectServiceBindingOutputsFailure tests injectServiceBinding to
+// ensure the credentials are not written to outputs that cannot hold them.
+func TestInjectServiceBindingOutputsFailure(t *testing.T) {
+	isController := true
+	cases := []struct {
+		name             string
+		output           v1beta1.ServiceBindingOutput
+		existing         runtime.Object
+		expectedErrorMsg string
+	}{
+		{
+			name: "missing key",
+			output: v1beta1.ServiceBindingOutput{
+				Name: "db-config",
+				Kind: v1beta1.ServiceBindingOutputKindConfigMap,
+				Keys: []v1beta1.ServiceBindingOutputKey{{Key: "host"}},
+			},
+			expectedErrorMsg: `Credential key "host" written to ConfigMap "db-config" was not found in the credentials`,
+		},
+		{
+			name: "configmap without keys",
+			output: v1beta1.ServiceBindingOutput{
+				Name: "db-config",
+				Kind: v1beta1.ServiceBindingOutputKindConfigMap,
+			},
+			expectedErrorMsg: `No credential keys are selected for ConfigMap "db-config"`,
+		},
+		{
+			name: "configmap not owned by the binding",
+			output: v1beta1.ServiceBindingOutput{
+				Name: "db-config",
+				Kind: v1beta1.ServiceBindingOutputKindConfigMap,
+				Keys: []v1beta1.ServiceBindingOutputKey{{Key: "uri"}},
+			},
+			existing: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "db-config", Namespace: testNamespace},
+			},
+			expectedErrorMsg: `ConfigMap "test-ns/db-config" is not owned by ServiceBinding`,
+		},
+		{
+			name: "secret of another type",
+			output: v1beta1.ServiceBindingOutput{
+				Name:       "db-tls",
+				Kind:       v1beta1.ServiceBindingOutputKindSecret,
+				SecretType: string(corev1.SecretTypeTLS),
+			},
+			existing: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "db-tls",
+					Namespace: testNamespace,
+					OwnerReferences: []metav1.OwnerReference{{
+						APIVersion: bindingControllerKind.GroupVersion().String(),
+						Kind:       bindingControllerKind.Kind,
+						Name:       testServiceBindingName,
+						UID:        "binding-uid",
+						Controller: &isController,
+					}},
+				},
+				Type: corev1.SecretTypeOpaque,
+			},
+			expectedErrorMsg: `Secret "test-ns/db-tls" has type "Opaque" instead of "kubernetes.io/tls"`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			binding := getTestServiceBinding()
+			binding.UID = "binding-uid"
+			binding.Spec.SecretName = testServiceBindingSecretName
+			binding.Spec.Outputs = []v1beta1.ServiceBindingOutput{tc.output}
+
+			fakeKubeClient := clientgofake.NewSimpleClientset()
+			if tc.existing != nil {
+				fakeKubeClient = clientgofake.NewSimpleClientset(tc.existing)
+			}
+			_, _, _, testController, _ := newTestController(t, noFakeActions())
+			testController.kubeClient = fakeKubeClient
+
+			err := testController.injectServiceBinding(binding, map[string]interface{}{"uri": "mysql://db:3306"})
+			if err == nil || !strings.Contains(err.Error(), tc.expectedErrorMsg) {
+				t.Fatalf("Unexpected error: %s", expectedGot(tc.expectedErrorMsg, err))
+			}
+		})
+	}
+}
+
+// TestEjectServiceBindingOutputs tests ejectServiceBinding to ensure the
+// outputs of a binding are deleted along with its credentials Secret, unless
+// they are not owned by the binding.
+func TestEjectServiceBindingOutputs(t *testing.T) {
+	binding := getTestServiceBinding()
+	binding.UID = "binding-uid"
+	binding.Spec.SecretName = testServiceBindingSecretName
+	binding.Spec.Outputs = []v1beta1.ServiceBindingOutput{
+		{Name: "db-config", Kind: v1beta1.ServiceBindingOutputKindConfigMap},
+		{Name: "db-tls", Kind: v1beta1.ServiceBindingOutputKindSecret},
+		{Name: "missing", Kind: v1beta1.ServiceBindingOutputKindConfigMap},
+	}
+	controllerRef := *metav1.NewControllerRef(binding, bindingControllerKind)
+
+	fakeKubeClient := clientgofake.NewSimpleClientset(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "db-config", Namespace: testNamespace, OwnerReferences: []metav1.OwnerReference{controllerRef}},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "db-tls", Namespace: testNamespace},
+		},
+	)
+	_, _, _, testController, _ := newTestController(t, noFakeActions())
+	testController.kubeClient = fakeKubeClient
+
+	if err := testController.ejectServiceBinding(binding); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	kubeActions := fakeKubeClient.Actions()
+	assertNumberOfActions(t, kubeActions, 5)
+	assertActionEquals(t, kubeActions[0], "delete", "secrets")
+	assertActionEquals(t, kubeActions[1], "get", "configmaps")
+	assertActionEquals(t, kubeActions[2], "delete", "configmaps")
+	assertActionEquals(t, kubeActions[3], "get", "secrets")
+	assertActionEquals(t, kubeActions[4], "get", "configmaps")
+
+	if _, err := fakeKubeClient.CoreV1().Secrets(testNamespace).Get("db-tls", metav1.GetOptions{}); err != nil {
+		t.Fatalf("Expected the Secret not owned by the binding to be kept: %v", err)
+	}
+}
+
+// TestInjectServiceBindingRestartsWorkloads tests injectServiceBinding to
+// ensure the workloads selected by the annotations of a binding are restarted
+// when its credentials change, and only then, provided that they allow the
+// binding to restart them.
+func TestInjectServiceBindingRestartsWorkloads(t *testing.T) {
+	binding := getTestServiceBinding()
+	binding.UID = "binding-uid"
+	binding.Spec.SecretName = testServiceBindingSecretName
+	binding.Annotations = map[string]string{
+		v1beta1.ServiceBindingRestartWorkloadsAnnotation:         "deployment/web,daemonset/missing",
+		v1beta1.ServiceBindingRestartWorkloadsSelectorAnnotation: "app=db",
+	}
+	allowed := map[string]string{v1beta1.RestartAllowedServiceBindingsAnnotation: "other-binding, " + testServiceBindingName}
+
+	fakeKubeClient := clientgofake.NewSimpleClientset(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            testServiceBindingSecretName,
+				Namespace:       testNamespace,
+				OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(binding, bindingControllerKind)},
+			},
+			Data: map[string][]byte{"password": []byte("old")},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: testNamespace, Annotations: allowed},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "admin", Namespace: testNamespace, Labels: map[string]string{"app": "db"}},
+		},
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: testNamespace, Labels: map[string]string{"app": "db"}, Annotations: allowed},
+		},
+		&appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: testNamespace, Labels: map[string]string{"app": "agent"}, Annotations: allowed},
+		},
+	)
+	_, _, _, testController, _ := newTestController(t, noFakeActions())
+	testController.kubeClient = fakeKubeClient
+
+	credentials := map[string]interface{}{"password": "new"}
+	if err := testController.injectServiceBinding(binding, credentials); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	checksum := credentialsChecksum(map[string][]byte{"password": []byte("new")})
+	deployment, err := fakeKubeClient.AppsV1().Deployments(testNamespace).Get("web", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if e, a := checksum, deployment.Spec.Template.Annotations[v1beta1.CredentialsChecksumAnnotation]; e != a {
+		t.Fatalf("Unexpected checksum on the Deployment: %s", expectedGot(e, a))
+	}
+	statefulSet, err := fakeKubeClient.AppsV1().StatefulSets(testNamespace).Get("db", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if e, a := checksum, statefulSet.Spec.Template.Annotations[v1beta1.CredentialsChecksumAnnotation]; e != a {
+		t.Fatalf("Unexpected checksum on the StatefulSet: %s", expectedGot(e, a))
+	}
+	daemonSet, err := fakeKubeClient.AppsV1().DaemonSets(testNamespace).Get("agent", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := daemonSet.Spec.Template.Annotations[v1beta1.CredentialsChecksumAnnotation]; ok {
+		t.Fatal("Expected the DaemonSet that is not selected not to be restarted")
+	}
+	admin, err := fakeKubeClient.AppsV1().Deployments(testNamespace).Get("admin", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := admin.Spec.Template.Annotations[v1beta1.CredentialsChecksumAnnotation]; ok {
+		t.Fatal("Expected the Deployment that does not allow the binding to restart it not to be restarted")
+	}
+
+	events := getRecordedEvents(testController)
+	expectedEvents := []string{
+		warningEventBuilder(errorRestartingWorkloadsReason).msg("Error listing the workloads to restart:").String(),
+		normalEventBuilder(successRestartedWorkloadReason).msg(`Restarted deployment "test-ns/web"`).String(),
+		warningEventBuilder(errorRestartingWorkloadsReason).msg(`Not restarting deployment "test-ns/admin"`).String(),
+		normalEventBuilder(successRestartedWorkloadReason).msg(`Restarted statefulset "test-ns/db"`).String(),
+	}
+	if e, a := len(expectedEvents), len(events); e != a {
+		t.Fatalf("Unexpected number of events: %s", expectedGot(e, a))
+	}
+	for i, e := range expectedEvents {
+		if !strings.HasPrefix(events[i], e) {
+			t.Fatalf("Unexpected event: %s", expectedGot(e, events[i]))
+		}
+	}
+
+	// Injecting the same credentials again does not restart the workloads.
+	fakeKubeClient.ClearActions()
+	if err := testController.injectServiceBinding(binding, credentials); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	kubeActions := fakeKubeClient.Actions()
+	assertNumberOfActions(t, kubeActions, 2)
+	assertActionEquals(t, kubeActions[0], "get", "secrets")
+	assertActionEquals(t, kubeActions[1], "update", "secrets")
+}
+
 func TestTransformSecretData(t *testing.T) {
 	cases := []struct {
 		name                   string
@@ -4056,6 +4813,130 @@ func TestTransformSecretData(t *testing.T) {
 				"foo": "123",
 			},
 		},
+		{
+			name: "TemplateTransform",
+			transforms: []v1beta1.SecretTransform{
+				{
+					Template: &v1beta1.TemplateTransform{
+						Key:      "uri",
+						Template: "postgres://{{.user}}:{{.password}}@{{.host}}:{{.port}}",
+					},
+				},
+			},
+			credentials: map[string]interface{}{
+				"user":     "johndoe",
+				"password": []byte("s3cret"),
+				"host":     "db",
+				"port":     float64(5432),
+			},
+			transformedCredentials: map[string]interface{}{
+				"user":     "johndoe",
+				"password": []byte("s3cret"),
+				"host":     "db",
+				"port":     float64(5432),
+				"uri":      "postgres://johndoe:s3cret@db:5432",
+			},
+		},
+		{
+			name: "FlattenTransform",
+			transforms: []v1beta1.SecretTransform{
+				{
+					Flatten: &v1beta1.FlattenTransform{
+						Key: "db",
+					},
+				},
+			},
+			credentials: map[string]interface{}{
+				"db": map[string]interface{}{
+					"host":  "db",
+					"ports": []interface{}{float64(5432), float64(5433)},
+				},
+				"other": map[string]interface{}{
+					"foo": "123",
+				},
+			},
+			transformedCredentials: map[string]interface{}{
+				"db_host":    "db",
+				"db_ports_0": float64(5432),
+				"db_ports_1": float64(5433),
+				"other": map[string]interface{}{
+					"foo": "123",
+				},
+			},
+		},
+		{
+			name: "FlattenTransform of all keys with separator",
+			transforms: []v1beta1.SecretTransform{
+				{
+					Flatten: &v1beta1.FlattenTransform{
+						Separator: ".",
+					},
+				},
+			},
+			credentials: map[string]interface{}{
+				"db": map[string]interface{}{
+					"host": "db",
+				},
+				"other": map[string]interface{}{
+					"foo": "123",
+				},
+				"bar": "456",
+			},
+			transformedCredentials: map[string]interface{}{
+				"db.host":   "db",
+				"other.foo": "123",
+				"bar":       "456",
+			},
+		},
+		{
+			name: "Base64DecodeTransform",
+			transforms: []v1beta1.SecretTransform{
+				{
+					Base64Decode: &v1beta1.Base64DecodeTransform{
+						Key: "foo",
+					},
+				},
+			},
+			credentials: map[string]interface{}{
+				"foo": "MTIz",
+			},
+			transformedCredentials: map[string]interface{}{
+				"foo": []byte("123"),
+			},
+		},
+		{
+			name: "Base64EncodeTransform",
+			transforms: []v1beta1.SecretTransform{
+				{
+					Base64Encode: &v1beta1.Base64EncodeTransform{
+						Key: "foo",
+					},
+				},
+			},
+			credentials: map[string]interface{}{
+				"foo": "123",
+			},
+			transformedCredentials: map[string]interface{}{
+				"foo": "MTIz",
+			},
+		},
+		{
+			name: "OnlyKeysTransform",
+			transforms: []v1beta1.SecretTransform{
+				{
+					OnlyKeys: &v1beta1.OnlyKeysTransform{
+						Keys: []string{"foo", "baz"},
+					},
+				},
+			},
+			credentials: map[string]interface{}{
+				"foo": "123",
+				"bar": "456",
+			},
+			transformedCredentials: map[string]interface{}{
+				"foo": "123",
+			},
+		},
 	}
 
 	for _, tc := range cases {
@@ -4075,6 +4956,95 @@ func TestTransformSecretData(t *testing.T) {
 	}
 }
 
+func TestTransformSecretDataFailure(t *testing.T) {
+	cases := []struct {
+		name        string
+		transforms  []v1beta1.SecretTransform
+		credentials map[string]interface{}
+	}{
+		{
+			name: "TemplateTransform with missing key",
+			transforms: []v1beta1.SecretTransform{
+				{
+					Template: &v1beta1.TemplateTransform{
+						Key:      "uri",
+						Template: "postgres://{{.host}}",
+					},
+				},
+			},
+			credentials: map[string]interface{}{
+				"foo": "123",
+			},
+		},
+		{
+			name: "Base64DecodeTransform of invalid value",
+			transforms: []v1beta1.SecretTransform{
+				{
+					Base64Decode: &v1beta1.Base64DecodeTransform{
+						Key: "foo",
+					},
+				},
+			},
+			credentials: map[string]interface{}{
+				"foo": "not base64!",
+			},
+		},
+		{
+			name: "Base64DecodeTransform of non-string value",
+			transforms: []v1beta1.SecretTransform{
+				{
+					Base64Decode: &v1beta1.Base64DecodeTransform{
+						Key: "foo",
+					},
+				},
+			},
+			credentials: map[string]interface{}{
+				"foo": float64(123),
+			},
+		},
+		{
+			name: "FlattenTransform to an existing key",
+			transforms: []v1beta1.SecretTransform{
+				{
+					Flatten: &v1beta1.FlattenTransform{
+						Key: "db",
+					},
+				},
+			},
+			credentials: map[string]interface{}{
+				"db": map[string]interface{}{
+					"host": "db",
+				},
+				"db_host": "other",
+			},
+		},
+		{
+			name: "FlattenTransform of keys flattened to the same key",
+			transforms: []v1beta1.SecretTransform{
+				{
+					Flatten: &v1beta1.FlattenTransform{},
+				},
+			},
+			credentials: map[string]interface{}{
+				"db": map[string]interface{}{
+					"host_name": "db",
+					"host": map[string]interface{}{
+						"name": "other",
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		_, _, _, testController, _ := newTestController(t, fakeosb.FakeClientConfiguration{})
+
+		if err := testController.transformCredentials(tc.transforms, tc.credentials); err == nil {
+			t.Errorf("%v: expected an error", tc.name)
+		}
+	}
+}
+
 func assertServiceBindingBindInProgressIsTheOnlyCatalogAction(t *testing.T, fakeCatalogClient *fake.Clientset, binding *v1beta1.ServiceBinding) *v1beta1.ServiceBinding {
 	return assertServiceBindingOperationInProgressIsTheOnlyCatalogAction(t, fakeCatalogClient, binding, v1beta1.ServiceBindingOperationBind)
 }
@@ -32,6 +32,7 @@ import (
 
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"github.com/kubernetes-incubator/service-catalog/pkg/metrics"
+	"github.com/kubernetes-incubator/service-catalog/pkg/osbclient"
 	"github.com/kubernetes-incubator/service-catalog/pkg/pretty"
 )
 
@@ -116,7 +117,10 @@ func (c *controller) reconcileClusterServiceBrokerKey(key string) error {
 		return err
 	}
 
-	return c.reconcileClusterServiceBroker(broker)
+	span := c.reconcileSpans.start(pretty.ClusterServiceBroker, "", key)
+	err = c.reconcileClusterServiceBroker(broker)
+	c.reconcileSpans.finish(pretty.ClusterServiceBroker, "", key, span, err)
+	return err
 }
 
 // reconcileClusterServiceBroker is the control-loop that reconciles a Broker. An
@@ -130,6 +134,14 @@ func (c *controller) reconcileClusterServiceBroker(broker *v1beta1.ClusterServic
 	// set to Manual, do not reconcile it.
 	// * If the broker's ready condition is true and the relist interval has not
 	// elapsed, do not reconcile it.
+	// While requests to the broker are suspended, only probe the broker
+	// until it recovers.
+	if broker.DeletionTimestamp == nil {
+		if done, err := c.reconcileClusterServiceBrokerCircuit(broker); done {
+			return err
+		}
+	}
+
 	if !shouldReconcileClusterServiceBroker(broker, time.Now(), c.brokerRelistInterval) {
 		return nil
 	}
@@ -149,7 +161,12 @@ func (c *controller) reconcileClusterServiceBroker(broker *v1beta1.ClusterServic
 		clientConfig := NewClientConfigurationForBroker(broker.ObjectMeta, &broker.Spec.CommonServiceBrokerSpec, authConfig)
 
 		glog.V(4).Info(pcb.Messagef("Creating client, URL: %v", broker.Spec.URL))
-		brokerClient, err := c.brokerClientCreateFunc(clientConfig)
+		brokerClient, err := c.newBrokerClient(broker.ObjectMeta, &broker.Spec.CommonServiceBrokerSpec, clientConfig, c.reconcileSpans.get(pretty.ClusterServiceBroker, "", broker.Name))
+		if isBrokerRequestsThrottledError(err) {
+			glog.V(4).Info(pcb.Message(err.Error()))
+			c.clusterServiceBrokerQueue.AddAfter(broker.Name, brokerRequestsThrottledRetryDelay)
+			return nil
+		}
 		if err != nil {
 			s := fmt.Sprintf("Error creating client for broker %q: %s", broker.Name, err)
 			glog.Info(pcb.Message(s))
@@ -428,6 +445,9 @@ func (c *controller) reconcileClusterServiceBroker(broker *v1beta1.ClusterServic
 		// delete the metrics associated with this broker
 		metrics.BrokerServiceClassCount.DeleteLabelValues(broker.Name)
 		metrics.BrokerServicePlanCount.DeleteLabelValues(broker.Name)
+
+		c.brokerRequestLimiters.remove(broker.ObjectMeta)
+		c.brokerCircuitBreakers.remove(broker.ObjectMeta)
 		return nil
 	}
 
@@ -490,6 +510,7 @@ func (c *controller) reconcileClusterServiceClassFromClusterServiceBrokerCatalog
 	// update it.
 	toUpdate := existingServiceClass.DeepCopy()
 	toUpdate.Spec.BindingRetrievable = serviceClass.Spec.BindingRetrievable
+	toUpdate.Spec.InstancesRetrievable = serviceClass.Spec.InstancesRetrievable
 	toUpdate.Spec.Bindable = serviceClass.Spec.Bindable
 	toUpdate.Spec.PlanUpdatable = serviceClass.Spec.PlanUpdatable
 	toUpdate.Spec.Tags = serviceClass.Spec.Tags
@@ -586,6 +607,7 @@ func (c *controller) reconcileClusterServicePlanFromClusterServiceBrokerCatalog(
 	toUpdate.Spec.ServiceInstanceCreateParameterSchema = servicePlan.Spec.ServiceInstanceCreateParameterSchema
 	toUpdate.Spec.ServiceInstanceUpdateParameterSchema = servicePlan.Spec.ServiceInstanceUpdateParameterSchema
 	toUpdate.Spec.ServiceBindingCreateParameterSchema = servicePlan.Spec.ServiceBindingCreateParameterSchema
+	toUpdate.Spec.MaintenanceInfo = servicePlan.Spec.MaintenanceInfo
 
 	markAsServiceCatalogManagedResource(toUpdate, broker)
 
@@ -614,6 +636,55 @@ func (c *controller) reconcileClusterServicePlanFromClusterServiceBrokerCatalog(
 	return nil
 }
 
+// reconcileClusterServiceBrokerCircuit keeps the Degraded condition of the broker
+// in line with its circuit breaker, and probes the broker while its circuit
+// is open. It returns true when the reconciliation of the broker should stop
+// there.
+func (c *controller) reconcileClusterServiceBrokerCircuit(broker *v1beta1.ClusterServiceBroker) (bool, error) {
+	pcb := pretty.NewClusterServiceBrokerContextBuilder(broker)
+	degraded := isServiceBrokerDegraded(&broker.Status.CommonServiceBrokerStatus)
+
+	breaker := c.brokerCircuitBreakers.get(broker.ObjectMeta)
+	if breaker == nil || !breaker.isOpen() {
+		if !degraded {
+			return false, nil
+		}
+		s := "Requests to the broker are resumed"
+		glog.Info(pcb.Message(s))
+		c.recorder.Event(broker, corev1.EventTypeNormal, brokerRecoveredReason, s)
+		return true, c.updateClusterServiceBrokerCondition(broker, v1beta1.ServiceBrokerConditionDegraded, v1beta1.ConditionFalse, brokerRecoveredReason, s)
+	}
+
+	if !degraded {
+		s := breaker.degradedMessage()
+		glog.Warning(pcb.Message(s))
+		c.recorder.Event(broker, corev1.EventTypeWarning, brokerDegradedReason, s)
+		return true, c.updateClusterServiceBrokerCondition(broker, v1beta1.ServiceBrokerConditionDegraded, v1beta1.ConditionTrue, brokerDegradedReason, s)
+	}
+
+	recovered := c.probeBroker(
+		breaker,
+		broker.ObjectMeta,
+		func(d time.Duration) { c.clusterServiceBrokerQueue.AddAfter(broker.Name, d) },
+		func() (osbclient.Client, error) {
+			authConfig, err := getAuthCredentialsFromClusterServiceBroker(c.kubeClient, broker)
+			if err != nil {
+				return nil, err
+			}
+			clientConfig := NewClientConfigurationForBroker(broker.ObjectMeta, &broker.Spec.CommonServiceBrokerSpec, authConfig)
+			return c.brokerClientCreateFunc(clientConfig)
+		},
+	)
+	if !recovered {
+		return true, nil
+	}
+
+	s := "The broker answered a catalog request; requests to the broker are resumed"
+	glog.Info(pcb.Message(s))
+	c.recorder.Event(broker, corev1.EventTypeNormal, brokerRecoveredReason, s)
+	return true, c.updateClusterServiceBrokerCondition(broker, v1beta1.ServiceBrokerConditionDegraded, v1beta1.ConditionFalse, brokerRecoveredReason, s)
+}
+
 // updateClusterServiceBrokerCondition updates the ready condition for the given Broker
 // with the given status, reason, and message.
 func (c *controller) updateClusterServiceBrokerCondition(broker *v1beta1.ClusterServiceBroker, conditionType v1beta1.ServiceBrokerConditionType, status v1beta1.ConditionStatus, reason, message string) error {
@@ -633,6 +704,7 @@ func (c *controller) updateClusterServiceBrokerCondition(broker *v1beta1.Cluster
 		newCondition.LastTransitionTime = metav1.NewTime(t)
 		toUpdate.Status.Conditions = []v1beta1.ServiceBrokerCondition{newCondition}
 	} else {
+		found := false
 		for i, cond := range broker.Status.Conditions {
 			if cond.Type == conditionType {
 				if cond.Status != newCondition.Status {
@@ -646,9 +718,15 @@ func (c *controller) updateClusterServiceBrokerCondition(broker *v1beta1.Cluster
 				}
 
 				toUpdate.Status.Conditions[i] = newCondition
+				found = true
 				break
 			}
 		}
+		if !found {
+			glog.Info(pcb.Messagef("Setting lastTransitionTime for condition %q to %v", conditionType, t))
+			newCondition.LastTransitionTime = metav1.NewTime(t)
+			toUpdate.Status.Conditions = append(toUpdate.Status.Conditions, newCondition)
+		}
 	}
 
 	// Set status.ReconciledGeneration && status.LastCatalogRetrievalTime if updating ready condition to true
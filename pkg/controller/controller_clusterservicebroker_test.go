@@ -1228,6 +1228,28 @@ func TestUpdateServiceBrokerCondition(t *testing.T) {
 	}
 }
 
+// TestUpdateServiceBrokerConditionNewType tests that a condition of a type
+// the broker does not have yet is added next to the existing conditions.
+func TestUpdateServiceBrokerConditionNewType(t *testing.T) {
+	_, fakeCatalogClient, _, testController, _ := newTestController(t, getTestCatalogConfig())
+
+	broker := getTestClusterServiceBrokerWithStatus(v1beta1.ConditionTrue)
+	if err := testController.updateClusterServiceBrokerCondition(broker, v1beta1.ServiceBrokerConditionDegraded, v1beta1.ConditionTrue, "reason", "message"); err != nil {
+		t.Fatalf("error updating broker condition: %v", err)
+	}
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	updatedClusterServiceBroker := assertUpdateStatus(t, actions[0], broker).(*v1beta1.ClusterServiceBroker)
+	if e, a := 2, len(updatedClusterServiceBroker.Status.Conditions); e != a {
+		t.Fatalf("unexpected number of conditions: %s", expectedGot(e, a))
+	}
+	assertClusterServiceBrokerReadyTrue(t, updatedClusterServiceBroker)
+	if e, a := v1beta1.ServiceBrokerConditionDegraded, updatedClusterServiceBroker.Status.Conditions[1].Type; e != a {
+		t.Fatalf("unexpected condition type: %s", expectedGot(e, a))
+	}
+}
+
 func TestReconcileClusterServicePlanFromClusterServiceBrokerCatalog(t *testing.T) {
 	updatedPlan := func() *v1beta1.ClusterServicePlan {
 		p := getTestClusterServicePlan()
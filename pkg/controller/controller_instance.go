@@ -17,9 +17,13 @@ limitations under the License.
 package controller
 
 import (
+	"encoding/json"
 	stderrors "errors"
 	"fmt"
 	"net/url"
+	"reflect"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -31,14 +35,15 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
 	"k8s.io/client-go/tools/cache"
-	"k8s.io/client-go/util/workqueue"
 
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
+	"github.com/kubernetes-incubator/service-catalog/pkg/osbclient"
 	"github.com/kubernetes-incubator/service-catalog/pkg/pretty"
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -52,12 +57,20 @@ const (
 	successProvisionMessage        string = "The instance was provisioned successfully"
 	successOrphanMitigationReason  string = "OrphanMitigationSuccessful"
 	successOrphanMitigationMessage string = "Orphan mitigation was completed successfully"
+	driftedInstanceReason          string = "InstanceDrifted"
+	inSyncInstanceReason           string = "InstanceInSync"
+	inSyncInstanceMessage          string = "The plan and parameters reported by the broker match the ones last sent to it"
+	upgradeAvailableReason         string = "UpgradeAvailable"
+	upToDateInstanceReason         string = "InstanceUpToDate"
+	upToDateInstanceMessage        string = "The instance runs the latest version of its plan"
+	upgradingInstanceReason        string = "UpgradingInstance"
 
 	errorWithParameters                        string = "ErrorWithParameters"
 	errorProvisionCallFailedReason             string = "ProvisionCallFailed"
 	errorErrorCallingProvisionReason           string = "ErrorCallingProvision"
 	errorUpdateInstanceCallFailedReason        string = "UpdateInstanceCallFailed"
 	errorErrorCallingUpdateInstanceReason      string = "ErrorCallingUpdateInstance"
+	errorFetchingInstanceReason                string = "ErrorFetchingInstance"
 	errorDeprovisionCalledReason               string = "DeprovisionCallFailed"
 	errorDeprovisionBlockedByCredentialsReason string = "DeprovisionBlockedByExistingCredentials"
 	errorPollingLastOperationReason            string = "ErrorPollingLastOperation"
@@ -107,19 +120,6 @@ const (
 	maxBrokerOperationRetryDelay time.Duration = time.Minute * 20
 )
 
-type backoffEntry struct {
-	generation          int64
-	calculatedRetryTime time.Time // earliest time we should retry
-	dirty               bool      // true indicates new backoff should be calculated
-}
-
-type instanceOperationBackoff struct {
-	// lock to be used for accessing retry map
-	mutex       sync.RWMutex
-	instances   map[string]backoffEntry // Key is K8s metadata UID
-	rateLimiter workqueue.RateLimiter   // used to calculate next retry time, key is UID
-}
-
 // ServiceInstance handlers and control-loop
 
 // instanceAdd adds the instance key to the work queue
@@ -159,6 +159,8 @@ func (c *controller) instanceDelete(obj interface{}) {
 		return
 	}
 
+	c.instanceDriftChecks.forget(instance.UID)
+
 	pcb := pretty.NewInstanceContextBuilder(instance)
 	glog.V(4).Info(pcb.Message("Received delete event; no further processing will occur"))
 }
@@ -278,7 +280,10 @@ func (c *controller) reconcileServiceInstanceKey(key string) error {
 		return err
 	}
 
-	return c.reconcileServiceInstance(instance)
+	span := c.reconcileSpans.start(pretty.ServiceInstance, namespace, name)
+	err = c.reconcileServiceInstance(instance)
+	c.reconcileSpans.finish(pretty.ServiceInstance, namespace, name, span, err)
+	return err
 }
 
 // reconcileServiceInstance is the control-loop for reconciling Instances. An
@@ -371,111 +376,66 @@ func (c *controller) initOrphanMitigationCondition(instance *v1beta1.ServiceInst
 	return false, nil
 }
 
-// setRetryBackoffRequired marks the specified instance/generation as needing a
-// delay before the next provision/update is attempted.  We always set this flag
-// before attempting a provision or update operation in case we must retry.  This
-// will eventually be cleared by the background worker running
-// purgeExpiredRetryEntries() or when the operation is successful.
-func (c *controller) setRetryBackoffRequired(instance *v1beta1.ServiceInstance) {
+// setRetryBackoffRequired records in the status of the specified instance
+// that its provision/update operation failed with an error that will be
+// retried, and calculates the earliest time of the next attempt with an
+// exponential backoff.  The backoff is generation specific and is reset when
+// the generation changes.  It is persisted with the status of the instance so
+// that it survives a restart of the controller and the orphan mitigation of a
+// failed provision, and it is cleared when the operation succeeds or fails
+// terminally.
+func (c *controller) setRetryBackoffRequired(instance *v1beta1.ServiceInstance, err error) {
 	pcb := pretty.NewInstanceContextBuilder(instance)
-	c.instanceOperationRetryQueue.mutex.Lock()
-	defer c.instanceOperationRetryQueue.mutex.Unlock()
-	key := string(instance.GetUID())
-	retryEntry, found := c.instanceOperationRetryQueue.instances[key]
-	if !found || retryEntry.generation != instance.Generation {
-		retryEntry.generation = instance.Generation
+	retry := instance.Status.OperationRetry
+	if retry == nil || retry.Generation != instance.Generation {
+		retry = &v1beta1.ServiceInstanceOperationRetry{
+			Generation: instance.Generation,
+		}
+	}
+	retry.Attempts++
+	retry.NextRetryTime = metav1.NewTime(time.Now().Add(brokerOperationRetryDelay(retry.Attempts)))
+	retry.LastError = err.Error()
+	instance.Status.OperationRetry = retry
+	glog.V(4).Info(pcb.Messagef("BrokerOpRetry: generation %v attempt %v failed, retryTime calculated as %v", instance.Generation, retry.Attempts, retry.NextRetryTime))
+}
 
-		// reset the backoff as the generation changed
-		if found {
-			c.instanceOperationRetryQueue.rateLimiter.Forget(key)
+// brokerOperationRetryDelay returns the delay to observe after the given
+// number of failed attempts of an operation, doubling from
+// minBrokerOperationRetryDelay up to maxBrokerOperationRetryDelay.
+func brokerOperationRetryDelay(attempts int32) time.Duration {
+	delay := minBrokerOperationRetryDelay
+	for i := int32(1); i < attempts; i++ {
+		delay *= 2
+		if delay >= maxBrokerOperationRetryDelay {
+			return maxBrokerOperationRetryDelay
 		}
 	}
-	retryEntry.dirty = true
-	c.instanceOperationRetryQueue.instances[key] = retryEntry
-	glog.V(4).Info(pcb.Messagef("BrokerOpRetry: added %v (%v/%v) generation %v to backoffBeforeRetrying map", key, instance.GetNamespace(), instance.GetName(), instance.Generation))
+	return delay
 }
 
 // backoffAndRequeueIfRetrying returns true if this is a retry and a backoff
 // (delay) needs to be observed before retrying.  This only applies to
 // Provisioning and Updating and is generation specific.  If the generation has
-// been bumped since the instance was added to the retry map there will be no
-// backoff delay.
+// been bumped since the last attempt failed there will be no backoff delay.
 func (c *controller) backoffAndRequeueIfRetrying(instance *v1beta1.ServiceInstance, operation string) bool {
-	pcb := pretty.NewInstanceContextBuilder(instance)
-	key := string(instance.GetUID())
-	delay := time.Millisecond * 0
-
-	// if there is a pending delay, calculate it and clear the dirty bit
-	c.instanceOperationRetryQueue.mutex.Lock()
-	defer c.instanceOperationRetryQueue.mutex.Unlock()
-	retryEntry, exists := c.instanceOperationRetryQueue.instances[key]
-	if exists {
-		if retryEntry.generation != instance.Generation {
-			// the retry entry was on an old generation, we don't care,
-			// cleanup and no delay
-			delete(c.instanceOperationRetryQueue.instances, key)
-			c.instanceOperationRetryQueue.rateLimiter.Forget(key)
-			return false
-		}
-		if retryEntry.dirty {
-			// calculate earliest retry time with exponential backoff
-			retryEntry.calculatedRetryTime = time.Now().Add(c.instanceOperationRetryQueue.rateLimiter.When(key))
-			retryEntry.dirty = false
-			c.instanceOperationRetryQueue.instances[key] = retryEntry
-			glog.V(4).Infof(pcb.Messagef("BrokerOpRetry: generation %v retryTime calculated as %v", instance.Generation, retryEntry.calculatedRetryTime))
-		}
-
-		now := time.Now()
-		delay = retryEntry.calculatedRetryTime.Sub(now)
-
-		if delay > 0 {
-			msg := fmt.Sprintf("Delaying %s retry, next attempt will be after %s", operation, retryEntry.calculatedRetryTime)
-			c.recorder.Event(instance, corev1.EventTypeWarning, "RetryBackoff", msg)
-			glog.V(2).Info(pcb.Messagef("BrokerOpRetry: %s", msg))
-
-			// add back to worker queue to retry at the specified time
-			c.instanceAddAfter(instance, delay)
-			return true
-		}
+	retry := instance.Status.OperationRetry
+	if retry == nil || retry.Generation != instance.Generation {
+		return false
 	}
-	return false
-}
-
-// purgeExpiredRetryEntries clears entries from the map that have an expired
-// retry time.  Invoked by a worker on a timer.
-func (c *controller) purgeExpiredRetryEntries() {
-	now := time.Now()
-
-	c.instanceOperationRetryQueue.mutex.Lock()
-	defer c.instanceOperationRetryQueue.mutex.Unlock()
 
-	// Ensure we only purge items that aren't being acted on by retries.
-	// Due to queues and potential delays, only remove entries that are at
-	// least maxBrokerOperationRetryDelay past next retry time to ensure
-	// entries are not prematurely removed
-	overDue := now.Add(-maxBrokerOperationRetryDelay)
-	purgedEntries := 0
-	for k, v := range c.instanceOperationRetryQueue.instances {
-		if v.calculatedRetryTime.Before(overDue) {
-			glog.V(5).Infof("BrokerOpRetry: removing %s from instanceOperationRetryQueue which had retry time of %v", k, v.calculatedRetryTime)
-			delete(c.instanceOperationRetryQueue.instances, k)
-			c.instanceOperationRetryQueue.rateLimiter.Forget(k)
-			purgedEntries++
-		}
+	delay := retry.NextRetryTime.Sub(time.Now())
+	if delay <= 0 {
+		return false
 	}
-	glog.V(5).Infof("BrokerOpRetry: purged %v expired entries from instanceOperationRetryQueue.instances, number of entries remaining: %v", purgedEntries, len(c.instanceOperationRetryQueue.instances))
-
-}
 
-// removeInstanceFromRetryMap removes the instance from the retry & ratelimter maps
-func (c *controller) removeInstanceFromRetryMap(instance *v1beta1.ServiceInstance) {
 	pcb := pretty.NewInstanceContextBuilder(instance)
-	key := string(instance.GetUID())
-	c.instanceOperationRetryQueue.mutex.Lock()
-	defer c.instanceOperationRetryQueue.mutex.Unlock()
-	delete(c.instanceOperationRetryQueue.instances, key)
-	c.instanceOperationRetryQueue.rateLimiter.Forget(key)
-	glog.V(4).Infof(pcb.Message("BrokerOpRetry: removed %v from instanceOperationRetryQueue"), key)
+	msg := fmt.Sprintf("Delaying %s retry, next attempt will be after %s", operation, retry.NextRetryTime)
+	c.recorder.Event(instance, corev1.EventTypeWarning, "RetryBackoff", msg)
+	glog.V(2).Info(pcb.Messagef("BrokerOpRetry: %s", msg))
+
+	// add back to worker queue to retry at the specified time
+	c.instanceAddAfter(instance, delay)
+	return true
 }
 
 // reconcileServiceInstanceAdd is responsible for handling the provisioning
@@ -552,25 +512,28 @@ func (c *controller) reconcileServiceInstanceAdd(instance *v1beta1.ServiceInstan
 
 	var prettyClass string
 	var brokerName string
-	var brokerClient osb.Client
+	var brokerClient osbclient.Client
 	if instance.Spec.ClusterServiceClassSpecified() {
 		var serviceClass *v1beta1.ClusterServiceClass
-		serviceClass, _, brokerName, brokerClient, _ = c.getClusterServiceClassPlanAndClusterServiceBroker(instance)
+		serviceClass, _, brokerName, brokerClient, err = c.getClusterServiceClassPlanAndClusterServiceBroker(instance)
 		prettyClass = pretty.ClusterServiceClassName(serviceClass)
 	} else {
 		var serviceClass *v1beta1.ServiceClass
-		serviceClass, _, brokerName, brokerClient, _ = c.getServiceClassPlanAndServiceBroker(instance)
+		serviceClass, _, brokerName, brokerClient, err = c.getServiceClassPlanAndServiceBroker(instance)
 		prettyClass = pretty.ServiceClassName(serviceClass)
 	}
+	if err != nil {
+		return c.handleServiceInstanceReconciliationError(instance, err)
+	}
 
 	glog.V(4).Info(pcb.Messagef(
 		"Provisioning a new ServiceInstance of %s at Broker %q",
 		prettyClass, brokerName,
 	))
 
-	c.setRetryBackoffRequired(instance)
 	response, err := brokerClient.ProvisionInstance(request)
 	if err != nil {
+		c.setRetryBackoffRequired(instance, err)
 		if httpErr, ok := osb.IsHTTPError(err); ok {
 			msg := fmt.Sprintf(
 				"Error provisioning ServiceInstance of %s at ClusterServiceBroker %q: %s",
@@ -626,6 +589,13 @@ func (c *controller) reconcileServiceInstanceUpdate(instance *v1beta1.ServiceIns
 
 	if isServiceInstanceProcessedAlready(instance) {
 		glog.V(4).Info(pcb.Message("Not processing event because status showed there is no work to do"))
+		if isServiceInstanceReady(instance) {
+			updated, err := c.checkServiceInstanceUpgrade(instance)
+			if err != nil || updated {
+				return err
+			}
+			return c.checkServiceInstanceDrift(instance)
+		}
 		return nil
 	}
 
@@ -653,8 +623,8 @@ func (c *controller) reconcileServiceInstanceUpdate(instance *v1beta1.ServiceIns
 
 	glog.V(4).Info(pcb.Message("Processing updating event"))
 
-	var brokerClient osb.Client
-	var request *osb.UpdateInstanceRequest
+	var brokerClient osbclient.Client
+	var request *osbclient.UpdateInstanceRequest
 
 	if instance.Spec.ClusterServiceClassSpecified() {
 
@@ -731,9 +701,9 @@ func (c *controller) reconcileServiceInstanceUpdate(instance *v1beta1.ServiceIns
 		))
 	}
 
-	c.setRetryBackoffRequired(instance)
 	response, err := brokerClient.UpdateInstance(request)
 	if err != nil {
+		c.setRetryBackoffRequired(instance, err)
 		if httpErr, ok := osb.IsHTTPError(err); ok {
 			msg := fmt.Sprintf("ServiceBroker returned a failure for update call; update will not be retried: %v", httpErr)
 			readyCond := newServiceInstanceReadyCondition(v1beta1.ConditionFalse, errorUpdateInstanceCallFailedReason, msg)
@@ -839,7 +809,7 @@ func (c *controller) reconcileServiceInstanceDelete(instance *v1beta1.ServiceIns
 
 	var prettyName string
 	var brokerName string
-	var brokerClient osb.Client
+	var brokerClient osbclient.Client
 	if instance.Spec.ClusterServiceClassSpecified() {
 		serviceClass, name, bClient, err := c.getClusterServiceClassAndClusterServiceBroker(instance)
 		if err != nil {
@@ -928,7 +898,7 @@ func (c *controller) pollServiceInstance(instance *v1beta1.ServiceInstance) erro
 
 	instance = instance.DeepCopy()
 
-	var brokerClient osb.Client
+	var brokerClient osbclient.Client
 	var err error
 	if instance.Spec.ClusterServiceClassSpecified() {
 		_, _, _, brokerClient, err = c.getClusterServiceClassPlanAndClusterServiceBroker(instance)
@@ -1100,6 +1070,272 @@ func isServiceInstanceProcessedAlready(instance *v1beta1.ServiceInstance) bool {
 		!instance.Status.OrphanMitigationInProgress
 }
 
+// checkServiceInstanceUpgrade sets the UpgradeAvailable condition of a
+// provisioned instance according to whether the plan of the instance has a
+// newer version than the one the instance was last provisioned or updated
+// with. When automatic upgrades are enabled, it also starts updating the
+// instance to the newer version.
+// Returns true if the status of the instance was updated.
+func (c *controller) checkServiceInstanceUpgrade(instance *v1beta1.ServiceInstance) (bool, error) {
+	if instance.Status.ExternalProperties == nil {
+		return false, nil
+	}
+
+	pcb := pretty.NewInstanceContextBuilder(instance)
+
+	var maintenanceInfo *v1beta1.MaintenanceInfo
+	var planRemoved bool
+
+	if instance.Spec.ClusterServiceClassSpecified() && instance.Spec.ClusterServicePlanRef != nil {
+		servicePlan, err := c.clusterServicePlanLister.Get(instance.Spec.ClusterServicePlanRef.Name)
+		if err != nil {
+			glog.V(4).Info(pcb.Messagef("Not checking the instance for upgrades: %v", err))
+			return false, nil
+		}
+		maintenanceInfo = servicePlan.Spec.MaintenanceInfo
+		planRemoved = servicePlan.Status.RemovedFromBrokerCatalog
+	} else if instance.Spec.ServiceClassSpecified() && instance.Spec.ServicePlanRef != nil {
+		servicePlan, err := c.servicePlanLister.ServicePlans(instance.Namespace).Get(instance.Spec.ServicePlanRef.Name)
+		if err != nil {
+			glog.V(4).Info(pcb.Messagef("Not checking the instance for upgrades: %v", err))
+			return false, nil
+		}
+		maintenanceInfo = servicePlan.Spec.MaintenanceInfo
+		planRemoved = servicePlan.Status.RemovedFromBrokerCatalog
+	} else {
+		return false, nil
+	}
+
+	upgradeAvailable := maintenanceInfo != nil && !planRemoved &&
+		!isMaintenanceInfoVersionEqual(maintenanceInfo, instance.Status.ExternalProperties.MaintenanceInfo)
+
+	var status v1beta1.ConditionStatus
+	var reason, message string
+	if upgradeAvailable {
+		status = v1beta1.ConditionTrue
+		reason = upgradeAvailableReason
+		message = fmt.Sprintf("Version %q of the plan is available", maintenanceInfo.Version)
+	} else {
+		if !isServiceInstanceConditionTrue(instance, v1beta1.ServiceInstanceConditionUpgradeAvailable) {
+			return false, nil
+		}
+		status = v1beta1.ConditionFalse
+		reason = upToDateInstanceReason
+		message = upToDateInstanceMessage
+	}
+
+	toUpdate := instance.DeepCopy()
+	conditionChanged := true
+	for _, cond := range instance.Status.Conditions {
+		if cond.Type == v1beta1.ServiceInstanceConditionUpgradeAvailable && cond.Status == status && cond.Message == message {
+			conditionChanged = false
+		}
+	}
+	if conditionChanged {
+		setServiceInstanceCondition(toUpdate, v1beta1.ServiceInstanceConditionUpgradeAvailable, status, reason, message)
+		c.recorder.Event(instance, corev1.EventTypeNormal, reason, message)
+	}
+
+	if upgradeAvailable && c.autoUpgradeInstances {
+		_, inProgressProperties, err := c.prepareUpdateInstanceRequest(toUpdate)
+		if err != nil {
+			return true, c.handleServiceInstanceReconciliationError(toUpdate, err)
+		}
+		msg := fmt.Sprintf("Upgrading the instance to version %q of the plan", maintenanceInfo.Version)
+		glog.V(4).Info(pcb.Message(msg))
+		c.recorder.Event(instance, corev1.EventTypeNormal, upgradingInstanceReason, msg)
+		_, err = c.recordStartOfServiceInstanceOperation(toUpdate, v1beta1.ServiceInstanceOperationUpdate, inProgressProperties)
+		return true, err
+	}
+
+	if !conditionChanged {
+		return false, nil
+	}
+	_, err := c.updateServiceInstanceStatus(toUpdate)
+	return true, err
+}
+
+// instanceDriftChecks records when each instance was last fetched from its
+// broker to check for drift, so that the instances are not fetched on every
+// resync.
+type instanceDriftChecks struct {
+	mutex sync.Mutex
+	// interval is the minimum time between two checks of an instance. Zero
+	// disables the checks.
+	interval   time.Duration
+	lastChecks map[types.UID]time.Time
+}
+
+// due returns whether the instance with the given UID should be checked for
+// drift at the given time, and if so records the check.
+func (d *instanceDriftChecks) due(uid types.UID, now time.Time) bool {
+	if d.interval <= 0 {
+		return false
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if last, ok := d.lastChecks[uid]; ok && now.Before(last.Add(d.interval)) {
+		return false
+	}
+	d.lastChecks[uid] = now
+	return true
+}
+
+// forget drops the last check of the instance with the given UID.
+func (d *instanceDriftChecks) forget(uid types.UID) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	delete(d.lastChecks, uid)
+}
+
+// checkServiceInstanceDrift fetches a provisioned instance from its broker,
+// when the class of the instance supports it, and sets the Drifted condition
+// of the instance according to whether the plan and parameters reported by
+// the broker still match the ones last sent to it. Ready instances are
+// processed on every resync, but an instance is fetched at most once per
+// drift check interval.
+func (c *controller) checkServiceInstanceDrift(instance *v1beta1.ServiceInstance) error {
+	if instance.Status.ExternalProperties == nil {
+		return nil
+	}
+
+	pcb := pretty.NewInstanceContextBuilder(instance)
+
+	var brokerClient osbclient.Client
+	var planExternalID string
+
+	if instance.Spec.ClusterServiceClassSpecified() {
+		serviceClass, _, bClient, err := c.getClusterServiceClassAndClusterServiceBroker(instance)
+		if err != nil {
+			glog.V(4).Info(pcb.Messagef("Not checking the instance for drift: %v", err))
+			return nil
+		}
+		if !serviceClass.Spec.InstancesRetrievable {
+			return nil
+		}
+		brokerClient = bClient
+		planExternalID = instance.Status.ExternalProperties.ClusterServicePlanExternalID
+	} else if instance.Spec.ServiceClassSpecified() {
+		serviceClass, _, bClient, err := c.getServiceClassAndServiceBroker(instance)
+		if err != nil {
+			glog.V(4).Info(pcb.Messagef("Not checking the instance for drift: %v", err))
+			return nil
+		}
+		if !serviceClass.Spec.InstancesRetrievable {
+			return nil
+		}
+		brokerClient = bClient
+		planExternalID = instance.Status.ExternalProperties.ServicePlanExternalID
+	} else {
+		return nil
+	}
+
+	if !c.instanceDriftChecks.due(instance.UID, time.Now()) {
+		return nil
+	}
+
+	glog.V(4).Info(pcb.Message("Fetching the instance from the broker to check for drift"))
+	response, err := brokerClient.GetInstance(&osbclient.GetInstanceRequest{
+		InstanceID: instance.Spec.ExternalID,
+	})
+	if err != nil {
+		// The check is repeated once the drift check interval has
+		// passed, there is no need to requeue the instance.
+		msg := fmt.Sprintf("Error fetching the instance from the broker to check for drift: %v", err)
+		glog.Warning(pcb.Message(msg))
+		c.recorder.Event(instance, corev1.EventTypeWarning, errorFetchingInstanceReason, msg)
+		return nil
+	}
+
+	differences, err := getServiceInstanceDrift(instance.Status.ExternalProperties, planExternalID, response)
+	if err != nil {
+		glog.Warning(pcb.Messagef("Error comparing the instance fetched from the broker: %v", err))
+		return nil
+	}
+
+	var status v1beta1.ConditionStatus
+	var reason, message string
+	if len(differences) > 0 {
+		status = v1beta1.ConditionTrue
+		reason = driftedInstanceReason
+		message = fmt.Sprintf("The instance reported by the broker differs from the last one sent to it: %s", strings.Join(differences, "; "))
+	} else {
+		if !isServiceInstanceConditionTrue(instance, v1beta1.ServiceInstanceConditionDrifted) {
+			return nil
+		}
+		status = v1beta1.ConditionFalse
+		reason = inSyncInstanceReason
+		message = inSyncInstanceMessage
+	}
+
+	for _, cond := range instance.Status.Conditions {
+		if cond.Type == v1beta1.ServiceInstanceConditionDrifted && cond.Status == status && cond.Message == message {
+			return nil
+		}
+	}
+
+	toUpdate := instance.DeepCopy()
+	setServiceInstanceCondition(toUpdate, v1beta1.ServiceInstanceConditionDrifted, status, reason, message)
+	if status == v1beta1.ConditionTrue {
+		c.recorder.Event(instance, corev1.EventTypeWarning, reason, message)
+	} else {
+		c.recorder.Event(instance, corev1.EventTypeNormal, reason, message)
+	}
+	_, err = c.updateServiceInstanceStatus(toUpdate)
+	return err
+}
+
+// getServiceInstanceDrift describes every difference between the plan and
+// parameters of an instance reported by the broker and the ones last sent to
+// it. Parameters whose values came from secrets are redacted in the external
+// properties and are not compared, and parameters the broker reports without
+// having been sent are ignored.
+func getServiceInstanceDrift(externalProperties *v1beta1.ServiceInstancePropertiesState, planExternalID string, response *osbclient.GetInstanceResponse) ([]string, error) {
+	var differences []string
+
+	if response.PlanID != "" && response.PlanID != planExternalID {
+		differences = append(differences, fmt.Sprintf("plan is %q instead of %q", response.PlanID, planExternalID))
+	}
+
+	if response.Parameters == nil || externalProperties.Parameters == nil {
+		return differences, nil
+	}
+
+	expected, err := unmarshalJSON(externalProperties.Parameters.Raw)
+	if err != nil {
+		return nil, err
+	}
+	// Round-trip the reported parameters so that both sides hold the same
+	// types for the same JSON values.
+	raw, err := json.Marshal(response.Parameters)
+	if err != nil {
+		return nil, err
+	}
+	actual, err := unmarshalJSON(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(expected))
+	for k := range expected {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if expected[k] == redactedParameterValue {
+			continue
+		}
+		v, ok := actual[k]
+		if !ok {
+			differences = append(differences, fmt.Sprintf("parameter %q is missing", k))
+		} else if !reflect.DeepEqual(v, expected[k]) {
+			differences = append(differences, fmt.Sprintf("parameter %q has changed", k))
+		}
+	}
+
+	return differences, nil
+}
+
 // processServiceInstancePollingFailureRetryTimeout marks the instance as having
 // failed polling due to its reconciliation retry duration expiring
 func (c *controller) processServiceInstancePollingFailureRetryTimeout(instance *v1beta1.ServiceInstance, readyCond *v1beta1.ServiceInstanceCondition) error {
@@ -1584,7 +1820,7 @@ func (c *controller) getDefaultProvisioningParameters(instance *v1beta1.ServiceI
 	return nil, fmt.Errorf("invalid plan reference %v", instance.Spec.PlanReference)
 }
 
-func (c *controller) prepareProvisionRequest(instance *v1beta1.ServiceInstance) (*osb.ProvisionRequest, *v1beta1.ServiceInstancePropertiesState, error) {
+func (c *controller) prepareProvisionRequest(instance *v1beta1.ServiceInstance) (*osbclient.ProvisionRequest, *v1beta1.ServiceInstancePropertiesState, error) {
 	if instance.Spec.ClusterServiceClassSpecified() {
 		serviceClass, servicePlan, _, _, err := c.getClusterServiceClassPlanAndClusterServiceBroker(instance)
 		if err != nil {
@@ -1923,10 +2159,34 @@ func isServiceInstancePropertiesStateEqual(s1 *v1beta1.ServiceInstanceProperties
 			return false
 		}
 	}
+	if !isMaintenanceInfoVersionEqual(s1.MaintenanceInfo, s2.MaintenanceInfo) {
+		return false
+	}
 
 	return true
 }
 
+// isMaintenanceInfoVersionEqual returns whether two maintenance infos have
+// the same version, a missing maintenance info having none.
+func isMaintenanceInfoVersionEqual(m1 *v1beta1.MaintenanceInfo, m2 *v1beta1.MaintenanceInfo) bool {
+	if m1 == nil || m2 == nil {
+		return m1 == nil && m2 == nil
+	}
+	return m1.Version == m2.Version
+}
+
+// toOSBMaintenanceInfo converts the maintenance info of a plan into the one
+// sent to brokers.
+func toOSBMaintenanceInfo(maintenanceInfo *v1beta1.MaintenanceInfo) *osbclient.MaintenanceInfo {
+	if maintenanceInfo == nil {
+		return nil
+	}
+	return &osbclient.MaintenanceInfo{
+		Version:     maintenanceInfo.Version,
+		Description: maintenanceInfo.Description,
+	}
+}
+
 // recordStartOfServiceInstanceOperation updates the instance to indicate that
 // there is an operation being performed. If the instance was already
 // performing a different operation, that operation is replaced. The Status of
@@ -1939,6 +2199,11 @@ func isServiceInstancePropertiesStateEqual(s1 *v1beta1.ServiceInstanceProperties
 //     if there was an error
 // 2 - any error that occurred
 func (c *controller) recordStartOfServiceInstanceOperation(toUpdate *v1beta1.ServiceInstance, operation v1beta1.ServiceInstanceOperation, inProgressProperties *v1beta1.ServiceInstancePropertiesState) (*v1beta1.ServiceInstance, error) {
+	for _, cond := range toUpdate.Status.Conditions {
+		if cond.Type == v1beta1.ServiceInstanceConditionReady {
+			recordServiceInstanceOperationResult(toUpdate, v1beta1.ServiceInstanceOperationSuperseded, cond.Reason, cond.Message)
+		}
+	}
 	clearServiceInstanceCurrentOperation(toUpdate)
 	toUpdate.Status.CurrentOperation = operation
 	now := metav1.Now()
@@ -2059,6 +2324,37 @@ func clearServiceInstanceCurrentOperation(toUpdate *v1beta1.ServiceInstance) {
 	toUpdate.Status.InProgressProperties = nil
 }
 
+// maxServiceInstanceOperationHistory is the number of completed operations
+// kept in the status of an instance.
+const maxServiceInstanceOperationHistory = 10
+
+// recordServiceInstanceOperationResult adds the current operation of the
+// instance to its operation history, dropping the oldest operations beyond
+// maxServiceInstanceOperationHistory. It must be called before the current
+// operation is cleared.
+func recordServiceInstanceOperationResult(toUpdate *v1beta1.ServiceInstance, result v1beta1.ServiceInstanceOperationResult, reason, message string) {
+	if toUpdate.Status.CurrentOperation == "" {
+		return
+	}
+	record := v1beta1.ServiceInstanceOperationRecord{
+		Operation:      toUpdate.Status.CurrentOperation,
+		CompletionTime: metav1.Now(),
+		Result:         result,
+		Reason:         reason,
+		Message:        message,
+	}
+	record.StartTime = record.CompletionTime
+	if toUpdate.Status.OperationStartTime != nil {
+		record.StartTime = *toUpdate.Status.OperationStartTime
+	}
+
+	history := toUpdate.Status.OperationHistory
+	if len(history) >= maxServiceInstanceOperationHistory {
+		history = history[len(history)-maxServiceInstanceOperationHistory+1:]
+	}
+	toUpdate.Status.OperationHistory = append(append([]v1beta1.ServiceInstanceOperationRecord{}, history...), record)
+}
+
 // serviceInstanceHasExistingBindings returns true if there are any existing
 // bindings associated with the given ServiceInstance.
 func (c *controller) checkServiceInstanceHasExistingBindings(instance *v1beta1.ServiceInstance) error {
@@ -2170,44 +2466,48 @@ func (c *controller) prepareRequestHelper(instance *v1beta1.ServiceInstance, pla
 // innerPrepareProvisionRequest creates a provision request object to be passed to
 // the broker client to provision the given instance, with a cluster scoped
 // class and plan
-func (c *controller) innerPrepareProvisionRequest(instance *v1beta1.ServiceInstance, classCommon v1beta1.CommonServiceClassSpec, planCommon v1beta1.CommonServicePlanSpec) (*osb.ProvisionRequest, *v1beta1.ServiceInstancePropertiesState, error) {
+func (c *controller) innerPrepareProvisionRequest(instance *v1beta1.ServiceInstance, classCommon v1beta1.CommonServiceClassSpec, planCommon v1beta1.CommonServicePlanSpec) (*osbclient.ProvisionRequest, *v1beta1.ServiceInstancePropertiesState, error) {
 	rh, err := c.prepareRequestHelper(instance, planCommon.ExternalName, planCommon.ExternalID, true)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	request := &osb.ProvisionRequest{
-		AcceptsIncomplete: true,
-		InstanceID:        instance.Spec.ExternalID,
-		ServiceID:         classCommon.ExternalID,
-		PlanID:            planCommon.ExternalID,
-		Parameters:        rh.parameters,
-		// This field is DEPRECATED, but required to be sent by OSBAPI specification
-		// Consider using the context profile as defined in
-		// https://github.com/openservicebrokerapi/servicebroker/blob/v2.14/profile.md#kubernetes-context-object
-		OrganizationGUID: c.getClusterID(),
-		// This field is DEPRECATED, but required to be sent by OSBAPI specification
-		// Consider using the context profile as defined in
-		// https://github.com/openservicebrokerapi/servicebroker/blob/v2.14/profile.md#kubernetes-context-object
-		SpaceGUID:           string(rh.ns.UID),
-		Context:             rh.requestContext,
-		OriginatingIdentity: rh.originatingIdentity,
+	request := &osbclient.ProvisionRequest{
+		ProvisionRequest: osb.ProvisionRequest{
+			AcceptsIncomplete: true,
+			InstanceID:        instance.Spec.ExternalID,
+			ServiceID:         classCommon.ExternalID,
+			PlanID:            planCommon.ExternalID,
+			Parameters:        rh.parameters,
+			// This field is DEPRECATED, but required to be sent by OSBAPI specification
+			// Consider using the context profile as defined in
+			// https://github.com/openservicebrokerapi/servicebroker/blob/v2.14/profile.md#kubernetes-context-object
+			OrganizationGUID: c.getClusterID(),
+			// This field is DEPRECATED, but required to be sent by OSBAPI specification
+			// Consider using the context profile as defined in
+			// https://github.com/openservicebrokerapi/servicebroker/blob/v2.14/profile.md#kubernetes-context-object
+			SpaceGUID:           string(rh.ns.UID),
+			Context:             rh.requestContext,
+			OriginatingIdentity: rh.originatingIdentity,
+		},
+		MaintenanceInfo: toOSBMaintenanceInfo(planCommon.MaintenanceInfo),
 	}
+	rh.inProgressProperties.MaintenanceInfo = planCommon.MaintenanceInfo
 
 	return request, rh.inProgressProperties, nil
 }
 
 // prepareUpdateInstanceRequest creates an update instance request object to be
 // passed to the broker client to update the given instance.
-func (c *controller) prepareUpdateInstanceRequest(instance *v1beta1.ServiceInstance) (*osb.UpdateInstanceRequest, *v1beta1.ServiceInstancePropertiesState, error) {
+func (c *controller) prepareUpdateInstanceRequest(instance *v1beta1.ServiceInstance) (*osbclient.UpdateInstanceRequest, *v1beta1.ServiceInstancePropertiesState, error) {
 
 	var rh *requestHelper
-	var request *osb.UpdateInstanceRequest
+	var request *osbclient.UpdateInstanceRequest
 
 	if instance.Spec.ClusterServiceClassSpecified() {
 		serviceClass, servicePlan, _, _, err := c.getClusterServiceClassPlanAndClusterServiceBroker(instance)
 		if err != nil {
-			return nil, nil, c.handleServiceInstanceReconciliationError(instance, err)
+			return nil, nil, err
 		}
 
 		rh, err = c.prepareRequestHelper(instance, servicePlan.Spec.ExternalName, servicePlan.Spec.ExternalID, true)
@@ -2215,12 +2515,14 @@ func (c *controller) prepareUpdateInstanceRequest(instance *v1beta1.ServiceInsta
 			return nil, nil, err
 		}
 
-		request = &osb.UpdateInstanceRequest{
-			AcceptsIncomplete:   true,
-			InstanceID:          instance.Spec.ExternalID,
-			ServiceID:           serviceClass.Spec.ExternalID,
-			Context:             rh.requestContext,
-			OriginatingIdentity: rh.originatingIdentity,
+		request = &osbclient.UpdateInstanceRequest{
+			UpdateInstanceRequest: osb.UpdateInstanceRequest{
+				AcceptsIncomplete:   true,
+				InstanceID:          instance.Spec.ExternalID,
+				ServiceID:           serviceClass.Spec.ExternalID,
+				Context:             rh.requestContext,
+				OriginatingIdentity: rh.originatingIdentity,
+			},
 		}
 
 		// Only send the plan ID if the plan ID has changed from what the Broker has
@@ -2238,11 +2540,18 @@ func (c *controller) prepareUpdateInstanceRequest(instance *v1beta1.ServiceInsta
 				request.Parameters = make(map[string]interface{})
 			}
 		}
+		// Only send the maintenance info if the plan has a version other
+		// than the one the Broker has, which upgrades the instance
+		rh.inProgressProperties.MaintenanceInfo = servicePlan.Spec.MaintenanceInfo
+		if instance.Status.ExternalProperties == nil ||
+			!isMaintenanceInfoVersionEqual(servicePlan.Spec.MaintenanceInfo, instance.Status.ExternalProperties.MaintenanceInfo) {
+			request.MaintenanceInfo = toOSBMaintenanceInfo(servicePlan.Spec.MaintenanceInfo)
+		}
 
 	} else if instance.Spec.ServiceClassSpecified() {
 		serviceClass, servicePlan, _, _, err := c.getServiceClassPlanAndServiceBroker(instance)
 		if err != nil {
-			return nil, nil, c.handleServiceInstanceReconciliationError(instance, err)
+			return nil, nil, err
 		}
 
 		rh, err = c.prepareRequestHelper(instance, servicePlan.Spec.ExternalName, servicePlan.Spec.ExternalID, true)
@@ -2250,12 +2559,14 @@ func (c *controller) prepareUpdateInstanceRequest(instance *v1beta1.ServiceInsta
 			return nil, nil, err
 		}
 
-		request = &osb.UpdateInstanceRequest{
-			AcceptsIncomplete:   true,
-			InstanceID:          instance.Spec.ExternalID,
-			ServiceID:           serviceClass.Spec.ExternalID,
-			Context:             rh.requestContext,
-			OriginatingIdentity: rh.originatingIdentity,
+		request = &osbclient.UpdateInstanceRequest{
+			UpdateInstanceRequest: osb.UpdateInstanceRequest{
+				AcceptsIncomplete:   true,
+				InstanceID:          instance.Spec.ExternalID,
+				ServiceID:           serviceClass.Spec.ExternalID,
+				Context:             rh.requestContext,
+				OriginatingIdentity: rh.originatingIdentity,
+			},
 		}
 
 		// Only send the plan ID if the plan ID has changed from what the Broker has
@@ -2273,6 +2584,13 @@ func (c *controller) prepareUpdateInstanceRequest(instance *v1beta1.ServiceInsta
 				request.Parameters = make(map[string]interface{})
 			}
 		}
+		// Only send the maintenance info if the plan has a version other
+		// than the one the Broker has, which upgrades the instance
+		rh.inProgressProperties.MaintenanceInfo = servicePlan.Spec.MaintenanceInfo
+		if instance.Status.ExternalProperties == nil ||
+			!isMaintenanceInfoVersionEqual(servicePlan.Spec.MaintenanceInfo, instance.Status.ExternalProperties.MaintenanceInfo) {
+			request.MaintenanceInfo = toOSBMaintenanceInfo(servicePlan.Spec.MaintenanceInfo)
+		}
 
 	}
 
@@ -2293,13 +2611,13 @@ func (c *controller) prepareDeprovisionRequest(instance *v1beta1.ServiceInstance
 	if instance.Spec.ClusterServiceClassSpecified() {
 		serviceClass, _, _, err := c.getClusterServiceClassAndClusterServiceBroker(instance)
 		if err != nil {
-			return nil, nil, c.handleServiceInstanceReconciliationError(instance, err)
+			return nil, nil, err
 		}
 		scExternalID = serviceClass.Spec.ExternalID
 	} else if instance.Spec.ServiceClassSpecified() {
 		serviceClass, _, _, err := c.getServiceClassAndServiceBroker(instance)
 		if err != nil {
-			return nil, nil, c.handleServiceInstanceReconciliationError(instance, err)
+			return nil, nil, err
 		}
 		scExternalID = serviceClass.Spec.ExternalID
 	}
@@ -2356,7 +2674,7 @@ func (c *controller) prepareServiceInstanceLastOperationRequest(instance *v1beta
 	if instance.Spec.ClusterServiceClassSpecified() {
 		serviceClass, servicePlan, _, _, err := c.getClusterServiceClassPlanAndClusterServiceBroker(instance)
 		if err != nil {
-			return nil, c.handleServiceInstanceReconciliationError(instance, err)
+			return nil, err
 		}
 
 		scExternalID = serviceClass.Spec.ExternalID
@@ -2377,7 +2695,7 @@ func (c *controller) prepareServiceInstanceLastOperationRequest(instance *v1beta
 	} else if instance.Spec.ServiceClassSpecified() {
 		serviceClass, servicePlan, _, _, err := c.getServiceClassPlanAndServiceBroker(instance)
 		if err != nil {
-			return nil, c.handleServiceInstanceReconciliationError(instance, err)
+			return nil, err
 		}
 
 		scExternalID = serviceClass.Spec.ExternalID
@@ -2424,7 +2742,6 @@ func (c *controller) processServiceInstanceGracefulDeletionSuccess(instance *v1b
 	pcb := pretty.NewInstanceContextBuilder(instance)
 	glog.Info(pcb.Message("Cleared finalizer"))
 
-	c.removeInstanceFromRetryMap(instance)
 	return nil
 }
 
@@ -2444,11 +2761,39 @@ func (c *controller) handleServiceInstanceReconciliationError(instance *v1beta1.
 			status = v1beta1.ConditionUnknown
 		}
 		readyCond := newServiceInstanceReadyCondition(status, resourceErr.reason, resourceErr.message)
+		switch resourceErr.reason {
+		case errorBrokerUnavailableReason:
+			return c.processServiceInstanceBrokerUnavailable(instance, readyCond)
+		case errorBrokerRequestsThrottledReason:
+			pcb := pretty.NewInstanceContextBuilder(instance)
+			glog.V(4).Info(pcb.Message(resourceErr.message))
+			c.instanceAddAfter(instance, brokerRequestsThrottledRetryDelay)
+			return nil
+		}
 		return c.processServiceInstanceOperationError(instance, readyCond)
 	}
 	return err
 }
 
+// processServiceInstanceBrokerUnavailable parks a ServiceInstance whose
+// broker is not sent requests anymore because too many of them failed. The
+// instance is queued again after the broker probe interval instead of being
+// retried with backoff.
+func (c *controller) processServiceInstanceBrokerUnavailable(instance *v1beta1.ServiceInstance, readyCond *v1beta1.ServiceInstanceCondition) error {
+	if !hasServiceInstanceConditionReason(instance, readyCond.Type, readyCond.Reason) {
+		setServiceInstanceCondition(instance, readyCond.Type, readyCond.Status, readyCond.Reason, readyCond.Message)
+		if _, err := c.updateServiceInstanceStatus(instance); err != nil {
+			return err
+		}
+		c.recorder.Event(instance, corev1.EventTypeWarning, readyCond.Reason, readyCond.Message)
+	}
+
+	pcb := pretty.NewInstanceContextBuilder(instance)
+	glog.V(4).Info(pcb.Message(readyCond.Message))
+	c.instanceAddAfter(instance, c.brokerCircuitBreakers.probeInterval)
+	return nil
+}
+
 // processServiceInstanceOperationError handles the logging and updating of
 // a ServiceInstance that hit a retryable error during reconciliation.
 func (c *controller) processServiceInstanceOperationError(instance *v1beta1.ServiceInstance, readyCond *v1beta1.ServiceInstanceCondition) error {
@@ -2471,7 +2816,9 @@ func (c *controller) processProvisionSuccess(instance *v1beta1.ServiceInstance,
 	setServiceInstanceDashboardURL(instance, dashboardURL)
 	setServiceInstanceCondition(instance, v1beta1.ServiceInstanceConditionReady, v1beta1.ConditionTrue, successProvisionReason, successProvisionMessage)
 	instance.Status.ExternalProperties = instance.Status.InProgressProperties
+	recordServiceInstanceOperationResult(instance, v1beta1.ServiceInstanceOperationSucceeded, successProvisionReason, successProvisionMessage)
 	clearServiceInstanceCurrentOperation(instance)
+	instance.Status.OperationRetry = nil
 	instance.Status.ProvisionStatus = v1beta1.ServiceInstanceProvisionStatusProvisioned
 	instance.Status.ReconciledGeneration = instance.Status.ObservedGeneration
 
@@ -2479,7 +2826,6 @@ func (c *controller) processProvisionSuccess(instance *v1beta1.ServiceInstance,
 		return err
 	}
 
-	c.removeInstanceFromRetryMap(instance)
 	c.recorder.Eventf(instance, corev1.EventTypeNormal, successProvisionReason, successProvisionMessage)
 	return nil
 }
@@ -2490,7 +2836,7 @@ func (c *controller) processTerminalProvisionFailure(instance *v1beta1.ServiceIn
 	if failedCond == nil {
 		return fmt.Errorf("failedCond must not be nil")
 	}
-	c.removeInstanceFromRetryMap(instance)
+	instance.Status.OperationRetry = nil
 	return c.processProvisionFailure(instance, readyCond, failedCond, shouldMitigateOrphan)
 }
 
@@ -2541,6 +2887,7 @@ func (c *controller) processProvisionFailure(instance *v1beta1.ServiceInstance,
 		clearServiceInstanceAsyncOsbOperation(instance)
 	} else {
 		// Reset the current operation if there was a terminal error
+		recordServiceInstanceOperationResult(instance, v1beta1.ServiceInstanceOperationFailed, failedCond.Reason, failedCond.Message)
 		clearServiceInstanceCurrentOperation(instance)
 	}
 
@@ -2580,14 +2927,15 @@ func (c *controller) processProvisionAsyncResponse(instance *v1beta1.ServiceInst
 func (c *controller) processUpdateServiceInstanceSuccess(instance *v1beta1.ServiceInstance) error {
 	setServiceInstanceCondition(instance, v1beta1.ServiceInstanceConditionReady, v1beta1.ConditionTrue, successUpdateInstanceReason, successUpdateInstanceMessage)
 	instance.Status.ExternalProperties = instance.Status.InProgressProperties
+	recordServiceInstanceOperationResult(instance, v1beta1.ServiceInstanceOperationSucceeded, successUpdateInstanceReason, successUpdateInstanceMessage)
 	clearServiceInstanceCurrentOperation(instance)
+	instance.Status.OperationRetry = nil
 	instance.Status.ReconciledGeneration = instance.Status.ObservedGeneration
 
 	if _, err := c.updateServiceInstanceStatus(instance); err != nil {
 		return err
 	}
 
-	c.removeInstanceFromRetryMap(instance)
 	c.recorder.Eventf(instance, corev1.EventTypeNormal, successUpdateInstanceReason, successUpdateInstanceMessage)
 	return nil
 }
@@ -2598,7 +2946,7 @@ func (c *controller) processTerminalUpdateServiceInstanceFailure(instance *v1bet
 	if failedCond == nil {
 		return fmt.Errorf("failedCond must not be nil")
 	}
-	c.removeInstanceFromRetryMap(instance)
+	instance.Status.OperationRetry = nil
 	return c.processUpdateServiceInstanceFailure(instance, readyCond, failedCond)
 }
 
@@ -2617,6 +2965,7 @@ func (c *controller) processUpdateServiceInstanceFailure(instance *v1beta1.Servi
 	if failedCond != nil {
 		setServiceInstanceCondition(instance, v1beta1.ServiceInstanceConditionFailed, failedCond.Status, failedCond.Reason, failedCond.Message)
 		// Reset the current operation if there was a terminal error
+		recordServiceInstanceOperationResult(instance, v1beta1.ServiceInstanceOperationFailed, failedCond.Reason, failedCond.Message)
 		clearServiceInstanceCurrentOperation(instance)
 	} else {
 		// Don't reset the current operation if the error is retriable
@@ -2662,7 +3011,18 @@ func (c *controller) processDeprovisionSuccess(instance *v1beta1.ServiceInstance
 
 	reason := successDeprovisionReason
 	msg := successDeprovisionMessage
+	result := v1beta1.ServiceInstanceOperationSucceeded
+	resultReason, resultMsg := reason, msg
 	if mitigatingOrphan {
+		// The current operation is the provision that failed, which is
+		// recorded with the failure kept in the OrphanMitigation condition.
+		result = v1beta1.ServiceInstanceOperationFailed
+		resultReason, resultMsg = "", ""
+		for _, cond := range instance.Status.Conditions {
+			if cond.Type == v1beta1.ServiceInstanceConditionOrphanMitigation {
+				resultReason, resultMsg = cond.Reason, cond.Message
+			}
+		}
 		removeServiceInstanceCondition(instance, v1beta1.ServiceInstanceConditionOrphanMitigation)
 		instance.Status.OrphanMitigationInProgress = false
 		reason = successOrphanMitigationReason
@@ -2670,6 +3030,7 @@ func (c *controller) processDeprovisionSuccess(instance *v1beta1.ServiceInstance
 	}
 
 	setServiceInstanceCondition(instance, v1beta1.ServiceInstanceConditionReady, v1beta1.ConditionFalse, reason, msg)
+	recordServiceInstanceOperationResult(instance, result, resultReason, resultMsg)
 	clearServiceInstanceCurrentOperation(instance)
 	instance.Status.ExternalProperties = nil
 	instance.Status.ProvisionStatus = v1beta1.ServiceInstanceProvisionStatusNotProvisioned
@@ -2716,6 +3077,7 @@ func (c *controller) processDeprovisionFailure(instance *v1beta1.ServiceInstance
 		c.recorder.Event(instance, corev1.EventTypeWarning, failedCond.Reason, failedCond.Message)
 	}
 
+	recordServiceInstanceOperationResult(instance, v1beta1.ServiceInstanceOperationFailed, failedCond.Reason, failedCond.Message)
 	clearServiceInstanceCurrentOperation(instance)
 	instance.Status.DeprovisionStatus = v1beta1.ServiceInstanceDeprovisionStatusFailed
 
@@ -41,6 +41,8 @@ import (
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
 
 	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
+	"github.com/kubernetes-incubator/service-catalog/pkg/osbclient"
+	fakeosbclient "github.com/kubernetes-incubator/service-catalog/pkg/osbclient/fake"
 	"github.com/kubernetes-incubator/service-catalog/test/fake"
 	sctestutil "github.com/kubernetes-incubator/service-catalog/test/util"
 	corev1 "k8s.io/api/core/v1"
@@ -1221,6 +1223,7 @@ func TestReconcileServiceInstanceWithTerminalProvisionFailure(t *testing.T) {
 		"ClusterServiceBrokerReturnedFailure",
 		instance,
 	)
+	assertServiceInstanceLastOperationRecord(t, updatedServiceInstance.(*v1beta1.ServiceInstance), v1beta1.ServiceInstanceOperationProvision, v1beta1.ServiceInstanceOperationFailed, "ClusterServiceBrokerReturnedFailure")
 
 	events := getRecordedEvents(testController)
 
@@ -1303,6 +1306,7 @@ func TestReconcileServiceInstance(t *testing.T) {
 	updatedServiceInstance := assertUpdateStatus(t, actions[0], instance)
 	assertServiceInstanceOperationSuccess(t, updatedServiceInstance, v1beta1.ServiceInstanceOperationProvision, testClusterServicePlanName, testClusterServicePlanGUID, instance)
 	assertServiceInstanceDashboardURL(t, updatedServiceInstance, testDashboardURL)
+	assertServiceInstanceLastOperationRecord(t, updatedServiceInstance.(*v1beta1.ServiceInstance), v1beta1.ServiceInstanceOperationProvision, v1beta1.ServiceInstanceOperationSucceeded, successProvisionReason)
 
 	events := getRecordedEvents(testController)
 
@@ -3797,9 +3801,9 @@ func TestReconcileInstanceUsingOriginatingIdentity(t *testing.T) {
 
 			brokerActions := fakeBrokerClient.Actions()
 			assertNumberOfBrokerActions(t, brokerActions, 1)
-			actualRequest, ok := brokerActions[0].Request.(*osb.ProvisionRequest)
+			actualRequest, ok := brokerActions[0].Request.(*osbclient.ProvisionRequest)
 			if !ok {
-				t.Errorf("%v: unexpected request type; expected %T, got %T", tc.name, &osb.ProvisionRequest{}, actualRequest)
+				t.Errorf("%v: unexpected request type; expected %T, got %T", tc.name, &osbclient.ProvisionRequest{}, actualRequest)
 				return
 			}
 			var expectedOriginatingIdentity *osb.OriginatingIdentity
@@ -4309,6 +4313,10 @@ func TestReconcileServiceInstanceOrphanMitigation(t *testing.T) {
 
 			if tc.finishedOrphanMitigation {
 				assertServiceInstanceOrphanMitigationMissing(t, updatedServiceInstance)
+				// The provision that needed the orphan mitigation is
+				// recorded as failed with its original failure.
+				assertServiceInstanceLastOperationRecord(t, updatedServiceInstance,
+					v1beta1.ServiceInstanceOperationProvision, v1beta1.ServiceInstanceOperationFailed, startingInstanceOrphanMitigationReason)
 			} else {
 				assertServiceInstanceOrphanMitigationTrue(t, updatedServiceInstance, startingInstanceOrphanMitigationReason)
 			}
@@ -6060,6 +6068,744 @@ func TestReconcileServiceInstanceUpdateMissingOrphanMitigation(t *testing.T) {
 
 }
 
+// TestReconcileServiceInstanceDrift tests that reconciling a provisioned
+// ServiceInstance with no work to do fetches the instance from the broker,
+// when its class allows it, and reports whether the instance has drifted.
+func TestReconcileServiceInstanceDrift(t *testing.T) {
+	driftedMessage := "The instance reported by the broker differs from the last one sent to it: "
+	cases := []struct {
+		name                 string
+		instancesRetrievable bool
+		drifted              bool
+		getInstanceReaction  *fakeosbclient.GetInstanceReaction
+		expectedBrokerAction bool
+		expectedCondition    *v1beta1.ServiceInstanceCondition
+		expectedEvent        string
+	}{
+		{
+			name:                 "class does not allow fetching instances",
+			instancesRetrievable: false,
+			getInstanceReaction: &fakeosbclient.GetInstanceReaction{
+				Response: &osbclient.GetInstanceResponse{PlanID: "other-plan"},
+			},
+		},
+		{
+			name:                 "instance in sync",
+			instancesRetrievable: true,
+			getInstanceReaction: &fakeosbclient.GetInstanceReaction{
+				Response: &osbclient.GetInstanceResponse{
+					ServiceID: testClusterServiceClassGUID,
+					PlanID:    testClusterServicePlanGUID,
+					Parameters: map[string]interface{}{
+						"count":    1,
+						"password": "s3cret",
+						"extra":    "default",
+					},
+				},
+			},
+			expectedBrokerAction: true,
+		},
+		{
+			name:                 "plan drifted",
+			instancesRetrievable: true,
+			getInstanceReaction: &fakeosbclient.GetInstanceReaction{
+				Response: &osbclient.GetInstanceResponse{
+					PlanID:     "other-plan",
+					Parameters: map[string]interface{}{"count": 1},
+				},
+			},
+			expectedBrokerAction: true,
+			expectedCondition: &v1beta1.ServiceInstanceCondition{
+				Type:    v1beta1.ServiceInstanceConditionDrifted,
+				Status:  v1beta1.ConditionTrue,
+				Reason:  driftedInstanceReason,
+				Message: driftedMessage + fmt.Sprintf("plan is %q instead of %q", "other-plan", testClusterServicePlanGUID),
+			},
+			expectedEvent: warningEventBuilder(driftedInstanceReason).msg(
+				driftedMessage + fmt.Sprintf("plan is %q instead of %q", "other-plan", testClusterServicePlanGUID),
+			).String(),
+		},
+		{
+			name:                 "parameters drifted",
+			instancesRetrievable: true,
+			getInstanceReaction: &fakeosbclient.GetInstanceReaction{
+				Response: &osbclient.GetInstanceResponse{
+					PlanID:     testClusterServicePlanGUID,
+					Parameters: map[string]interface{}{"password": "changed"},
+				},
+			},
+			expectedBrokerAction: true,
+			expectedCondition: &v1beta1.ServiceInstanceCondition{
+				Type:    v1beta1.ServiceInstanceConditionDrifted,
+				Status:  v1beta1.ConditionTrue,
+				Reason:  driftedInstanceReason,
+				Message: driftedMessage + `parameter "count" is missing`,
+			},
+			expectedEvent: warningEventBuilder(driftedInstanceReason).msg(
+				driftedMessage + `parameter "count" is missing`,
+			).String(),
+		},
+		{
+			name:                 "drifted instance back in sync",
+			instancesRetrievable: true,
+			drifted:              true,
+			getInstanceReaction: &fakeosbclient.GetInstanceReaction{
+				Response: &osbclient.GetInstanceResponse{PlanID: testClusterServicePlanGUID},
+			},
+			expectedBrokerAction: true,
+			expectedCondition: &v1beta1.ServiceInstanceCondition{
+				Type:    v1beta1.ServiceInstanceConditionDrifted,
+				Status:  v1beta1.ConditionFalse,
+				Reason:  inSyncInstanceReason,
+				Message: inSyncInstanceMessage,
+			},
+			expectedEvent: normalEventBuilder(inSyncInstanceReason).msg(inSyncInstanceMessage).String(),
+		},
+		{
+			name:                 "drifted instance still drifted",
+			instancesRetrievable: true,
+			drifted:              true,
+			getInstanceReaction: &fakeosbclient.GetInstanceReaction{
+				Response: &osbclient.GetInstanceResponse{
+					PlanID:     testClusterServicePlanGUID,
+					Parameters: map[string]interface{}{"count": 2},
+				},
+			},
+			expectedBrokerAction: true,
+		},
+		{
+			name:                 "error fetching instance",
+			instancesRetrievable: true,
+			getInstanceReaction: &fakeosbclient.GetInstanceReaction{
+				Error: errors.New("fake error"),
+			},
+			expectedBrokerAction: true,
+			expectedEvent: warningEventBuilder(errorFetchingInstanceReason).msg(
+				"Error fetching the instance from the broker to check for drift: fake error",
+			).String(),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, noFakeActions())
+			fakeClusterServiceBrokerClient.GetInstanceReaction = tc.getInstanceReaction
+
+			serviceClass := getTestClusterServiceClass()
+			serviceClass.Spec.InstancesRetrievable = tc.instancesRetrievable
+			sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+			sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(serviceClass)
+			sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+			instance := getTestServiceInstanceWithRefsAndExternalProperties()
+			instance.Status.ObservedGeneration = instance.Generation
+			instance.Status.ProvisionStatus = v1beta1.ServiceInstanceProvisionStatusProvisioned
+			instance.Status.ExternalProperties.Parameters = &runtime.RawExtension{
+				Raw: []byte(`{"count":1,"password":"<redacted>"}`),
+			}
+			instance.Status.Conditions = []v1beta1.ServiceInstanceCondition{
+				{Type: v1beta1.ServiceInstanceConditionReady, Status: v1beta1.ConditionTrue},
+			}
+			if tc.drifted {
+				instance.Status.Conditions = append(instance.Status.Conditions, v1beta1.ServiceInstanceCondition{
+					Type:    v1beta1.ServiceInstanceConditionDrifted,
+					Status:  v1beta1.ConditionTrue,
+					Reason:  driftedInstanceReason,
+					Message: driftedMessage + `parameter "count" has changed`,
+				})
+			}
+
+			if err := reconcileServiceInstance(t, testController, instance); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			brokerActions := fakeClusterServiceBrokerClient.Actions()
+			if tc.expectedBrokerAction {
+				assertNumberOfBrokerActions(t, brokerActions, 1)
+				assertGetInstance(t, brokerActions[0], &osbclient.GetInstanceRequest{
+					InstanceID: testServiceInstanceGUID,
+				})
+			} else {
+				assertNumberOfBrokerActions(t, brokerActions, 0)
+			}
+
+			actions := fakeCatalogClient.Actions()
+			if tc.expectedCondition == nil {
+				assertNumberOfActions(t, actions, 0)
+			} else {
+				assertNumberOfActions(t, actions, 1)
+				updatedServiceInstance := assertUpdateStatus(t, actions[0], instance)
+				assertServiceInstanceCondition(t, updatedServiceInstance, v1beta1.ServiceInstanceConditionReady, v1beta1.ConditionTrue)
+				assertServiceInstanceCondition(t, updatedServiceInstance, tc.expectedCondition.Type, tc.expectedCondition.Status, tc.expectedCondition.Reason)
+				for _, cond := range updatedServiceInstance.(*v1beta1.ServiceInstance).Status.Conditions {
+					if cond.Type == tc.expectedCondition.Type && cond.Message != tc.expectedCondition.Message {
+						t.Fatalf("unexpected message: %v", expectedGot(tc.expectedCondition.Message, cond.Message))
+					}
+				}
+			}
+
+			expectedEvents := []string{}
+			if tc.expectedEvent != "" {
+				expectedEvents = append(expectedEvents, tc.expectedEvent)
+			}
+			if err := checkEvents(getRecordedEvents(testController), expectedEvents); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+// TestReconcileServiceInstanceDriftInterval tests that a Ready instance is
+// fetched from its broker at most once per drift check interval, and never
+// when the drift checks are disabled.
+func TestReconcileServiceInstanceDriftInterval(t *testing.T) {
+	cases := []struct {
+		name                  string
+		interval              time.Duration
+		lastCheck             time.Duration
+		expectedBrokerActions int
+	}{
+		{
+			name:                  "checks disabled",
+			interval:              0,
+			expectedBrokerActions: 0,
+		},
+		{
+			name:                  "first check",
+			interval:              time.Hour,
+			expectedBrokerActions: 1,
+		},
+		{
+			name:                  "checked within the interval",
+			interval:              time.Hour,
+			lastCheck:             30 * time.Minute,
+			expectedBrokerActions: 0,
+		},
+		{
+			name:                  "checked before the interval",
+			interval:              time.Hour,
+			lastCheck:             2 * time.Hour,
+			expectedBrokerActions: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, noFakeActions())
+			fakeClusterServiceBrokerClient.GetInstanceReaction = &fakeosbclient.GetInstanceReaction{
+				Response: &osbclient.GetInstanceResponse{PlanID: testClusterServicePlanGUID},
+			}
+
+			serviceClass := getTestClusterServiceClass()
+			serviceClass.Spec.InstancesRetrievable = true
+			sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+			sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(serviceClass)
+			sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+			instance := getTestServiceInstanceWithRefsAndExternalProperties()
+			instance.Status.ObservedGeneration = instance.Generation
+			instance.Status.ProvisionStatus = v1beta1.ServiceInstanceProvisionStatusProvisioned
+			instance.Status.Conditions = []v1beta1.ServiceInstanceCondition{
+				{Type: v1beta1.ServiceInstanceConditionReady, Status: v1beta1.ConditionTrue},
+			}
+
+			testController.instanceDriftChecks.interval = tc.interval
+			if tc.lastCheck != 0 {
+				testController.instanceDriftChecks.lastChecks[instance.UID] = time.Now().Add(-tc.lastCheck)
+			}
+
+			if err := reconcileServiceInstance(t, testController, instance); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), tc.expectedBrokerActions)
+
+			// A second reconciliation within the interval does not fetch
+			// the instance again.
+			if err := reconcileServiceInstance(t, testController, instance); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), tc.expectedBrokerActions)
+		})
+	}
+}
+
+// TestReconcileServiceInstanceUpgrade tests that reconciling a provisioned
+// ServiceInstance with no work to do reports whether a newer version of its
+// plan is available, and starts upgrading it when automatic upgrades are
+// enabled.
+func TestReconcileServiceInstanceUpgrade(t *testing.T) {
+	upgradeAvailableMessage := `Version "2.0.0" of the plan is available`
+	cases := []struct {
+		name                     string
+		planMaintenanceInfo      *v1beta1.MaintenanceInfo
+		instanceMaintenanceInfo  *v1beta1.MaintenanceInfo
+		upgradeAvailable         bool
+		autoUpgradeInstances     bool
+		expectedCondition        *v1beta1.ServiceInstanceCondition
+		expectedUpgradeOperation bool
+		expectedEvents           []string
+	}{
+		{
+			name:                    "plan without version",
+			instanceMaintenanceInfo: &v1beta1.MaintenanceInfo{Version: "1.0.0"},
+		},
+		{
+			name:                    "instance up to date",
+			planMaintenanceInfo:     &v1beta1.MaintenanceInfo{Version: "2.0.0"},
+			instanceMaintenanceInfo: &v1beta1.MaintenanceInfo{Version: "2.0.0"},
+		},
+		{
+			name:                    "upgrade available",
+			planMaintenanceInfo:     &v1beta1.MaintenanceInfo{Version: "2.0.0"},
+			instanceMaintenanceInfo: &v1beta1.MaintenanceInfo{Version: "1.0.0"},
+			expectedCondition: &v1beta1.ServiceInstanceCondition{
+				Type:    v1beta1.ServiceInstanceConditionUpgradeAvailable,
+				Status:  v1beta1.ConditionTrue,
+				Reason:  upgradeAvailableReason,
+				Message: upgradeAvailableMessage,
+			},
+			expectedEvents: []string{
+				normalEventBuilder(upgradeAvailableReason).msg(upgradeAvailableMessage).String(),
+			},
+		},
+		{
+			name:                "upgrade available for instance without version",
+			planMaintenanceInfo: &v1beta1.MaintenanceInfo{Version: "2.0.0"},
+			expectedCondition: &v1beta1.ServiceInstanceCondition{
+				Type:    v1beta1.ServiceInstanceConditionUpgradeAvailable,
+				Status:  v1beta1.ConditionTrue,
+				Reason:  upgradeAvailableReason,
+				Message: upgradeAvailableMessage,
+			},
+			expectedEvents: []string{
+				normalEventBuilder(upgradeAvailableReason).msg(upgradeAvailableMessage).String(),
+			},
+		},
+		{
+			name:                    "upgrade already reported",
+			planMaintenanceInfo:     &v1beta1.MaintenanceInfo{Version: "2.0.0"},
+			instanceMaintenanceInfo: &v1beta1.MaintenanceInfo{Version: "1.0.0"},
+			upgradeAvailable:        true,
+		},
+		{
+			name:                    "upgraded instance",
+			planMaintenanceInfo:     &v1beta1.MaintenanceInfo{Version: "2.0.0"},
+			instanceMaintenanceInfo: &v1beta1.MaintenanceInfo{Version: "2.0.0"},
+			upgradeAvailable:        true,
+			expectedCondition: &v1beta1.ServiceInstanceCondition{
+				Type:    v1beta1.ServiceInstanceConditionUpgradeAvailable,
+				Status:  v1beta1.ConditionFalse,
+				Reason:  upToDateInstanceReason,
+				Message: upToDateInstanceMessage,
+			},
+			expectedEvents: []string{
+				normalEventBuilder(upToDateInstanceReason).msg(upToDateInstanceMessage).String(),
+			},
+		},
+		{
+			name:                    "automatic upgrade",
+			planMaintenanceInfo:     &v1beta1.MaintenanceInfo{Version: "2.0.0"},
+			instanceMaintenanceInfo: &v1beta1.MaintenanceInfo{Version: "1.0.0"},
+			autoUpgradeInstances:    true,
+			expectedCondition: &v1beta1.ServiceInstanceCondition{
+				Type:    v1beta1.ServiceInstanceConditionUpgradeAvailable,
+				Status:  v1beta1.ConditionTrue,
+				Reason:  upgradeAvailableReason,
+				Message: upgradeAvailableMessage,
+			},
+			expectedUpgradeOperation: true,
+			expectedEvents: []string{
+				normalEventBuilder(upgradeAvailableReason).msg(upgradeAvailableMessage).String(),
+				normalEventBuilder(upgradingInstanceReason).msg(`Upgrading the instance to version "2.0.0" of the plan`).String(),
+			},
+		},
+		{
+			name:                    "automatic upgrade already reported",
+			planMaintenanceInfo:     &v1beta1.MaintenanceInfo{Version: "2.0.0"},
+			instanceMaintenanceInfo: &v1beta1.MaintenanceInfo{Version: "1.0.0"},
+			upgradeAvailable:        true,
+			autoUpgradeInstances:    true,
+			expectedCondition: &v1beta1.ServiceInstanceCondition{
+				Type:    v1beta1.ServiceInstanceConditionUpgradeAvailable,
+				Status:  v1beta1.ConditionTrue,
+				Reason:  upgradeAvailableReason,
+				Message: upgradeAvailableMessage,
+			},
+			expectedUpgradeOperation: true,
+			expectedEvents: []string{
+				normalEventBuilder(upgradingInstanceReason).msg(`Upgrading the instance to version "2.0.0" of the plan`).String(),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, noFakeActions())
+			testController.autoUpgradeInstances = tc.autoUpgradeInstances
+
+			servicePlan := getTestClusterServicePlan()
+			servicePlan.Spec.MaintenanceInfo = tc.planMaintenanceInfo
+			sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+			sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+			sharedInformers.ClusterServicePlans().Informer().GetStore().Add(servicePlan)
+
+			instance := getTestServiceInstanceWithRefsAndExternalProperties()
+			instance.Status.ObservedGeneration = instance.Generation
+			instance.Status.ProvisionStatus = v1beta1.ServiceInstanceProvisionStatusProvisioned
+			instance.Status.ExternalProperties.MaintenanceInfo = tc.instanceMaintenanceInfo
+			instance.Status.Conditions = []v1beta1.ServiceInstanceCondition{
+				{Type: v1beta1.ServiceInstanceConditionReady, Status: v1beta1.ConditionTrue},
+			}
+			if tc.upgradeAvailable {
+				instance.Status.Conditions = append(instance.Status.Conditions, v1beta1.ServiceInstanceCondition{
+					Type:    v1beta1.ServiceInstanceConditionUpgradeAvailable,
+					Status:  v1beta1.ConditionTrue,
+					Reason:  upgradeAvailableReason,
+					Message: upgradeAvailableMessage,
+				})
+			}
+
+			if err := reconcileServiceInstance(t, testController, instance); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 0)
+
+			actions := fakeCatalogClient.Actions()
+			if tc.expectedCondition == nil {
+				assertNumberOfActions(t, actions, 0)
+			} else {
+				assertNumberOfActions(t, actions, 1)
+				updatedServiceInstance := assertUpdateStatus(t, actions[0], instance)
+				assertServiceInstanceCondition(t, updatedServiceInstance, tc.expectedCondition.Type, tc.expectedCondition.Status, tc.expectedCondition.Reason)
+				if tc.expectedUpgradeOperation {
+					assertServiceInstanceReadyFalse(t, updatedServiceInstance, instanceUpdatingInFlightReason)
+					assertServiceInstanceCurrentOperation(t, updatedServiceInstance, v1beta1.ServiceInstanceOperationUpdate)
+					inProgressProperties := updatedServiceInstance.(*v1beta1.ServiceInstance).Status.InProgressProperties
+					if e, a := tc.planMaintenanceInfo, inProgressProperties.MaintenanceInfo; !reflect.DeepEqual(e, a) {
+						t.Fatalf("unexpected maintenance info in progress: %v", expectedGot(e, a))
+					}
+				} else {
+					assertServiceInstanceReadyTrue(t, updatedServiceInstance)
+				}
+			}
+
+			expectedEvents := tc.expectedEvents
+			if expectedEvents == nil {
+				expectedEvents = []string{}
+			}
+			if err := checkEvents(getRecordedEvents(testController), expectedEvents); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+// TestReconcileServiceInstanceUpdateMaintenanceInfo tests that updating a
+// ServiceInstance to a newer version of its plan sends the maintenance info
+// of the plan, and only that, to the broker.
+func TestReconcileServiceInstanceUpdateMaintenanceInfo(t *testing.T) {
+	_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		UpdateInstanceReaction: &fakeosb.UpdateInstanceReaction{
+			Response: &osb.UpdateInstanceResponse{},
+		},
+	})
+
+	servicePlan := getTestClusterServicePlan()
+	servicePlan.Spec.MaintenanceInfo = &v1beta1.MaintenanceInfo{Version: "2.0.0", Description: "OS image update"}
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(servicePlan)
+
+	instance := getTestServiceInstanceWithRefsAndExternalProperties()
+	instance.Status.ObservedGeneration = instance.Generation
+	instance.Status.ProvisionStatus = v1beta1.ServiceInstanceProvisionStatusProvisioned
+	instance.Status.DeprovisionStatus = v1beta1.ServiceInstanceDeprovisionStatusRequired
+	instance.Status.ExternalProperties.MaintenanceInfo = &v1beta1.MaintenanceInfo{Version: "1.0.0"}
+	instance.Status.CurrentOperation = v1beta1.ServiceInstanceOperationUpdate
+	instance.Status.InProgressProperties = &v1beta1.ServiceInstancePropertiesState{
+		ClusterServicePlanExternalName: testClusterServicePlanName,
+		ClusterServicePlanExternalID:   testClusterServicePlanGUID,
+		MaintenanceInfo:                servicePlan.Spec.MaintenanceInfo,
+	}
+	instance.Status.Conditions = []v1beta1.ServiceInstanceCondition{
+		{
+			Type:    v1beta1.ServiceInstanceConditionReady,
+			Status:  v1beta1.ConditionFalse,
+			Reason:  instanceUpdatingInFlightReason,
+			Message: instanceUpdatingInFlightMessage,
+		},
+	}
+
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	brokerActions := fakeClusterServiceBrokerClient.Actions()
+	assertNumberOfBrokerActions(t, brokerActions, 1)
+	assertUpdateInstanceWithMaintenanceInfo(t, brokerActions[0], &osb.UpdateInstanceRequest{
+		AcceptsIncomplete: true,
+		InstanceID:        testServiceInstanceGUID,
+		ServiceID:         testClusterServiceClassGUID,
+		Context:           testContext,
+	}, &osbclient.MaintenanceInfo{Version: "2.0.0", Description: "OS image update"})
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+
+	updatedServiceInstance := assertUpdateStatus(t, actions[0], instance)
+	assertServiceInstanceReadyTrue(t, updatedServiceInstance, successUpdateInstanceReason)
+	externalProperties := updatedServiceInstance.(*v1beta1.ServiceInstance).Status.ExternalProperties
+	if e, a := servicePlan.Spec.MaintenanceInfo, externalProperties.MaintenanceInfo; !reflect.DeepEqual(e, a) {
+		t.Fatalf("unexpected maintenance info: %v", expectedGot(e, a))
+	}
+}
+
+// TestReconcileServiceInstanceRetryBackoff tests that the backoff between
+// retries of a failing provision is persisted in the status of the instance
+// and observed before calling the broker again.
+func TestReconcileServiceInstanceRetryBackoff(t *testing.T) {
+	_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		ProvisionReaction: &fakeosb.ProvisionReaction{
+			Error: errors.New("fake creation failure"),
+		},
+	})
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+	instance := getTestServiceInstanceWithClusterRefs()
+
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("Reconcile not expected to fail : %v", err)
+	}
+	instance = assertServiceInstanceProvisionInProgressIsTheOnlyCatalogClientAction(t, fakeCatalogClient, instance)
+	fakeCatalogClient.ClearActions()
+
+	// The first failure of the provision call starts the backoff.
+	if err := reconcileServiceInstance(t, testController, instance); err == nil {
+		t.Fatalf("Expected the provision to fail")
+	}
+	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 1)
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	instance = assertUpdateStatus(t, actions[0], instance).(*v1beta1.ServiceInstance)
+	assertServiceInstanceOperationRetry(t, instance, 1, minBrokerOperationRetryDelay)
+	if e, a := "fake creation failure", instance.Status.OperationRetry.LastError; !strings.Contains(a, e) {
+		t.Fatalf("unexpected last error: expected to contain %q, got %q", e, a)
+	}
+
+	// The broker is not called again before the next retry time.
+	fakeCatalogClient.ClearActions()
+	getRecordedEvents(testController)
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("Reconcile not expected to fail : %v", err)
+	}
+	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 1)
+	assertNumberOfActions(t, fakeCatalogClient.Actions(), 0)
+	events := getRecordedEvents(testController)
+	expectedEvent := warningEventBuilder("RetryBackoff").msg("Delaying provision retry, next attempt will be after").String()
+	if len(events) != 1 || !strings.HasPrefix(events[0], expectedEvent) {
+		t.Fatalf("unexpected events: expected an event starting with %q, got %v", expectedEvent, events)
+	}
+
+	// The next failure after the retry time doubles the backoff.
+	instance.Status.OperationRetry.NextRetryTime = metav1.NewTime(time.Now().Add(-time.Second))
+	if err := reconcileServiceInstance(t, testController, instance); err == nil {
+		t.Fatalf("Expected the provision to fail")
+	}
+	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 2)
+	actions = fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	instance = assertUpdateStatus(t, actions[0], instance).(*v1beta1.ServiceInstance)
+	assertServiceInstanceOperationRetry(t, instance, 2, 2*minBrokerOperationRetryDelay)
+
+	// The backoff does not apply to a new generation of the instance.
+	fakeCatalogClient.ClearActions()
+	fakeClusterServiceBrokerClient.ProvisionReaction = &fakeosb.ProvisionReaction{
+		Response: &osb.ProvisionResponse{},
+	}
+	instance.Generation = instance.Generation + 1
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("Reconcile not expected to fail : %v", err)
+	}
+	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 3)
+	actions = fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	instance = assertUpdateStatus(t, actions[0], instance).(*v1beta1.ServiceInstance)
+	assertServiceInstanceReadyTrue(t, instance, successProvisionReason)
+	if instance.Status.OperationRetry != nil {
+		t.Fatalf("expected the retry state to be cleared, got %+v", instance.Status.OperationRetry)
+	}
+}
+
+// TestReconcileServiceInstanceRetryBackoffAfterOrphanMitigation tests that
+// the backoff of a provision that failed with an error requiring orphan
+// mitigation survives the orphan mitigation, and keeps growing when the
+// provision is retried.
+func TestReconcileServiceInstanceRetryBackoffAfterOrphanMitigation(t *testing.T) {
+	_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		ProvisionReaction: &fakeosb.ProvisionReaction{
+			Error: osb.HTTPStatusCodeError{
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+		DeprovisionReaction: &fakeosb.DeprovisionReaction{
+			Response: &osb.DeprovisionResponse{},
+		},
+	})
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+	instance := getTestServiceInstanceWithClusterRefs()
+	instance.ObjectMeta.Finalizers = []string{v1beta1.FinalizerServiceCatalog}
+
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("Reconcile not expected to fail : %v", err)
+	}
+	instance = assertServiceInstanceProvisionInProgressIsTheOnlyCatalogClientAction(t, fakeCatalogClient, instance)
+	fakeCatalogClient.ClearActions()
+	getRecordedEvents(testController)
+
+	// The 5xx response starts the backoff and the orphan mitigation.
+	if err := reconcileServiceInstance(t, testController, instance); err == nil {
+		t.Fatalf("Expected the provision to fail")
+	}
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	instance = assertUpdateStatus(t, actions[0], instance).(*v1beta1.ServiceInstance)
+	assertServiceInstanceOrphanMitigationInProgressTrue(t, instance)
+	assertServiceInstanceOperationRetry(t, instance, 1, minBrokerOperationRetryDelay)
+
+	// The orphan mitigation keeps the backoff.
+	fakeCatalogClient.ClearActions()
+	getRecordedEvents(testController)
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("Reconcile not expected to fail : %v", err)
+	}
+	actions = fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	instance = assertUpdateStatus(t, actions[0], instance).(*v1beta1.ServiceInstance)
+	assertServiceInstanceOrphanMitigationInProgressFalse(t, instance)
+	assertServiceInstanceOperationRetry(t, instance, 1, minBrokerOperationRetryDelay)
+	assertServiceInstanceLastOperationRecord(t, instance,
+		v1beta1.ServiceInstanceOperationProvision, v1beta1.ServiceInstanceOperationFailed, errorProvisionCallFailedReason)
+
+	// The provision is not retried before the next retry time.
+	fakeCatalogClient.ClearActions()
+	getRecordedEvents(testController)
+	brokerActions := len(fakeClusterServiceBrokerClient.Actions())
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("Reconcile not expected to fail : %v", err)
+	}
+	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), brokerActions)
+	assertNumberOfActions(t, fakeCatalogClient.Actions(), 0)
+
+	// The provision starts again after the next retry time, and its failure
+	// doubles the backoff.
+	instance.Status.OperationRetry.NextRetryTime = metav1.NewTime(time.Now().Add(-time.Second))
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("Reconcile not expected to fail : %v", err)
+	}
+	instance = assertServiceInstanceProvisionInProgressIsTheOnlyCatalogClientAction(t, fakeCatalogClient, instance)
+	fakeCatalogClient.ClearActions()
+	getRecordedEvents(testController)
+
+	if err := reconcileServiceInstance(t, testController, instance); err == nil {
+		t.Fatalf("Expected the provision to fail")
+	}
+	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), brokerActions+1)
+	actions = fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	instance = assertUpdateStatus(t, actions[0], instance).(*v1beta1.ServiceInstance)
+	assertServiceInstanceOperationRetry(t, instance, 2, 2*minBrokerOperationRetryDelay)
+}
+
+func TestBrokerOperationRetryDelay(t *testing.T) {
+	cases := []struct {
+		attempts int32
+		expected time.Duration
+	}{
+		{attempts: 1, expected: time.Second},
+		{attempts: 2, expected: 2 * time.Second},
+		{attempts: 5, expected: 16 * time.Second},
+		{attempts: 11, expected: 1024 * time.Second},
+		{attempts: 12, expected: maxBrokerOperationRetryDelay},
+		{attempts: 100, expected: maxBrokerOperationRetryDelay},
+	}
+	for _, tc := range cases {
+		if e, a := tc.expected, brokerOperationRetryDelay(tc.attempts); e != a {
+			t.Errorf("unexpected delay after %v attempts: %v", tc.attempts, expectedGot(e, a))
+		}
+	}
+}
+
+func TestRecordServiceInstanceOperationResult(t *testing.T) {
+	instance := getTestServiceInstance()
+	recordServiceInstanceOperationResult(instance, v1beta1.ServiceInstanceOperationSucceeded, successProvisionReason, successProvisionMessage)
+	if len(instance.Status.OperationHistory) != 0 {
+		t.Fatalf("expected no record without a current operation, got %+v", instance.Status.OperationHistory)
+	}
+
+	startTime := metav1.NewTime(time.Now().Add(-time.Minute))
+	for i := 0; i < maxServiceInstanceOperationHistory+2; i++ {
+		instance.Status.CurrentOperation = v1beta1.ServiceInstanceOperationUpdate
+		instance.Status.OperationStartTime = &startTime
+		recordServiceInstanceOperationResult(instance, v1beta1.ServiceInstanceOperationFailed, "reason", fmt.Sprintf("attempt %d", i))
+	}
+
+	history := instance.Status.OperationHistory
+	if e, a := maxServiceInstanceOperationHistory, len(history); e != a {
+		t.Fatalf("unexpected number of records: %v", expectedGot(e, a))
+	}
+	if e, a := "attempt 2", history[0].Message; e != a {
+		t.Fatalf("expected the oldest records to be dropped: %v", expectedGot(e, a))
+	}
+	last := history[len(history)-1]
+	if e, a := fmt.Sprintf("attempt %d", maxServiceInstanceOperationHistory+1), last.Message; e != a {
+		t.Fatalf("unexpected last record: %v", expectedGot(e, a))
+	}
+	if !last.StartTime.Equal(&startTime) || last.CompletionTime.Before(&startTime) {
+		t.Fatalf("unexpected times for the operation %+v", last)
+	}
+}
+
+func assertServiceInstanceLastOperationRecord(t *testing.T, instance *v1beta1.ServiceInstance, operation v1beta1.ServiceInstanceOperation, result v1beta1.ServiceInstanceOperationResult, reason string) {
+	history := instance.Status.OperationHistory
+	if len(history) == 0 {
+		t.Fatalf("expected the operation to be recorded in the history")
+	}
+	last := history[len(history)-1]
+	if e, a := operation, last.Operation; e != a {
+		t.Fatalf("unexpected operation in the history: %v", expectedGot(e, a))
+	}
+	if e, a := result, last.Result; e != a {
+		t.Fatalf("unexpected result in the history: %v", expectedGot(e, a))
+	}
+	if e, a := reason, last.Reason; e != a {
+		t.Fatalf("unexpected reason in the history: %v", expectedGot(e, a))
+	}
+}
+
+func assertServiceInstanceOperationRetry(t *testing.T, instance *v1beta1.ServiceInstance, attempts int32, delay time.Duration) {
+	retry := instance.Status.OperationRetry
+	if retry == nil {
+		t.Fatalf("expected the retry state to be set")
+	}
+	if e, a := instance.Generation, retry.Generation; e != a {
+		t.Fatalf("unexpected retry generation: %v", expectedGot(e, a))
+	}
+	if e, a := attempts, retry.Attempts; e != a {
+		t.Fatalf("unexpected retry attempts: %v", expectedGot(e, a))
+	}
+	if remaining := retry.NextRetryTime.Sub(time.Now()); remaining <= 0 || remaining > delay {
+		t.Fatalf("unexpected next retry time %v, expected it within %v", retry.NextRetryTime, delay)
+	}
+}
+
 func generateChecksumOfParametersOrFail(t *testing.T, params map[string]interface{}) string {
 	expectedParametersChecksum, err := generateChecksumOfParameters(params)
 	if err != nil {
@@ -31,6 +31,7 @@ import (
 
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"github.com/kubernetes-incubator/service-catalog/pkg/metrics"
+	"github.com/kubernetes-incubator/service-catalog/pkg/osbclient"
 	"github.com/kubernetes-incubator/service-catalog/pkg/pretty"
 )
 
@@ -109,7 +110,10 @@ func (c *controller) reconcileServiceBrokerKey(key string) error {
 		return err
 	}
 
-	return c.reconcileServiceBroker(broker)
+	span := c.reconcileSpans.start(pretty.ServiceBroker, namespace, name)
+	err = c.reconcileServiceBroker(broker)
+	c.reconcileSpans.finish(pretty.ServiceBroker, namespace, name, span, err)
+	return err
 }
 
 // reconcileServiceBroker is the control-loop that reconciles a ServiceBroker. An
@@ -123,6 +127,14 @@ func (c *controller) reconcileServiceBroker(broker *v1beta1.ServiceBroker) error
 	// set to Manual, do not reconcile it.
 	// * If the broker's ready condition is true and the relist interval has not
 	// elapsed, do not reconcile it.
+	// While requests to the broker are suspended, only probe the broker
+	// until it recovers.
+	if broker.DeletionTimestamp == nil {
+		if done, err := c.reconcileServiceBrokerCircuit(broker); done {
+			return err
+		}
+	}
+
 	if !shouldReconcileServiceBroker(broker, time.Now(), c.brokerRelistInterval) {
 		return nil
 	}
@@ -143,7 +155,12 @@ func (c *controller) reconcileServiceBroker(broker *v1beta1.ServiceBroker) error
 		clientConfig := NewClientConfigurationForBroker(broker.ObjectMeta, &broker.Spec.CommonServiceBrokerSpec, authConfig)
 
 		glog.V(4).Info(pcb.Messagef("Creating client, URL: %v", broker.Spec.URL))
-		brokerClient, err := c.brokerClientCreateFunc(clientConfig)
+		brokerClient, err := c.newBrokerClient(broker.ObjectMeta, &broker.Spec.CommonServiceBrokerSpec, clientConfig, c.reconcileSpans.get(pretty.ServiceBroker, broker.Namespace, broker.Name))
+		if isBrokerRequestsThrottledError(err) {
+			glog.V(4).Info(pcb.Message(err.Error()))
+			c.serviceBrokerQueue.AddAfter(brokerKey(broker.ObjectMeta), brokerRequestsThrottledRetryDelay)
+			return nil
+		}
 		if err != nil {
 			s := fmt.Sprintf("Error creating client for broker %q: %s", broker.Name, err)
 			glog.Info(pcb.Message(s))
@@ -411,6 +428,9 @@ func (c *controller) reconcileServiceBroker(broker *v1beta1.ServiceBroker) error
 		// delete the metrics associated with this broker
 		metrics.BrokerServiceClassCount.DeleteLabelValues(broker.Name)
 		metrics.BrokerServicePlanCount.DeleteLabelValues(broker.Name)
+
+		c.brokerRequestLimiters.remove(broker.ObjectMeta)
+		c.brokerCircuitBreakers.remove(broker.ObjectMeta)
 		return nil
 	}
 
@@ -471,6 +491,7 @@ func (c *controller) reconcileServiceClassFromServiceBrokerCatalog(broker *v1bet
 	// update it.
 	toUpdate := existingServiceClass.DeepCopy()
 	toUpdate.Spec.BindingRetrievable = serviceClass.Spec.BindingRetrievable
+	toUpdate.Spec.InstancesRetrievable = serviceClass.Spec.InstancesRetrievable
 	toUpdate.Spec.Bindable = serviceClass.Spec.Bindable
 	toUpdate.Spec.PlanUpdatable = serviceClass.Spec.PlanUpdatable
 	toUpdate.Spec.Tags = serviceClass.Spec.Tags
@@ -563,6 +584,7 @@ func (c *controller) reconcileServicePlanFromServiceBrokerCatalog(broker *v1beta
 	toUpdate.Spec.ServiceInstanceCreateParameterSchema = servicePlan.Spec.ServiceInstanceCreateParameterSchema
 	toUpdate.Spec.ServiceInstanceUpdateParameterSchema = servicePlan.Spec.ServiceInstanceUpdateParameterSchema
 	toUpdate.Spec.ServiceBindingCreateParameterSchema = servicePlan.Spec.ServiceBindingCreateParameterSchema
+	toUpdate.Spec.MaintenanceInfo = servicePlan.Spec.MaintenanceInfo
 
 	updatedPlan, err := c.serviceCatalogClient.ServicePlans(broker.Namespace).Update(toUpdate)
 	if err != nil {
@@ -606,6 +628,7 @@ func updateCommonStatusCondition(pcb *pretty.ContextBuilder, meta metav1.ObjectM
 		newCondition.LastTransitionTime = metav1.NewTime(t)
 		commonStatus.Conditions = []v1beta1.ServiceBrokerCondition{newCondition}
 	} else {
+		found := false
 		for i, cond := range commonStatus.Conditions {
 			if cond.Type == conditionType {
 				if cond.Status != newCondition.Status {
@@ -619,9 +642,15 @@ func updateCommonStatusCondition(pcb *pretty.ContextBuilder, meta metav1.ObjectM
 				}
 
 				commonStatus.Conditions[i] = newCondition
+				found = true
 				break
 			}
 		}
+		if !found {
+			glog.Info(pcb.Messagef("Setting lastTransitionTime for condition %q to %v", conditionType, t))
+			newCondition.LastTransitionTime = metav1.NewTime(t)
+			commonStatus.Conditions = append(commonStatus.Conditions, newCondition)
+		}
 	}
 
 	// Set status.ReconciledGeneration && status.LastCatalogRetrievalTime if updating ready condition to true
@@ -632,6 +661,55 @@ func updateCommonStatusCondition(pcb *pretty.ContextBuilder, meta metav1.ObjectM
 	}
 }
 
+// reconcileServiceBrokerCircuit keeps the Degraded condition of the broker
+// in line with its circuit breaker, and probes the broker while its circuit
+// is open. It returns true when the reconciliation of the broker should stop
+// there.
+func (c *controller) reconcileServiceBrokerCircuit(broker *v1beta1.ServiceBroker) (bool, error) {
+	pcb := pretty.NewServiceBrokerContextBuilder(broker)
+	degraded := isServiceBrokerDegraded(&broker.Status.CommonServiceBrokerStatus)
+
+	breaker := c.brokerCircuitBreakers.get(broker.ObjectMeta)
+	if breaker == nil || !breaker.isOpen() {
+		if !degraded {
+			return false, nil
+		}
+		s := "Requests to the broker are resumed"
+		glog.Info(pcb.Message(s))
+		c.recorder.Event(broker, corev1.EventTypeNormal, brokerRecoveredReason, s)
+		return true, c.updateServiceBrokerCondition(broker, v1beta1.ServiceBrokerConditionDegraded, v1beta1.ConditionFalse, brokerRecoveredReason, s)
+	}
+
+	if !degraded {
+		s := breaker.degradedMessage()
+		glog.Warning(pcb.Message(s))
+		c.recorder.Event(broker, corev1.EventTypeWarning, brokerDegradedReason, s)
+		return true, c.updateServiceBrokerCondition(broker, v1beta1.ServiceBrokerConditionDegraded, v1beta1.ConditionTrue, brokerDegradedReason, s)
+	}
+
+	recovered := c.probeBroker(
+		breaker,
+		broker.ObjectMeta,
+		func(d time.Duration) { c.serviceBrokerQueue.AddAfter(brokerKey(broker.ObjectMeta), d) },
+		func() (osbclient.Client, error) {
+			authConfig, err := getAuthCredentialsFromServiceBroker(c.kubeClient, broker)
+			if err != nil {
+				return nil, err
+			}
+			clientConfig := NewClientConfigurationForBroker(broker.ObjectMeta, &broker.Spec.CommonServiceBrokerSpec, authConfig)
+			return c.brokerClientCreateFunc(clientConfig)
+		},
+	)
+	if !recovered {
+		return true, nil
+	}
+
+	s := "The broker answered a catalog request; requests to the broker are resumed"
+	glog.Info(pcb.Message(s))
+	c.recorder.Event(broker, corev1.EventTypeNormal, brokerRecoveredReason, s)
+	return true, c.updateServiceBrokerCondition(broker, v1beta1.ServiceBrokerConditionDegraded, v1beta1.ConditionFalse, brokerRecoveredReason, s)
+}
+
 // updateServiceBrokerCondition updates the ready condition for the given ServiceBroker
 // with the given status, reason, and message.
 func (c *controller) updateServiceBrokerCondition(broker *v1beta1.ServiceBroker, conditionType v1beta1.ServiceBrokerConditionType, status v1beta1.ConditionStatus, reason, message string) error {
@@ -41,6 +41,8 @@ import (
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
 
 	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
+	"github.com/kubernetes-incubator/service-catalog/pkg/osbclient"
+	fakeosbclient "github.com/kubernetes-incubator/service-catalog/pkg/osbclient/fake"
 	"github.com/kubernetes-incubator/service-catalog/test/fake"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -1118,7 +1120,8 @@ func getTestServiceBindingWithFailedStatus() *v1beta1.ServiceBinding {
 			Type:   v1beta1.ServiceBindingConditionFailed,
 			Status: v1beta1.ConditionTrue,
 		}},
-		UnbindStatus: v1beta1.ServiceBindingUnbindStatusNotRequired,
+		ReconciledGeneration: binding.Generation,
+		UnbindStatus:         v1beta1.ServiceBindingUnbindStatusNotRequired,
 	}
 
 	return binding
@@ -1250,7 +1253,7 @@ type bindingParameters struct {
 }
 
 func TestEmptyCatalogConversion(t *testing.T) {
-	serviceClasses, servicePlans, err := convertAndFilterCatalog(&osb.CatalogResponse{}, nil)
+	serviceClasses, servicePlans, err := convertAndFilterCatalog(&osbclient.CatalogResponse{}, nil)
 	if err != nil {
 		t.Fatalf("Failed to convertAndFilterCatalog: %v", err)
 	}
@@ -1263,7 +1266,7 @@ func TestEmptyCatalogConversion(t *testing.T) {
 }
 
 func TestCatalogConversion(t *testing.T) {
-	catalog := &osb.CatalogResponse{}
+	catalog := &osbclient.CatalogResponse{}
 	err := json.Unmarshal([]byte(testCatalog), &catalog)
 	if err != nil {
 		t.Fatalf("Failed to unmarshal test catalog: %v", err)
@@ -1287,7 +1290,7 @@ func TestCatalogConversionWithParameterSchemas(t *testing.T) {
 	utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.ResponseSchema))
 	defer utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.ResponseSchema))
 
-	catalog := &osb.CatalogResponse{}
+	catalog := &osbclient.CatalogResponse{}
 	err := json.Unmarshal([]byte(alphaParameterSchemaCatalogBytes), &catalog)
 	if err != nil {
 		t.Fatalf("Failed to unmarshal test catalog: %v", err)
@@ -1351,6 +1354,47 @@ func TestCatalogConversionWithParameterSchemas(t *testing.T) {
 	}
 }
 
+func TestCatalogConversionClusterServicePlanMaintenanceInfo(t *testing.T) {
+	catalog := &osbclient.CatalogResponse{}
+	err := json.Unmarshal([]byte(`{
+  "services": [{
+    "name": "fake-service",
+    "id": "fake-service-id",
+    "description": "fake service",
+    "bindable": true,
+    "plans": [{
+      "name": "versioned",
+      "id": "versioned-id",
+      "description": "a plan with a version",
+      "maintenance_info": {"version": "2.0.0", "description": "OS image update"}
+    }, {
+      "name": "unversioned",
+      "id": "unversioned-id",
+      "description": "a plan without a version"
+    }]
+  }]
+}`), &catalog)
+	if err != nil {
+		t.Fatalf("Failed to unmarshal test catalog: %v", err)
+	}
+
+	_, plans, err := convertAndFilterCatalog(catalog, nil)
+	if err != nil {
+		t.Fatalf("Failed to convertAndFilterCatalog: %v", err)
+	}
+	if len(plans) != 2 {
+		t.Fatalf("Expected 2 plans for testCatalog, but got: %d", len(plans))
+	}
+
+	expected := &v1beta1.MaintenanceInfo{Version: "2.0.0", Description: "OS image update"}
+	if e, a := expected, plans[0].Spec.MaintenanceInfo; !reflect.DeepEqual(e, a) {
+		t.Fatalf("Unexpected maintenance info: %v", expectedGot(e, a))
+	}
+	if a := plans[1].Spec.MaintenanceInfo; a != nil {
+		t.Fatalf("Expected no maintenance info, but got: %+v", a)
+	}
+}
+
 func checkPlan(plan *v1beta1.ClusterServicePlan, planID, planName, planDescription string, t *testing.T) {
 	if plan.Name != planID {
 		t.Errorf("Expected plan name to be %q, but was: %q", planID, plan.Name)
@@ -1589,7 +1633,7 @@ func TestConvertAndFilterCatalog(t *testing.T) {
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			catalog := &osb.CatalogResponse{}
+			catalog := &osbclient.CatalogResponse{}
 			err := json.Unmarshal([]byte(tc.catalog), &catalog)
 			if err != nil {
 				t.Fatalf("Failed to unmarshal test catalog: %v", err)
@@ -1665,7 +1709,7 @@ func TestFilterServicePlans(t *testing.T) {
 	for _, tc := range cases {
 		testName := fmt.Sprintf("%s:%s", tc.name, tc.requirements)
 		t.Run(testName, func(t *testing.T) {
-			catalog := &osb.CatalogResponse{}
+			catalog := &osbclient.CatalogResponse{}
 			err := json.Unmarshal([]byte(tc.catalog), &catalog)
 			if err != nil {
 				t.Fatalf("Failed to unmarshal test catalog: %v", err)
@@ -1742,7 +1786,7 @@ func strPtr(s string) *string {
 }
 
 func TestCatalogConversionClusterServicePlanBindable(t *testing.T) {
-	catalog := &osb.CatalogResponse{}
+	catalog := &osbclient.CatalogResponse{}
 	err := json.Unmarshal([]byte(testCatalogForClusterServicePlanBindableOverride), &catalog)
 	if err != nil {
 		t.Fatalf("Failed to unmarshal test catalog: %v", err)
@@ -1965,7 +2009,7 @@ func TestIsPlanBindable(t *testing.T) {
 func newTestController(t *testing.T, config fakeosb.FakeClientConfiguration) (
 	*clientgofake.Clientset,
 	*fake.Clientset,
-	*fakeosb.FakeClient,
+	*fakeosbclient.FakeClient,
 	*controller,
 	v1beta1informers.Interface) {
 	// create a fake kube client
@@ -1973,8 +2017,8 @@ func newTestController(t *testing.T, config fakeosb.FakeClientConfiguration) (
 	// create a fake sc client
 	fakeCatalogClient := &fake.Clientset{Clientset: &servicecatalogclientset.Clientset{}}
 
-	fakeOSBClient := fakeosb.NewFakeClient(config) // error should always be nil
-	brokerClFunc := fakeosb.ReturnFakeClientFunc(fakeOSBClient)
+	fakeOSBClient := fakeosbclient.NewFakeClient(config) // error should always be nil
+	brokerClFunc := fakeosbclient.ReturnFakeClientFunc(fakeOSBClient)
 
 	// create informers
 	informerFactory := servicecataloginformers.NewSharedInformerFactory(fakeCatalogClient, 0)
@@ -2002,6 +2046,11 @@ func newTestController(t *testing.T, config fakeosb.FakeClientConfiguration) (
 		7*24*time.Hour,
 		DefaultClusterIDConfigMapName,
 		DefaultClusterIDConfigMapNamespace,
+		false,
+		0,
+		time.Minute,
+		10*time.Minute,
+		time.Hour,
 	)
 
 	if c, ok := testController.(*controller); ok {
@@ -3596,17 +3645,25 @@ func assertProvision(t *testing.T, action fakeosb.Action, request *osb.Provision
 		fatalf(t, "unexpected action type; expected %v, got %v", e, a)
 	}
 
-	if e, a := request, action.Request; !reflect.DeepEqual(e, a) {
+	if e, a := (&osbclient.ProvisionRequest{ProvisionRequest: *request}), action.Request; !reflect.DeepEqual(e, a) {
 		fatalf(t, "unexpected diff in provision request: %v\nexpected %+v\ngot      %+v", diff.ObjectReflectDiff(e, a), e, a)
 	}
 }
 
 func assertUpdateInstance(t *testing.T, action fakeosb.Action, request *osb.UpdateInstanceRequest) {
+	assertUpdateInstanceWithMaintenanceInfo(t, action, request, nil)
+}
+
+func assertUpdateInstanceWithMaintenanceInfo(t *testing.T, action fakeosb.Action, request *osb.UpdateInstanceRequest, maintenanceInfo *osbclient.MaintenanceInfo) {
 	if e, a := fakeosb.UpdateInstance, action.Type; e != a {
 		fatalf(t, "unexpected action type; expected %v, got %v", e, a)
 	}
 
-	if e, a := request, action.Request; !reflect.DeepEqual(e, a) {
+	expected := &osbclient.UpdateInstanceRequest{
+		UpdateInstanceRequest: *request,
+		MaintenanceInfo:       maintenanceInfo,
+	}
+	if e, a := expected, action.Request; !reflect.DeepEqual(e, a) {
 		fatalf(t, "unexpected diff in update instance request: %v\nexpected %+v\ngot      %+v", diff.ObjectReflectDiff(e, a), e, a)
 	}
 }
@@ -3621,6 +3678,16 @@ func assertDeprovision(t *testing.T, action fakeosb.Action, request *osb.Deprovi
 	}
 }
 
+func assertGetInstance(t *testing.T, action fakeosb.Action, request *osbclient.GetInstanceRequest) {
+	if e, a := fakeosbclient.GetInstance, action.Type; e != a {
+		fatalf(t, "unexpected action type; expected %v, got %v", e, a)
+	}
+
+	if e, a := request, action.Request; !reflect.DeepEqual(e, a) {
+		fatalf(t, "unexpected diff in GET instance request: %v\nexpected %+v\ngot      %+v", diff.ObjectReflectDiff(e, a), e, a)
+	}
+}
+
 func assertPollLastOperation(t *testing.T, action fakeosb.Action, request *osb.LastOperationRequest) {
 	if e, a := fakeosb.PollLastOperation, action.Type; e != a {
 		fatalf(t, "unexpected action type; expected %v, got %v", e, a)
@@ -0,0 +1,68 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	servicecatalog "github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+)
+
+// TestDoctorReasons checks that the condition reasons diagnosed by svcat
+// doctor match the reasons set by the controller.
+func TestDoctorReasons(t *testing.T) {
+	reasons := []struct {
+		controller string
+		doctor     string
+	}{
+		{errorNonexistentClusterServiceClassReason, servicecatalog.ReasonNonexistentServiceClass},
+		{errorNonexistentServiceClassReason, servicecatalog.ReasonNonexistentServiceClass},
+		{errorNonexistentClusterServicePlanReason, servicecatalog.ReasonNonexistentServicePlan},
+		{errorNonexistentServicePlanReason, servicecatalog.ReasonNonexistentServicePlan},
+		{errorNonexistentClusterServiceBrokerReason, servicecatalog.ReasonNonexistentBroker},
+		{errorNonexistentServiceBrokerReason, servicecatalog.ReasonNonexistentBroker},
+		{errorNonexistentServiceInstanceReason, servicecatalog.ReasonNonexistentInstance},
+		{errorDeletedClusterServiceClassReason, servicecatalog.ReasonDeletedServiceClass},
+		{errorDeletedServiceClassReason, servicecatalog.ReasonDeletedServiceClass},
+		{errorDeletedClusterServicePlanReason, servicecatalog.ReasonDeletedServicePlan},
+		{errorDeletedServicePlanReason, servicecatalog.ReasonDeletedServicePlan},
+		{errorProvisionCallFailedReason, servicecatalog.ReasonProvisionCallFailed},
+		{errorErrorCallingProvisionReason, servicecatalog.ReasonErrorCallingProvision},
+		{errorUpdateInstanceCallFailedReason, servicecatalog.ReasonUpdateInstanceCallFailed},
+		{errorErrorCallingUpdateInstanceReason, servicecatalog.ReasonErrorCallingUpdateInstance},
+		{errorDeprovisionCalledReason, servicecatalog.ReasonDeprovisionCallFailed},
+		{errorDeprovisionBlockedByCredentialsReason, servicecatalog.ReasonDeprovisionBlocked},
+		{errorPollingLastOperationReason, servicecatalog.ReasonErrorPollingLastOperation},
+		{errorReconciliationRetryTimeoutReason, servicecatalog.ReasonReconciliationRetryTimeout},
+		{startingInstanceOrphanMitigationReason, servicecatalog.ReasonStartingOrphanMitigation},
+		{errorOrphanMitigationFailedReason, servicecatalog.ReasonOrphanMitigationFailed},
+		{errorBindCallReason, servicecatalog.ReasonBindCallFailed},
+		{errorInjectingBindResultReason, servicecatalog.ReasonErrorInjectingBindResult},
+		{errorNonbindableClusterServiceClassReason, servicecatalog.ReasonErrorNonbindableServiceClass},
+		{errorServiceInstanceNotReadyReason, servicecatalog.ReasonErrorInstanceNotReady},
+		{errorAsyncOpTimeoutReason, servicecatalog.ReasonAsyncOperationTimeout},
+		{errorFetchingCatalogReason, servicecatalog.ReasonErrorFetchingCatalog},
+		{errorSyncingCatalogReason, servicecatalog.ReasonErrorSyncingCatalog},
+		{errorAuthCredentialsReason, servicecatalog.ReasonErrorAuthCredentials},
+		{errorBrokerUnavailableReason, servicecatalog.ReasonBrokerUnavailable},
+	}
+	for _, r := range reasons {
+		if r.controller != r.doctor {
+			t.Errorf("svcat doctor diagnoses reason %q, but the controller sets %q", r.doctor, r.controller)
+		}
+	}
+}
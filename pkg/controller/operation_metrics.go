@@ -0,0 +1,144 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"strings"
+
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-incubator/service-catalog/pkg/metrics"
+)
+
+// The metrics about the operations on instances and bindings are derived from
+// the changes reported by the informers, so that they are kept in line with
+// the state of the resources whichever code path changed it.
+
+// instanceOperationMetricsHandler updates the operation metrics on changes to
+// ServiceInstances.
+var instanceOperationMetricsHandler = cache.ResourceEventHandlerFuncs{
+	AddFunc: func(obj interface{}) {
+		updateAsyncOperationsInProgress("", instanceAsyncOperation(obj))
+	},
+	UpdateFunc: func(oldObj, newObj interface{}) {
+		updateAsyncOperationsInProgress(instanceAsyncOperation(oldObj), instanceAsyncOperation(newObj))
+		oldInstance, ok := oldObj.(*v1beta1.ServiceInstance)
+		if !ok {
+			return
+		}
+		newInstance, ok := newObj.(*v1beta1.ServiceInstance)
+		if !ok {
+			return
+		}
+		// Count the failure when the Failed condition becomes true
+		_, wasFailed := instanceFailedReason(oldInstance)
+		if reason, failed := instanceFailedReason(newInstance); failed && !wasFailed {
+			metrics.TerminalFailureCount.WithLabelValues("ServiceInstance", reason).Inc()
+		}
+	},
+	DeleteFunc: func(obj interface{}) {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+		updateAsyncOperationsInProgress(instanceAsyncOperation(obj), "")
+	},
+}
+
+// bindingOperationMetricsHandler updates the operation metrics on changes to
+// ServiceBindings.
+var bindingOperationMetricsHandler = cache.ResourceEventHandlerFuncs{
+	AddFunc: func(obj interface{}) {
+		updateAsyncOperationsInProgress("", bindingAsyncOperation(obj))
+	},
+	UpdateFunc: func(oldObj, newObj interface{}) {
+		updateAsyncOperationsInProgress(bindingAsyncOperation(oldObj), bindingAsyncOperation(newObj))
+		oldBinding, ok := oldObj.(*v1beta1.ServiceBinding)
+		if !ok {
+			return
+		}
+		newBinding, ok := newObj.(*v1beta1.ServiceBinding)
+		if !ok {
+			return
+		}
+		// Count the failure when the Failed condition becomes true
+		_, wasFailed := bindingFailedReason(oldBinding)
+		if reason, failed := bindingFailedReason(newBinding); failed && !wasFailed {
+			metrics.TerminalFailureCount.WithLabelValues("ServiceBinding", reason).Inc()
+		}
+	},
+	DeleteFunc: func(obj interface{}) {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+		updateAsyncOperationsInProgress(bindingAsyncOperation(obj), "")
+	},
+}
+
+// instanceAsyncOperation returns the asynchronous operation in progress for
+// the given instance, or an empty string if there is none.
+func instanceAsyncOperation(obj interface{}) string {
+	instance, ok := obj.(*v1beta1.ServiceInstance)
+	if !ok || !instance.Status.AsyncOpInProgress {
+		return ""
+	}
+	return strings.ToLower(string(instance.Status.CurrentOperation))
+}
+
+// bindingAsyncOperation returns the asynchronous operation in progress for
+// the given binding, or an empty string if there is none.
+func bindingAsyncOperation(obj interface{}) string {
+	binding, ok := obj.(*v1beta1.ServiceBinding)
+	if !ok || !binding.Status.AsyncOpInProgress {
+		return ""
+	}
+	return strings.ToLower(string(binding.Status.CurrentOperation))
+}
+
+func updateAsyncOperationsInProgress(oldOperation, newOperation string) {
+	if oldOperation == newOperation {
+		return
+	}
+	if oldOperation != "" {
+		metrics.AsyncOperationsInProgress.WithLabelValues(oldOperation).Dec()
+	}
+	if newOperation != "" {
+		metrics.AsyncOperationsInProgress.WithLabelValues(newOperation).Inc()
+	}
+}
+
+// instanceFailedReason returns the reason of the Failed condition of the
+// given instance, and whether the status of the condition is true.
+func instanceFailedReason(instance *v1beta1.ServiceInstance) (string, bool) {
+	for _, cond := range instance.Status.Conditions {
+		if cond.Type == v1beta1.ServiceInstanceConditionFailed {
+			return cond.Reason, cond.Status == v1beta1.ConditionTrue
+		}
+	}
+	return "", false
+}
+
+// bindingFailedReason returns the reason of the Failed condition of the
+// given binding, and whether the status of the condition is true.
+func bindingFailedReason(binding *v1beta1.ServiceBinding) (string, bool) {
+	for _, cond := range binding.Status.Conditions {
+		if cond.Type == v1beta1.ServiceBindingConditionFailed {
+			return cond.Reason, cond.Status == v1beta1.ConditionTrue
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	dto "github.com/prometheus/client_model/go"
+
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-incubator/service-catalog/pkg/metrics"
+)
+
+func asyncOperationsInProgress(t *testing.T, operation string) float64 {
+	m := &dto.Metric{}
+	if err := metrics.AsyncOperationsInProgress.WithLabelValues(operation).Write(m); err != nil {
+		t.Fatalf("unexpected error reading metric: %v", err)
+	}
+	return m.GetGauge().GetValue()
+}
+
+func terminalFailureCount(t *testing.T, resource, reason string) float64 {
+	m := &dto.Metric{}
+	if err := metrics.TerminalFailureCount.WithLabelValues(resource, reason).Write(m); err != nil {
+		t.Fatalf("unexpected error reading metric: %v", err)
+	}
+	return m.GetCounter().GetValue()
+}
+
+func TestInstanceOperationMetricsHandler(t *testing.T) {
+	provisioning := getTestServiceInstanceAsyncProvisioning("")
+	deprovisioning := getTestServiceInstanceAsyncDeprovisioning("")
+	initialProvisions := asyncOperationsInProgress(t, "provision")
+	initialDeprovisions := asyncOperationsInProgress(t, "deprovision")
+
+	instanceOperationMetricsHandler.OnAdd(provisioning)
+	if e, a := initialProvisions+1, asyncOperationsInProgress(t, "provision"); e != a {
+		t.Fatalf("unexpected provisions in progress: %s", expectedGot(e, a))
+	}
+
+	// A resync does not change the metrics
+	instanceOperationMetricsHandler.OnUpdate(provisioning, provisioning)
+	if e, a := initialProvisions+1, asyncOperationsInProgress(t, "provision"); e != a {
+		t.Fatalf("unexpected provisions in progress after resync: %s", expectedGot(e, a))
+	}
+
+	instanceOperationMetricsHandler.OnUpdate(provisioning, deprovisioning)
+	if e, a := initialProvisions, asyncOperationsInProgress(t, "provision"); e != a {
+		t.Fatalf("unexpected provisions in progress: %s", expectedGot(e, a))
+	}
+	if e, a := initialDeprovisions+1, asyncOperationsInProgress(t, "deprovision"); e != a {
+		t.Fatalf("unexpected deprovisions in progress: %s", expectedGot(e, a))
+	}
+
+	instanceOperationMetricsHandler.OnDelete(cache.DeletedFinalStateUnknown{Key: "test-ns/test-instance", Obj: deprovisioning})
+	if e, a := initialDeprovisions, asyncOperationsInProgress(t, "deprovision"); e != a {
+		t.Fatalf("unexpected deprovisions in progress after delete: %s", expectedGot(e, a))
+	}
+}
+
+func TestInstanceOperationMetricsHandlerTerminalFailure(t *testing.T) {
+	instance := getTestServiceInstanceWithClusterRefs()
+	failed := instance.DeepCopy()
+	failed.Status.Conditions = []v1beta1.ServiceInstanceCondition{{
+		Type:   v1beta1.ServiceInstanceConditionFailed,
+		Status: v1beta1.ConditionTrue,
+		Reason: errorReconciliationRetryTimeoutReason,
+	}}
+	initialFailures := terminalFailureCount(t, "ServiceInstance", errorReconciliationRetryTimeoutReason)
+
+	instanceOperationMetricsHandler.OnUpdate(instance, failed)
+	instanceOperationMetricsHandler.OnUpdate(failed, failed)
+	if e, a := initialFailures+1, terminalFailureCount(t, "ServiceInstance", errorReconciliationRetryTimeoutReason); e != a {
+		t.Fatalf("unexpected terminal failures: %s", expectedGot(e, a))
+	}
+}
+
+func TestBindingOperationMetricsHandler(t *testing.T) {
+	binding := getTestServiceBinding()
+	binding.Status.AsyncOpInProgress = true
+	binding.Status.CurrentOperation = v1beta1.ServiceBindingOperationBind
+	failed := binding.DeepCopy()
+	failed.Status.AsyncOpInProgress = false
+	failed.Status.Conditions = []v1beta1.ServiceBindingCondition{{
+		Type:   v1beta1.ServiceBindingConditionFailed,
+		Status: v1beta1.ConditionTrue,
+		Reason: errorBindCallReason,
+	}}
+	initialBinds := asyncOperationsInProgress(t, "bind")
+	initialFailures := terminalFailureCount(t, "ServiceBinding", errorBindCallReason)
+
+	bindingOperationMetricsHandler.OnAdd(binding)
+	if e, a := initialBinds+1, asyncOperationsInProgress(t, "bind"); e != a {
+		t.Fatalf("unexpected binds in progress: %s", expectedGot(e, a))
+	}
+
+	bindingOperationMetricsHandler.OnUpdate(binding, failed)
+	if e, a := initialBinds, asyncOperationsInProgress(t, "bind"); e != a {
+		t.Fatalf("unexpected binds in progress: %s", expectedGot(e, a))
+	}
+	if e, a := initialFailures+1, terminalFailureCount(t, "ServiceBinding", errorBindCallReason); e != a {
+		t.Fatalf("unexpected terminal failures: %s", expectedGot(e, a))
+	}
+}
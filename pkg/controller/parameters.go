@@ -29,6 +29,10 @@ import (
 	"k8s.io/client-go/kubernetes"
 )
 
+// redactedParameterValue replaces the values of parameters that come from
+// secrets wherever the parameters are stored.
+const redactedParameterValue = "<redacted>"
+
 // buildParameters generates the parameters JSON structure to be passed
 // to the broker.
 // The first return value is a map of parameters to send to the Broker, including
@@ -50,7 +54,7 @@ func buildParameters(kubeClient kubernetes.Interface, namespace string, paramete
 					return nil, nil, fmt.Errorf("conflict: duplicate entry for parameter %q", k)
 				}
 				params[k] = v
-				paramsWithSecretsRedacted[k] = "<redacted>"
+				paramsWithSecretsRedacted[k] = redactedParameterValue
 			}
 		}
 	}
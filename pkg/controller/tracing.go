@@ -0,0 +1,71 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sync"
+
+	opentracing "github.com/opentracing/opentracing-go"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/pretty"
+	"github.com/kubernetes-incubator/service-catalog/pkg/tracing"
+)
+
+// reconcileSpans holds the spans of the reconciliations in progress, so that
+// the requests sent to a broker while reconciling a resource are traced under
+// the span of the reconciliation. The work queues never hand out a key to two
+// workers at once, so a resource has at most one reconciliation in progress.
+type reconcileSpans struct {
+	mutex sync.Mutex
+	spans map[string]opentracing.Span // Key is kind/namespace/name
+}
+
+func reconcileSpanKey(kind pretty.Kind, namespace, name string) string {
+	return kind.String() + "/" + namespace + "/" + name
+}
+
+// start starts the span of the reconciliation of a resource with the global
+// tracer, which does not record it unless tracing is enabled.
+func (r *reconcileSpans) start(kind pretty.Kind, namespace, name string) opentracing.Span {
+	span := opentracing.StartSpan("Reconcile " + kind.String())
+	if namespace != "" {
+		span.SetTag("namespace", namespace)
+	}
+	span.SetTag("name", name)
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.spans[reconcileSpanKey(kind, namespace, name)] = span
+	return span
+}
+
+// finish ends the span of the reconciliation of a resource with the error
+// the reconciliation failed with, if any.
+func (r *reconcileSpans) finish(kind pretty.Kind, namespace, name string, span opentracing.Span, err error) {
+	r.mutex.Lock()
+	delete(r.spans, reconcileSpanKey(kind, namespace, name))
+	r.mutex.Unlock()
+	tracing.FinishSpan(span, err)
+}
+
+// get returns the span of the reconciliation in progress of a resource, or
+// nil if there is none.
+func (r *reconcileSpans) get(kind pretty.Kind, namespace, name string) opentracing.Span {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.spans[reconcileSpanKey(kind, namespace, name)]
+}
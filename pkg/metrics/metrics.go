@@ -29,6 +29,9 @@ import (
 
 var registerMetrics sync.Once
 
+// registry holds the Service Catalog metrics exposed at /metrics.
+var registry = prometheus.NewRegistry()
+
 const (
 	catalogNamespace = "servicecatalog" // Prometheus namespace (nothing to do with k8s namespace)
 )
@@ -72,6 +75,56 @@ var (
 		},
 		[]string{"broker", "method", "status"},
 	)
+
+	// OSBRequestQueueWaitSeconds exposes the time that requests to Open
+	// Service Brokers are delayed by the request limits of the broker.  The
+	// metric is broken out by broker, namespace/name for namespaced brokers.
+	OSBRequestQueueWaitSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: catalogNamespace,
+			Name:      "osb_request_queue_wait_seconds",
+			Help:      "Time in seconds that requests from the OSB Client to the specified Service Broker were delayed by the request limits of the broker.",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 4, 10),
+		},
+		[]string{"broker"},
+	)
+
+	// OSBRequestDurationSeconds exposes the latency of the HTTP requests made
+	// to Open Service Brokers.  The metric is broken out by broker name and
+	// broker method.
+	OSBRequestDurationSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: catalogNamespace,
+			Name:      "osb_request_duration_seconds",
+			Help:      "Latency in seconds of HTTP requests from the OSB Client to the specified Service Broker grouped by broker name and broker method.",
+			Buckets:   prometheus.ExponentialBuckets(0.01, 2, 14),
+		},
+		[]string{"broker", "method"},
+	)
+
+	// AsyncOperationsInProgress exposes the number of asynchronous operations
+	// that brokers are currently performing.  The metric is broken out by
+	// operation (provision/update/deprovision/bind/unbind).
+	AsyncOperationsInProgress = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: catalogNamespace,
+			Name:      "async_operations_in_progress",
+			Help:      "Number of asynchronous operations in progress at Service Brokers grouped by operation.",
+		},
+		[]string{"operation"},
+	)
+
+	// TerminalFailureCount exposes the number of operations on instances and
+	// bindings that failed and will not be retried.  The metric is broken out
+	// by resource type and failure reason.
+	TerminalFailureCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: catalogNamespace,
+			Name:      "terminal_failure_count",
+			Help:      "Cumulative number of operations on ServiceInstances and ServiceBindings that failed without being retried grouped by resource type and reason.",
+		},
+		[]string{"resource", "reason"},
+	)
 )
 
 func register(registry *prometheus.Registry) {
@@ -79,6 +132,11 @@ func register(registry *prometheus.Registry) {
 		registry.MustRegister(BrokerServiceClassCount)
 		registry.MustRegister(BrokerServicePlanCount)
 		registry.MustRegister(OSBRequestCount)
+		registry.MustRegister(OSBRequestQueueWaitSeconds)
+		registry.MustRegister(OSBRequestDurationSeconds)
+		registry.MustRegister(AsyncOperationsInProgress)
+		registry.MustRegister(TerminalFailureCount)
+		registerWorkqueueMetrics(registry)
 	})
 }
 
@@ -86,8 +144,13 @@ func register(registry *prometheus.Registry) {
 // objects with Prometheus and installs the Prometheus http handler at the
 // default context.
 func RegisterMetricsAndInstallHandler(m *http.ServeMux) {
-	registry := prometheus.NewRegistry()
 	register(registry)
 	m.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{ErrorHandling: promhttp.ContinueOnError}))
 	glog.V(4).Info("Registered /metrics with prometheus")
 }
+
+// RegisterCollector registers an additional collector of Service Catalog
+// metrics, such as a collector that needs the informers of the controller.
+func RegisterCollector(collector prometheus.Collector) error {
+	return registry.Register(collector)
+}
@@ -15,14 +15,18 @@ limitations under the License.
 */
 
 // Package osbclientproxy proxies the OSB Client Library enabling
-// metrics instrumentation
+// metrics instrumentation and tracing
 package osbclientproxy
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	"github.com/golang/glog"
 	"github.com/kubernetes-incubator/service-catalog/pkg/metrics"
+	"github.com/kubernetes-incubator/service-catalog/pkg/osbclient"
+	"github.com/kubernetes-incubator/service-catalog/pkg/tracing"
 	osb "github.com/pmorie/go-open-service-broker-client/v2"
 )
 
@@ -30,22 +34,25 @@ import (
 // interface
 type proxyclient struct {
 	brokerName    string
-	realOSBClient osb.Client
+	realOSBClient osbclient.Client
+	// ctx is the context of the requests to the broker, whose span the
+	// requests are traced under
+	ctx context.Context
 }
 
 // NewClient is a CreateFunc for creating a new functional Client and
 // implements the CreateFunc interface.
-func NewClient(config *osb.ClientConfiguration) (osb.Client, error) {
-	osbClient, err := osb.NewClient(config)
+func NewClient(config *osb.ClientConfiguration) (osbclient.Client, error) {
+	osbClient, err := osbclient.NewClient(config)
 	if err != nil {
 		return nil, err
 	}
-	proxy := proxyclient{realOSBClient: osbClient}
+	proxy := proxyclient{realOSBClient: osbClient, ctx: context.Background()}
 	proxy.brokerName = config.Name
 	return proxy, nil
 }
 
-var _ osb.CreateFunc = NewClient
+var _ osbclient.CreateFunc = NewClient
 
 const (
 	getCatalog               = "GetCatalog"
@@ -57,25 +64,32 @@ const (
 	bind                     = "Bind"
 	unbind                   = "Unbind"
 	getBinding               = "GetBinding"
+	getInstance              = "GetInstance"
 )
 
 // GetCatalog implements go-open-service-broker-client/v2/Client.GetCatalog by
 // proxying the method to the underlying implementation and capturing request
 // metrics.
-func (pc proxyclient) GetCatalog() (*osb.CatalogResponse, error) {
+func (pc proxyclient) GetCatalog() (*osbclient.CatalogResponse, error) {
 	glog.V(9).Info("OSBClientProxy getCatalog()")
-	response, err := pc.realOSBClient.GetCatalog()
-	pc.updateMetrics(getCatalog, err)
+	start := time.Now()
+	span, client := pc.startSpan(getCatalog)
+	response, err := client.GetCatalog()
+	pc.updateMetrics(getCatalog, start, err)
+	tracing.FinishSpan(span, err)
 	return response, err
 }
 
 // ProvisionInstance implements
 // go-open-service-broker-client/v2/Client.ProvisionInstance by proxying the
 // method to the underlying implementation and capturing request metrics.
-func (pc proxyclient) ProvisionInstance(r *osb.ProvisionRequest) (*osb.ProvisionResponse, error) {
+func (pc proxyclient) ProvisionInstance(r *osbclient.ProvisionRequest) (*osb.ProvisionResponse, error) {
 	glog.V(9).Info("OSBClientProxy ProvisionInstance()")
-	response, err := pc.realOSBClient.ProvisionInstance(r)
-	pc.updateMetrics(provisionInstance, err)
+	start := time.Now()
+	span, client := pc.startSpan(provisionInstance)
+	response, err := client.ProvisionInstance(r)
+	pc.updateMetrics(provisionInstance, start, err)
+	tracing.FinishSpan(span, err)
 	return response, err
 
 }
@@ -83,10 +97,13 @@ func (pc proxyclient) ProvisionInstance(r *osb.ProvisionRequest) (*osb.Provision
 // UpdateInstance implements
 // go-open-service-broker-client/v2/Client.UpdateInstance by proxying the method
 // to the underlying implementation and capturing request metrics.
-func (pc proxyclient) UpdateInstance(r *osb.UpdateInstanceRequest) (*osb.UpdateInstanceResponse, error) {
+func (pc proxyclient) UpdateInstance(r *osbclient.UpdateInstanceRequest) (*osb.UpdateInstanceResponse, error) {
 	glog.V(9).Info("OSBClientProxy UpdateInstance()")
-	response, err := pc.realOSBClient.UpdateInstance(r)
-	pc.updateMetrics(updateInstance, err)
+	start := time.Now()
+	span, client := pc.startSpan(updateInstance)
+	response, err := client.UpdateInstance(r)
+	pc.updateMetrics(updateInstance, start, err)
+	tracing.FinishSpan(span, err)
 	return response, err
 }
 
@@ -95,8 +112,11 @@ func (pc proxyclient) UpdateInstance(r *osb.UpdateInstanceRequest) (*osb.UpdateI
 // method to the underlying implementation and capturing request metrics.
 func (pc proxyclient) DeprovisionInstance(r *osb.DeprovisionRequest) (*osb.DeprovisionResponse, error) {
 	glog.V(9).Info("OSBClientProxy DeprovisionInstance()")
-	response, err := pc.realOSBClient.DeprovisionInstance(r)
-	pc.updateMetrics(deprovisionInstance, err)
+	start := time.Now()
+	span, client := pc.startSpan(deprovisionInstance)
+	response, err := client.DeprovisionInstance(r)
+	pc.updateMetrics(deprovisionInstance, start, err)
+	tracing.FinishSpan(span, err)
 	return response, err
 }
 
@@ -105,8 +125,11 @@ func (pc proxyclient) DeprovisionInstance(r *osb.DeprovisionRequest) (*osb.Depro
 // method to the underlying implementation and capturing request metrics.
 func (pc proxyclient) PollLastOperation(r *osb.LastOperationRequest) (*osb.LastOperationResponse, error) {
 	glog.V(9).Info("OSBClientProxy PollLastOperation()")
-	response, err := pc.realOSBClient.PollLastOperation(r)
-	pc.updateMetrics(pollLastOperation, err)
+	start := time.Now()
+	span, client := pc.startSpan(pollLastOperation)
+	response, err := client.PollLastOperation(r)
+	pc.updateMetrics(pollLastOperation, start, err)
+	tracing.FinishSpan(span, err)
 	return response, err
 }
 
@@ -115,8 +138,11 @@ func (pc proxyclient) PollLastOperation(r *osb.LastOperationRequest) (*osb.LastO
 // the method to the underlying implementation and capturing request metrics.
 func (pc proxyclient) PollBindingLastOperation(r *osb.BindingLastOperationRequest) (*osb.LastOperationResponse, error) {
 	glog.V(9).Info("OSBClientProxy PollBindingLastOperation()")
-	response, err := pc.realOSBClient.PollBindingLastOperation(r)
-	pc.updateMetrics(pollBindingLastOperation, err)
+	start := time.Now()
+	span, client := pc.startSpan(pollBindingLastOperation)
+	response, err := client.PollBindingLastOperation(r)
+	pc.updateMetrics(pollBindingLastOperation, start, err)
+	tracing.FinishSpan(span, err)
 	return response, err
 }
 
@@ -124,8 +150,11 @@ func (pc proxyclient) PollBindingLastOperation(r *osb.BindingLastOperationReques
 // method to the underlying implementation and capturing request metrics.
 func (pc proxyclient) Bind(r *osb.BindRequest) (*osb.BindResponse, error) {
 	glog.V(9).Info("OSBClientProxy Bind().")
-	response, err := pc.realOSBClient.Bind(r)
-	pc.updateMetrics(bind, err)
+	start := time.Now()
+	span, client := pc.startSpan(bind)
+	response, err := client.Bind(r)
+	pc.updateMetrics(bind, start, err)
+	tracing.FinishSpan(span, err)
 	return response, err
 }
 
@@ -133,8 +162,11 @@ func (pc proxyclient) Bind(r *osb.BindRequest) (*osb.BindResponse, error) {
 // the method to the underlying implementation and capturing request metrics.
 func (pc proxyclient) Unbind(r *osb.UnbindRequest) (*osb.UnbindResponse, error) {
 	glog.V(9).Info("OSBClientProxy Unbind()")
-	response, err := pc.realOSBClient.Unbind(r)
-	pc.updateMetrics(unbind, err)
+	start := time.Now()
+	span, client := pc.startSpan(unbind)
+	response, err := client.Unbind(r)
+	pc.updateMetrics(unbind, start, err)
+	tracing.FinishSpan(span, err)
 	return response, err
 }
 
@@ -143,18 +175,36 @@ func (pc proxyclient) Unbind(r *osb.UnbindRequest) (*osb.UnbindResponse, error)
 // metrics.
 func (pc proxyclient) GetBinding(r *osb.GetBindingRequest) (*osb.GetBindingResponse, error) {
 	glog.V(9).Info("OSBClientProxy GetBinding()")
-	response, err := pc.realOSBClient.GetBinding(r)
-	pc.updateMetrics(getBinding, err)
+	start := time.Now()
+	span, client := pc.startSpan(getBinding)
+	response, err := client.GetBinding(r)
+	pc.updateMetrics(getBinding, start, err)
+	tracing.FinishSpan(span, err)
+	return response, err
+}
+
+// GetInstance implements go-open-service-broker-client/v2/Client.GetInstance by
+// proxying the method to the underlying implementation and capturing request
+// metrics.
+func (pc proxyclient) GetInstance(r *osbclient.GetInstanceRequest) (*osbclient.GetInstanceResponse, error) {
+	glog.V(9).Info("OSBClientProxy GetInstance()")
+	start := time.Now()
+	span, client := pc.startSpan(getInstance)
+	response, err := client.GetInstance(r)
+	pc.updateMetrics(getInstance, start, err)
+	tracing.FinishSpan(span, err)
 	return response, err
 }
 
 const clientErr = "client-error"
 
 // updateMetrics bumps the request count metric for the specific broker, method
-// and status
-func (pc proxyclient) updateMetrics(method string, err error) {
+// and status, and records the latency of the request started at the given time
+func (pc proxyclient) updateMetrics(method string, start time.Time, err error) {
 	var statusGroup string
 
+	metrics.OSBRequestDurationSeconds.WithLabelValues(pc.brokerName, method).Observe(time.Since(start).Seconds())
+
 	// for this metric, lack of an error translates into a 2xx status
 	if err == nil {
 		metrics.OSBRequestCount.WithLabelValues(pc.brokerName, method, "2xx").Inc()
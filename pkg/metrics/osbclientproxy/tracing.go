@@ -0,0 +1,48 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osbclientproxy
+
+import (
+	"context"
+
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/osbclient"
+)
+
+var _ osbclient.ContextClient = proxyclient{}
+
+// WithContext returns a copy of the client whose requests to the broker are
+// traced as children of the span of the given context, if any.
+func (pc proxyclient) WithContext(ctx context.Context) osbclient.Client {
+	pc.ctx = ctx
+	return pc
+}
+
+// startSpan starts the span of a request to the broker. It returns the span
+// and the client to send the request with, which propagates the span to the
+// broker. Each request carries its own span in its context, so that the
+// requests sent concurrently through a client do not share a span.
+func (pc proxyclient) startSpan(method string) (opentracing.Span, osbclient.Client) {
+	span, ctx := opentracing.StartSpanFromContext(pc.ctx, "OSB "+method, ext.SpanKindRPCClient)
+	span.SetTag("broker", pc.brokerName)
+	if contextClient, ok := pc.realOSBClient.(osbclient.ContextClient); ok {
+		return span, contextClient.WithContext(ctx)
+	}
+	return span, pc.realOSBClient
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osbclientproxy
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+
+	basictracer "github.com/opentracing/basictracer-go"
+	opentracing "github.com/opentracing/opentracing-go"
+	osb "github.com/pmorie/go-open-service-broker-client/v2"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/osbclient"
+	"github.com/kubernetes-incubator/service-catalog/pkg/tracing"
+)
+
+// The header in which basictracer-go propagates the span ID of a request
+const spanIDHeader = "Ot-Tracer-Spanid"
+
+// setTestTracer sets a global tracer that records the finished spans in
+// memory, and returns its recorder and the function that restores the
+// global tracer.
+func setTestTracer() (*basictracer.InMemorySpanRecorder, func()) {
+	recorder := basictracer.NewInMemoryRecorder()
+	previous := opentracing.GlobalTracer()
+	opentracing.SetGlobalTracer(tracing.NewRecordingTracer(recorder))
+	return recorder, func() { opentracing.SetGlobalTracer(previous) }
+}
+
+func newTestClient(t *testing.T, url string) osbclient.ContextClient {
+	config := osb.DefaultClientConfiguration()
+	config.Name = "test-broker"
+	config.URL = url
+	client, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return client.(osbclient.ContextClient)
+}
+
+func TestTracedRequest(t *testing.T) {
+	recorder, restore := setTestTracer()
+	defer restore()
+
+	var spanID string
+	broker := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		spanID = r.Header.Get(spanIDHeader)
+		w.Write([]byte(`{"services": []}`))
+	}))
+	defer broker.Close()
+
+	parent := opentracing.StartSpan("Reconcile ClusterServiceBroker")
+	client := newTestClient(t, broker.URL).WithContext(opentracing.ContextWithSpan(context.Background(), parent))
+
+	if _, err := client.GetCatalog(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	spans := recorder.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %v", len(spans))
+	}
+	span := spans[0]
+	if e, a := "OSB GetCatalog", span.Operation; e != a {
+		t.Fatalf("expected the name of the span to be %q, got %q", e, a)
+	}
+	if e, a := "test-broker", span.Tags["broker"]; e != a {
+		t.Fatalf("expected the broker tag of the span to be %q, got %q", e, a)
+	}
+	if e, a := parent.Context().(basictracer.SpanContext).SpanID, span.ParentSpanID; e != a {
+		t.Fatalf("expected the span to be a child of the reconcile span %v, got %v", e, a)
+	}
+	if e, a := strconv.FormatUint(span.Context.SpanID, 16), spanID; e != a {
+		t.Fatalf("expected the broker to receive the span %q, got %q", e, a)
+	}
+}
+
+// TestConcurrentTracedRequests tests that the requests sent concurrently
+// through a client each propagate their own span to the broker.
+func TestConcurrentTracedRequests(t *testing.T) {
+	recorder, restore := setTestTracer()
+	defer restore()
+
+	const requests = 10
+	var mutex sync.Mutex
+	receivedSpanIDs := map[string]bool{}
+	broker := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		receivedSpanIDs[r.Header.Get(spanIDHeader)] = true
+		mutex.Unlock()
+		w.Write([]byte(`{"services": []}`))
+	}))
+	defer broker.Close()
+
+	parent := opentracing.StartSpan("Reconcile ClusterServiceBroker")
+	client := newTestClient(t, broker.URL).WithContext(opentracing.ContextWithSpan(context.Background(), parent))
+
+	var wg sync.WaitGroup
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.GetCatalog(); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	spans := recorder.GetSpans()
+	if len(spans) != requests {
+		t.Fatalf("expected %v spans, got %v", requests, len(spans))
+	}
+	for _, span := range spans {
+		if spanID := strconv.FormatUint(span.Context.SpanID, 16); !receivedSpanIDs[spanID] {
+			t.Errorf("expected the broker to receive the span %q, got %v", spanID, receivedSpanIDs)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	listers "github.com/kubernetes-incubator/service-catalog/pkg/client/listers_generated/servicecatalog/v1beta1"
+)
+
+var (
+	serviceInstanceInfo = prometheus.NewDesc(
+		prometheus.BuildFQName(catalogNamespace, "", "service_instance_info"),
+		"State of a ServiceInstance: its class, plan and broker, the status of its Ready and Failed conditions and its current operation.",
+		[]string{"namespace", "name", "class", "plan", "broker", "ready", "failed", "operation"},
+		nil,
+	)
+	serviceInstanceOperationAgeSeconds = prometheus.NewDesc(
+		prometheus.BuildFQName(catalogNamespace, "", "service_instance_operation_age_seconds"),
+		"Time in seconds since the current operation of a ServiceInstance started.",
+		[]string{"namespace", "name", "operation"},
+		nil,
+	)
+	serviceBindingInfo = prometheus.NewDesc(
+		prometheus.BuildFQName(catalogNamespace, "", "service_binding_info"),
+		"State of a ServiceBinding: its instance, the class, plan and broker of the instance, the status of its Ready and Failed conditions and its current operation.",
+		[]string{"namespace", "name", "instance", "class", "plan", "broker", "ready", "failed", "operation"},
+		nil,
+	)
+	serviceBindingOperationAgeSeconds = prometheus.NewDesc(
+		prometheus.BuildFQName(catalogNamespace, "", "service_binding_operation_age_seconds"),
+		"Time in seconds since the current operation of a ServiceBinding started.",
+		[]string{"namespace", "name", "operation"},
+		nil,
+	)
+)
+
+// ResourceListers are the listers that the resource collector reads the
+// ServiceInstances and ServiceBindings, and their classes and plans from. The
+// listers of the namespaced classes and plans are optional.
+type ResourceListers struct {
+	ServiceInstances      listers.ServiceInstanceLister
+	ServiceBindings       listers.ServiceBindingLister
+	ClusterServiceClasses listers.ClusterServiceClassLister
+	ClusterServicePlans   listers.ClusterServicePlanLister
+	ServiceClasses        listers.ServiceClassLister
+	ServicePlans          listers.ServicePlanLister
+}
+
+// resourceCollector exports the state of every ServiceInstance and
+// ServiceBinding known to the controller each time the metrics are scraped.
+type resourceCollector struct {
+	listers ResourceListers
+}
+
+// NewResourceCollector creates a collector of the state of the
+// ServiceInstances and ServiceBindings read from the given listers.
+func NewResourceCollector(listers ResourceListers) prometheus.Collector {
+	return &resourceCollector{listers: listers}
+}
+
+// Describe implements prometheus.Collector.
+func (c *resourceCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- serviceInstanceInfo
+	ch <- serviceInstanceOperationAgeSeconds
+	ch <- serviceBindingInfo
+	ch <- serviceBindingOperationAgeSeconds
+}
+
+// Collect implements prometheus.Collector.
+func (c *resourceCollector) Collect(ch chan<- prometheus.Metric) {
+	now := time.Now()
+
+	instances, err := c.listers.ServiceInstances.List(labels.Everything())
+	if err != nil {
+		glog.Errorf("Error listing ServiceInstances for metrics: %v", err)
+		return
+	}
+	// The plans of the instances, by namespace/name, for their bindings
+	instancePlans := make(map[string]planLabels, len(instances))
+	for _, instance := range instances {
+		plan := c.instancePlanLabels(instance)
+		instancePlans[instance.Namespace+"/"+instance.Name] = plan
+
+		var ready, failed v1beta1.ConditionStatus
+		for _, cond := range instance.Status.Conditions {
+			switch cond.Type {
+			case v1beta1.ServiceInstanceConditionReady:
+				ready = cond.Status
+			case v1beta1.ServiceInstanceConditionFailed:
+				failed = cond.Status
+			}
+		}
+		operation := strings.ToLower(string(instance.Status.CurrentOperation))
+		ch <- prometheus.MustNewConstMetric(
+			serviceInstanceInfo, prometheus.GaugeValue, 1,
+			instance.Namespace, instance.Name, plan.class, plan.plan, plan.broker,
+			conditionStatusLabel(ready, v1beta1.ConditionUnknown), conditionStatusLabel(failed, v1beta1.ConditionFalse), operation,
+		)
+		if operation != "" && instance.Status.OperationStartTime != nil {
+			ch <- prometheus.MustNewConstMetric(
+				serviceInstanceOperationAgeSeconds, prometheus.GaugeValue, now.Sub(instance.Status.OperationStartTime.Time).Seconds(),
+				instance.Namespace, instance.Name, operation,
+			)
+		}
+	}
+
+	bindings, err := c.listers.ServiceBindings.List(labels.Everything())
+	if err != nil {
+		glog.Errorf("Error listing ServiceBindings for metrics: %v", err)
+		return
+	}
+	for _, binding := range bindings {
+		plan := instancePlans[binding.Namespace+"/"+binding.Spec.ServiceInstanceRef.Name]
+
+		var ready, failed v1beta1.ConditionStatus
+		for _, cond := range binding.Status.Conditions {
+			switch cond.Type {
+			case v1beta1.ServiceBindingConditionReady:
+				ready = cond.Status
+			case v1beta1.ServiceBindingConditionFailed:
+				failed = cond.Status
+			}
+		}
+		operation := strings.ToLower(string(binding.Status.CurrentOperation))
+		ch <- prometheus.MustNewConstMetric(
+			serviceBindingInfo, prometheus.GaugeValue, 1,
+			binding.Namespace, binding.Name, binding.Spec.ServiceInstanceRef.Name, plan.class, plan.plan, plan.broker,
+			conditionStatusLabel(ready, v1beta1.ConditionUnknown), conditionStatusLabel(failed, v1beta1.ConditionFalse), operation,
+		)
+		if operation != "" && binding.Status.OperationStartTime != nil {
+			ch <- prometheus.MustNewConstMetric(
+				serviceBindingOperationAgeSeconds, prometheus.GaugeValue, now.Sub(binding.Status.OperationStartTime.Time).Seconds(),
+				binding.Namespace, binding.Name, operation,
+			)
+		}
+	}
+}
+
+// conditionStatusLabel returns the label value for the status of a
+// condition, using the given default when the condition is not set.
+func conditionStatusLabel(status, defaultStatus v1beta1.ConditionStatus) string {
+	if status == "" {
+		status = defaultStatus
+	}
+	return string(status)
+}
+
+// planLabels are the labels identifying the plan of an instance.
+type planLabels struct {
+	class  string
+	plan   string
+	broker string
+}
+
+// instancePlanLabels resolves the external names of the class and plan of an
+// instance and the name of their broker. The external names from the spec of
+// the instance are used when the class or plan is not known.
+func (c *resourceCollector) instancePlanLabels(instance *v1beta1.ServiceInstance) planLabels {
+	spec := instance.Spec
+	switch {
+	case spec.ClusterServiceClassSpecified():
+		l := planLabels{class: spec.ClusterServiceClassExternalName, plan: spec.ClusterServicePlanExternalName}
+		if spec.ClusterServiceClassRef != nil && c.listers.ClusterServiceClasses != nil {
+			if class, err := c.listers.ClusterServiceClasses.Get(spec.ClusterServiceClassRef.Name); err == nil {
+				l.class = class.Spec.ExternalName
+				l.broker = class.Spec.ClusterServiceBrokerName
+			}
+		}
+		if spec.ClusterServicePlanRef != nil && c.listers.ClusterServicePlans != nil {
+			if plan, err := c.listers.ClusterServicePlans.Get(spec.ClusterServicePlanRef.Name); err == nil {
+				l.plan = plan.Spec.ExternalName
+			}
+		}
+		return l
+	case spec.ServiceClassSpecified():
+		l := planLabels{class: spec.ServiceClassExternalName, plan: spec.ServicePlanExternalName}
+		if spec.ServiceClassRef != nil && c.listers.ServiceClasses != nil {
+			if class, err := c.listers.ServiceClasses.ServiceClasses(instance.Namespace).Get(spec.ServiceClassRef.Name); err == nil {
+				l.class = class.Spec.ExternalName
+				l.broker = class.Spec.ServiceBrokerName
+			}
+		}
+		if spec.ServicePlanRef != nil && c.listers.ServicePlans != nil {
+			if plan, err := c.listers.ServicePlans.ServicePlans(instance.Namespace).Get(spec.ServicePlanRef.Name); err == nil {
+				l.plan = plan.Spec.ExternalName
+			}
+		}
+		return l
+	}
+	return planLabels{}
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	listers "github.com/kubernetes-incubator/service-catalog/pkg/client/listers_generated/servicecatalog/v1beta1"
+)
+
+func newTestIndexer(t *testing.T, objs ...interface{}) cache.Indexer {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, obj := range objs {
+		if err := indexer.Add(obj); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	return indexer
+}
+
+func TestResourceCollector(t *testing.T) {
+	operationStartTime := metav1.NewTime(time.Now().Add(-time.Minute))
+	class := &v1beta1.ClusterServiceClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "class-uuid"},
+		Spec: v1beta1.ClusterServiceClassSpec{
+			ClusterServiceBrokerName: "test-broker",
+			CommonServiceClassSpec:   v1beta1.CommonServiceClassSpec{ExternalName: "mysqldb"},
+		},
+	}
+	plan := &v1beta1.ClusterServicePlan{
+		ObjectMeta: metav1.ObjectMeta{Name: "plan-uuid"},
+		Spec: v1beta1.ClusterServicePlanSpec{
+			CommonServicePlanSpec: v1beta1.CommonServicePlanSpec{ExternalName: "free"},
+		},
+	}
+	instance := &v1beta1.ServiceInstance{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-instance", Namespace: "test-ns"},
+		Spec: v1beta1.ServiceInstanceSpec{
+			PlanReference: v1beta1.PlanReference{
+				ClusterServiceClassName: "class-uuid",
+				ClusterServicePlanName:  "plan-uuid",
+			},
+			ClusterServiceClassRef: &v1beta1.ClusterObjectReference{Name: "class-uuid"},
+			ClusterServicePlanRef:  &v1beta1.ClusterObjectReference{Name: "plan-uuid"},
+		},
+		Status: v1beta1.ServiceInstanceStatus{
+			Conditions: []v1beta1.ServiceInstanceCondition{
+				{Type: v1beta1.ServiceInstanceConditionReady, Status: v1beta1.ConditionFalse},
+			},
+			CurrentOperation:   v1beta1.ServiceInstanceOperationProvision,
+			OperationStartTime: &operationStartTime,
+		},
+	}
+	binding := &v1beta1.ServiceBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-binding", Namespace: "test-ns"},
+		Spec: v1beta1.ServiceBindingSpec{
+			ServiceInstanceRef: v1beta1.LocalObjectReference{Name: "test-instance"},
+		},
+		Status: v1beta1.ServiceBindingStatus{
+			Conditions: []v1beta1.ServiceBindingCondition{
+				{Type: v1beta1.ServiceBindingConditionReady, Status: v1beta1.ConditionTrue},
+			},
+		},
+	}
+
+	registry := prometheus.NewPedanticRegistry()
+	registry.MustRegister(NewResourceCollector(ResourceListers{
+		ServiceInstances:      listers.NewServiceInstanceLister(newTestIndexer(t, instance)),
+		ServiceBindings:       listers.NewServiceBindingLister(newTestIndexer(t, binding)),
+		ClusterServiceClasses: listers.NewClusterServiceClassLister(newTestIndexer(t, class)),
+		ClusterServicePlans:   listers.NewClusterServicePlanLister(newTestIndexer(t, plan)),
+	}))
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	metrics := make(map[string][]*dto.Metric)
+	for _, family := range families {
+		metrics[family.GetName()] = family.GetMetric()
+	}
+
+	cases := []struct {
+		name   string
+		labels map[string]string
+	}{
+		{
+			name: "servicecatalog_service_instance_info",
+			labels: map[string]string{
+				"namespace": "test-ns",
+				"name":      "test-instance",
+				"class":     "mysqldb",
+				"plan":      "free",
+				"broker":    "test-broker",
+				"ready":     "False",
+				"failed":    "False",
+				"operation": "provision",
+			},
+		},
+		{
+			name: "servicecatalog_service_binding_info",
+			labels: map[string]string{
+				"namespace": "test-ns",
+				"name":      "test-binding",
+				"instance":  "test-instance",
+				"class":     "mysqldb",
+				"plan":      "free",
+				"broker":    "test-broker",
+				"ready":     "True",
+				"failed":    "False",
+				"operation": "",
+			},
+		},
+	}
+	for _, tc := range cases {
+		if len(metrics[tc.name]) != 1 {
+			t.Fatalf("%v: expected 1 series, got %v", tc.name, len(metrics[tc.name]))
+		}
+		for _, label := range metrics[tc.name][0].GetLabel() {
+			if e, a := tc.labels[label.GetName()], label.GetValue(); e != a {
+				t.Errorf("%v: unexpected value of label %q: expected %q, got %q", tc.name, label.GetName(), e, a)
+			}
+		}
+	}
+
+	age := metrics["servicecatalog_service_instance_operation_age_seconds"]
+	if len(age) != 1 || age[0].GetGauge().GetValue() < time.Minute.Seconds() {
+		t.Errorf("expected the age of the provision operation to be at least a minute, got %v", age)
+	}
+	if _, ok := metrics["servicecatalog_service_binding_operation_age_seconds"]; ok {
+		t.Error("expected no operation age for a binding without a current operation")
+	}
+}
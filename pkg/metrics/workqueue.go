@@ -0,0 +1,121 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// The workqueue metrics are broken out by queue name; the controller names
+// its queues after the resource they hold (service-instance,
+// instance-poller, cluster-service-broker, ...).
+var (
+	// WorkqueueDepth exposes the number of items waiting in each workqueue.
+	WorkqueueDepth = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: catalogNamespace,
+			Subsystem: "workqueue",
+			Name:      "depth",
+			Help:      "Current number of items waiting in the workqueue.",
+		},
+		[]string{"queue"},
+	)
+
+	// WorkqueueAddCount exposes the number of items added to each workqueue.
+	WorkqueueAddCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: catalogNamespace,
+			Subsystem: "workqueue",
+			Name:      "add_count",
+			Help:      "Cumulative number of items added to the workqueue.",
+		},
+		[]string{"queue"},
+	)
+
+	// WorkqueueRetryCount exposes the number of items requeued with backoff
+	// after a failed reconciliation in each workqueue.
+	WorkqueueRetryCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: catalogNamespace,
+			Subsystem: "workqueue",
+			Name:      "retry_count",
+			Help:      "Cumulative number of items requeued with backoff in the workqueue.",
+		},
+		[]string{"queue"},
+	)
+
+	// WorkqueueLatencyMicroseconds exposes how long items wait in each
+	// workqueue before being processed.
+	WorkqueueLatencyMicroseconds = prometheus.NewSummaryVec(
+		prometheus.SummaryOpts{
+			Namespace: catalogNamespace,
+			Subsystem: "workqueue",
+			Name:      "queue_latency_microseconds",
+			Help:      "Time in microseconds that items wait in the workqueue before being processed.",
+		},
+		[]string{"queue"},
+	)
+
+	// WorkqueueWorkDurationMicroseconds exposes how long processing the items
+	// of each workqueue takes.
+	WorkqueueWorkDurationMicroseconds = prometheus.NewSummaryVec(
+		prometheus.SummaryOpts{
+			Namespace: catalogNamespace,
+			Subsystem: "workqueue",
+			Name:      "work_duration_microseconds",
+			Help:      "Time in microseconds that processing an item of the workqueue takes.",
+		},
+		[]string{"queue"},
+	)
+)
+
+// registerWorkqueueMetrics registers the workqueue metrics and makes them
+// the metrics of the named workqueues created from now on.
+func registerWorkqueueMetrics(registry *prometheus.Registry) {
+	registry.MustRegister(WorkqueueDepth)
+	registry.MustRegister(WorkqueueAddCount)
+	registry.MustRegister(WorkqueueRetryCount)
+	registry.MustRegister(WorkqueueLatencyMicroseconds)
+	registry.MustRegister(WorkqueueWorkDurationMicroseconds)
+	workqueue.SetProvider(workqueueMetricsProvider{})
+}
+
+// workqueueMetricsProvider provides the metrics of the named workqueues.
+type workqueueMetricsProvider struct{}
+
+var _ workqueue.MetricsProvider = workqueueMetricsProvider{}
+
+func (workqueueMetricsProvider) NewDepthMetric(name string) workqueue.GaugeMetric {
+	return WorkqueueDepth.WithLabelValues(name)
+}
+
+func (workqueueMetricsProvider) NewAddsMetric(name string) workqueue.CounterMetric {
+	return WorkqueueAddCount.WithLabelValues(name)
+}
+
+func (workqueueMetricsProvider) NewLatencyMetric(name string) workqueue.SummaryMetric {
+	return WorkqueueLatencyMicroseconds.WithLabelValues(name)
+}
+
+func (workqueueMetricsProvider) NewWorkDurationMetric(name string) workqueue.SummaryMetric {
+	return WorkqueueWorkDurationMicroseconds.WithLabelValues(name)
+}
+
+func (workqueueMetricsProvider) NewRetriesMetric(name string) workqueue.CounterMetric {
+	return WorkqueueRetryCount.WithLabelValues(name)
+}
@@ -33,8 +33,11 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 	return map[string]common.OpenAPIDefinition{
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.AddKeyTransform":                schema_pkg_apis_servicecatalog_v1beta1_AddKeyTransform(ref),
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.AddKeysFromTransform":           schema_pkg_apis_servicecatalog_v1beta1_AddKeysFromTransform(ref),
+		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.Base64DecodeTransform":          schema_pkg_apis_servicecatalog_v1beta1_Base64DecodeTransform(ref),
+		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.Base64EncodeTransform":          schema_pkg_apis_servicecatalog_v1beta1_Base64EncodeTransform(ref),
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.BasicAuthConfig":                schema_pkg_apis_servicecatalog_v1beta1_BasicAuthConfig(ref),
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.BearerTokenAuthConfig":          schema_pkg_apis_servicecatalog_v1beta1_BearerTokenAuthConfig(ref),
+		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.BrokerRequestLimits":            schema_pkg_apis_servicecatalog_v1beta1_BrokerRequestLimits(ref),
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.CatalogRestrictions":            schema_pkg_apis_servicecatalog_v1beta1_CatalogRestrictions(ref),
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterBasicAuthConfig":         schema_pkg_apis_servicecatalog_v1beta1_ClusterBasicAuthConfig(ref),
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterBearerTokenAuthConfig":   schema_pkg_apis_servicecatalog_v1beta1_ClusterBearerTokenAuthConfig(ref),
@@ -58,17 +61,23 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.CommonServiceClassStatus":       schema_pkg_apis_servicecatalog_v1beta1_CommonServiceClassStatus(ref),
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.CommonServicePlanSpec":          schema_pkg_apis_servicecatalog_v1beta1_CommonServicePlanSpec(ref),
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.CommonServicePlanStatus":        schema_pkg_apis_servicecatalog_v1beta1_CommonServicePlanStatus(ref),
+		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.FlattenTransform":               schema_pkg_apis_servicecatalog_v1beta1_FlattenTransform(ref),
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.LocalObjectReference":           schema_pkg_apis_servicecatalog_v1beta1_LocalObjectReference(ref),
+		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.MaintenanceInfo":                schema_pkg_apis_servicecatalog_v1beta1_MaintenanceInfo(ref),
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ObjectReference":                schema_pkg_apis_servicecatalog_v1beta1_ObjectReference(ref),
+		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.OnlyKeysTransform":              schema_pkg_apis_servicecatalog_v1beta1_OnlyKeysTransform(ref),
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ParametersFromSource":           schema_pkg_apis_servicecatalog_v1beta1_ParametersFromSource(ref),
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.PlanReference":                  schema_pkg_apis_servicecatalog_v1beta1_PlanReference(ref),
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.RemoveKeyTransform":             schema_pkg_apis_servicecatalog_v1beta1_RemoveKeyTransform(ref),
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.RenameKeyTransform":             schema_pkg_apis_servicecatalog_v1beta1_RenameKeyTransform(ref),
+		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.RetiredServiceBinding":          schema_pkg_apis_servicecatalog_v1beta1_RetiredServiceBinding(ref),
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.SecretKeyReference":             schema_pkg_apis_servicecatalog_v1beta1_SecretKeyReference(ref),
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.SecretTransform":                schema_pkg_apis_servicecatalog_v1beta1_SecretTransform(ref),
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBinding":                 schema_pkg_apis_servicecatalog_v1beta1_ServiceBinding(ref),
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBindingCondition":        schema_pkg_apis_servicecatalog_v1beta1_ServiceBindingCondition(ref),
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBindingList":             schema_pkg_apis_servicecatalog_v1beta1_ServiceBindingList(ref),
+		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBindingOutput":           schema_pkg_apis_servicecatalog_v1beta1_ServiceBindingOutput(ref),
+		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBindingOutputKey":        schema_pkg_apis_servicecatalog_v1beta1_ServiceBindingOutputKey(ref),
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBindingPropertiesState":  schema_pkg_apis_servicecatalog_v1beta1_ServiceBindingPropertiesState(ref),
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBindingSpec":             schema_pkg_apis_servicecatalog_v1beta1_ServiceBindingSpec(ref),
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBindingStatus":           schema_pkg_apis_servicecatalog_v1beta1_ServiceBindingStatus(ref),
@@ -85,6 +94,8 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstance":                schema_pkg_apis_servicecatalog_v1beta1_ServiceInstance(ref),
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceCondition":       schema_pkg_apis_servicecatalog_v1beta1_ServiceInstanceCondition(ref),
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceList":            schema_pkg_apis_servicecatalog_v1beta1_ServiceInstanceList(ref),
+		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceOperationRecord": schema_pkg_apis_servicecatalog_v1beta1_ServiceInstanceOperationRecord(ref),
+		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceOperationRetry":  schema_pkg_apis_servicecatalog_v1beta1_ServiceInstanceOperationRetry(ref),
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstancePropertiesState": schema_pkg_apis_servicecatalog_v1beta1_ServiceInstancePropertiesState(ref),
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceSpec":            schema_pkg_apis_servicecatalog_v1beta1_ServiceInstanceSpec(ref),
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceStatus":          schema_pkg_apis_servicecatalog_v1beta1_ServiceInstanceStatus(ref),
@@ -92,6 +103,7 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServicePlanList":                schema_pkg_apis_servicecatalog_v1beta1_ServicePlanList(ref),
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServicePlanSpec":                schema_pkg_apis_servicecatalog_v1beta1_ServicePlanSpec(ref),
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServicePlanStatus":              schema_pkg_apis_servicecatalog_v1beta1_ServicePlanStatus(ref),
+		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.TemplateTransform":              schema_pkg_apis_servicecatalog_v1beta1_TemplateTransform(ref),
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.UserInfo":                       schema_pkg_apis_servicecatalog_v1beta1_UserInfo(ref),
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/settings/v1alpha1.PodPreset":                           schema_pkg_apis_settings_v1alpha1_PodPreset(ref),
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/settings/v1alpha1.PodPresetList":                       schema_pkg_apis_settings_v1alpha1_PodPresetList(ref),
@@ -397,6 +409,48 @@ func schema_pkg_apis_servicecatalog_v1beta1_AddKeysFromTransform(ref common.Refe
 	}
 }
 
+func schema_pkg_apis_servicecatalog_v1beta1_Base64DecodeTransform(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "Base64DecodeTransform specifies that the value of one of the credentials keys is base64 encoded, and should be decoded before it is stored in the Secret.",
+				Properties: map[string]spec.Schema{
+					"key": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The key whose value to decode",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"key"},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
+
+func schema_pkg_apis_servicecatalog_v1beta1_Base64EncodeTransform(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "Base64EncodeTransform specifies that the value of one of the credentials keys should be encoded in base64 before it is stored in the Secret.",
+				Properties: map[string]spec.Schema{
+					"key": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The key whose value to encode",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"key"},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
+
 func schema_pkg_apis_servicecatalog_v1beta1_BasicAuthConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -437,6 +491,40 @@ func schema_pkg_apis_servicecatalog_v1beta1_BearerTokenAuthConfig(ref common.Ref
 	}
 }
 
+func schema_pkg_apis_servicecatalog_v1beta1_BrokerRequestLimits(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BrokerRequestLimits is a set of limits on the requests sent to a broker.",
+				Properties: map[string]spec.Schema{
+					"qps": {
+						SchemaProps: spec.SchemaProps{
+							Description: "QPS is the maximum average number of requests per second sent to the broker. Zero means that the rate of requests is not limited.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"burst": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Burst is the maximum number of requests sent to the broker at once when the rate of requests is limited. Defaults to QPS.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"maxInFlightRequests": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxInFlightRequests is the maximum number of requests sent to the broker that may await a response at the same time. Zero means that the number of requests is not limited.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
+
 func schema_pkg_apis_servicecatalog_v1beta1_CatalogRestrictions(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -714,6 +802,12 @@ func schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceBrokerSpec(ref common.
 							Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.CatalogRestrictions"),
 						},
 					},
+					"requestLimits": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RequestLimits limits the rate and the concurrency of the requests that the controller sends to the broker. Requests are not limited when RequestLimits is unset.",
+							Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.BrokerRequestLimits"),
+						},
+					},
 					"authInfo": {
 						SchemaProps: spec.SchemaProps{
 							Description: "AuthInfo contains the data that the service catalog should use to authenticate with the ClusterServiceBroker.",
@@ -725,7 +819,7 @@ func schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceBrokerSpec(ref common.
 			},
 		},
 		Dependencies: []string{
-			"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.CatalogRestrictions", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceBrokerAuthInfo", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+			"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.BrokerRequestLimits", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.CatalogRestrictions", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceBrokerAuthInfo", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
 	}
 }
 
@@ -913,6 +1007,13 @@ func schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceClassSpec(ref common.R
 							Format:      "",
 						},
 					},
+					"instancesRetrievable": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nInstancesRetrievable indicates whether fetching a service instance via a GET on its endpoint is supported for all plans.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 					"planUpdatable": {
 						SchemaProps: spec.SchemaProps{
 							Description: "PlanUpdatable indicates whether instances provisioned from this ServiceClass may change ServicePlans after being provisioned.",
@@ -1165,6 +1266,12 @@ func schema_pkg_apis_servicecatalog_v1beta1_ClusterServicePlanSpec(ref common.Re
 							Ref:         ref("k8s.io/apimachinery/pkg/runtime.RawExtension"),
 						},
 					},
+					"maintenanceInfo": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nMaintenanceInfo is the version of the plan reported by the broker. Instances provisioned from an earlier version of the plan can be upgraded to this version.",
+							Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.MaintenanceInfo"),
+						},
+					},
 					"clusterServiceBrokerName": {
 						SchemaProps: spec.SchemaProps{
 							Description: "ClusterServiceBrokerName is the name of the ClusterServiceBroker that offers this ClusterServicePlan.",
@@ -1183,7 +1290,7 @@ func schema_pkg_apis_servicecatalog_v1beta1_ClusterServicePlanSpec(ref common.Re
 			},
 		},
 		Dependencies: []string{
-			"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterObjectReference", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
+			"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterObjectReference", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.MaintenanceInfo", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
 	}
 }
 
@@ -1261,12 +1368,18 @@ func schema_pkg_apis_servicecatalog_v1beta1_CommonServiceBrokerSpec(ref common.R
 							Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.CatalogRestrictions"),
 						},
 					},
+					"requestLimits": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RequestLimits limits the rate and the concurrency of the requests that the controller sends to the broker. Requests are not limited when RequestLimits is unset.",
+							Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.BrokerRequestLimits"),
+						},
+					},
 				},
 				Required: []string{"url"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.CatalogRestrictions", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+			"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.BrokerRequestLimits", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.CatalogRestrictions", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
 	}
 }
 
@@ -1357,6 +1470,13 @@ func schema_pkg_apis_servicecatalog_v1beta1_CommonServiceClassSpec(ref common.Re
 							Format:      "",
 						},
 					},
+					"instancesRetrievable": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nInstancesRetrievable indicates whether fetching a service instance via a GET on its endpoint is supported for all plans.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 					"planUpdatable": {
 						SchemaProps: spec.SchemaProps{
 							Description: "PlanUpdatable indicates whether instances provisioned from this ServiceClass may change ServicePlans after being provisioned.",
@@ -1505,12 +1625,18 @@ func schema_pkg_apis_servicecatalog_v1beta1_CommonServicePlanSpec(ref common.Ref
 							Ref:         ref("k8s.io/apimachinery/pkg/runtime.RawExtension"),
 						},
 					},
+					"maintenanceInfo": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nMaintenanceInfo is the version of the plan reported by the broker. Instances provisioned from an earlier version of the plan can be upgraded to this version.",
+							Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.MaintenanceInfo"),
+						},
+					},
 				},
 				Required: []string{"externalName", "externalID", "description", "free"},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/runtime.RawExtension"},
+			"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.MaintenanceInfo", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
 	}
 }
 
@@ -1535,6 +1661,33 @@ func schema_pkg_apis_servicecatalog_v1beta1_CommonServicePlanStatus(ref common.R
 	}
 }
 
+func schema_pkg_apis_servicecatalog_v1beta1_FlattenTransform(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "FlattenTransform specifies that nested JSON credentials should be replaced with one entry per nested value, named after the path to the value. For example, given the following credentials entry:\n    \"db\": {\"host\": \"db\", \"ports\": [5432, 5433]}\nand the following FlattenTransform:\n    {\"key\": \"db\"}\nthe following entries will appear in the Secret:\n    \"db_host\": \"db\"\n    \"db_ports_0\": \"5432\"\n    \"db_ports_1\": \"5433\"",
+				Properties: map[string]spec.Schema{
+					"key": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The key of the nested credentials to flatten. All of the nested credentials are flattened when empty.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"separator": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The separator between the names of nested keys. Defaults to \"_\".",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
+
 func schema_pkg_apis_servicecatalog_v1beta1_LocalObjectReference(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -1555,6 +1708,34 @@ func schema_pkg_apis_servicecatalog_v1beta1_LocalObjectReference(ref common.Refe
 	}
 }
 
+func schema_pkg_apis_servicecatalog_v1beta1_MaintenanceInfo(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "MaintenanceInfo describes the version of a plan, which brokers change to indicate that instances of the plan should be upgraded.",
+				Properties: map[string]spec.Schema{
+					"version": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Version is the version of the plan, as a semantic version.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"description": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Description describes the changes of this version of the plan.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"version"},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
+
 func schema_pkg_apis_servicecatalog_v1beta1_ObjectReference(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -1582,6 +1763,34 @@ func schema_pkg_apis_servicecatalog_v1beta1_ObjectReference(ref common.Reference
 	}
 }
 
+func schema_pkg_apis_servicecatalog_v1beta1_OnlyKeysTransform(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "OnlyKeysTransform specifies that only some of the credentials keys should be included in the credentials Secret. For example, given the following OnlyKeysTransform:\n    {\"keys\": [\"uri\", \"username\", \"password\"]}\nall of the entries but \"uri\", \"username\" and \"password\" will be removed from the Secret. The listed keys that are not in the credentials are ignored.",
+				Properties: map[string]spec.Schema{
+					"keys": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The keys to keep in the Secret",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"keys"},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
+
 func schema_pkg_apis_servicecatalog_v1beta1_ParametersFromSource(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -1748,6 +1957,34 @@ func schema_pkg_apis_servicecatalog_v1beta1_RenameKeyTransform(ref common.Refere
 	}
 }
 
+func schema_pkg_apis_servicecatalog_v1beta1_RetiredServiceBinding(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RetiredServiceBinding is a previous binding with the broker of a ServiceBinding whose credentials were rotated.",
+				Properties: map[string]spec.Schema{
+					"externalID": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ExternalID is the identity of the previous binding for use with the OSB API.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"retiredTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RetiredTime is the time at which the credentials of the previous binding were replaced in the Secret. It is not set while the new credentials are being bound, and the previous binding is unbound once the rotation grace period has elapsed since this time.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+				},
+				Required: []string{"externalID"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
 func schema_pkg_apis_servicecatalog_v1beta1_SecretKeyReference(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -1806,11 +2043,41 @@ func schema_pkg_apis_servicecatalog_v1beta1_SecretTransform(ref common.Reference
 							Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.RemoveKeyTransform"),
 						},
 					},
+					"template": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Template represents a transform that adds a key whose value is built from the credentials with a Go template",
+							Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.TemplateTransform"),
+						},
+					},
+					"flatten": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Flatten represents a transform that replaces nested JSON credentials with one entry per nested value",
+							Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.FlattenTransform"),
+						},
+					},
+					"base64Decode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Base64Decode represents a transform that decodes the base64 value of a credentials Secret entry",
+							Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.Base64DecodeTransform"),
+						},
+					},
+					"base64Encode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Base64Encode represents a transform that encodes the value of a credentials Secret entry in base64",
+							Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.Base64EncodeTransform"),
+						},
+					},
+					"onlyKeys": {
+						SchemaProps: spec.SchemaProps{
+							Description: "OnlyKeys represents a transform that removes all the credentials Secret entries but the listed ones",
+							Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.OnlyKeysTransform"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.AddKeyTransform", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.AddKeysFromTransform", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.RemoveKeyTransform", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.RenameKeyTransform"},
+			"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.AddKeyTransform", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.AddKeysFromTransform", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.Base64DecodeTransform", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.Base64EncodeTransform", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.FlattenTransform", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.OnlyKeysTransform", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.RemoveKeyTransform", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.RenameKeyTransform", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.TemplateTransform"},
 	}
 }
 
@@ -1960,6 +2227,83 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceBindingList(ref common.Refere
 	}
 }
 
+func schema_pkg_apis_servicecatalog_v1beta1_ServiceBindingOutput(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ServiceBindingOutput specifies an additional Secret or ConfigMap that Service Catalog should write some of the credentials of a ServiceBinding to. The object is owned by the ServiceBinding, and is deleted along with the credentials Secret. For example, given the following credentials:\n    {\"uri\": \"mysql://db:3306\", \"tls.crt\": \"...\", \"tls.key\": \"...\"}\nand the following outputs:\n    {\"name\": \"db-config\", \"kind\": \"ConfigMap\", \"keys\": [{\"key\": \"uri\", \"targetKey\": \"DB_URI\"}]}\n    {\"name\": \"db-tls\", \"kind\": \"Secret\", \"secretType\": \"kubernetes.io/tls\",\n     \"keys\": [{\"key\": \"tls.crt\"}, {\"key\": \"tls.key\"}]}\nthe ConfigMap \"db-config\" will hold the entry \"DB_URI\", and the TLS Secret \"db-tls\" will hold the certificate and its key.",
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the Secret or ConfigMap to write the credentials to. It must be different from the SecretName of the ServiceBinding.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind of the object to write the credentials to, either Secret or ConfigMap.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"secretType": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SecretType is the type of the Secret, one of Opaque, kubernetes.io/tls and kubernetes.io/dockerconfigjson. Defaults to Opaque. It must not be set for a ConfigMap.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"keys": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Keys selects the credentials keys to write to the object. All of the credentials are written to a Secret that selects no key. The keys are required for a ConfigMap.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBindingOutputKey"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"name", "kind"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBindingOutputKey"},
+	}
+}
+
+func schema_pkg_apis_servicecatalog_v1beta1_ServiceBindingOutputKey(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ServiceBindingOutputKey selects a credentials key to write to an output of a ServiceBinding.",
+				Properties: map[string]spec.Schema{
+					"key": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The credentials key, after the SecretTransforms are applied",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"targetKey": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The key to write the value under in the object. Defaults to Key.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"key"},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
+
 func schema_pkg_apis_servicecatalog_v1beta1_ServiceBindingPropertiesState(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -1997,7 +2341,7 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceBindingSpec(ref common.Refere
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "ServiceBindingSpec represents the desired state of a ServiceBinding.\n\nThe spec field cannot be changed after a ServiceBinding is created.  Changes submitted to the spec field will be ignored.",
+				Description: "ServiceBindingSpec represents the desired state of a ServiceBinding.\n\nOnly the parameters and the rotation requests in the spec field can be changed after a ServiceBinding is created. Changes submitted to the rest of the spec field will be ignored.",
 				Properties: map[string]spec.Schema{
 					"instanceRef": {
 						SchemaProps: spec.SchemaProps{
@@ -2044,9 +2388,22 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceBindingSpec(ref common.Refere
 							},
 						},
 					},
+					"outputs": {
+						SchemaProps: spec.SchemaProps{
+							Description: "List of additional Secrets and ConfigMaps in the ServiceBinding's namespace that the credentials, after the SecretTransforms are applied, are written to. The Secret named by SecretName always holds all of the credentials.\n\nImmutable.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBindingOutput"),
+									},
+								},
+							},
+						},
+					},
 					"externalID": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ExternalID is the identity of this object for use with the OSB API.\n\nImmutable.",
+							Description: "ExternalID is the identity of this object for use with the OSB API.\n\nImmutable, except that the API server replaces it with a new identity when RotationRequests is incremented, or when the parameters of a bound ServiceBinding are changed.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
@@ -2057,12 +2414,19 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceBindingSpec(ref common.Refere
 							Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.UserInfo"),
 						},
 					},
+					"rotationRequests": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RotationRequests is a strictly increasing, non-negative integer counter that can be manually incremented by a user to rotate the credentials of the ServiceBinding. The controller binds again with a new ExternalID, writes the new credentials into the Secret, and unbinds the previous credentials once they have been retired for the rotation grace period.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
 				},
 				Required: []string{"instanceRef"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.LocalObjectReference", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ParametersFromSource", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.SecretTransform", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.UserInfo", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
+			"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.LocalObjectReference", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ParametersFromSource", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.SecretTransform", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBindingOutput", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.UserInfo", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
 	}
 }
 
@@ -2144,12 +2508,25 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceBindingStatus(ref common.Refe
 							Format:      "",
 						},
 					},
+					"retiredBindings": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RetiredBindings are the previous bindings with the broker whose credentials were replaced by a rotation and that still have to be unbound.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.RetiredServiceBinding"),
+									},
+								},
+							},
+						},
+					},
 				},
 				Required: []string{"conditions", "asyncOpInProgress", "reconciledGeneration", "orphanMitigationInProgress", "unbindStatus"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBindingCondition", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBindingPropertiesState", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+			"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.RetiredServiceBinding", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBindingCondition", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBindingPropertiesState", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
@@ -2378,6 +2755,12 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceBrokerSpec(ref common.Referen
 							Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.CatalogRestrictions"),
 						},
 					},
+					"requestLimits": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RequestLimits limits the rate and the concurrency of the requests that the controller sends to the broker. Requests are not limited when RequestLimits is unset.",
+							Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.BrokerRequestLimits"),
+						},
+					},
 					"authInfo": {
 						SchemaProps: spec.SchemaProps{
 							Description: "AuthInfo contains the data that the service catalog should use to authenticate with the ServiceBroker.",
@@ -2389,7 +2772,7 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceBrokerSpec(ref common.Referen
 			},
 		},
 		Dependencies: []string{
-			"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.CatalogRestrictions", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBrokerAuthInfo", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+			"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.BrokerRequestLimits", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.CatalogRestrictions", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBrokerAuthInfo", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
 	}
 }
 
@@ -2572,6 +2955,13 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceClassSpec(ref common.Referenc
 							Format:      "",
 						},
 					},
+					"instancesRetrievable": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nInstancesRetrievable indicates whether fetching a service instance via a GET on its endpoint is supported for all plans.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 					"planUpdatable": {
 						SchemaProps: spec.SchemaProps{
 							Description: "PlanUpdatable indicates whether instances provisioned from this ServiceClass may change ServicePlans after being provisioned.",
@@ -2796,6 +3186,103 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceInstanceList(ref common.Refer
 	}
 }
 
+func schema_pkg_apis_servicecatalog_v1beta1_ServiceInstanceOperationRecord(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ServiceInstanceOperationRecord describes a completed operation on a ServiceInstance.",
+				Properties: map[string]spec.Schema{
+					"operation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Operation is the operation that was performed.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"startTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StartTime is the time at which the operation started.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"completionTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CompletionTime is the time at which the operation completed.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"result": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Result is the outcome of the operation.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"reason": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Reason is a brief machine-readable explanation of the result.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message is a human-readable description of the result.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"operation", "startTime", "completionTime", "result"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_pkg_apis_servicecatalog_v1beta1_ServiceInstanceOperationRetry(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ServiceInstanceOperationRetry is the state of the exponential backoff between attempts of an operation on a ServiceInstance.",
+				Properties: map[string]spec.Schema{
+					"generation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Generation is the 'Generation' of the serviceInstanceSpec that the operation was attempted for. The backoff is reset when the generation changes.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"attempts": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Attempts is the number of attempts of the operation that failed.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"nextRetryTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NextRetryTime is the earliest time at which the operation will be attempted again.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"lastError": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastError is the error returned by the last attempt of the operation.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"generation", "attempts", "nextRetryTime"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
 func schema_pkg_apis_servicecatalog_v1beta1_ServiceInstancePropertiesState(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -2849,12 +3336,18 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceInstancePropertiesState(ref c
 							Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.UserInfo"),
 						},
 					},
+					"maintenanceInfo": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaintenanceInfo is the version of the plan that the broker knows this ServiceInstance to run.",
+							Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.MaintenanceInfo"),
+						},
+					},
 				},
 				Required: []string{"clusterServicePlanExternalName", "clusterServicePlanExternalID"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.UserInfo", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
+			"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.MaintenanceInfo", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.UserInfo", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
 	}
 }
 
@@ -3125,12 +3618,31 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceInstanceStatus(ref common.Ref
 							Ref:         ref("k8s.io/apimachinery/pkg/runtime.RawExtension"),
 						},
 					},
+					"operationRetry": {
+						SchemaProps: spec.SchemaProps{
+							Description: "OperationRetry is the backoff state of a Provision or Update operation that failed with an error which will be retried.",
+							Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceOperationRetry"),
+						},
+					},
+					"operationHistory": {
+						SchemaProps: spec.SchemaProps{
+							Description: "OperationHistory records the most recent operations that were completed for the instance, oldest first. At most 10 operations are kept.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceOperationRecord"),
+									},
+								},
+							},
+						},
+					},
 				},
 				Required: []string{"conditions", "asyncOpInProgress", "orphanMitigationInProgress", "reconciledGeneration", "observedGeneration", "provisionStatus", "deprovisionStatus"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceCondition", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstancePropertiesState", "k8s.io/apimachinery/pkg/apis/meta/v1.Time", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
+			"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceCondition", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceOperationRecord", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceOperationRetry", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstancePropertiesState", "k8s.io/apimachinery/pkg/apis/meta/v1.Time", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
 	}
 }
 
@@ -3308,6 +3820,12 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServicePlanSpec(ref common.Reference
 							Ref:         ref("k8s.io/apimachinery/pkg/runtime.RawExtension"),
 						},
 					},
+					"maintenanceInfo": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nMaintenanceInfo is the version of the plan reported by the broker. Instances provisioned from an earlier version of the plan can be upgraded to this version.",
+							Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.MaintenanceInfo"),
+						},
+					},
 					"serviceBrokerName": {
 						SchemaProps: spec.SchemaProps{
 							Description: "ServiceBrokerName is the name of the ServiceBroker that offers this ServicePlan.",
@@ -3326,7 +3844,7 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServicePlanSpec(ref common.Reference
 			},
 		},
 		Dependencies: []string{
-			"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.LocalObjectReference", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
+			"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.LocalObjectReference", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.MaintenanceInfo", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
 	}
 }
 
@@ -3351,6 +3869,34 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServicePlanStatus(ref common.Referen
 	}
 }
 
+func schema_pkg_apis_servicecatalog_v1beta1_TemplateTransform(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TemplateTransform specifies that Service Catalog should add an entry to the Secret associated with the ServiceBinding, whose value is the result of a Go template executed with the credentials. For example, given the following credentials:\n    {\"host\": \"db\", \"port\": 5432, \"database\": \"orders\"}\nand the following TemplateTransform:\n    {\"key\": \"JDBC_URL\", \"template\": \"jdbc:postgresql://{{.host}}:{{.port}}/{{.database}}\"}\nthe following entry will appear in the Secret:\n    \"JDBC_URL\": \"jdbc:postgresql://db:5432/orders\"\nReferencing a key that is not in the credentials is an error.",
+				Properties: map[string]spec.Schema{
+					"key": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The name of the key to add",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"template": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The Go template that builds the value to add to the Secret under the specified key",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"key", "template"},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
+
 func schema_pkg_apis_servicecatalog_v1beta1_UserInfo(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
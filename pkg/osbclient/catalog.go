@@ -0,0 +1,55 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osbclient
+
+import (
+	"fmt"
+	"net/http"
+
+	osb "github.com/pmorie/go-open-service-broker-client/v2"
+)
+
+func (c *client) GetCatalog() (*CatalogResponse, error) {
+	fullURL := fmt.Sprintf(catalogURLFmt, c.url)
+
+	catalogResponse := &CatalogResponse{}
+	if _, err := c.do(http.MethodGet, fullURL, nil /* params */, nil /* request body */, nil /* originating identity */, catalogResponse, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	alphaAllowed := c.validateAlphaFeatureAllowed("alpha catalog fields") == nil
+	for i := range catalogResponse.Services {
+		service := &catalogResponse.Services[i]
+		if !alphaAllowed {
+			service.InstancesRetrievable = false
+		}
+		for j := range service.Plans {
+			plan := &service.Plans[j]
+			if !alphaAllowed {
+				plan.MaintenanceInfo = nil
+			}
+			if !c.apiVersion.AtLeast(osb.Version2_13()) {
+				plan.Schemas = nil
+			} else if !c.enableAlphaFeatures && plan.Schemas != nil &&
+				plan.Schemas.ServiceBinding != nil && plan.Schemas.ServiceBinding.Create != nil {
+				plan.Schemas.ServiceBinding.Create.Response = nil
+			}
+		}
+	}
+
+	return catalogResponse, nil
+}
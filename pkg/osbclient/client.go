@@ -0,0 +1,277 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osbclient
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	opentracing "github.com/opentracing/opentracing-go"
+	osb "github.com/pmorie/go-open-service-broker-client/v2"
+)
+
+const (
+	catalogURLFmt         = "%s/v2/catalog"
+	serviceInstanceURLFmt = "%s/v2/service_instances/%s"
+
+	contentTypeHeader = "Content-Type"
+	jsonContentType   = "application/json"
+)
+
+// NewClient creates a Client from the given configuration. It is a
+// CreateFunc.
+func NewClient(config *osb.ClientConfiguration) (Client, error) {
+	osbClient, err := osb.NewClient(config)
+	if err != nil {
+		return nil, err
+	}
+	return &client{
+		Client:              osbClient,
+		ctx:                 context.Background(),
+		name:                config.Name,
+		url:                 strings.TrimRight(config.URL, "/"),
+		apiVersion:          config.APIVersion,
+		authConfig:          config.AuthConfig,
+		enableAlphaFeatures: config.EnableAlphaFeatures,
+		verbose:             config.Verbose,
+		httpClient:          newHTTPClient(config),
+	}, nil
+}
+
+var _ CreateFunc = NewClient
+
+// newHTTPClient returns an HTTP client with the timeout and the TLS settings
+// of the given configuration, as set up by osb.NewClient.
+func newHTTPClient(config *osb.ClientConfiguration) *http.Client {
+	transport := &http.Transport{}
+	if config.TLSConfig != nil {
+		transport.TLSClientConfig = config.TLSConfig
+	} else {
+		transport.TLSClientConfig = &tls.Config{}
+	}
+	if config.Insecure {
+		transport.TLSClientConfig.InsecureSkipVerify = true
+	}
+	if len(config.CAData) != 0 {
+		if transport.TLSClientConfig.RootCAs == nil {
+			transport.TLSClientConfig.RootCAs = x509.NewCertPool()
+		}
+		transport.TLSClientConfig.RootCAs.AppendCertsFromPEM(config.CAData)
+	}
+	return &http.Client{
+		Timeout:   time.Duration(config.TimeoutSeconds) * time.Second,
+		Transport: transport,
+	}
+}
+
+// client sends the requests of the Client interface to a broker. The
+// requests that go-open-service-broker-client supports as is are sent by the
+// embedded osb.Client. The client sends the other ones itself: fetching the
+// catalog, with its alpha fields, provisioning and updating instances, with
+// their maintenance info, and fetching an instance.
+type client struct {
+	osb.Client
+
+	ctx                 context.Context
+	name                string
+	url                 string
+	apiVersion          osb.APIVersion
+	authConfig          *osb.AuthConfig
+	enableAlphaFeatures bool
+	verbose             bool
+
+	httpClient *http.Client
+}
+
+var _ ContextClient = &client{}
+
+func (c *client) WithContext(ctx context.Context) Client {
+	copied := *c
+	copied.ctx = ctx
+	return &copied
+}
+
+// do sends a request with the given method, URL, query parameters and JSON
+// body to the broker, and unmarshals the body of the response into obj when
+// the broker responds with one of the given success status codes. The
+// failures of the broker are returned as osb.HTTPStatusCodeError, as they are
+// by go-open-service-broker-client. do returns the status code of the
+// response.
+func (c *client) do(method, url string, params map[string]string, body interface{}, originatingIdentity *osb.OriginatingIdentity, obj interface{}, successCodes ...int) (int, error) {
+	request, err := c.newRequest(method, url, params, body, originatingIdentity)
+	if err != nil {
+		return 0, err
+	}
+	if c.verbose {
+		glog.Infof("broker %q: doing request to %q", c.name, url)
+	}
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		io.Copy(ioutil.Discard, io.LimitReader(response.Body, 4096))
+		response.Body.Close()
+	}()
+
+	responseBody, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return response.StatusCode, err
+	}
+	if c.verbose {
+		glog.Infof("broker %q: response body: %v, type: %T", c.name, string(responseBody), obj)
+	}
+
+	for _, code := range successCodes {
+		if response.StatusCode != code {
+			continue
+		}
+		if err := json.Unmarshal(responseBody, obj); err != nil {
+			return response.StatusCode, osb.HTTPStatusCodeError{StatusCode: response.StatusCode, ResponseError: err}
+		}
+		return response.StatusCode, nil
+	}
+	return response.StatusCode, failureResponseError(response.StatusCode, responseBody)
+}
+
+// newRequest builds a request to the broker, with the headers that
+// go-open-service-broker-client sends, and the trace context of the
+// OpenTracing span of the context of the client, if any.
+func (c *client) newRequest(method, url string, params map[string]string, body interface{}, originatingIdentity *osb.OriginatingIdentity) (*http.Request, error) {
+	var bodyReader io.Reader
+	if body != nil {
+		bodyBytes, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		bodyReader = bytes.NewReader(bodyBytes)
+	}
+
+	request, err := http.NewRequest(method, url, bodyReader)
+	if err != nil {
+		return nil, err
+	}
+	request = request.WithContext(c.ctx)
+
+	if span := opentracing.SpanFromContext(c.ctx); span != nil {
+		// The broker can continue the trace of the request from its headers
+		err := span.Tracer().Inject(span.Context(), opentracing.HTTPHeaders, opentracing.HTTPHeadersCarrier(request.Header))
+		if err != nil {
+			glog.Warningf("broker %q: failed to propagate the trace context of the request: %v", c.name, err)
+		}
+	}
+
+	request.Header.Set(osb.APIVersionHeader, c.apiVersion.HeaderValue())
+	if bodyReader != nil {
+		request.Header.Set(contentTypeHeader, jsonContentType)
+	}
+	if c.authConfig != nil {
+		if basicAuth := c.authConfig.BasicAuthConfig; basicAuth != nil {
+			request.SetBasicAuth(basicAuth.Username, basicAuth.Password)
+		} else if bearer := c.authConfig.BearerConfig; bearer != nil {
+			request.Header.Set("Authorization", "Bearer "+bearer.Token)
+		}
+	}
+	if c.apiVersion.AtLeast(osb.Version2_13()) && originatingIdentity != nil {
+		headerValue, err := originatingIdentityHeaderValue(originatingIdentity)
+		if err != nil {
+			return nil, err
+		}
+		request.Header.Set(osb.OriginatingIdentityHeader, headerValue)
+	}
+
+	if len(params) != 0 {
+		q := request.URL.Query()
+		for k, v := range params {
+			q.Set(k, v)
+		}
+		request.URL.RawQuery = q.Encode()
+	}
+	return request, nil
+}
+
+// failureResponseError returns the osb.HTTPStatusCodeError of a response
+// that reports a failure.
+func failureResponseError(statusCode int, body []byte) error {
+	httpErr := osb.HTTPStatusCodeError{
+		StatusCode: statusCode,
+	}
+
+	brokerResponse := make(map[string]interface{})
+	if err := json.Unmarshal(body, &brokerResponse); err != nil {
+		httpErr.ResponseError = err
+		return httpErr
+	}
+	if errorMessage, ok := brokerResponse["error"].(string); ok {
+		httpErr.ErrorMessage = &errorMessage
+	}
+	if description, ok := brokerResponse["description"].(string); ok {
+		httpErr.Description = &description
+	}
+	return httpErr
+}
+
+// validateAlphaFeatureAllowed returns an AlphaFeatureNotAllowedError unless
+// the client is allowed to use the given alpha feature of the API.
+func (c *client) validateAlphaFeatureAllowed(feature string) error {
+	if !c.enableAlphaFeatures {
+		return AlphaFeatureNotAllowedError{
+			Feature: feature,
+			Reason:  "alpha features must be enabled",
+		}
+	}
+	if latest := osb.LatestAPIVersion(); !c.apiVersion.AtLeast(latest) {
+		return AlphaFeatureNotAllowedError{
+			Feature: feature,
+			Reason: fmt.Sprintf("must have latest API Version. Current: %s, Expected: %s",
+				c.apiVersion.HeaderValue(), latest.HeaderValue()),
+		}
+	}
+	return nil
+}
+
+// originatingIdentityHeaderValue returns the value of the
+// osb.OriginatingIdentityHeader for the given identity.
+func originatingIdentityHeaderValue(i *osb.OriginatingIdentity) (string, error) {
+	if i.Platform == "" {
+		return "", errors.New("originating identity platform must not be empty")
+	}
+	if i.Value == "" {
+		return "", errors.New("originating identity value must not be empty")
+	}
+	var js json.RawMessage
+	if err := json.Unmarshal([]byte(i.Value), &js); err != nil {
+		return "", fmt.Errorf("originating identity value must be valid JSON: %v", err)
+	}
+	return i.Platform + " " + base64.StdEncoding.EncodeToString([]byte(i.Value)), nil
+}
+
+func required(name string) error {
+	return fmt.Errorf("%v is required", name)
+}
@@ -0,0 +1,251 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osbclient
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	osb "github.com/pmorie/go-open-service-broker-client/v2"
+)
+
+const (
+	testInstanceID = "test-instance-id"
+	testServiceID  = "test-service-id"
+	testPlanID     = "test-plan-id"
+)
+
+// newTestClient returns a Client of the broker with the given handler, and
+// the function that stops the broker.
+func newTestClient(t *testing.T, enableAlphaFeatures bool, handler http.HandlerFunc) (Client, func()) {
+	broker := httptest.NewServer(handler)
+	config := osb.DefaultClientConfiguration()
+	config.Name = "test-broker"
+	config.URL = broker.URL
+	config.APIVersion = osb.LatestAPIVersion()
+	config.EnableAlphaFeatures = enableAlphaFeatures
+	client, err := NewClient(config)
+	if err != nil {
+		broker.Close()
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return client, broker.Close
+}
+
+func TestGetInstance(t *testing.T) {
+	client, stop := newTestClient(t, true, func(w http.ResponseWriter, r *http.Request) {
+		if e, a := http.MethodGet, r.Method; e != a {
+			t.Errorf("expected method %q, got %q", e, a)
+		}
+		if e, a := "/v2/service_instances/"+testInstanceID, r.URL.Path; e != a {
+			t.Errorf("expected path %q, got %q", e, a)
+		}
+		if e, a := osb.LatestAPIVersion().HeaderValue(), r.Header.Get(osb.APIVersionHeader); e != a {
+			t.Errorf("expected API version header %q, got %q", e, a)
+		}
+		w.Write([]byte(`{"service_id": "test-service-id", "plan_id": "test-plan-id", "parameters": {"count": 1}}`))
+	})
+	defer stop()
+
+	response, err := client.GetInstance(&GetInstanceRequest{InstanceID: testInstanceID})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := &GetInstanceResponse{
+		ServiceID:  testServiceID,
+		PlanID:     testPlanID,
+		Parameters: map[string]interface{}{"count": float64(1)},
+	}
+	if !reflect.DeepEqual(expected, response) {
+		t.Fatalf("unexpected response\nexpected %+v\ngot      %+v", expected, response)
+	}
+}
+
+func TestGetInstanceAlphaFeaturesDisabled(t *testing.T) {
+	client, stop := newTestClient(t, false, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to the broker: %v %v", r.Method, r.URL)
+	})
+	defer stop()
+
+	_, err := client.GetInstance(&GetInstanceRequest{InstanceID: testInstanceID})
+	if !IsAlphaFeatureNotAllowedError(err) {
+		t.Fatalf("expected an AlphaFeatureNotAllowedError, got %v", err)
+	}
+}
+
+func TestGetCatalogAlphaFields(t *testing.T) {
+	catalog := `{"services": [{"id": "test-service-id", "name": "test-service", "instances_retrievable": true, "plans": [{"id": "test-plan-id", "name": "test-plan", "maintenance_info": {"version": "2.0.0"}}]}]}`
+	cases := []struct {
+		name                 string
+		enableAlphaFeatures  bool
+		instancesRetrievable bool
+		maintenanceInfo      *MaintenanceInfo
+	}{
+		{
+			name:                 "alpha features enabled",
+			enableAlphaFeatures:  true,
+			instancesRetrievable: true,
+			maintenanceInfo:      &MaintenanceInfo{Version: "2.0.0"},
+		},
+		{
+			name:                 "alpha features disabled",
+			enableAlphaFeatures:  false,
+			instancesRetrievable: false,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client, stop := newTestClient(t, tc.enableAlphaFeatures, func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(catalog))
+			})
+			defer stop()
+
+			response, err := client.GetCatalog()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if e, a := 1, len(response.Services); e != a {
+				t.Fatalf("expected %v services, got %v", e, a)
+			}
+			service := response.Services[0]
+			if e, a := testServiceID, service.ID; e != a {
+				t.Fatalf("expected service %q, got %q", e, a)
+			}
+			if e, a := 1, len(service.Plans); e != a {
+				t.Fatalf("expected %v plans, got %v", e, a)
+			}
+			if e, a := tc.instancesRetrievable, service.InstancesRetrievable; e != a {
+				t.Fatalf("expected InstancesRetrievable to be %v, got %v", e, a)
+			}
+			plan := service.Plans[0]
+			if e, a := testPlanID, plan.ID; e != a {
+				t.Fatalf("expected plan %q, got %q", e, a)
+			}
+			if e, a := tc.maintenanceInfo, plan.MaintenanceInfo; !reflect.DeepEqual(e, a) {
+				t.Fatalf("expected maintenance info %+v, got %+v", e, a)
+			}
+		})
+	}
+}
+
+func TestProvisionInstanceAsync(t *testing.T) {
+	client, stop := newTestClient(t, false, func(w http.ResponseWriter, r *http.Request) {
+		if e, a := http.MethodPut, r.Method; e != a {
+			t.Errorf("expected method %q, got %q", e, a)
+		}
+		if e, a := "true", r.URL.Query().Get(osb.AcceptsIncomplete); e != a {
+			t.Errorf("expected %v query parameter %q, got %q", osb.AcceptsIncomplete, e, a)
+		}
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"operation": "test-operation"}`))
+	})
+	defer stop()
+
+	response, err := client.ProvisionInstance(&ProvisionRequest{
+		ProvisionRequest: osb.ProvisionRequest{
+			InstanceID:        testInstanceID,
+			ServiceID:         testServiceID,
+			PlanID:            testPlanID,
+			OrganizationGUID:  "test-organization-guid",
+			SpaceGUID:         "test-space-guid",
+			AcceptsIncomplete: true,
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !response.Async {
+		t.Fatalf("expected an asynchronous response")
+	}
+	if response.OperationKey == nil || *response.OperationKey != "test-operation" {
+		t.Fatalf("expected operation key %q, got %v", "test-operation", response.OperationKey)
+	}
+}
+
+func TestUpdateInstanceMaintenanceInfo(t *testing.T) {
+	cases := []struct {
+		name                string
+		enableAlphaFeatures bool
+		maintenanceInfo     *MaintenanceInfo
+	}{
+		{
+			name:                "alpha features enabled",
+			enableAlphaFeatures: true,
+			maintenanceInfo:     &MaintenanceInfo{Version: "2.0.0"},
+		},
+		{
+			name:                "alpha features disabled",
+			enableAlphaFeatures: false,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var body updateInstanceRequestBody
+			client, stop := newTestClient(t, tc.enableAlphaFeatures, func(w http.ResponseWriter, r *http.Request) {
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					t.Errorf("unexpected error decoding the request body: %v", err)
+				}
+				w.Write([]byte(`{}`))
+			})
+			defer stop()
+
+			_, err := client.UpdateInstance(&UpdateInstanceRequest{
+				UpdateInstanceRequest: osb.UpdateInstanceRequest{
+					InstanceID: testInstanceID,
+					ServiceID:  testServiceID,
+				},
+				MaintenanceInfo: &MaintenanceInfo{Version: "2.0.0"},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if e, a := tc.maintenanceInfo, body.MaintenanceInfo; !reflect.DeepEqual(e, a) {
+				t.Fatalf("expected the broker to receive maintenance info %+v, got %+v", e, a)
+			}
+		})
+	}
+}
+
+func TestFailureResponse(t *testing.T) {
+	client, stop := newTestClient(t, false, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"error": "TestError", "description": "test description"}`))
+	})
+	defer stop()
+
+	_, err := client.DeprovisionInstance(&osb.DeprovisionRequest{
+		InstanceID: testInstanceID,
+		ServiceID:  testServiceID,
+		PlanID:     testPlanID,
+	})
+	httpErr, ok := osb.IsHTTPError(err)
+	if !ok {
+		t.Fatalf("expected an HTTPStatusCodeError, got %v", err)
+	}
+	if e, a := http.StatusInternalServerError, httpErr.StatusCode; e != a {
+		t.Fatalf("expected status code %v, got %v", e, a)
+	}
+	if httpErr.ErrorMessage == nil || *httpErr.ErrorMessage != "TestError" {
+		t.Fatalf("expected error message %q, got %v", "TestError", httpErr.ErrorMessage)
+	}
+	if httpErr.Description == nil || *httpErr.Description != "test description" {
+		t.Fatalf("expected description %q, got %v", "test description", httpErr.Description)
+	}
+}
@@ -0,0 +1,44 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osbclient
+
+import (
+	"fmt"
+)
+
+// AlphaFeatureNotAllowedError is returned for a request that uses an alpha
+// feature of the API, such as GetInstance or an asynchronous binding
+// operation, with a client that is not allowed to use the alpha features.
+// The failures of the broker are returned as osb.HTTPStatusCodeError, as
+// they are by go-open-service-broker-client.
+type AlphaFeatureNotAllowedError struct {
+	// Feature is the alpha feature the request uses.
+	Feature string
+	// Reason is the reason the client is not allowed to use it.
+	Reason string
+}
+
+func (e AlphaFeatureNotAllowedError) Error() string {
+	return fmt.Sprintf("%s not allowed: %s", e.Feature, e.Reason)
+}
+
+// IsAlphaFeatureNotAllowedError returns whether the error is an
+// AlphaFeatureNotAllowedError.
+func IsAlphaFeatureNotAllowedError(err error) bool {
+	_, ok := err.(AlphaFeatureNotAllowedError)
+	return ok
+}
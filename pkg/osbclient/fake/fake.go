@@ -0,0 +1,187 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fake provides a fake osbclient.Client built on the fake client of
+// go-open-service-broker-client.
+package fake
+
+import (
+	"sync"
+
+	osb "github.com/pmorie/go-open-service-broker-client/v2"
+	fakeosb "github.com/pmorie/go-open-service-broker-client/v2/fake"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/osbclient"
+)
+
+// GetInstance is the type of the actions recorded for the GetInstance method.
+const GetInstance fakeosb.ActionType = "GetInstance"
+
+// ReturnFakeClientFunc returns an osbclient.CreateFunc that returns the given
+// FakeClient.
+func ReturnFakeClientFunc(c *FakeClient) osbclient.CreateFunc {
+	return func(_ *osb.ClientConfiguration) (osbclient.Client, error) {
+		return c, nil
+	}
+}
+
+// NewFakeClient returns a new FakeClient with the reactions of the given
+// configuration.
+func NewFakeClient(config fakeosb.FakeClientConfiguration) *FakeClient {
+	return WrapFakeClient(fakeosb.NewFakeClient(config))
+}
+
+// WrapFakeClient returns a new FakeClient that runs the reactions of the
+// given fake client of go-open-service-broker-client.
+func WrapFakeClient(c *fakeosb.FakeClient) *FakeClient {
+	return &FakeClient{FakeClient: c}
+}
+
+// FakeClient is a fake implementation of the osbclient.Client interface. The
+// methods of the osb.Client interface run the reactions of the embedded
+// fake client of go-open-service-broker-client, and GetInstance runs the
+// GetInstanceReaction. FakeClient records all of the actions taken on it,
+// with the osbclient requests it is given, in the order they are taken, and
+// is threadsafe.
+type FakeClient struct {
+	*fakeosb.FakeClient
+
+	GetInstanceReaction GetInstanceReactionInterface
+
+	mutex   sync.Mutex
+	actions []fakeosb.Action
+}
+
+var _ osbclient.Client = &FakeClient{}
+
+// Actions returns the actions taken on the FakeClient.
+func (c *FakeClient) Actions() []fakeosb.Action {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.actions
+}
+
+func (c *FakeClient) record(actionType fakeosb.ActionType, request interface{}) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.actions = append(c.actions, fakeosb.Action{Type: actionType, Request: request})
+}
+
+// GetCatalog implements the Client.GetCatalog method for the FakeClient.
+func (c *FakeClient) GetCatalog() (*osbclient.CatalogResponse, error) {
+	c.record(fakeosb.GetCatalog, nil)
+	response, err := c.FakeClient.GetCatalog()
+	if response == nil {
+		return nil, err
+	}
+	catalog := &osbclient.CatalogResponse{}
+	for _, service := range response.Services {
+		plans := make([]osbclient.Plan, 0, len(service.Plans))
+		for _, plan := range service.Plans {
+			plans = append(plans, osbclient.Plan{Plan: plan})
+		}
+		catalog.Services = append(catalog.Services, osbclient.Service{Service: service, Plans: plans})
+	}
+	return catalog, err
+}
+
+// ProvisionInstance implements the Client.ProvisionInstance method for the
+// FakeClient.
+func (c *FakeClient) ProvisionInstance(r *osbclient.ProvisionRequest) (*osb.ProvisionResponse, error) {
+	c.record(fakeosb.ProvisionInstance, r)
+	return c.FakeClient.ProvisionInstance(&r.ProvisionRequest)
+}
+
+// UpdateInstance implements the Client.UpdateInstance method for the
+// FakeClient.
+func (c *FakeClient) UpdateInstance(r *osbclient.UpdateInstanceRequest) (*osb.UpdateInstanceResponse, error) {
+	c.record(fakeosb.UpdateInstance, r)
+	return c.FakeClient.UpdateInstance(&r.UpdateInstanceRequest)
+}
+
+// DeprovisionInstance implements the Client.DeprovisionInstance method for
+// the FakeClient.
+func (c *FakeClient) DeprovisionInstance(r *osb.DeprovisionRequest) (*osb.DeprovisionResponse, error) {
+	c.record(fakeosb.DeprovisionInstance, r)
+	return c.FakeClient.DeprovisionInstance(r)
+}
+
+// PollLastOperation implements the Client.PollLastOperation method for the
+// FakeClient.
+func (c *FakeClient) PollLastOperation(r *osb.LastOperationRequest) (*osb.LastOperationResponse, error) {
+	c.record(fakeosb.PollLastOperation, r)
+	return c.FakeClient.PollLastOperation(r)
+}
+
+// PollBindingLastOperation implements the Client.PollBindingLastOperation
+// method for the FakeClient.
+func (c *FakeClient) PollBindingLastOperation(r *osb.BindingLastOperationRequest) (*osb.LastOperationResponse, error) {
+	c.record(fakeosb.PollBindingLastOperation, r)
+	return c.FakeClient.PollBindingLastOperation(r)
+}
+
+// Bind implements the Client.Bind method for the FakeClient.
+func (c *FakeClient) Bind(r *osb.BindRequest) (*osb.BindResponse, error) {
+	c.record(fakeosb.Bind, r)
+	return c.FakeClient.Bind(r)
+}
+
+// Unbind implements the Client.Unbind method for the FakeClient.
+func (c *FakeClient) Unbind(r *osb.UnbindRequest) (*osb.UnbindResponse, error) {
+	c.record(fakeosb.Unbind, r)
+	return c.FakeClient.Unbind(r)
+}
+
+// GetBinding implements the Client.GetBinding method for the FakeClient.
+func (c *FakeClient) GetBinding(r *osb.GetBindingRequest) (*osb.GetBindingResponse, error) {
+	c.record(fakeosb.GetBinding, nil)
+	return c.FakeClient.GetBinding(r)
+}
+
+// GetInstance implements the Client.GetInstance method for the FakeClient.
+func (c *FakeClient) GetInstance(r *osbclient.GetInstanceRequest) (*osbclient.GetInstanceResponse, error) {
+	c.record(GetInstance, r)
+	if c.GetInstanceReaction != nil {
+		return c.GetInstanceReaction.react(r)
+	}
+	return nil, fakeosb.UnexpectedActionError()
+}
+
+// GetInstanceReactionInterface defines the reaction to GetInstance requests.
+type GetInstanceReactionInterface interface {
+	react(*osbclient.GetInstanceRequest) (*osbclient.GetInstanceResponse, error)
+}
+
+// GetInstanceReaction sets the reaction to GetInstance requests.
+type GetInstanceReaction struct {
+	Response *osbclient.GetInstanceResponse
+	Error    error
+}
+
+func (r *GetInstanceReaction) react(_ *osbclient.GetInstanceRequest) (*osbclient.GetInstanceResponse, error) {
+	if r == nil {
+		return nil, fakeosb.UnexpectedActionError()
+	}
+	return r.Response, r.Error
+}
+
+// DynamicGetInstanceReaction is a function that reacts to GetInstance
+// requests.
+type DynamicGetInstanceReaction func(*osbclient.GetInstanceRequest) (*osbclient.GetInstanceResponse, error)
+
+func (r DynamicGetInstanceReaction) react(req *osbclient.GetInstanceRequest) (*osbclient.GetInstanceResponse, error) {
+	return r(req)
+}
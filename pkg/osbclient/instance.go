@@ -0,0 +1,173 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osbclient
+
+import (
+	"fmt"
+	"net/http"
+
+	osb "github.com/pmorie/go-open-service-broker-client/v2"
+)
+
+type provisionRequestBody struct {
+	ServiceID        string                 `json:"service_id"`
+	PlanID           string                 `json:"plan_id"`
+	OrganizationGUID string                 `json:"organization_guid"`
+	SpaceGUID        string                 `json:"space_guid"`
+	Parameters       map[string]interface{} `json:"parameters,omitempty"`
+	Context          map[string]interface{} `json:"context,omitempty"`
+	MaintenanceInfo  *MaintenanceInfo       `json:"maintenance_info,omitempty"`
+}
+
+type updateInstanceRequestBody struct {
+	ServiceID       string                 `json:"service_id"`
+	PlanID          *string                `json:"plan_id,omitempty"`
+	Parameters      map[string]interface{} `json:"parameters,omitempty"`
+	Context         map[string]interface{} `json:"context,omitempty"`
+	PreviousValues  *osb.PreviousValues    `json:"previous_values,omitempty"`
+	MaintenanceInfo *MaintenanceInfo       `json:"maintenance_info,omitempty"`
+}
+
+// asyncParams returns the query parameters of a request that allows the
+// broker to complete it asynchronously when acceptsIncomplete is set, and the
+// status codes of the successful responses to the request. If the client did
+// not signify that it could handle asynchronous operations, a '202 Accepted'
+// response is treated as an error.
+func asyncParams(acceptsIncomplete bool, successCodes ...int) (map[string]string, []int) {
+	if !acceptsIncomplete {
+		return nil, successCodes
+	}
+	return map[string]string{osb.AcceptsIncomplete: "true"}, append(successCodes, http.StatusAccepted)
+}
+
+func (c *client) ProvisionInstance(r *ProvisionRequest) (*osb.ProvisionResponse, error) {
+	if err := validateProvisionRequest(&r.ProvisionRequest); err != nil {
+		return nil, err
+	}
+
+	fullURL := fmt.Sprintf(serviceInstanceURLFmt, c.url, r.InstanceID)
+
+	requestBody := &provisionRequestBody{
+		ServiceID:        r.ServiceID,
+		PlanID:           r.PlanID,
+		OrganizationGUID: r.OrganizationGUID,
+		SpaceGUID:        r.SpaceGUID,
+		Parameters:       r.Parameters,
+	}
+	if c.apiVersion.AtLeast(osb.Version2_12()) {
+		requestBody.Context = r.Context
+	}
+	if c.validateAlphaFeatureAllowed("maintenance info") == nil {
+		requestBody.MaintenanceInfo = r.MaintenanceInfo
+	}
+
+	params, successCodes := asyncParams(r.AcceptsIncomplete, http.StatusCreated, http.StatusOK)
+	userResponse := &osb.ProvisionResponse{}
+	statusCode, err := c.do(http.MethodPut, fullURL, params, requestBody, r.OriginatingIdentity, userResponse, successCodes...)
+	if err != nil {
+		return nil, err
+	}
+	if statusCode == http.StatusAccepted {
+		return &osb.ProvisionResponse{
+			Async:        true,
+			DashboardURL: userResponse.DashboardURL,
+			OperationKey: userResponse.OperationKey,
+		}, nil
+	}
+	userResponse.OperationKey = nil
+	if !c.apiVersion.AtLeast(osb.Version2_13()) || !c.enableAlphaFeatures {
+		userResponse.ExtensionAPIs = nil
+	}
+	return userResponse, nil
+}
+
+func validateProvisionRequest(request *osb.ProvisionRequest) error {
+	if request.InstanceID == "" {
+		return required("instanceID")
+	}
+	if request.ServiceID == "" {
+		return required("serviceID")
+	}
+	if request.PlanID == "" {
+		return required("planID")
+	}
+	if request.OrganizationGUID == "" {
+		return required("organizationGUID")
+	}
+	if request.SpaceGUID == "" {
+		return required("spaceGUID")
+	}
+	return nil
+}
+
+func (c *client) UpdateInstance(r *UpdateInstanceRequest) (*osb.UpdateInstanceResponse, error) {
+	if r.InstanceID == "" {
+		return nil, required("instanceID")
+	}
+	if r.ServiceID == "" {
+		return nil, required("serviceID")
+	}
+
+	fullURL := fmt.Sprintf(serviceInstanceURLFmt, c.url, r.InstanceID)
+
+	requestBody := &updateInstanceRequestBody{
+		ServiceID:      r.ServiceID,
+		PlanID:         r.PlanID,
+		Parameters:     r.Parameters,
+		PreviousValues: r.PreviousValues,
+	}
+	if c.apiVersion.AtLeast(osb.Version2_12()) {
+		requestBody.Context = r.Context
+	}
+	alphaAllowed := c.validateAlphaFeatureAllowed("maintenance info") == nil
+	if alphaAllowed {
+		requestBody.MaintenanceInfo = r.MaintenanceInfo
+	}
+
+	params, successCodes := asyncParams(r.AcceptsIncomplete, http.StatusOK)
+	responseBody := &osb.UpdateInstanceResponse{}
+	statusCode, err := c.do(http.MethodPatch, fullURL, params, requestBody, r.OriginatingIdentity, responseBody, successCodes...)
+	if err != nil {
+		return nil, err
+	}
+	userResponse := &osb.UpdateInstanceResponse{}
+	if statusCode == http.StatusAccepted {
+		userResponse.Async = true
+		userResponse.OperationKey = responseBody.OperationKey
+	}
+	if alphaAllowed {
+		userResponse.DashboardURL = responseBody.DashboardURL
+	}
+	return userResponse, nil
+}
+
+func (c *client) GetInstance(r *GetInstanceRequest) (*GetInstanceResponse, error) {
+	if err := c.validateAlphaFeatureAllowed("GetInstance"); err != nil {
+		return nil, err
+	}
+	if r.InstanceID == "" {
+		return nil, required("instanceID")
+	}
+
+	fullURL := fmt.Sprintf(serviceInstanceURLFmt, c.url, r.InstanceID)
+
+	userResponse := &GetInstanceResponse{}
+	if _, err := c.do(http.MethodGet, fullURL, nil /* params */, nil /* request body */, nil /* originating identity */, userResponse, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return userResponse, nil
+}
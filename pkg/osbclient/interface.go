@@ -0,0 +1,86 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package osbclient wraps the client of go-open-service-broker-client used
+// by the controller. It adds the parts of the Open Service Broker API that
+// the version of that library pinned in Gopkg.toml does not implement:
+// fetching an instance, the alpha fields of the catalog that describe it,
+// and the maintenance info of plans and instances.
+package osbclient
+
+import (
+	"context"
+
+	osb "github.com/pmorie/go-open-service-broker-client/v2"
+)
+
+// Client is the interface of a client of the Open Service Broker API. It is
+// the osb.Client interface of go-open-service-broker-client, with the
+// catalog of the broker returned as a CatalogResponse, the maintenance info
+// of instances sent in the ProvisionRequest and UpdateInstanceRequest, and
+// the GetInstance method that the pinned version of the library does not
+// have.
+type Client interface {
+	// GetCatalog returns information about the services the broker offers
+	// and their plans or an error. GetCatalog calls GET on the Broker's
+	// catalog endpoint (/v2/catalog).
+	GetCatalog() (*CatalogResponse, error)
+	// ProvisionInstance requests that a new instance of a service be
+	// provisioned, see osb.Client.ProvisionInstance.
+	ProvisionInstance(r *ProvisionRequest) (*osb.ProvisionResponse, error)
+	// UpdateInstance requests that the plan or the parameters of an instance
+	// be updated, see osb.Client.UpdateInstance.
+	UpdateInstance(r *UpdateInstanceRequest) (*osb.UpdateInstanceResponse, error)
+	// DeprovisionInstance requests that an instance be deprovisioned, see
+	// osb.Client.DeprovisionInstance.
+	DeprovisionInstance(r *osb.DeprovisionRequest) (*osb.DeprovisionResponse, error)
+	// PollLastOperation queries the state of the last operation of an
+	// instance, see osb.Client.PollLastOperation.
+	PollLastOperation(r *osb.LastOperationRequest) (*osb.LastOperationResponse, error)
+	// PollBindingLastOperation queries the state of the last operation of a
+	// binding, see osb.Client.PollBindingLastOperation. It is an alpha
+	// method of the API.
+	PollBindingLastOperation(r *osb.BindingLastOperationRequest) (*osb.LastOperationResponse, error)
+	// Bind requests a new binding to an instance, see osb.Client.Bind.
+	Bind(r *osb.BindRequest) (*osb.BindResponse, error)
+	// Unbind requests that a binding be deleted, see osb.Client.Unbind.
+	Unbind(r *osb.UnbindRequest) (*osb.UnbindResponse, error)
+	// GetBinding fetches a binding, see osb.Client.GetBinding. It is an alpha
+	// method of the API.
+	GetBinding(r *osb.GetBindingRequest) (*osb.GetBindingResponse, error)
+	// GetInstance returns the service, plan and parameters of an existing
+	// instance. GetInstance calls GET on the Broker's endpoint for the
+	// requested instance ID (/v2/service_instances/instance-id). It is an
+	// alpha method of the API, which the broker supports for the services of
+	// its catalog that are InstancesRetrievable.
+	GetInstance(r *GetInstanceRequest) (*GetInstanceResponse, error)
+}
+
+// ContextClient is implemented by the clients whose requests to the broker
+// can carry a context.
+type ContextClient interface {
+	Client
+	// WithContext returns a copy of the client whose requests to the broker
+	// carry the given context. The trace context of the OpenTracing span of
+	// the context, if any, is propagated to the broker in the headers of the
+	// requests. The requests that the client leaves to
+	// go-open-service-broker-client, which does not take a context, do not
+	// carry it.
+	WithContext(ctx context.Context) Client
+}
+
+// CreateFunc creates a Client from the given configuration.
+type CreateFunc func(config *osb.ClientConfiguration) (Client, error)
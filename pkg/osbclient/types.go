@@ -0,0 +1,117 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osbclient
+
+import (
+	osb "github.com/pmorie/go-open-service-broker-client/v2"
+)
+
+// CatalogResponse is the catalog of a broker.
+type CatalogResponse struct {
+	Services []Service `json:"services"`
+}
+
+// Service is a service of the catalog of a broker, with the alpha fields
+// that osb.Service does not have.
+type Service struct {
+	osb.Service
+
+	// InstancesRetrievable is ALPHA and may change or disappear at any time.
+	// InstancesRetrievable will only be provided if alpha features are
+	// enabled.
+	//
+	// InstancesRetrievable represents whether fetching a service instance
+	// via a GET on the instance's endpoint
+	// (/v2/service_instances/instance-id) is supported for all plans.
+	InstancesRetrievable bool `json:"instances_retrievable,omitempty"`
+
+	// Plans is the list of the plans of the service.
+	Plans []Plan `json:"plans"`
+}
+
+// Plan is a plan of a service of the catalog of a broker, with the alpha
+// fields that osb.Plan does not have.
+type Plan struct {
+	osb.Plan
+
+	// MaintenanceInfo is ALPHA and may change or disappear at any time.
+	// MaintenanceInfo will only be provided if alpha features are enabled.
+	//
+	// MaintenanceInfo is the version of the plan that instances provisioned
+	// from or updated to the plan will run. A change of version indicates
+	// that existing instances of the plan should be upgraded.
+	MaintenanceInfo *MaintenanceInfo `json:"maintenance_info,omitempty"`
+}
+
+// MaintenanceInfo is ALPHA and may change or disappear at any time.
+//
+// MaintenanceInfo describes the version of a plan.
+type MaintenanceInfo struct {
+	// Version is the version of the plan, as a semantic version.
+	Version string `json:"version,omitempty"`
+	// Description describes the changes of this version of the plan.
+	// Optional.
+	Description string `json:"description,omitempty"`
+}
+
+// ProvisionRequest is a request to provision an instance, with the alpha
+// fields that osb.ProvisionRequest does not have.
+type ProvisionRequest struct {
+	osb.ProvisionRequest
+
+	// MaintenanceInfo is ALPHA and may change or disappear at any time.
+	// MaintenanceInfo will only be sent if alpha features are enabled.
+	//
+	// MaintenanceInfo is the maintenance info of the plan to provision the
+	// instance with.
+	MaintenanceInfo *MaintenanceInfo
+}
+
+// UpdateInstanceRequest is a request to update an instance, with the alpha
+// fields that osb.UpdateInstanceRequest does not have.
+type UpdateInstanceRequest struct {
+	osb.UpdateInstanceRequest
+
+	// MaintenanceInfo is ALPHA and may change or disappear at any time.
+	// MaintenanceInfo will only be sent if alpha features are enabled.
+	//
+	// MaintenanceInfo is the maintenance info of the plan to update the
+	// instance to. If it differs from the maintenance info the instance was
+	// last provisioned or updated with, the broker upgrades the instance.
+	MaintenanceInfo *MaintenanceInfo
+}
+
+// GetInstanceRequest represents a request to do a GET on a particular
+// instance.
+type GetInstanceRequest struct {
+	// InstanceID is the ID of the instance to fetch.
+	InstanceID string `json:"instance_id"`
+}
+
+// GetInstanceResponse is sent as the response to doing a GET on a particular
+// instance.
+type GetInstanceResponse struct {
+	// ServiceID is the ID of the service the instance is an instance of.
+	ServiceID string `json:"service_id,omitempty"`
+	// PlanID is the ID of the plan the instance is currently using.
+	PlanID string `json:"plan_id,omitempty"`
+	// DashboardURL is the URL of a web-based management user interface for
+	// the service instance.
+	DashboardURL *string `json:"dashboard_url,omitempty"`
+	// Parameters is configuration parameters for the instance.
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+}
@@ -140,18 +140,33 @@ func (bindingRESTStrategy) PrepareForUpdate(ctx context.Context, new, old runtim
 	}
 	newServiceBinding.Status = oldServiceBinding.Status
 
-	// TODO: We currently don't handle any changes to the spec in the
-	// reconciler. Once we do that, this check needs to be removed and
-	// proper validation of allowed changes needs to be implemented in
-	// ValidateUpdate. Also, the check for whether the generation needs
-	// to be updated needs to be un-commented.
+	// The reconciler only handles changes to the parameters of the binding
+	// and credential rotation. Changes to the rest of the spec are ignored.
+	parameters := newServiceBinding.Spec.Parameters
+	parametersFrom := newServiceBinding.Spec.ParametersFrom
+	rotationRequests := newServiceBinding.Spec.RotationRequests
 	newServiceBinding.Spec = oldServiceBinding.Spec
+	newServiceBinding.Spec.Parameters = parameters
+	newServiceBinding.Spec.ParametersFrom = parametersFrom
+
+	// Ignore the RotationRequests field when it is the default value
+	if rotationRequests != 0 {
+		newServiceBinding.Spec.RotationRequests = rotationRequests
+	}
+
+	// Rotating the credentials, or changing the parameters of credentials
+	// that the broker may already hold, binds again with a new identity.
+	// Brokers cannot update a binding, and unbinding it first would leave
+	// the applications using the secret without valid credentials.
+	rotated := newServiceBinding.Spec.RotationRequests > oldServiceBinding.Spec.RotationRequests
+	parametersChanged := !apiequality.Semantic.DeepEqual(oldServiceBinding.Spec.Parameters, newServiceBinding.Spec.Parameters) ||
+		!apiequality.Semantic.DeepEqual(oldServiceBinding.Spec.ParametersFrom, newServiceBinding.Spec.ParametersFrom)
+	if rotated || (parametersChanged && oldServiceBinding.Status.UnbindStatus == sc.ServiceBindingUnbindStatusRequired) {
+		retireServiceBindingExternalID(newServiceBinding, oldServiceBinding)
+	}
 
 	// Spec updates bump the generation so that we can distinguish between
 	// spec changes and other changes to the object.
-	//
-	// Note that since we do not currently handle any changes to the spec,
-	// the generation will never be incremented
 	if !apiequality.Semantic.DeepEqual(oldServiceBinding.Spec, newServiceBinding.Spec) {
 		if utilfeature.DefaultFeatureGate.Enabled(scfeatures.OriginatingIdentity) {
 			setServiceBindingUserInfo(ctx, newServiceBinding)
@@ -160,6 +175,18 @@ func (bindingRESTStrategy) PrepareForUpdate(ctx context.Context, new, old runtim
 	}
 }
 
+// retireServiceBindingExternalID gives the binding a new identity. The
+// previous identity is retired so that the controller unbinds it once the new
+// credentials are in the secret.
+func retireServiceBindingExternalID(new, old *sc.ServiceBinding) {
+	new.Spec.ExternalID = string(uuid.NewUUID())
+	retiredBindings := make([]sc.RetiredServiceBinding, 0, len(old.Status.RetiredBindings)+1)
+	retiredBindings = append(retiredBindings, old.Status.RetiredBindings...)
+	new.Status.RetiredBindings = append(retiredBindings, sc.RetiredServiceBinding{
+		ExternalID: old.Spec.ExternalID,
+	})
+}
+
 func (bindingRESTStrategy) ValidateUpdate(ctx context.Context, new, old runtime.Object) field.ErrorList {
 	newServiceBinding, ok := new.(*sc.ServiceBinding)
 	if !ok {
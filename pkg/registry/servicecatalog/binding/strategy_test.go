@@ -18,6 +18,7 @@ package binding
 
 import (
 	"fmt"
+	"reflect"
 	"testing"
 
 	sctestutil "github.com/kubernetes-incubator/service-catalog/test/util"
@@ -26,6 +27,7 @@ import (
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
 	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 func getTestInstanceCredential() *servicecatalog.ServiceBinding {
@@ -49,9 +51,6 @@ func getTestInstanceCredential() *servicecatalog.ServiceBinding {
 	}
 }
 
-// TODO: Un-comment "spec-change" test case when there is a field
-// in the spec to which the reconciler allows a change.
-
 // TestInstanceCredentialUpdate tests that generation is incremented correctly when the
 // spec of a ServiceBinding is updated.
 func TestInstanceCredentialUpdate(t *testing.T) {
@@ -66,18 +65,49 @@ func TestInstanceCredentialUpdate(t *testing.T) {
 			older: getTestInstanceCredential(),
 			newer: getTestInstanceCredential(),
 		},
-		//		{
-		//			name:  "spec change",
-		//			older: getTestInstanceCredential(),
-		//			newer: func() *v1beta1.ServiceBinding {
-		//				ic := getTestInstanceCredential()
-		//				ic.Spec.ServiceInstanceRef = servicecatalog.LocalObjectReference{
-		//					Name: "new-string",
-		//				}
-		//				return ic
-		//			},
-		//			shouldGenerationIncrement: true,
-		//		},
+		{
+			name:  "unsupported spec change",
+			older: getTestInstanceCredential(),
+			newer: func() *servicecatalog.ServiceBinding {
+				ic := getTestInstanceCredential()
+				ic.Spec.ServiceInstanceRef = servicecatalog.LocalObjectReference{
+					Name: "new-string",
+				}
+				return ic
+			}(),
+		},
+		{
+			name:  "rotation requested",
+			older: getTestInstanceCredential(),
+			newer: func() *servicecatalog.ServiceBinding {
+				ic := getTestInstanceCredential()
+				ic.Spec.RotationRequests = 1
+				return ic
+			}(),
+			shouldGenerationIncrement: true,
+		},
+		{
+			name:  "parameters change",
+			older: getTestInstanceCredential(),
+			newer: func() *servicecatalog.ServiceBinding {
+				ic := getTestInstanceCredential()
+				ic.Spec.Parameters = &runtime.RawExtension{Raw: []byte(`{"role":"read-only"}`)}
+				return ic
+			}(),
+			shouldGenerationIncrement: true,
+		},
+		{
+			name:  "parametersFrom change",
+			older: getTestInstanceCredential(),
+			newer: func() *servicecatalog.ServiceBinding {
+				ic := getTestInstanceCredential()
+				ic.Spec.ParametersFrom = []servicecatalog.ParametersFromSource{
+					{SecretKeyRef: &servicecatalog.SecretKeyReference{Name: "secret", Key: "params"}},
+				}
+				return ic
+			}(),
+			shouldGenerationIncrement: true,
+		},
 	}
 	creatorUserName := "creator"
 	createContext := sctestutil.ContextWithUserName(creatorUserName)
@@ -110,17 +140,15 @@ func TestInstanceCredentialUserInfo(t *testing.T) {
 		t.Errorf("unexpected user info in created spec: expected %q, got %q", e, a)
 	}
 
-	// TODO: Un-comment the following portion of this test when there is a field
-	// in the spec to which the reconciler allows a change.
-
-	//  updaterUserName := "updater"
-	//	updatedInstanceCredential := getTestInstanceCredential()
-	//	updateContext := sctestutil.ContextWithUserName(updaterUserName)
-	//	bindingRESTStrategies.PrepareForUpdate(updateContext, updatedInstanceCredential, createdInstanceCredential)
+	updaterUserName := "updater"
+	updatedInstanceCredential := getTestInstanceCredential()
+	updatedInstanceCredential.Spec.RotationRequests = 1
+	updateContext := sctestutil.ContextWithUserName(updaterUserName)
+	bindingRESTStrategies.PrepareForUpdate(updateContext, updatedInstanceCredential, createdInstanceCredential)
 
-	//	if e, a := updaterUserName, updatedInstanceCredential.Spec.UserInfo.Username; e != a {
-	//		t.Errorf("unexpected user info in updated spec: expected %q, got %q", e, a)
-	//	}
+	if e, a := updaterUserName, updatedInstanceCredential.Spec.UserInfo.Username; e != a {
+		t.Errorf("unexpected user info in updated spec: expected %q, got %q", e, a)
+	}
 
 	deleterUserName := "deleter"
 	deletedInstanceCredential := getTestInstanceCredential()
@@ -157,3 +185,92 @@ func TestExternalIDUserProvided(t *testing.T) {
 		t.Errorf("Modified user provided ExternalID to %q", createdInstanceCredential.Spec.ExternalID)
 	}
 }
+
+// TestRotationRetiresExternalID checks that incrementing RotationRequests
+// gives the binding a new ExternalID and retires the previous one.
+func TestRotationRetiresExternalID(t *testing.T) {
+	older := getTestInstanceCredential()
+	older.Spec.ExternalID = "old-id"
+	older.Spec.RotationRequests = 1
+	older.Status.RetiredBindings = []servicecatalog.RetiredServiceBinding{{ExternalID: "older-id"}}
+	newer := getTestInstanceCredential()
+	newer.Spec.ExternalID = "old-id"
+	newer.Spec.RotationRequests = 2
+	bindingRESTStrategies.PrepareForUpdate(sctestutil.ContextWithUserName("rotator"), newer, older)
+
+	if newer.Spec.ExternalID == "" || newer.Spec.ExternalID == "old-id" {
+		t.Errorf("Expected a new ExternalID to be set, got %q", newer.Spec.ExternalID)
+	}
+	if e, a := []servicecatalog.RetiredServiceBinding{{ExternalID: "older-id"}, {ExternalID: "old-id"}}, newer.Status.RetiredBindings; !reflect.DeepEqual(e, a) {
+		t.Errorf("unexpected retired bindings: expected %v, got %v", e, a)
+	}
+	if e, a := 1, len(older.Status.RetiredBindings); e != a {
+		t.Errorf("Modified the retired bindings of the old binding: expected %v, got %v", e, a)
+	}
+
+	// Leaving RotationRequests unset does not rotate the credentials again.
+	unchanged := getTestInstanceCredential()
+	bindingRESTStrategies.PrepareForUpdate(sctestutil.ContextWithUserName("rotator"), unchanged, newer)
+
+	if e, a := newer.Spec.ExternalID, unchanged.Spec.ExternalID; e != a {
+		t.Errorf("Expected the ExternalID to be kept: expected %q, got %q", e, a)
+	}
+	if e, a := int64(2), unchanged.Spec.RotationRequests; e != a {
+		t.Errorf("Expected RotationRequests to be kept: expected %v, got %v", e, a)
+	}
+}
+
+// TestParametersChangeRetiresExternalID checks that changing the parameters
+// of a binding gives it a new ExternalID only when the broker may hold a
+// binding for the previous one.
+func TestParametersChangeRetiresExternalID(t *testing.T) {
+	cases := []struct {
+		name         string
+		unbindStatus servicecatalog.ServiceBindingUnbindStatus
+		shouldRetire bool
+	}{
+		{
+			name:         "bound",
+			unbindStatus: servicecatalog.ServiceBindingUnbindStatusRequired,
+			shouldRetire: true,
+		},
+		{
+			name:         "never bound",
+			unbindStatus: servicecatalog.ServiceBindingUnbindStatusNotRequired,
+		},
+		{
+			name:         "orphan mitigated",
+			unbindStatus: servicecatalog.ServiceBindingUnbindStatusSucceeded,
+		},
+	}
+
+	for _, tc := range cases {
+		older := getTestInstanceCredential()
+		older.Spec.ExternalID = "old-id"
+		older.Spec.Parameters = &runtime.RawExtension{Raw: []byte(`{"role":"read-write"}`)}
+		older.Status.UnbindStatus = tc.unbindStatus
+		newer := getTestInstanceCredential()
+		newer.Spec.ExternalID = "old-id"
+		newer.Spec.Parameters = &runtime.RawExtension{Raw: []byte(`{"role":"read-only"}`)}
+		bindingRESTStrategies.PrepareForUpdate(sctestutil.ContextWithUserName("updater"), newer, older)
+
+		if e, a := `{"role":"read-only"}`, string(newer.Spec.Parameters.Raw); e != a {
+			t.Errorf("%v: unexpected parameters: expected %v, got %v", tc.name, e, a)
+		}
+		if !tc.shouldRetire {
+			if e, a := "old-id", newer.Spec.ExternalID; e != a {
+				t.Errorf("%v: expected the ExternalID to be kept: expected %q, got %q", tc.name, e, a)
+			}
+			if len(newer.Status.RetiredBindings) != 0 {
+				t.Errorf("%v: unexpected retired bindings: %v", tc.name, newer.Status.RetiredBindings)
+			}
+			continue
+		}
+		if newer.Spec.ExternalID == "" || newer.Spec.ExternalID == "old-id" {
+			t.Errorf("%v: expected a new ExternalID to be set, got %q", tc.name, newer.Spec.ExternalID)
+		}
+		if e, a := []servicecatalog.RetiredServiceBinding{{ExternalID: "old-id"}}, newer.Status.RetiredBindings; !reflect.DeepEqual(e, a) {
+			t.Errorf("%v: unexpected retired bindings: expected %v, got %v", tc.name, e, a)
+		}
+	}
+}
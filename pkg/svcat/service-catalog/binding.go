@@ -29,6 +29,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 // RetrieveBindings lists all bindings in a namespace.
@@ -41,6 +42,17 @@ func (sdk *SDK) RetrieveBindings(ns string) (*v1beta1.ServiceBindingList, error)
 	return bindings, nil
 }
 
+// WatchBindings watches the changes to the bindings in a namespace, starting
+// after the specified resource version.
+func (sdk *SDK) WatchBindings(ns, resourceVersion string) (watch.Interface, error) {
+	w, err := sdk.ServiceCatalog().ServiceBindings(ns).Watch(v1.ListOptions{ResourceVersion: resourceVersion})
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to watch bindings in %s", ns)
+	}
+
+	return w, nil
+}
+
 // RetrieveBinding gets a binding by its name.
 func (sdk *SDK) RetrieveBinding(ns, name string) (*v1beta1.ServiceBinding, error) {
 	binding, err := sdk.ServiceCatalog().ServiceBindings(ns).Get(name, v1.GetOptions{})
@@ -73,13 +85,26 @@ func (sdk *SDK) RetrieveBindingsByInstance(instance *v1beta1.ServiceInstance,
 func (sdk *SDK) Bind(namespace, bindingName, externalID, instanceName, secretName string,
 	params interface{}, secrets map[string]string) (*v1beta1.ServiceBinding, error) {
 
+	request := newBinding(namespace, bindingName, externalID, instanceName, secretName, params, secrets)
+	result, err := sdk.ServiceCatalog().ServiceBindings(namespace).Create(request)
+	if err != nil {
+		return nil, errors.Wrap(err, "bind request failed")
+	}
+
+	return result, nil
+}
+
+// newBinding builds the binding of an instance to be created.
+func newBinding(namespace, bindingName, externalID, instanceName, secretName string,
+	params interface{}, secrets map[string]string) *v1beta1.ServiceBinding {
+
 	// Manually defaulting the name of the binding
 	// I'm not doing the same for the secret since the API handles defaulting that value.
 	if bindingName == "" {
 		bindingName = instanceName
 	}
 
-	request := &v1beta1.ServiceBinding{
+	return &v1beta1.ServiceBinding{
 		ObjectMeta: v1.ObjectMeta{
 			Name:      bindingName,
 			Namespace: namespace,
@@ -94,13 +119,6 @@ func (sdk *SDK) Bind(namespace, bindingName, externalID, instanceName, secretNam
 			ParametersFrom: BuildParametersFrom(secrets),
 		},
 	}
-
-	result, err := sdk.ServiceCatalog().ServiceBindings(namespace).Create(request)
-	if err != nil {
-		return nil, errors.Wrap(err, "bind request failed")
-	}
-
-	return result, nil
 }
 
 // Unbind deletes all bindings associated to an instance.
@@ -183,20 +201,17 @@ func joinErrors(groupMsg string, errors []error, sep string, a ...interface{}) s
 	return strings.Join(msgs, sep)
 }
 
-// BindingParentHierarchy retrieves all ancestor resources of a binding.
+// BindingParentHierarchy retrieves all ancestor resources of a binding. The
+// class, plan and broker are either cluster-scoped or in the namespace of the
+// binding.
 func (sdk *SDK) BindingParentHierarchy(binding *v1beta1.ServiceBinding,
-) (*v1beta1.ServiceInstance, *v1beta1.ClusterServiceClass, *v1beta1.ClusterServicePlan, *v1beta1.ClusterServiceBroker, error) {
+) (*v1beta1.ServiceInstance, Class, Plan, Broker, error) {
 	instance, err := sdk.RetrieveInstanceByBinding(binding)
 	if err != nil {
 		return nil, nil, nil, nil, err
 	}
 
-	class, plan, err := sdk.InstanceToServiceClassAndPlan(instance)
-	if err != nil {
-		return nil, nil, nil, nil, err
-	}
-
-	broker, err := sdk.RetrieveBrokerByClass(class)
+	class, plan, broker, err := sdk.InstanceParentHierarchy(instance)
 	if err != nil {
 		return nil, nil, nil, nil, err
 	}
@@ -23,7 +23,9 @@ import (
 	"time"
 
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-incubator/service-catalog/pkg/filter"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -43,6 +45,9 @@ type Broker interface {
 
 	// GetStatus returns the broker's status.
 	GetStatus() v1beta1.CommonServiceBrokerStatus
+
+	// GetSpec returns the spec that is common to brokers of both scopes.
+	GetSpec() v1beta1.CommonServiceBrokerSpec
 }
 
 // Deregister deletes a broker
@@ -98,10 +103,18 @@ func (sdk *SDK) RetrieveBroker(name string) (*v1beta1.ClusterServiceBroker, erro
 	return broker, nil
 }
 
-// RetrieveBrokerByClass gets the parent broker of a class.
-func (sdk *SDK) RetrieveBrokerByClass(class *v1beta1.ClusterServiceClass,
-) (*v1beta1.ClusterServiceBroker, error) {
-	brokerName := class.Spec.ClusterServiceBrokerName
+// RetrieveBrokerByClass gets the parent broker of a class, which is in the
+// same scope as the class.
+func (sdk *SDK) RetrieveBrokerByClass(class Class) (Broker, error) {
+	brokerName := class.GetServiceBrokerName()
+	if ns := class.GetNamespace(); ns != "" {
+		broker, err := sdk.ServiceCatalog().ServiceBrokers(ns).Get(brokerName, v1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return broker, nil
+	}
+
 	broker, err := sdk.ServiceCatalog().ClusterServiceBrokers().Get(brokerName, v1.GetOptions{})
 	if err != nil {
 		return nil, err
@@ -109,9 +122,33 @@ func (sdk *SDK) RetrieveBrokerByClass(class *v1beta1.ClusterServiceClass,
 	return broker, nil
 }
 
-//Register creates a broker
-func (sdk *SDK) Register(brokerName string, url string, opts *RegisterOptions) (*v1beta1.ClusterServiceBroker, error) {
-	var err error
+// RetrieveBrokerInScope gets a cluster broker, or a broker in the namespace
+// of the options when their scope is NamespaceScope.
+func (sdk *SDK) RetrieveBrokerInScope(name string, opts ScopeOptions) (Broker, error) {
+	if opts.Scope == NamespaceScope {
+		broker, err := sdk.ServiceCatalog().ServiceBrokers(opts.Namespace).Get(name, v1.GetOptions{})
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to get broker '%s/%s'", opts.Namespace, name)
+		}
+		return broker, nil
+	}
+
+	broker, err := sdk.RetrieveBroker(name)
+	if err != nil {
+		return nil, err
+	}
+	return broker, nil
+}
+
+// Register creates a broker, at the cluster level or in the namespace of the
+// options depending on their scope. When auth credentials are given, the
+// secret holding them is created along with the broker.
+func (sdk *SDK) Register(brokerName string, url string, opts *RegisterOptions) (Broker, error) {
+	err := ValidateCatalogRestrictions(opts.ClassRestrictions, opts.PlanRestrictions, opts.Scope)
+	if err != nil {
+		return nil, err
+	}
+
 	var caBytes []byte
 	if opts.CAFile != "" {
 		caBytes, err = ioutil.ReadFile(opts.CAFile)
@@ -120,50 +157,171 @@ func (sdk *SDK) Register(brokerName string, url string, opts *RegisterOptions) (
 		}
 
 	}
+	spec := v1beta1.CommonServiceBrokerSpec{
+		CABundle:              caBytes,
+		InsecureSkipTLSVerify: opts.SkipTLS,
+		RelistBehavior:        opts.RelistBehavior,
+		RelistDuration:        opts.RelistDuration,
+		URL:                   url,
+		CatalogRestrictions: &v1beta1.CatalogRestrictions{
+			ServiceClass: opts.ClassRestrictions,
+			ServicePlan:  opts.PlanRestrictions,
+		},
+	}
+
+	basicSecret, bearerSecret := opts.BasicSecret, opts.BearerSecret
+	secret := newBrokerAuthSecret(brokerName, opts)
+	if secret != nil {
+		if opts.BasicUsername != "" {
+			basicSecret = secret.Name
+		} else {
+			bearerSecret = secret.Name
+		}
+		_, err = sdk.Core().Secrets(secret.Namespace).Create(secret)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create the auth secret %s/%s of the broker (%s)", secret.Namespace, secret.Name, err)
+		}
+	}
+
+	var result Broker
+	if opts.Scope == NamespaceScope {
+		result, err = sdk.registerServiceBroker(brokerName, spec, basicSecret, bearerSecret, opts)
+	} else {
+		result, err = sdk.registerClusterServiceBroker(brokerName, spec, basicSecret, bearerSecret, opts)
+	}
+	if err != nil {
+		if secret != nil {
+			// Do not leave behind the credentials of a broker that does not exist
+			sdk.Core().Secrets(secret.Namespace).Delete(secret.Name, &v1.DeleteOptions{})
+		}
+		return nil, fmt.Errorf("register request failed (%s)", err)
+	}
+
+	return result, nil
+}
+
+func (sdk *SDK) registerClusterServiceBroker(brokerName string, spec v1beta1.CommonServiceBrokerSpec,
+	basicSecret, bearerSecret string, opts *RegisterOptions) (Broker, error) {
 	request := &v1beta1.ClusterServiceBroker{
 		ObjectMeta: v1.ObjectMeta{
 			Name: brokerName,
 		},
 		Spec: v1beta1.ClusterServiceBrokerSpec{
-			CommonServiceBrokerSpec: v1beta1.CommonServiceBrokerSpec{
-				CABundle:              caBytes,
-				InsecureSkipTLSVerify: opts.SkipTLS,
-				RelistBehavior:        opts.RelistBehavior,
-				RelistDuration:        opts.RelistDuration,
-				URL:                   url,
-				CatalogRestrictions: &v1beta1.CatalogRestrictions{
-					ServiceClass: opts.ClassRestrictions,
-					ServicePlan:  opts.PlanRestrictions,
-				},
-			},
+			CommonServiceBrokerSpec: spec,
 		},
 	}
-	if opts.BasicSecret != "" {
+	if basicSecret != "" {
 		request.Spec.AuthInfo = &v1beta1.ClusterServiceBrokerAuthInfo{
 			Basic: &v1beta1.ClusterBasicAuthConfig{
 				SecretRef: &v1beta1.ObjectReference{
-					Name:      opts.BasicSecret,
+					Name:      basicSecret,
 					Namespace: opts.Namespace,
 				},
 			},
 		}
-	} else if opts.BearerSecret != "" {
+	} else if bearerSecret != "" {
 		request.Spec.AuthInfo = &v1beta1.ClusterServiceBrokerAuthInfo{
 			Bearer: &v1beta1.ClusterBearerTokenAuthConfig{
 				SecretRef: &v1beta1.ObjectReference{
-					Name:      opts.BearerSecret,
+					Name:      bearerSecret,
 					Namespace: opts.Namespace,
 				},
 			},
 		}
 	}
 
-	result, err := sdk.ServiceCatalog().ClusterServiceBrokers().Create(request)
-	if err != nil {
-		return nil, fmt.Errorf("register request failed (%s)", err)
+	return sdk.ServiceCatalog().ClusterServiceBrokers().Create(request)
+}
+
+func (sdk *SDK) registerServiceBroker(brokerName string, spec v1beta1.CommonServiceBrokerSpec,
+	basicSecret, bearerSecret string, opts *RegisterOptions) (Broker, error) {
+	request := &v1beta1.ServiceBroker{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      brokerName,
+			Namespace: opts.Namespace,
+		},
+		Spec: v1beta1.ServiceBrokerSpec{
+			CommonServiceBrokerSpec: spec,
+		},
+	}
+	if basicSecret != "" {
+		request.Spec.AuthInfo = &v1beta1.ServiceBrokerAuthInfo{
+			Basic: &v1beta1.BasicAuthConfig{
+				SecretRef: &v1beta1.LocalObjectReference{Name: basicSecret},
+			},
+		}
+	} else if bearerSecret != "" {
+		request.Spec.AuthInfo = &v1beta1.ServiceBrokerAuthInfo{
+			Bearer: &v1beta1.BearerTokenAuthConfig{
+				SecretRef: &v1beta1.LocalObjectReference{Name: bearerSecret},
+			},
+		}
 	}
 
-	return result, nil
+	return sdk.ServiceCatalog().ServiceBrokers(opts.Namespace).Create(request)
+}
+
+// newBrokerAuthSecret builds the secret holding the basic auth or bearer
+// credentials given in the options, or returns nil when there are none.
+func newBrokerAuthSecret(brokerName string, opts *RegisterOptions) *corev1.Secret {
+	switch {
+	case opts.BasicUsername != "":
+		name := opts.BasicSecret
+		if name == "" {
+			name = brokerName + "-auth"
+		}
+		return &corev1.Secret{
+			ObjectMeta: v1.ObjectMeta{Name: name, Namespace: opts.Namespace},
+			Type:       corev1.SecretTypeBasicAuth,
+			Data: map[string][]byte{
+				corev1.BasicAuthUsernameKey: []byte(opts.BasicUsername),
+				corev1.BasicAuthPasswordKey: []byte(opts.BasicPassword),
+			},
+		}
+	case opts.BearerToken != "":
+		name := opts.BearerSecret
+		if name == "" {
+			name = brokerName + "-auth"
+		}
+		return &corev1.Secret{
+			ObjectMeta: v1.ObjectMeta{Name: name, Namespace: opts.Namespace},
+			Type:       corev1.SecretTypeOpaque,
+			Data:       map[string][]byte{"token": []byte(opts.BearerToken)},
+		}
+	}
+	return nil
+}
+
+// ValidateCatalogRestrictions checks that the class and plan restrictions of
+// a broker are predicates that the controller accepts for brokers of the
+// given scope.
+func ValidateCatalogRestrictions(classRestrictions, planRestrictions []string, scope Scope) error {
+	isValidClassProperty := v1beta1.IsValidClusterServiceClassProperty
+	isValidPlanProperty := v1beta1.IsValidClusterServicePlanProperty
+	if scope == NamespaceScope {
+		isValidClassProperty = v1beta1.IsValidServiceClassProperty
+		isValidPlanProperty = v1beta1.IsValidServicePlanProperty
+	}
+
+	if err := validateRestrictions("class", classRestrictions, isValidClassProperty); err != nil {
+		return err
+	}
+	return validateRestrictions("plan", planRestrictions, isValidPlanProperty)
+}
+
+func validateRestrictions(kind string, restrictions []string, isValidProperty func(string) bool) error {
+	if len(restrictions) == 0 {
+		return nil
+	}
+	if _, err := filter.CreatePredicate(restrictions); err != nil {
+		return fmt.Errorf("invalid %s restrictions %v (%s)", kind, restrictions, err)
+	}
+	for _, restriction := range restrictions {
+		if p := filter.ExtractProperty(restriction); !isValidProperty(p) {
+			return fmt.Errorf("invalid %s restriction %q, %q is not a supported property", kind, restriction, p)
+		}
+	}
+	return nil
 }
 
 // Sync or relist a broker to refresh its catalog metadata.
@@ -189,14 +347,14 @@ func (sdk *SDK) Sync(name string, retries int) error {
 }
 
 // WaitForBroker waits for the specified broker to be Ready or Failed
-func (sdk *SDK) WaitForBroker(name string, interval time.Duration, timeout *time.Duration) (broker Broker, err error) {
+func (sdk *SDK) WaitForBroker(name string, opts ScopeOptions, interval time.Duration, timeout *time.Duration) (broker Broker, err error) {
 	if timeout == nil {
 		notimeout := time.Duration(math.MaxInt64)
 		timeout = &notimeout
 	}
 	err = wait.PollImmediate(interval, *timeout,
 		func() (bool, error) {
-			broker, err = sdk.RetrieveBroker(name)
+			broker, err = sdk.RetrieveBrokerInScope(name, opts)
 			if err != nil {
 				if apierrors.IsNotFound(errors.Cause(err)) {
 					err = nil
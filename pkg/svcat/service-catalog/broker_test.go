@@ -23,8 +23,11 @@ import (
 
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/clientset/fake"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/testing"
 
 	. "github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
@@ -216,6 +219,20 @@ var _ = Describe("Broker", func() {
 			Expect(actions[0].Matches("get", "clusterservicebrokers")).To(BeTrue())
 			Expect(actions[0].(testing.GetActionImpl).Name).To(Equal(csb.Name))
 		})
+		It("Retrieves the namespaced broker of a namespaced class", func() {
+			sc := &v1beta1.ServiceClass{
+				ObjectMeta: metav1.ObjectMeta{Namespace: sb.Namespace},
+				Spec:       v1beta1.ServiceClassSpec{ServiceBrokerName: sb.Name},
+			}
+			broker, err := sdk.RetrieveBrokerByClass(sc)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(broker).To(Equal(sb))
+			actions := svcCatClient.Actions()
+			Expect(actions[0].Matches("get", "servicebrokers")).To(BeTrue())
+			Expect(actions[0].GetNamespace()).To(Equal(sb.Namespace))
+			Expect(actions[0].(testing.GetActionImpl).Name).To(Equal(sb.Name))
+		})
 
 		It("Bubbles up errors", func() {
 			brokerName := "banana"
@@ -230,6 +247,27 @@ var _ = Describe("Broker", func() {
 			Expect(actions[0].(testing.GetActionImpl).Name).To(Equal(brokerName))
 		})
 	})
+	Describe("RetrieveBrokerInScope", func() {
+		It("Gets a cluster broker by default", func() {
+			broker, err := sdk.RetrieveBrokerInScope(csb.Name, ScopeOptions{})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(broker).To(Equal(csb))
+		})
+		It("Gets a namespaced broker", func() {
+			broker, err := sdk.RetrieveBrokerInScope(sb2.Name, ScopeOptions{Namespace: "ns2", Scope: NamespaceScope})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(broker).To(Equal(sb2))
+		})
+		It("Bubbles up errors", func() {
+			broker, err := sdk.RetrieveBrokerInScope("notfound", ScopeOptions{Namespace: "ns2", Scope: NamespaceScope})
+
+			Expect(broker).To(BeNil())
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("unable to get broker 'ns2/notfound'"))
+		})
+	})
 	Describe("Register", func() {
 		It("creates a broker by calling the v1beta1 Create method with the passed in arguements", func() {
 			brokerName := "potato_broker"
@@ -237,7 +275,7 @@ var _ = Describe("Broker", func() {
 			basicSecret := "potatobasicsecret"
 			caFile := "assets/ca"
 			namespace := "potatonamespace"
-			planRestrictions := []string{"potatoplana", "potatoplanb"}
+			planRestrictions := []string{"spec.externalName=potatoplana", "spec.free=true"}
 			relistBehavior := v1beta1.ServiceBrokerRelistBehaviorDuration
 			relistDuration := &metav1.Duration{Duration: 10 * time.Minute}
 			skipTLS := true
@@ -255,13 +293,13 @@ var _ = Describe("Broker", func() {
 
 			Expect(err).NotTo(HaveOccurred())
 			Expect(broker).NotTo(BeNil())
-			Expect(broker.Name).To(Equal(brokerName))
-			Expect(broker.Spec.URL).To(Equal(url))
-			Expect(broker.Spec.CABundle).To(Equal([]byte("foo\n")))
-			Expect(broker.Spec.InsecureSkipTLSVerify).To(BeTrue())
-			Expect(broker.Spec.RelistBehavior).To(Equal(relistBehavior))
-			Expect(broker.Spec.RelistDuration).To(Equal(relistDuration))
-			Expect(broker.Spec.CatalogRestrictions.ServicePlan).To(Equal(planRestrictions))
+			Expect(broker.GetName()).To(Equal(brokerName))
+			Expect(broker.GetSpec().URL).To(Equal(url))
+			Expect(broker.GetSpec().CABundle).To(Equal([]byte("foo\n")))
+			Expect(broker.GetSpec().InsecureSkipTLSVerify).To(BeTrue())
+			Expect(broker.GetSpec().RelistBehavior).To(Equal(relistBehavior))
+			Expect(broker.GetSpec().RelistDuration).To(Equal(relistDuration))
+			Expect(broker.GetSpec().CatalogRestrictions.ServicePlan).To(Equal(planRestrictions))
 
 			actions := svcCatClient.Actions()
 			Expect(actions[0].Matches("create", "clusterservicebrokers")).To(BeTrue())
@@ -289,8 +327,8 @@ var _ = Describe("Broker", func() {
 
 			Expect(err).NotTo(HaveOccurred())
 			Expect(broker).NotTo(BeNil())
-			Expect(broker.Name).To(Equal(brokerName))
-			Expect(broker.Spec.URL).To(Equal(url))
+			Expect(broker.GetName()).To(Equal(brokerName))
+			Expect(broker.GetSpec().URL).To(Equal(url))
 
 			actions := svcCatClient.Actions()
 			Expect(actions[0].Matches("create", "clusterservicebrokers")).To(BeTrue())
@@ -299,6 +337,87 @@ var _ = Describe("Broker", func() {
 			Expect(objectFromRequest.Spec.URL).To(Equal(url))
 			Expect(objectFromRequest.Spec.AuthInfo.Bearer.SecretRef.Name).To(Equal(bearerSecret))
 		})
+		It("creates a namespaced broker along with its basic auth secret", func() {
+			k8sClient := k8sfake.NewSimpleClientset()
+			sdk.K8sClient = k8sClient
+			opts := &RegisterOptions{
+				BasicUsername: "admin",
+				BasicPassword: "s3cret",
+				Namespace:     "potatonamespace",
+				Scope:         NamespaceScope,
+			}
+
+			broker, err := sdk.Register("potato_broker", "http://potato.com", opts)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(broker.GetNamespace()).To(Equal("potatonamespace"))
+
+			secret, err := k8sClient.CoreV1().Secrets("potatonamespace").Get("potato_broker-auth", metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(secret.Type).To(Equal(corev1.SecretTypeBasicAuth))
+			Expect(secret.Data).To(Equal(map[string][]byte{
+				"username": []byte("admin"),
+				"password": []byte("s3cret"),
+			}))
+
+			actions := svcCatClient.Actions()
+			Expect(actions[0].Matches("create", "servicebrokers")).To(BeTrue())
+			objectFromRequest := actions[0].(testing.CreateActionImpl).Object.(*v1beta1.ServiceBroker)
+			Expect(objectFromRequest.Namespace).To(Equal("potatonamespace"))
+			Expect(objectFromRequest.Spec.AuthInfo.Basic.SecretRef.Name).To(Equal("potato_broker-auth"))
+		})
+		It("creates the bearer secret named by the options", func() {
+			k8sClient := k8sfake.NewSimpleClientset()
+			sdk.K8sClient = k8sClient
+			opts := &RegisterOptions{
+				BearerSecret: "potatotoken",
+				BearerToken:  "t0ken",
+				Namespace:    "potatonamespace",
+			}
+
+			_, err := sdk.Register("potato_broker", "http://potato.com", opts)
+
+			Expect(err).NotTo(HaveOccurred())
+			secret, err := k8sClient.CoreV1().Secrets("potatonamespace").Get("potatotoken", metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(secret.Data).To(Equal(map[string][]byte{"token": []byte("t0ken")}))
+
+			objectFromRequest := svcCatClient.Actions()[0].(testing.CreateActionImpl).Object.(*v1beta1.ClusterServiceBroker)
+			Expect(objectFromRequest.Spec.AuthInfo.Bearer.SecretRef.Name).To(Equal("potatotoken"))
+			Expect(objectFromRequest.Spec.AuthInfo.Bearer.SecretRef.Namespace).To(Equal("potatonamespace"))
+		})
+		It("validates the catalog restrictions before creating anything", func() {
+			k8sClient := k8sfake.NewSimpleClientset()
+			sdk.K8sClient = k8sClient
+			opts := &RegisterOptions{
+				BasicUsername:     "admin",
+				BasicPassword:     "s3cret",
+				ClassRestrictions: []string{"spec.clusterServiceBrokerName=potato_broker"},
+				Scope:             NamespaceScope,
+			}
+
+			_, err := sdk.Register("potato_broker", "http://potato.com", opts)
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("\"spec.clusterServiceBrokerName\" is not a supported property"))
+			Expect(svcCatClient.Actions()).To(BeEmpty())
+			Expect(k8sClient.Actions()).To(BeEmpty())
+		})
+		It("deletes the secret it created when the broker cannot be created", func() {
+			k8sClient := k8sfake.NewSimpleClientset()
+			sdk.K8sClient = k8sClient
+			badClient := &fake.Clientset{}
+			badClient.AddReactor("create", "clusterservicebrokers", func(action testing.Action) (bool, runtime.Object, error) {
+				return true, nil, errors.New("already exists")
+			})
+			sdk.ServiceCatalogClient = badClient
+
+			_, err := sdk.Register("potato_broker", "http://potato.com", &RegisterOptions{BearerToken: "t0ken", Namespace: "potatonamespace"})
+
+			Expect(err).To(HaveOccurred())
+			_, err = k8sClient.CoreV1().Secrets("potatonamespace").Get("potato_broker-auth", metav1.GetOptions{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
 		It("Bubbles up errors", func() {
 			errorMessage := "error provisioning broker"
 			brokerName := "potato_broker"
@@ -363,7 +482,7 @@ var _ = Describe("Broker", func() {
 				return false, nil, nil
 			})
 
-			broker, err := sdk.WaitForBroker(csb.Name, interval, &timeout)
+			broker, err := sdk.WaitForBroker(csb.Name, ScopeOptions{}, interval, &timeout)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(broker).To(Equal(csb))
 			actions := waitClient.Actions()
@@ -384,7 +503,7 @@ var _ = Describe("Broker", func() {
 				return false, nil, nil
 			})
 
-			broker, err := sdk.WaitForBroker(csb.Name, interval, &timeout)
+			broker, err := sdk.WaitForBroker(csb.Name, ScopeOptions{}, interval, &timeout)
 
 			Expect(err).NotTo(HaveOccurred())
 			Expect(broker).To(Equal(failedBroker))
@@ -396,7 +515,7 @@ var _ = Describe("Broker", func() {
 			}
 		})
 		It("times out if the broker never becomes ready or failed", func() {
-			broker, err := sdk.WaitForBroker(csb.Name, interval, &timeout)
+			broker, err := sdk.WaitForBroker(csb.Name, ScopeOptions{}, interval, &timeout)
 
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("timed out"))
@@ -417,7 +536,7 @@ var _ = Describe("Broker", func() {
 				return false, nil, nil
 			})
 
-			broker, err := sdk.WaitForBroker(csb.Name, interval, &timeout)
+			broker, err := sdk.WaitForBroker(csb.Name, ScopeOptions{}, interval, &timeout)
 
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring(errorMessage))
@@ -44,6 +44,12 @@ type Class interface {
 
 	// GetDescription returns the class description.
 	GetDescription() string
+
+	// GetServiceBrokerName returns the name of the broker that offers the class.
+	GetServiceBrokerName() string
+
+	// GetSpec returns the spec that is common to classes of both scopes.
+	GetSpec() v1beta1.CommonServiceClassSpec
 }
 
 // RetrieveClasses lists all classes defined in the cluster.
@@ -96,6 +102,50 @@ func (sdk *SDK) RetrieveClassByName(name string) (*v1beta1.ClusterServiceClass,
 	return &searchResults.Items[0], nil
 }
 
+// retrieveScopedClassByName gets a class by its external name, searching the
+// namespace and the cluster scopes as limited by opts. It is an error for the
+// name to match classes in both scopes.
+func (sdk *SDK) retrieveScopedClassByName(name string, opts ScopeOptions) (Class, error) {
+	var classes []Class
+	listOpts := v1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector(FieldExternalClassName, name).String(),
+	}
+
+	if opts.Scope.Matches(NamespaceScope) {
+		sc, err := sdk.ServiceCatalog().ServiceClasses(opts.Namespace).List(listOpts)
+		// Gracefully handle when the feature-flag for namespaced broker resources isn't enabled on the server.
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, fmt.Errorf("unable to search classes by name in %q (%s)", opts.Namespace, err)
+		}
+		if err == nil {
+			for i := range sc.Items {
+				classes = append(classes, &sc.Items[i])
+			}
+		}
+	}
+
+	if opts.Scope.Matches(ClusterScope) {
+		csc, err := sdk.ServiceCatalog().ClusterServiceClasses().List(listOpts)
+		if err != nil {
+			return nil, fmt.Errorf("unable to search classes by name (%s)", err)
+		}
+		for i := range csc.Items {
+			classes = append(classes, &csc.Items[i])
+		}
+	}
+
+	if len(classes) == 0 {
+		return nil, fmt.Errorf("class '%s' not found", name)
+	}
+	if len(classes) > 1 {
+		if classes[0].GetNamespace() != classes[len(classes)-1].GetNamespace() {
+			return nil, fmt.Errorf("class '%s' is defined both in namespace %q and at the cluster scope", name, opts.Namespace)
+		}
+		return nil, fmt.Errorf("more than one matching class found for '%s'", name)
+	}
+	return classes[0], nil
+}
+
 // RetrieveClassByID gets a class by its UUID.
 func (sdk *SDK) RetrieveClassByID(uuid string) (*v1beta1.ClusterServiceClass, error) {
 	class, err := sdk.ServiceCatalog().ClusterServiceClasses().Get(uuid, v1.GetOptions{})
@@ -0,0 +1,500 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecatalog
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Condition reasons set by the controller that svcat doctor knows how to
+// diagnose. They must be kept in sync with the reasons in pkg/controller.
+const (
+	ReasonNonexistentServiceClass      = "ReferencesNonexistentServiceClass"
+	ReasonNonexistentServicePlan       = "ReferencesNonexistentServicePlan"
+	ReasonNonexistentBroker            = "ReferencesNonexistentBroker"
+	ReasonNonexistentInstance          = "ReferencesNonexistentInstance"
+	ReasonDeletedServiceClass          = "ReferencesDeletedServiceClass"
+	ReasonDeletedServicePlan           = "ReferencesDeletedServicePlan"
+	ReasonProvisionCallFailed          = "ProvisionCallFailed"
+	ReasonErrorCallingProvision        = "ErrorCallingProvision"
+	ReasonUpdateInstanceCallFailed     = "UpdateInstanceCallFailed"
+	ReasonErrorCallingUpdateInstance   = "ErrorCallingUpdateInstance"
+	ReasonDeprovisionCallFailed        = "DeprovisionCallFailed"
+	ReasonDeprovisionBlocked           = "DeprovisionBlockedByExistingCredentials"
+	ReasonErrorPollingLastOperation    = "ErrorPollingLastOperation"
+	ReasonReconciliationRetryTimeout   = "ErrorReconciliationRetryTimeout"
+	ReasonStartingOrphanMitigation     = "StartingInstanceOrphanMitigation"
+	ReasonOrphanMitigationFailed       = "OrphanMitigationFailed"
+	ReasonBindCallFailed               = "BindCallFailed"
+	ReasonErrorInjectingBindResult     = "ErrorInjectingBindResult"
+	ReasonErrorNonbindableServiceClass = "ErrorNonbindableServiceClass"
+	ReasonErrorInstanceNotReady        = "ErrorInstanceNotReady"
+	ReasonAsyncOperationTimeout        = "AsyncOperationTimeout"
+	ReasonErrorFetchingCatalog         = "ErrorFetchingCatalog"
+	ReasonErrorSyncingCatalog          = "ErrorSyncingCatalog"
+	ReasonErrorAuthCredentials         = "ErrorGettingAuthCredentials"
+	ReasonBrokerUnavailable            = "BrokerUnavailable"
+)
+
+// Severity ranks the problems found by svcat doctor.
+type Severity int
+
+const (
+	// SeverityError is a problem that the controller will not recover from
+	// without a change to the resource or to one of its parents.
+	SeverityError Severity = iota
+	// SeverityWarning is a problem that the controller is retrying.
+	SeverityWarning
+	// SeverityInfo is a notable state that is not a problem yet, such as an
+	// operation in progress.
+	SeverityInfo
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityError:
+		return "Error"
+	case SeverityWarning:
+		return "Warning"
+	default:
+		return "Info"
+	}
+}
+
+// MarshalText prints the severity by name in json and yaml.
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// Diagnosis is a problem found on a resource, or on one of its parents,
+// along with the commands to run to investigate or fix it.
+type Diagnosis struct {
+	Severity    Severity `json:"severity"`
+	Resource    string   `json:"resource"`
+	Reason      string   `json:"reason,omitempty"`
+	Problem     string   `json:"problem"`
+	Suggestions []string `json:"suggestions,omitempty"`
+}
+
+// diagnoses collects the problems found by svcat doctor.
+type diagnoses []Diagnosis
+
+func (d *diagnoses) add(severity Severity, resource, reason, problem string, suggestions ...string) {
+	*d = append(*d, Diagnosis{
+		Severity:    severity,
+		Resource:    resource,
+		Reason:      reason,
+		Problem:     problem,
+		Suggestions: suggestions,
+	})
+}
+
+// ranked returns the diagnoses with the most severe first. Diagnoses of the
+// same severity stay in the order they were found, from the resource up to
+// its broker.
+func (d diagnoses) ranked() []Diagnosis {
+	sort.SliceStable(d, func(i, j int) bool {
+		return d[i].Severity < d[j].Severity
+	})
+	return d
+}
+
+// DiagnoseInstance checks an instance, and the class, plan and broker that it
+// references, for the failure modes reported by the controller. The
+// diagnoses are ranked, most severe first.
+func (sdk *SDK) DiagnoseInstance(ns, name string) ([]Diagnosis, error) {
+	instance, err := sdk.RetrieveInstance(ns, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var d diagnoses
+	sdk.diagnoseInstanceHierarchy(&d, instance)
+	return d.ranked(), nil
+}
+
+// DiagnoseBinding checks a binding, its secret, and the instance, class,
+// plan and broker that it references, for the failure modes reported by the
+// controller. The diagnoses are ranked, most severe first.
+func (sdk *SDK) DiagnoseBinding(ns, name string) ([]Diagnosis, error) {
+	binding, err := sdk.RetrieveBinding(ns, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var d diagnoses
+	diagnoseBinding(&d, binding)
+	if sdk.IsBindingReady(binding) {
+		sdk.diagnoseBindingSecret(&d, binding)
+	}
+
+	instance, err := sdk.RetrieveInstanceByBinding(binding)
+	if err != nil {
+		if !hasDiagnosis(d, ReasonNonexistentInstance) {
+			d.add(SeverityError, bindingResource(binding), "",
+				fmt.Sprintf("Unable to get instance %q (%s)", binding.Spec.ServiceInstanceRef.Name, err),
+				fmt.Sprintf("svcat get instances -n %s", binding.Namespace))
+		}
+		return d.ranked(), nil
+	}
+	sdk.diagnoseInstanceHierarchy(&d, instance)
+	return d.ranked(), nil
+}
+
+// DiagnoseBroker checks a cluster broker for the failure modes reported by
+// the controller.
+func (sdk *SDK) DiagnoseBroker(name string) ([]Diagnosis, error) {
+	broker, err := sdk.RetrieveBroker(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var d diagnoses
+	diagnoseBroker(&d, broker)
+	return d.ranked(), nil
+}
+
+// diagnoseInstanceHierarchy checks an instance and walks up to its class,
+// plan and broker, stopping at the first parent that cannot be retrieved.
+func (sdk *SDK) diagnoseInstanceHierarchy(d *diagnoses, instance *v1beta1.ServiceInstance) {
+	diagnoseInstance(d, instance)
+
+	resource := instanceResource(instance)
+	class, plan, err := sdk.InstanceToServiceClassAndPlan(instance)
+	if err != nil {
+		if !hasDiagnosis(*d, ReasonNonexistentServiceClass, ReasonNonexistentServicePlan) {
+			d.add(SeverityError, resource, "",
+				fmt.Sprintf("Unable to resolve the class and plan of the instance (%s)", err),
+				"svcat marketplace",
+				fmt.Sprintf("svcat describe instance %s -n %s", instance.Name, instance.Namespace))
+		}
+		return
+	}
+
+	if classStatus(class).RemovedFromBrokerCatalog && !hasDiagnosis(*d, ReasonDeletedServiceClass) {
+		d.add(SeverityWarning, classResource(class), "",
+			fmt.Sprintf("The class was removed from the catalog of broker %q, its instances can no longer be updated", class.GetServiceBrokerName()),
+			fmt.Sprintf("svcat describe class %s", class.GetExternalName()))
+	}
+	if planStatus(plan).RemovedFromBrokerCatalog && !hasDiagnosis(*d, ReasonDeletedServicePlan) {
+		d.add(SeverityWarning, planResource(plan), "",
+			"The plan was removed from the catalog of the broker, the instance can only be updated to another plan",
+			fmt.Sprintf("svcat get plans --class %s", class.GetExternalName()),
+			fmt.Sprintf("svcat update instance %s -n %s --plan PLAN", instance.Name, instance.Namespace))
+	}
+
+	broker, err := sdk.RetrieveBrokerByClass(class)
+	if err != nil {
+		if !hasDiagnosis(*d, ReasonNonexistentBroker) {
+			severity := SeverityWarning
+			if apierrors.IsNotFound(err) {
+				severity = SeverityError
+			}
+			d.add(severity, classResource(class), "",
+				fmt.Sprintf("Unable to get broker %q (%s)", class.GetServiceBrokerName(), err),
+				"svcat get brokers")
+		}
+		return
+	}
+	diagnoseBroker(d, broker)
+}
+
+// diagnoseInstance checks the conditions of an instance against the reasons
+// reported by the controller.
+func diagnoseInstance(d *diagnoses, instance *v1beta1.ServiceInstance) {
+	resource := instanceResource(instance)
+	describe := fmt.Sprintf("svcat describe instance %s -n %s", instance.Name, instance.Namespace)
+	history := fmt.Sprintf("svcat history instance %s -n %s", instance.Name, instance.Namespace)
+	touch := fmt.Sprintf("svcat touch instance %s -n %s", instance.Name, instance.Namespace)
+
+	for _, cond := range instance.Status.Conditions {
+		if cond.Status != v1beta1.ConditionTrue && cond.Type != v1beta1.ServiceInstanceConditionReady {
+			continue
+		}
+
+		switch cond.Type {
+		case v1beta1.ServiceInstanceConditionFailed:
+			d.add(SeverityError, resource, cond.Reason,
+				fmt.Sprintf("The operation failed and will not be retried: %s", cond.Message),
+				history,
+				fmt.Sprintf("svcat deprovision %s -n %s", instance.Name, instance.Namespace))
+			continue
+		case v1beta1.ServiceInstanceConditionOrphanMitigation:
+			d.add(SeverityWarning, resource, cond.Reason,
+				"The broker is being asked to delete the resources of the failed provision before it is retried",
+				describe)
+			continue
+		case v1beta1.ServiceInstanceConditionDrifted:
+			d.add(SeverityWarning, resource, cond.Reason,
+				fmt.Sprintf("The broker reports a plan or parameters that differ from the spec: %s", cond.Message),
+				describe, touch)
+			continue
+		case v1beta1.ServiceInstanceConditionUpgradeAvailable:
+			d.add(SeverityInfo, resource, cond.Reason, cond.Message, describe)
+			continue
+		case v1beta1.ServiceInstanceConditionReady:
+		default:
+			continue
+		}
+
+		if cond.Status == v1beta1.ConditionTrue {
+			continue
+		}
+		switch cond.Reason {
+		case ReasonNonexistentServiceClass, ReasonNonexistentServicePlan:
+			d.add(SeverityError, resource, cond.Reason, cond.Message,
+				"svcat marketplace",
+				fmt.Sprintf("svcat deprovision %s -n %s", instance.Name, instance.Namespace))
+		case ReasonNonexistentBroker:
+			d.add(SeverityError, resource, cond.Reason, cond.Message, "svcat get brokers")
+		case ReasonDeletedServiceClass, ReasonDeletedServicePlan:
+			d.add(SeverityError, resource, cond.Reason,
+				fmt.Sprintf("The class or plan was removed from the catalog of the broker: %s", cond.Message),
+				"svcat marketplace",
+				fmt.Sprintf("svcat update instance %s -n %s --plan PLAN", instance.Name, instance.Namespace))
+		case ReasonDeprovisionBlocked:
+			d.add(SeverityError, resource, cond.Reason,
+				"The instance cannot be deprovisioned until its bindings are deleted",
+				fmt.Sprintf("svcat get bindings -n %s", instance.Namespace),
+				fmt.Sprintf("svcat unbind %s -n %s", instance.Name, instance.Namespace))
+		case ReasonOrphanMitigationFailed:
+			d.add(SeverityError, resource, cond.Reason,
+				fmt.Sprintf("The broker could not delete the resources of the failed provision: %s", cond.Message),
+				history, touch)
+		case ReasonReconciliationRetryTimeout:
+			d.add(SeverityError, resource, cond.Reason,
+				fmt.Sprintf("The controller stopped retrying the operation after the reconciliation retry duration: %s", cond.Message),
+				history, touch)
+		case ReasonProvisionCallFailed, ReasonUpdateInstanceCallFailed, ReasonDeprovisionCallFailed:
+			d.add(SeverityWarning, resource, cond.Reason,
+				fmt.Sprintf("The broker rejected the request, it will be retried: %s", cond.Message),
+				describe, history)
+		case ReasonErrorCallingProvision, ReasonErrorCallingUpdateInstance, ReasonErrorPollingLastOperation:
+			d.add(SeverityWarning, resource, cond.Reason,
+				fmt.Sprintf("The broker could not be reached, the request will be retried: %s", cond.Message),
+				describe, "svcat get brokers")
+		case ReasonBrokerUnavailable:
+			d.add(SeverityWarning, resource, cond.Reason,
+				fmt.Sprintf("Requests to the broker are suspended until it recovers: %s", cond.Message),
+				"svcat get brokers")
+		case ReasonStartingOrphanMitigation:
+			d.add(SeverityWarning, resource, cond.Reason, cond.Message, describe)
+		default:
+			if instance.Status.CurrentOperation != "" {
+				d.add(SeverityInfo, resource, cond.Reason,
+					fmt.Sprintf("%s operation in progress: %s", instance.Status.CurrentOperation, cond.Message),
+					fmt.Sprintf("svcat describe instance %s -n %s --wait", instance.Name, instance.Namespace))
+			} else {
+				d.add(SeverityWarning, resource, cond.Reason, cond.Message, describe)
+			}
+		}
+	}
+
+	if retry := instance.Status.OperationRetry; retry != nil {
+		d.add(SeverityWarning, resource, "",
+			fmt.Sprintf("The operation failed %d time(s), the next attempt is at %s", retry.Attempts, retry.NextRetryTime.UTC()),
+			history)
+	}
+}
+
+// diagnoseBinding checks the conditions of a binding against the reasons
+// reported by the controller.
+func diagnoseBinding(d *diagnoses, binding *v1beta1.ServiceBinding) {
+	resource := bindingResource(binding)
+	describe := fmt.Sprintf("svcat describe binding %s -n %s", binding.Name, binding.Namespace)
+	rebind := fmt.Sprintf("svcat unbind -n %s --name %s", binding.Namespace, binding.Name)
+
+	for _, cond := range binding.Status.Conditions {
+		if cond.Type == v1beta1.ServiceBindingConditionFailed {
+			if cond.Status == v1beta1.ConditionTrue {
+				d.add(SeverityError, resource, cond.Reason,
+					fmt.Sprintf("The binding failed and will not be retried: %s", cond.Message),
+					describe, rebind)
+			}
+			continue
+		}
+		if cond.Type != v1beta1.ServiceBindingConditionReady || cond.Status == v1beta1.ConditionTrue {
+			continue
+		}
+
+		switch cond.Reason {
+		case ReasonNonexistentInstance:
+			d.add(SeverityError, resource, cond.Reason, cond.Message,
+				fmt.Sprintf("svcat get instances -n %s", binding.Namespace))
+		case ReasonErrorInstanceNotReady:
+			d.add(SeverityWarning, resource, cond.Reason,
+				"The binding is waiting for its instance to be ready",
+				fmt.Sprintf("svcat doctor instance %s -n %s", binding.Spec.ServiceInstanceRef.Name, binding.Namespace))
+		case ReasonErrorNonbindableServiceClass:
+			d.add(SeverityError, resource, cond.Reason, cond.Message,
+				fmt.Sprintf("svcat describe instance %s -n %s", binding.Spec.ServiceInstanceRef.Name, binding.Namespace),
+				rebind)
+		case ReasonAsyncOperationTimeout:
+			d.add(SeverityError, resource, cond.Reason,
+				fmt.Sprintf("The broker did not complete the binding in time: %s", cond.Message),
+				rebind)
+		case ReasonBindCallFailed:
+			d.add(SeverityWarning, resource, cond.Reason,
+				fmt.Sprintf("The broker rejected the request, it will be retried: %s", cond.Message),
+				describe)
+		case ReasonErrorInjectingBindResult:
+			d.add(SeverityWarning, resource, cond.Reason,
+				fmt.Sprintf("The credentials could not be written to secret %q: %s", binding.Spec.SecretName, cond.Message),
+				fmt.Sprintf("kubectl get secret %s -n %s", binding.Spec.SecretName, binding.Namespace))
+		case ReasonBrokerUnavailable:
+			d.add(SeverityWarning, resource, cond.Reason,
+				fmt.Sprintf("Requests to the broker are suspended until it recovers: %s", cond.Message),
+				"svcat get brokers")
+		default:
+			if binding.Status.CurrentOperation != "" {
+				d.add(SeverityInfo, resource, cond.Reason,
+					fmt.Sprintf("%s operation in progress: %s", binding.Status.CurrentOperation, cond.Message),
+					describe)
+			} else {
+				d.add(SeverityWarning, resource, cond.Reason, cond.Message, describe)
+			}
+		}
+	}
+}
+
+// diagnoseBindingSecret checks that the secret of a ready binding exists.
+func (sdk *SDK) diagnoseBindingSecret(d *diagnoses, binding *v1beta1.ServiceBinding) {
+	_, err := sdk.Core().Secrets(binding.Namespace).Get(binding.Spec.SecretName, metav1.GetOptions{})
+	switch {
+	case err == nil:
+	case apierrors.IsNotFound(err):
+		d.add(SeverityError, bindingResource(binding), "",
+			fmt.Sprintf("The secret %q of the binding was deleted", binding.Spec.SecretName),
+			fmt.Sprintf("svcat unbind -n %s --name %s", binding.Namespace, binding.Name),
+			fmt.Sprintf("svcat bind %s -n %s --name %s", binding.Spec.ServiceInstanceRef.Name, binding.Namespace, binding.Name))
+	default:
+		d.add(SeverityWarning, bindingResource(binding), "",
+			fmt.Sprintf("Unable to get the secret %q of the binding (%s)", binding.Spec.SecretName, err),
+			fmt.Sprintf("kubectl get secret %s -n %s", binding.Spec.SecretName, binding.Namespace))
+	}
+}
+
+// diagnoseBroker checks the conditions of a broker against the reasons
+// reported by the controller.
+func diagnoseBroker(d *diagnoses, broker Broker) {
+	resource := brokerResource(broker)
+	describe := fmt.Sprintf("svcat describe broker %s", broker.GetName())
+	sync := fmt.Sprintf("svcat sync broker %s", broker.GetName())
+
+	for _, cond := range broker.GetStatus().Conditions {
+		switch cond.Type {
+		case v1beta1.ServiceBrokerConditionFailed:
+			if cond.Status == v1beta1.ConditionTrue {
+				d.add(SeverityError, resource, cond.Reason, cond.Message, describe)
+			}
+			continue
+		case v1beta1.ServiceBrokerConditionDegraded:
+			if cond.Status == v1beta1.ConditionTrue {
+				d.add(SeverityWarning, resource, cond.Reason,
+					fmt.Sprintf("Requests to the broker are suspended because too many of them failed: %s", cond.Message),
+					describe)
+			}
+			continue
+		case v1beta1.ServiceBrokerConditionReady:
+		default:
+			continue
+		}
+
+		if cond.Status == v1beta1.ConditionTrue {
+			continue
+		}
+		switch cond.Reason {
+		case ReasonErrorFetchingCatalog:
+			d.add(SeverityError, resource, cond.Reason,
+				fmt.Sprintf("The catalog could not be fetched from %s: %s", broker.GetURL(), cond.Message),
+				describe, sync)
+		case ReasonErrorSyncingCatalog:
+			d.add(SeverityError, resource, cond.Reason,
+				fmt.Sprintf("The catalog of the broker could not be saved: %s", cond.Message),
+				sync)
+		case ReasonErrorAuthCredentials:
+			d.add(SeverityError, resource, cond.Reason,
+				fmt.Sprintf("The credentials of the broker could not be read: %s", cond.Message),
+				describe)
+		default:
+			d.add(SeverityWarning, resource, cond.Reason, cond.Message, describe, sync)
+		}
+	}
+}
+
+func hasDiagnosis(d diagnoses, reasons ...string) bool {
+	for _, diagnosis := range d {
+		for _, reason := range reasons {
+			if diagnosis.Reason == reason {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func classStatus(class Class) v1beta1.CommonServiceClassStatus {
+	switch c := class.(type) {
+	case *v1beta1.ClusterServiceClass:
+		return c.Status.CommonServiceClassStatus
+	case *v1beta1.ServiceClass:
+		return c.Status.CommonServiceClassStatus
+	}
+	return v1beta1.CommonServiceClassStatus{}
+}
+
+func planStatus(plan Plan) v1beta1.CommonServicePlanStatus {
+	switch p := plan.(type) {
+	case *v1beta1.ClusterServicePlan:
+		return p.Status.CommonServicePlanStatus
+	case *v1beta1.ServicePlan:
+		return p.Status.CommonServicePlanStatus
+	}
+	return v1beta1.CommonServicePlanStatus{}
+}
+
+func instanceResource(instance *v1beta1.ServiceInstance) string {
+	return fmt.Sprintf("instance %s/%s", instance.Namespace, instance.Name)
+}
+
+func bindingResource(binding *v1beta1.ServiceBinding) string {
+	return fmt.Sprintf("binding %s/%s", binding.Namespace, binding.Name)
+}
+
+func classResource(class Class) string {
+	return "class " + qualifiedName(class.GetNamespace(), class.GetExternalName())
+}
+
+func planResource(plan Plan) string {
+	return "plan " + qualifiedName(plan.GetNamespace(), plan.GetExternalName())
+}
+
+func brokerResource(broker Broker) string {
+	return "broker " + qualifiedName(broker.GetNamespace(), broker.GetName())
+}
+
+func qualifiedName(ns, name string) string {
+	if ns == "" {
+		return name
+	}
+	return ns + "/" + name
+}
@@ -0,0 +1,258 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecatalog_test
+
+import (
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/clientset/fake"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+
+	. "github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Doctor", func() {
+	var (
+		sdk      *SDK
+		broker   *v1beta1.ClusterServiceBroker
+		class    *v1beta1.ClusterServiceClass
+		plan     *v1beta1.ClusterServicePlan
+		instance *v1beta1.ServiceInstance
+		binding  *v1beta1.ServiceBinding
+		secret   *corev1.Secret
+	)
+
+	BeforeEach(func() {
+		broker = &v1beta1.ClusterServiceBroker{
+			ObjectMeta: metav1.ObjectMeta{Name: "mybroker"},
+			Spec: v1beta1.ClusterServiceBrokerSpec{
+				CommonServiceBrokerSpec: v1beta1.CommonServiceBrokerSpec{URL: "http://mybroker"},
+			},
+		}
+		broker.Status.Conditions = []v1beta1.ServiceBrokerCondition{{
+			Type:   v1beta1.ServiceBrokerConditionReady,
+			Status: v1beta1.ConditionTrue,
+			Reason: "FetchedCatalog",
+		}}
+		class = &v1beta1.ClusterServiceClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "class-uuid"},
+			Spec: v1beta1.ClusterServiceClassSpec{
+				ClusterServiceBrokerName: "mybroker",
+				CommonServiceClassSpec:   v1beta1.CommonServiceClassSpec{ExternalName: "mysqldb"},
+			},
+		}
+		plan = &v1beta1.ClusterServicePlan{
+			ObjectMeta: metav1.ObjectMeta{Name: "plan-uuid"},
+			Spec: v1beta1.ClusterServicePlanSpec{
+				CommonServicePlanSpec:  v1beta1.CommonServicePlanSpec{ExternalName: "free"},
+				ClusterServiceClassRef: v1beta1.ClusterObjectReference{Name: "class-uuid"},
+			},
+		}
+		instance = &v1beta1.ServiceInstance{
+			ObjectMeta: metav1.ObjectMeta{Name: "myinstance", Namespace: "myns"},
+			Spec: v1beta1.ServiceInstanceSpec{
+				ClusterServiceClassRef: &v1beta1.ClusterObjectReference{Name: "class-uuid"},
+				ClusterServicePlanRef:  &v1beta1.ClusterObjectReference{Name: "plan-uuid"},
+			},
+		}
+		instance.Status.Conditions = []v1beta1.ServiceInstanceCondition{{
+			Type:   v1beta1.ServiceInstanceConditionReady,
+			Status: v1beta1.ConditionTrue,
+			Reason: "ProvisionedSuccessfully",
+		}}
+		binding = &v1beta1.ServiceBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "mybinding", Namespace: "myns"},
+			Spec: v1beta1.ServiceBindingSpec{
+				ServiceInstanceRef: v1beta1.LocalObjectReference{Name: "myinstance"},
+				SecretName:         "mybinding",
+			},
+		}
+		binding.Status.Conditions = []v1beta1.ServiceBindingCondition{{
+			Type:   v1beta1.ServiceBindingConditionReady,
+			Status: v1beta1.ConditionTrue,
+			Reason: "InjectedBindResult",
+		}}
+		secret = &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "mybinding", Namespace: "myns"}}
+	})
+
+	newSDK := func() *SDK {
+		return &SDK{
+			K8sClient:            k8sfake.NewSimpleClientset(secret),
+			ServiceCatalogClient: fake.NewSimpleClientset(broker, class, plan, instance, binding),
+		}
+	}
+
+	Describe("DiagnoseInstance", func() {
+		It("Finds no problems with a ready instance", func() {
+			sdk = newSDK()
+
+			diagnoses, err := sdk.DiagnoseInstance("myns", "myinstance")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diagnoses).To(BeEmpty())
+		})
+		It("Ranks the problems of the instance and of its broker", func() {
+			instance.Status.CurrentOperation = v1beta1.ServiceInstanceOperationProvision
+			instance.Status.Conditions = []v1beta1.ServiceInstanceCondition{{
+				Type:    v1beta1.ServiceInstanceConditionReady,
+				Status:  v1beta1.ConditionFalse,
+				Reason:  ReasonErrorCallingProvision,
+				Message: "connection refused",
+			}}
+			instance.Status.OperationRetry = &v1beta1.ServiceInstanceOperationRetry{Attempts: 3}
+			broker.Status.Conditions = []v1beta1.ServiceBrokerCondition{{
+				Type:    v1beta1.ServiceBrokerConditionReady,
+				Status:  v1beta1.ConditionFalse,
+				Reason:  ReasonErrorFetchingCatalog,
+				Message: "connection refused",
+			}}
+			sdk = newSDK()
+
+			diagnoses, err := sdk.DiagnoseInstance("myns", "myinstance")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diagnoses).To(HaveLen(3))
+			Expect(diagnoses[0].Severity).To(Equal(SeverityError))
+			Expect(diagnoses[0].Resource).To(Equal("broker mybroker"))
+			Expect(diagnoses[0].Reason).To(Equal(ReasonErrorFetchingCatalog))
+			Expect(diagnoses[0].Suggestions).To(ContainElement("svcat sync broker mybroker"))
+			Expect(diagnoses[1].Severity).To(Equal(SeverityWarning))
+			Expect(diagnoses[1].Resource).To(Equal("instance myns/myinstance"))
+			Expect(diagnoses[1].Reason).To(Equal(ReasonErrorCallingProvision))
+			Expect(diagnoses[2].Severity).To(Equal(SeverityWarning))
+			Expect(diagnoses[2].Problem).To(ContainSubstring("failed 3 time(s)"))
+		})
+		It("Suggests deleting the bindings of an instance blocked from deprovisioning", func() {
+			instance.Status.Conditions[0].Status = v1beta1.ConditionFalse
+			instance.Status.Conditions[0].Reason = ReasonDeprovisionBlocked
+			sdk = newSDK()
+
+			diagnoses, err := sdk.DiagnoseInstance("myns", "myinstance")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diagnoses).To(HaveLen(1))
+			Expect(diagnoses[0].Severity).To(Equal(SeverityError))
+			Expect(diagnoses[0].Suggestions).To(Equal([]string{
+				"svcat get bindings -n myns",
+				"svcat unbind myinstance -n myns",
+			}))
+		})
+		It("Reports a plan removed from the catalog of the broker", func() {
+			plan.Status.RemovedFromBrokerCatalog = true
+			sdk = newSDK()
+
+			diagnoses, err := sdk.DiagnoseInstance("myns", "myinstance")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diagnoses).To(HaveLen(1))
+			Expect(diagnoses[0].Resource).To(Equal("plan free"))
+			Expect(diagnoses[0].Suggestions).To(ContainElement("svcat get plans --class mysqldb"))
+		})
+		It("Stops at a missing broker", func() {
+			broker.Name = "otherbroker"
+			sdk = newSDK()
+
+			diagnoses, err := sdk.DiagnoseInstance("myns", "myinstance")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diagnoses).To(HaveLen(1))
+			Expect(diagnoses[0].Severity).To(Equal(SeverityError))
+			Expect(diagnoses[0].Problem).To(ContainSubstring("Unable to get broker \"mybroker\""))
+		})
+		It("Bubbles up errors", func() {
+			sdk = newSDK()
+
+			_, err := sdk.DiagnoseInstance("myns", "notfound")
+
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("DiagnoseBinding", func() {
+		It("Finds no problems with a ready binding", func() {
+			sdk = newSDK()
+
+			diagnoses, err := sdk.DiagnoseBinding("myns", "mybinding")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diagnoses).To(BeEmpty())
+		})
+		It("Reports a deleted secret", func() {
+			secret.Name = "othersecret"
+			sdk = newSDK()
+
+			diagnoses, err := sdk.DiagnoseBinding("myns", "mybinding")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diagnoses).To(HaveLen(1))
+			Expect(diagnoses[0].Severity).To(Equal(SeverityError))
+			Expect(diagnoses[0].Problem).To(ContainSubstring("was deleted"))
+		})
+		It("Walks up to the instance the binding is waiting for", func() {
+			binding.Status.Conditions[0].Status = v1beta1.ConditionFalse
+			binding.Status.Conditions[0].Reason = ReasonErrorInstanceNotReady
+			instance.Status.Conditions[0].Status = v1beta1.ConditionFalse
+			instance.Status.Conditions[0].Reason = ReasonReconciliationRetryTimeout
+			sdk = newSDK()
+
+			diagnoses, err := sdk.DiagnoseBinding("myns", "mybinding")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diagnoses).To(HaveLen(2))
+			Expect(diagnoses[0].Resource).To(Equal("instance myns/myinstance"))
+			Expect(diagnoses[0].Reason).To(Equal(ReasonReconciliationRetryTimeout))
+			Expect(diagnoses[0].Suggestions).To(ContainElement("svcat touch instance myinstance -n myns"))
+			Expect(diagnoses[1].Resource).To(Equal("binding myns/mybinding"))
+			Expect(diagnoses[1].Suggestions).To(Equal([]string{"svcat doctor instance myinstance -n myns"}))
+		})
+		It("Does not repeat a missing instance", func() {
+			binding.Status.Conditions[0].Status = v1beta1.ConditionFalse
+			binding.Status.Conditions[0].Reason = ReasonNonexistentInstance
+			instance.Name = "otherinstance"
+			sdk = newSDK()
+
+			diagnoses, err := sdk.DiagnoseBinding("myns", "mybinding")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diagnoses).To(HaveLen(1))
+			Expect(diagnoses[0].Reason).To(Equal(ReasonNonexistentInstance))
+		})
+	})
+
+	Describe("DiagnoseBroker", func() {
+		It("Reports a degraded broker", func() {
+			broker.Status.Conditions = append(broker.Status.Conditions, v1beta1.ServiceBrokerCondition{
+				Type:    v1beta1.ServiceBrokerConditionDegraded,
+				Status:  v1beta1.ConditionTrue,
+				Reason:  "BrokerDegraded",
+				Message: "5 consecutive requests failed",
+			})
+			sdk = newSDK()
+
+			diagnoses, err := sdk.DiagnoseBroker("mybroker")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diagnoses).To(HaveLen(1))
+			Expect(diagnoses[0].Severity).To(Equal(SeverityWarning))
+			Expect(diagnoses[0].Problem).To(ContainSubstring("5 consecutive requests failed"))
+		})
+	})
+})
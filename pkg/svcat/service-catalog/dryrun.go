@@ -0,0 +1,382 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecatalog
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ghodss/yaml"
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-incubator/service-catalog/pkg/util/jsonschema"
+	"github.com/peterbourgon/mergemap"
+	"github.com/pkg/errors"
+	osb "github.com/pmorie/go-open-service-broker-client/v2"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	// clusterIDConfigMapName is the name of the configmap in which the
+	// controller-manager stores the cluster id.
+	clusterIDConfigMapName = "cluster-info"
+	// clusterIDConfigMapNamespace is the namespace in which the
+	// controller-manager stores the cluster id by default.
+	clusterIDConfigMapNamespace = "default"
+	// clusterIDConfigMapKey is the configmap key holding the cluster id.
+	clusterIDConfigMapKey = "id"
+	// contextProfilePlatformKubernetes is the platform sent by the
+	// controller-manager in the context of OSB requests.
+	contextProfilePlatformKubernetes = "kubernetes"
+	// clusterIdentifierKey is the context key holding the cluster id.
+	clusterIdentifierKey = "clusterid"
+	// redactedParameter replaces the value of parameters read from secrets.
+	redactedParameter = "<redacted>"
+)
+
+// ProvisionPreview is the result of a dry-run provision.
+type ProvisionPreview struct {
+	// Instance is the instance that would be created.
+	Instance *v1beta1.ServiceInstance
+	// Request is the provision request that the controller-manager would
+	// send to the broker. Parameters read from secrets are redacted.
+	Request *osb.ProvisionRequest
+	// ValidationErrors lists the parameters that do not satisfy the
+	// instance create parameter schema of the plan.
+	ValidationErrors field.ErrorList
+}
+
+// BindPreview is the result of a dry-run bind.
+type BindPreview struct {
+	// Binding is the binding that would be created.
+	Binding *v1beta1.ServiceBinding
+	// Request is the bind request that the controller-manager would send
+	// to the broker. Parameters read from secrets are redacted.
+	Request *osb.BindRequest
+	// ValidationErrors lists the parameters that do not satisfy the
+	// binding create parameter schema of the plan.
+	ValidationErrors field.ErrorList
+}
+
+// DryRunProvision resolves the class and plan in the given scope, and builds
+// the provision request that would be sent to the broker, without creating
+// the instance. The user, when known, is used to build the originating
+// identity.
+func (sdk *SDK) DryRunProvision(namespace, instanceName, externalID, className, planName string,
+	params interface{}, secrets map[string]string, scope Scope, user *v1beta1.UserInfo) (*ProvisionPreview, error) {
+
+	class, err := sdk.retrieveScopedClassByName(className, ScopeOptions{Namespace: namespace, Scope: scope})
+	if err != nil {
+		return nil, err
+	}
+	plan, err := sdk.retrievePlanByClassAndName(class, planName)
+	if err != nil {
+		return nil, err
+	}
+
+	// The API server generates the external id when it is not specified.
+	if externalID == "" {
+		externalID = string(uuid.NewUUID())
+	}
+	instance := newInstance(namespace, instanceName, externalID, className, planName, params, secrets, classScope(class))
+	instance.Spec.UserInfo = user
+
+	// The controller-manager merges the default provision parameters of
+	// the plan into the parameters of the instance before provisioning.
+	inline, err := mergeDefaultParameters(instance.Spec.Parameters, plan.GetSpec().DefaultProvisionParameters)
+	if err != nil {
+		return nil, err
+	}
+	parameters, redacted, err := sdk.buildParameters(namespace, instance.Spec.ParametersFrom, inline)
+	if err != nil {
+		return nil, err
+	}
+
+	validationErrors, err := jsonschema.ValidateParameters(plan.GetSpec().ServiceInstanceCreateParameterSchema,
+		parameters, field.NewPath("spec", "parameters"))
+	if err != nil {
+		return nil, err
+	}
+	redactValidationErrors(validationErrors, instance.Spec.ParametersFrom)
+
+	nsUID, err := sdk.retrieveNamespaceUID(namespace)
+	if err != nil {
+		return nil, err
+	}
+	clusterID, err := sdk.retrieveClusterID()
+	if err != nil {
+		return nil, err
+	}
+	originatingIdentity, err := buildOriginatingIdentity(user)
+	if err != nil {
+		return nil, err
+	}
+
+	request := &osb.ProvisionRequest{
+		AcceptsIncomplete:   true,
+		InstanceID:          externalID,
+		ServiceID:           class.GetSpec().ExternalID,
+		PlanID:              plan.GetSpec().ExternalID,
+		Parameters:          redacted,
+		OrganizationGUID:    clusterID,
+		SpaceGUID:           string(nsUID),
+		Context:             buildRequestContext(namespace, clusterID),
+		OriginatingIdentity: originatingIdentity,
+	}
+
+	return &ProvisionPreview{
+		Instance:         instance,
+		Request:          request,
+		ValidationErrors: validationErrors,
+	}, nil
+}
+
+// DryRunBind resolves the instance, class and plan, and builds the bind
+// request that would be sent to the broker, without creating the binding.
+// The user, when known, is used to build the originating identity.
+func (sdk *SDK) DryRunBind(namespace, bindingName, externalID, instanceName, secretName string,
+	params interface{}, secrets map[string]string, user *v1beta1.UserInfo) (*BindPreview, error) {
+
+	instance, err := sdk.RetrieveInstance(namespace, instanceName)
+	if err != nil {
+		return nil, err
+	}
+	class, plan, err := sdk.InstanceToServiceClassAndPlan(instance)
+	if err != nil {
+		return nil, err
+	}
+
+	if externalID == "" {
+		externalID = string(uuid.NewUUID())
+	}
+	binding := newBinding(namespace, bindingName, externalID, instanceName, secretName, params, secrets)
+	binding.Spec.UserInfo = user
+
+	parameters, redacted, err := sdk.buildParameters(namespace, binding.Spec.ParametersFrom, binding.Spec.Parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	validationErrors, err := jsonschema.ValidateParameters(plan.GetSpec().ServiceBindingCreateParameterSchema,
+		parameters, field.NewPath("spec", "parameters"))
+	if err != nil {
+		return nil, err
+	}
+	redactValidationErrors(validationErrors, binding.Spec.ParametersFrom)
+
+	nsUID, err := sdk.retrieveNamespaceUID(namespace)
+	if err != nil {
+		return nil, err
+	}
+	clusterID, err := sdk.retrieveClusterID()
+	if err != nil {
+		return nil, err
+	}
+	originatingIdentity, err := buildOriginatingIdentity(user)
+	if err != nil {
+		return nil, err
+	}
+
+	appGUID := string(nsUID)
+	request := &osb.BindRequest{
+		BindingID:           externalID,
+		InstanceID:          instance.Spec.ExternalID,
+		ServiceID:           class.GetSpec().ExternalID,
+		PlanID:              plan.GetSpec().ExternalID,
+		AppGUID:             &appGUID,
+		Parameters:          redacted,
+		BindResource:        &osb.BindResource{AppGUID: &appGUID},
+		Context:             buildRequestContext(namespace, clusterID),
+		OriginatingIdentity: originatingIdentity,
+	}
+
+	return &BindPreview{
+		Binding:          binding,
+		Request:          request,
+		ValidationErrors: validationErrors,
+	}, nil
+}
+
+// buildParameters merges the parameters read from secrets with the inline
+// parameters, in the same way as the controller-manager. The first map holds
+// the actual values, the second one has the secret values redacted.
+func (sdk *SDK) buildParameters(namespace string, parametersFrom []v1beta1.ParametersFromSource,
+	parameters *runtime.RawExtension) (map[string]interface{}, map[string]interface{}, error) {
+
+	params := make(map[string]interface{})
+	paramsWithSecretsRedacted := make(map[string]interface{})
+	for _, p := range parametersFrom {
+		if p.SecretKeyRef == nil {
+			continue
+		}
+		secret, err := sdk.Core().Secrets(namespace).Get(p.SecretKeyRef.Name, v1.GetOptions{})
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "unable to get secret '%s.%s'", namespace, p.SecretKeyRef.Name)
+		}
+		fromSecret := make(map[string]interface{})
+		if err := json.Unmarshal(secret.Data[p.SecretKeyRef.Key], &fromSecret); err != nil {
+			return nil, nil, fmt.Errorf("failed to unmarshal parameters from secret '%s[%s]' as JSON object (%s)",
+				p.SecretKeyRef.Name, p.SecretKeyRef.Key, err)
+		}
+		for k, v := range fromSecret {
+			if _, ok := params[k]; ok {
+				return nil, nil, fmt.Errorf("conflict: duplicate entry for parameter %q", k)
+			}
+			params[k] = v
+			paramsWithSecretsRedacted[k] = redactedParameter
+		}
+	}
+	if parameters != nil && len(parameters.Raw) > 0 {
+		inline := make(map[string]interface{})
+		if err := yaml.Unmarshal(parameters.Raw, &inline); err != nil {
+			return nil, nil, fmt.Errorf("failed to unmarshal parameters (%s)", err)
+		}
+		for k, v := range inline {
+			if _, ok := params[k]; ok {
+				return nil, nil, fmt.Errorf("conflict: duplicate entry for parameter %q", k)
+			}
+			params[k] = v
+			paramsWithSecretsRedacted[k] = v
+		}
+	}
+	// Replace empty maps with nil so that the parameters are omitted from the request
+	if len(params) == 0 {
+		return nil, nil, nil
+	}
+	return params, paramsWithSecretsRedacted, nil
+}
+
+// redactValidationErrors replaces the invalid values reported by the schema
+// validation once any parameter is read from a secret. Some schema keywords
+// report the whole parameters object as the invalid value, so the values read
+// from secrets cannot be told apart from the other ones.
+func redactValidationErrors(errs field.ErrorList, parametersFrom []v1beta1.ParametersFromSource) {
+	fromSecret := false
+	for _, p := range parametersFrom {
+		if p.SecretKeyRef != nil {
+			fromSecret = true
+		}
+	}
+	if !fromSecret {
+		return
+	}
+	for _, e := range errs {
+		if e.Type != field.ErrorTypeRequired {
+			e.BadValue = redactedParameter
+		}
+	}
+}
+
+// mergeDefaultParameters applies the default parameters of a plan beneath
+// the parameters of an instance.
+func mergeDefaultParameters(params, defaultParams *runtime.RawExtension) (*runtime.RawExtension, error) {
+	if defaultParams == nil || len(defaultParams.Raw) == 0 {
+		return params, nil
+	}
+	if params == nil || len(params.Raw) == 0 {
+		return defaultParams, nil
+	}
+
+	paramsMap := make(map[string]interface{})
+	if err := json.Unmarshal(params.Raw, &paramsMap); err != nil {
+		return nil, fmt.Errorf("could not unmarshal parameters %s (%s)", string(params.Raw), err)
+	}
+	defaultParamsMap := make(map[string]interface{})
+	if err := json.Unmarshal(defaultParams.Raw, &defaultParamsMap); err != nil {
+		return nil, fmt.Errorf("could not unmarshal default parameters %s (%s)", string(defaultParams.Raw), err)
+	}
+
+	merged, err := json.Marshal(mergemap.Merge(defaultParamsMap, paramsMap))
+	if err != nil {
+		return nil, fmt.Errorf("could not merge parameters with the default parameters (%s)", err)
+	}
+	return &runtime.RawExtension{Raw: merged}, nil
+}
+
+// retrieveNamespaceUID gets the uid of a namespace, which is sent to brokers
+// as the space guid.
+func (sdk *SDK) retrieveNamespaceUID(namespace string) (types.UID, error) {
+	ns, err := sdk.Core().Namespaces().Get(namespace, v1.GetOptions{})
+	if err != nil {
+		return "", errors.Wrapf(err, "unable to get namespace '%s'", namespace)
+	}
+	return ns.UID, nil
+}
+
+// retrieveClusterID gets the cluster id published by the controller-manager.
+// The configmap is read from the default namespace of the controller-manager
+// first. Its namespace depends on how Service Catalog was deployed, so it is
+// then searched for across all namespaces, when the user is allowed to. An
+// empty id is returned when the configmap cannot be found, as when it has not
+// been created yet.
+func (sdk *SDK) retrieveClusterID() (string, error) {
+	cm, err := sdk.Core().ConfigMaps(clusterIDConfigMapNamespace).Get(clusterIDConfigMapName, v1.GetOptions{})
+	if err == nil && cm.Data[clusterIDConfigMapKey] != "" {
+		return cm.Data[clusterIDConfigMapKey], nil
+	}
+	if err != nil && !apierrors.IsNotFound(err) && !apierrors.IsForbidden(err) {
+		return "", errors.Wrap(err, "unable to get the cluster id")
+	}
+
+	opts := v1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("metadata.name", clusterIDConfigMapName).String(),
+	}
+	configMaps, err := sdk.Core().ConfigMaps("").List(opts)
+	if apierrors.IsForbidden(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", errors.Wrap(err, "unable to search for the cluster id")
+	}
+	for _, cm := range configMaps.Items {
+		if id := cm.Data[clusterIDConfigMapKey]; id != "" {
+			return id, nil
+		}
+	}
+	return "", nil
+}
+
+// buildRequestContext builds the OSB context object sent by the
+// controller-manager.
+func buildRequestContext(namespace, clusterID string) map[string]interface{} {
+	return map[string]interface{}{
+		"platform":           contextProfilePlatformKubernetes,
+		"namespace":          namespace,
+		clusterIdentifierKey: clusterID,
+	}
+}
+
+// buildOriginatingIdentity builds the OSB originating identity sent by the
+// controller-manager on behalf of a user.
+func buildOriginatingIdentity(user *v1beta1.UserInfo) (*osb.OriginatingIdentity, error) {
+	if user == nil {
+		return nil, nil
+	}
+	value, err := json.Marshal(user)
+	if err != nil {
+		return nil, fmt.Errorf("unable to build the originating identity (%s)", err)
+	}
+	return &osb.OriginatingIdentity{
+		Platform: contextProfilePlatformKubernetes,
+		Value:    string(value),
+	}, nil
+}
@@ -0,0 +1,278 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecatalog_test
+
+import (
+	"errors"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/clientset/fake"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	. "github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const dryRunSchema = `{
+  "type": "object",
+  "properties": {
+    "location": {"type": "string", "enum": ["eastus", "westus"]},
+    "password": {"type": "string"}
+  },
+  "required": ["location"]
+}`
+
+var _ = Describe("DryRun", func() {
+	var (
+		sdk          *SDK
+		svcCatClient *fake.Clientset
+		k8sClient    *k8sfake.Clientset
+		class        *v1beta1.ClusterServiceClass
+		plan         *v1beta1.ClusterServicePlan
+		instance     *v1beta1.ServiceInstance
+		user         *v1beta1.UserInfo
+	)
+
+	BeforeEach(func() {
+		class = &v1beta1.ClusterServiceClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "class-uuid"},
+			Spec: v1beta1.ClusterServiceClassSpec{
+				CommonServiceClassSpec: v1beta1.CommonServiceClassSpec{
+					ExternalName: "mysqldb",
+					ExternalID:   "class-external-id",
+				},
+			},
+		}
+		plan = &v1beta1.ClusterServicePlan{
+			ObjectMeta: metav1.ObjectMeta{Name: "plan-uuid"},
+			Spec: v1beta1.ClusterServicePlanSpec{
+				CommonServicePlanSpec: v1beta1.CommonServicePlanSpec{
+					ExternalName:                         "free",
+					ExternalID:                           "plan-external-id",
+					DefaultProvisionParameters:           &runtime.RawExtension{Raw: []byte(`{"location":"westus"}`)},
+					ServiceInstanceCreateParameterSchema: &runtime.RawExtension{Raw: []byte(dryRunSchema)},
+					ServiceBindingCreateParameterSchema:  &runtime.RawExtension{Raw: []byte(dryRunSchema)},
+				},
+				ClusterServiceClassRef: v1beta1.ClusterObjectReference{Name: "class-uuid"},
+			},
+		}
+		instance = &v1beta1.ServiceInstance{
+			ObjectMeta: metav1.ObjectMeta{Name: "myinstance", Namespace: "myns"},
+			Spec: v1beta1.ServiceInstanceSpec{
+				ExternalID:             "instance-external-id",
+				ClusterServiceClassRef: &v1beta1.ClusterObjectReference{Name: "class-uuid"},
+				ClusterServicePlanRef:  &v1beta1.ClusterObjectReference{Name: "plan-uuid"},
+			},
+		}
+		user = &v1beta1.UserInfo{Username: "alice", Groups: []string{"system:authenticated"}}
+
+		svcCatClient = fake.NewSimpleClientset(class, plan, instance)
+		k8sClient = k8sfake.NewSimpleClientset(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "myns", UID: "myns-uid"}},
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster-info", Namespace: "catalog"},
+				Data:       map[string]string{"id": "cluster-id"},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "mysecret", Namespace: "myns"},
+				Data:       map[string][]byte{"params": []byte(`{"password":"s3cret"}`)},
+			},
+		)
+		sdk = &SDK{
+			K8sClient:            k8sClient,
+			ServiceCatalogClient: svcCatClient,
+		}
+	})
+
+	Describe("DryRunProvision", func() {
+		It("Builds the provision request without creating the instance", func() {
+			params := map[string]string{"location": "eastus"}
+			secrets := map[string]string{"mysecret": "params"}
+
+			preview, err := sdk.DryRunProvision("myns", "newinstance", "new-external-id", "mysqldb", "free", params, secrets, AllScope, user)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(preview.ValidationErrors).To(BeEmpty())
+			Expect(preview.Instance.Name).To(Equal("newinstance"))
+			Expect(preview.Request.InstanceID).To(Equal("new-external-id"))
+			Expect(preview.Request.AcceptsIncomplete).To(BeTrue())
+			Expect(preview.Request.ServiceID).To(Equal("class-external-id"))
+			Expect(preview.Request.PlanID).To(Equal("plan-external-id"))
+			Expect(preview.Request.OrganizationGUID).To(Equal("cluster-id"))
+			Expect(preview.Request.SpaceGUID).To(Equal("myns-uid"))
+			Expect(preview.Request.Parameters).To(Equal(map[string]interface{}{
+				"location": "eastus",
+				"password": "<redacted>",
+			}))
+			Expect(preview.Request.Context).To(Equal(map[string]interface{}{
+				"platform":  "kubernetes",
+				"namespace": "myns",
+				"clusterid": "cluster-id",
+			}))
+			Expect(preview.Request.OriginatingIdentity).NotTo(BeNil())
+			Expect(preview.Request.OriginatingIdentity.Platform).To(Equal("kubernetes"))
+			Expect(preview.Request.OriginatingIdentity.Value).To(ContainSubstring(`"username":"alice"`))
+
+			actions := svcCatClient.Actions()
+			for _, action := range actions {
+				Expect(action.GetVerb()).NotTo(Equal("create"))
+			}
+		})
+		It("Resolves namespaced classes and plans", func() {
+			nsClass := &v1beta1.ServiceClass{
+				ObjectMeta: metav1.ObjectMeta{Name: "ns-class-uuid", Namespace: "myns"},
+				Spec: v1beta1.ServiceClassSpec{
+					CommonServiceClassSpec: v1beta1.CommonServiceClassSpec{
+						ExternalName: "redis",
+						ExternalID:   "ns-class-external-id",
+					},
+				},
+			}
+			nsPlan := &v1beta1.ServicePlan{
+				ObjectMeta: metav1.ObjectMeta{Name: "ns-plan-uuid", Namespace: "myns"},
+				Spec: v1beta1.ServicePlanSpec{
+					CommonServicePlanSpec: v1beta1.CommonServicePlanSpec{
+						ExternalName: "small",
+						ExternalID:   "ns-plan-external-id",
+					},
+					ServiceClassRef: v1beta1.LocalObjectReference{Name: "ns-class-uuid"},
+				},
+			}
+			sdk.ServiceCatalogClient = fake.NewSimpleClientset(nsClass, nsPlan)
+
+			preview, err := sdk.DryRunProvision("myns", "newinstance", "", "redis", "small", map[string]string{}, nil, NamespaceScope, nil)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(preview.Instance.Spec.ServiceClassExternalName).To(Equal("redis"))
+			Expect(preview.Instance.Spec.ServicePlanExternalName).To(Equal("small"))
+			Expect(preview.Instance.Spec.ClusterServiceClassExternalName).To(BeEmpty())
+			Expect(preview.Request.ServiceID).To(Equal("ns-class-external-id"))
+			Expect(preview.Request.PlanID).To(Equal("ns-plan-external-id"))
+		})
+		It("Applies the default provision parameters of the plan", func() {
+			preview, err := sdk.DryRunProvision("myns", "newinstance", "", "mysqldb", "free", map[string]string{}, nil, ClusterScope, nil)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(preview.ValidationErrors).To(BeEmpty())
+			Expect(preview.Request.InstanceID).NotTo(BeEmpty())
+			Expect(preview.Request.Parameters).To(Equal(map[string]interface{}{"location": "westus"}))
+			Expect(preview.Request.OriginatingIdentity).To(BeNil())
+		})
+		It("Reports the parameters that do not satisfy the plan's schema", func() {
+			params := map[string]string{"location": "northpole"}
+
+			preview, err := sdk.DryRunProvision("myns", "newinstance", "", "mysqldb", "free", params, nil, ClusterScope, nil)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(preview.ValidationErrors).To(HaveLen(1))
+			Expect(preview.ValidationErrors[0].Type).To(Equal(field.ErrorTypeInvalid))
+			Expect(preview.ValidationErrors[0].Field).To(Equal("spec.parameters.location"))
+		})
+		It("Reads the cluster id from the default namespace of the controller-manager", func() {
+			k8sClient = k8sfake.NewSimpleClientset(
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "myns", UID: "myns-uid"}},
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: "cluster-info", Namespace: "default"},
+					Data:       map[string]string{"id": "default-cluster-id"},
+				},
+			)
+			k8sClient.PrependReactor("list", "configmaps", forbiddenReaction)
+			sdk.K8sClient = k8sClient
+
+			preview, err := sdk.DryRunProvision("myns", "newinstance", "", "mysqldb", "free", map[string]string{}, nil, ClusterScope, nil)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(preview.Request.OrganizationGUID).To(Equal("default-cluster-id"))
+		})
+		It("Leaves the cluster id empty when the user cannot search for it", func() {
+			k8sClient.PrependReactor("list", "configmaps", forbiddenReaction)
+
+			preview, err := sdk.DryRunProvision("myns", "newinstance", "", "mysqldb", "free", map[string]string{}, nil, ClusterScope, nil)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(preview.Request.OrganizationGUID).To(BeEmpty())
+			Expect(preview.Request.Context).To(HaveKeyWithValue("clusterid", ""))
+		})
+		It("Bubbles up conflicts between parameters and secrets", func() {
+			params := map[string]string{"password": "letmein"}
+			secrets := map[string]string{"mysecret": "params"}
+
+			_, err := sdk.DryRunProvision("myns", "newinstance", "", "mysqldb", "free", params, secrets, ClusterScope, nil)
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("duplicate entry for parameter \"password\""))
+		})
+	})
+
+	Describe("DryRunBind", func() {
+		It("Builds the bind request without creating the binding", func() {
+			params := map[string]string{"location": "eastus"}
+
+			preview, err := sdk.DryRunBind("myns", "", "binding-external-id", "myinstance", "", params, nil, user)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(preview.ValidationErrors).To(BeEmpty())
+			Expect(preview.Binding.Name).To(Equal("myinstance"))
+			Expect(preview.Request.BindingID).To(Equal("binding-external-id"))
+			Expect(preview.Request.InstanceID).To(Equal("instance-external-id"))
+			Expect(preview.Request.ServiceID).To(Equal("class-external-id"))
+			Expect(preview.Request.PlanID).To(Equal("plan-external-id"))
+			Expect(*preview.Request.AppGUID).To(Equal("myns-uid"))
+			Expect(preview.Request.Parameters).To(Equal(map[string]interface{}{"location": "eastus"}))
+			Expect(preview.Request.OriginatingIdentity).NotTo(BeNil())
+		})
+		It("Reports the parameters that do not satisfy the plan's schema", func() {
+			preview, err := sdk.DryRunBind("myns", "", "", "myinstance", "", map[string]string{}, nil, nil)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(preview.ValidationErrors).To(HaveLen(1))
+			Expect(preview.ValidationErrors[0].Type).To(Equal(field.ErrorTypeRequired))
+		})
+		It("Redacts the invalid values read from secrets", func() {
+			_, err := k8sClient.CoreV1().Secrets("myns").Create(&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "badsecret", Namespace: "myns"},
+				Data:       map[string][]byte{"params": []byte(`{"location":"s3cret-location"}`)},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			secrets := map[string]string{"badsecret": "params"}
+
+			preview, err := sdk.DryRunBind("myns", "", "", "myinstance", "", map[string]string{}, secrets, nil)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(preview.ValidationErrors).To(HaveLen(1))
+			Expect(preview.ValidationErrors[0].Field).To(Equal("spec.parameters.location"))
+			Expect(preview.ValidationErrors[0].BadValue).To(Equal("<redacted>"))
+			Expect(preview.ValidationErrors.ToAggregate().Error()).NotTo(ContainSubstring("s3cret-location"))
+		})
+	})
+})
+
+// forbiddenReaction denies the request, as the API server does for a user
+// without access to the resource.
+func forbiddenReaction(action k8stesting.Action) (bool, runtime.Object, error) {
+	gr := schema.GroupResource{Resource: action.GetResource().Resource}
+	return true, nil, apierrors.NewForbidden(gr, "", errors.New("access denied"))
+}
@@ -27,6 +27,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 const (
@@ -46,15 +47,12 @@ func (sdk *SDK) RetrieveInstances(ns, classFilter, planFilter string) (*v1beta1.
 	}
 
 	filtered := v1beta1.ServiceInstanceList{
-		Items: []v1beta1.ServiceInstance{},
+		ListMeta: instances.ListMeta,
+		Items:    []v1beta1.ServiceInstance{},
 	}
 
 	for _, instance := range instances.Items {
-		if classFilter != "" && instance.Spec.GetSpecifiedClusterServiceClass() != classFilter {
-			continue
-		}
-
-		if planFilter != "" && instance.Spec.GetSpecifiedClusterServicePlan() != planFilter {
+		if !instanceMatchesFilters(&instance, classFilter, planFilter) {
 			continue
 		}
 
@@ -64,6 +62,38 @@ func (sdk *SDK) RetrieveInstances(ns, classFilter, planFilter string) (*v1beta1.
 	return &filtered, nil
 }
 
+// WatchInstances watches the changes to the instances in a namespace that
+// match the class and plan filters, starting after the specified resource
+// version.
+func (sdk *SDK) WatchInstances(ns, classFilter, planFilter, resourceVersion string) (watch.Interface, error) {
+	w, err := sdk.ServiceCatalog().ServiceInstances(ns).Watch(v1.ListOptions{ResourceVersion: resourceVersion})
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to watch instances in %s", ns)
+	}
+
+	if classFilter == "" && planFilter == "" {
+		return w, nil
+	}
+
+	return watch.Filter(w, func(event watch.Event) (watch.Event, bool) {
+		instance, ok := event.Object.(*v1beta1.ServiceInstance)
+		if !ok {
+			return event, true
+		}
+		return event, instanceMatchesFilters(instance, classFilter, planFilter)
+	}), nil
+}
+
+func instanceMatchesFilters(instance *v1beta1.ServiceInstance, classFilter, planFilter string) bool {
+	if classFilter != "" && instance.Spec.GetSpecifiedClass() != classFilter {
+		return false
+	}
+	if planFilter != "" && instance.Spec.GetSpecifiedPlan() != planFilter {
+		return false
+	}
+	return true
+}
+
 // RetrieveInstance gets an instance by its name.
 func (sdk *SDK) RetrieveInstance(ns, name string) (*v1beta1.ServiceInstance, error) {
 	instance, err := sdk.ServiceCatalog().ServiceInstances(ns).Get(name, v1.GetOptions{})
@@ -100,8 +130,10 @@ func (sdk *SDK) RetrieveInstancesByPlan(plan *v1beta1.ClusterServicePlan,
 }
 
 // InstanceParentHierarchy retrieves all ancestor resources of an instance.
+// The class, plan and broker are either cluster-scoped or in the namespace of
+// the instance.
 func (sdk *SDK) InstanceParentHierarchy(instance *v1beta1.ServiceInstance,
-) (*v1beta1.ClusterServiceClass, *v1beta1.ClusterServicePlan, *v1beta1.ClusterServiceBroker, error) {
+) (Class, Plan, Broker, error) {
 	class, plan, err := sdk.InstanceToServiceClassAndPlan(instance)
 	if err != nil {
 		return nil, nil, nil, err
@@ -115,14 +147,15 @@ func (sdk *SDK) InstanceParentHierarchy(instance *v1beta1.ServiceInstance,
 	return class, plan, broker, nil
 }
 
-// InstanceToServiceClassAndPlan retrieves the parent class and plan for an instance.
+// InstanceToServiceClassAndPlan retrieves the parent class and plan for an
+// instance, which are either cluster-scoped or in the namespace of the
+// instance.
 func (sdk *SDK) InstanceToServiceClassAndPlan(instance *v1beta1.ServiceInstance,
-) (*v1beta1.ClusterServiceClass, *v1beta1.ClusterServicePlan, error) {
-	classID := instance.Spec.ClusterServiceClassRef.Name
-	classCh := make(chan *v1beta1.ClusterServiceClass)
+) (Class, Plan, error) {
+	classCh := make(chan Class)
 	classErrCh := make(chan error)
 	go func() {
-		class, err := sdk.ServiceCatalog().ClusterServiceClasses().Get(classID, v1.GetOptions{})
+		class, err := sdk.retrieveInstanceClass(instance)
 		if err != nil {
 			classErrCh <- err
 			return
@@ -130,11 +163,10 @@ func (sdk *SDK) InstanceToServiceClassAndPlan(instance *v1beta1.ServiceInstance,
 		classCh <- class
 	}()
 
-	planID := instance.Spec.ClusterServicePlanRef.Name
-	planCh := make(chan *v1beta1.ClusterServicePlan)
+	planCh := make(chan Plan)
 	planErrCh := make(chan error)
 	go func() {
-		plan, err := sdk.ServiceCatalog().ClusterServicePlans().Get(planID, v1.GetOptions{})
+		plan, err := sdk.retrieveInstancePlan(instance)
 		if err != nil {
 			planErrCh <- err
 			return
@@ -142,8 +174,8 @@ func (sdk *SDK) InstanceToServiceClassAndPlan(instance *v1beta1.ServiceInstance,
 		planCh <- plan
 	}()
 
-	var class *v1beta1.ClusterServiceClass
-	var plan *v1beta1.ClusterServicePlan
+	var class Class
+	var plan Plan
 	for {
 		select {
 		case cl := <-classCh:
@@ -165,33 +197,204 @@ func (sdk *SDK) InstanceToServiceClassAndPlan(instance *v1beta1.ServiceInstance,
 	}
 }
 
-// Provision creates an instance of a service class and plan.
+// Provision creates an instance of a service class and plan. The class and
+// plan are cluster-scoped or namespaced, as specified by scope. When the scope
+// is AllScope, the class is looked up by its name in the namespace of the
+// instance and at the cluster scope to determine which of them is meant.
 func (sdk *SDK) Provision(namespace, instanceName, externalID, className, planName string,
-	params interface{}, secrets map[string]string) (*v1beta1.ServiceInstance, error) {
+	params interface{}, secrets map[string]string, scope Scope) (*v1beta1.ServiceInstance, error) {
+
+	if scope == AllScope {
+		class, err := sdk.retrieveScopedClassByName(className, ScopeOptions{Namespace: namespace, Scope: scope})
+		if err != nil {
+			return nil, err
+		}
+		scope = classScope(class)
+	}
 
-	request := &v1beta1.ServiceInstance{
+	request := newInstance(namespace, instanceName, externalID, className, planName, params, secrets, scope)
+	result, err := sdk.ServiceCatalog().ServiceInstances(namespace).Create(request)
+	if err != nil {
+		return nil, fmt.Errorf("provision request failed (%s)", err)
+	}
+	return result, nil
+}
+
+// newInstance builds the instance of a service class and plan to be created.
+// The class and plan are namespaced when scope is NamespaceScope, and
+// cluster-scoped otherwise.
+func newInstance(namespace, instanceName, externalID, className, planName string,
+	params interface{}, secrets map[string]string, scope Scope) *v1beta1.ServiceInstance {
+
+	instance := &v1beta1.ServiceInstance{
 		ObjectMeta: v1.ObjectMeta{
 			Name:      instanceName,
 			Namespace: namespace,
 		},
 		Spec: v1beta1.ServiceInstanceSpec{
-			ExternalID: externalID,
-			PlanReference: v1beta1.PlanReference{
-				ClusterServiceClassExternalName: className,
-				ClusterServicePlanExternalName:  planName,
-			},
+			ExternalID:     externalID,
 			Parameters:     BuildParameters(params),
 			ParametersFrom: BuildParametersFrom(secrets),
 		},
 	}
+	if scope == NamespaceScope {
+		instance.Spec.ServiceClassExternalName = className
+		instance.Spec.ServicePlanExternalName = planName
+	} else {
+		instance.Spec.ClusterServiceClassExternalName = className
+		instance.Spec.ClusterServicePlanExternalName = planName
+	}
+	return instance
+}
+
+// classScope returns the scope in which a class is defined.
+func classScope(class Class) Scope {
+	if class.GetNamespace() != "" {
+		return NamespaceScope
+	}
+	return ClusterScope
+}
 
-	result, err := sdk.ServiceCatalog().ServiceInstances(namespace).Create(request)
+// UpdateInstance changes the plan and the parameters of an instance. An empty
+// plan name keeps the current plan, and nil params or secrets keep the
+// current parameters or parameters from secrets; otherwise they replace them.
+// The plan cannot be changed when the class of the instance is not plan
+// updatable.
+func (sdk *SDK) UpdateInstance(namespace, instanceName, planName string,
+	params interface{}, secrets map[string]string) (*v1beta1.ServiceInstance, error) {
+
+	instance, err := sdk.RetrieveInstance(namespace, instanceName)
 	if err != nil {
-		return nil, fmt.Errorf("provision request failed (%s)", err)
+		return nil, err
+	}
+
+	if planName != "" && planName != CurrentPlanName(instance) {
+		class, err := sdk.retrieveInstanceClass(instance)
+		if err != nil {
+			return nil, err
+		}
+		if !class.GetSpec().PlanUpdatable {
+			return nil, fmt.Errorf("the plan of instance '%s/%s' cannot be changed because class '%s' is not plan updatable",
+				namespace, instanceName, class.GetExternalName())
+		}
+		if err := sdk.setInstancePlan(instance, class, planName); err != nil {
+			return nil, err
+		}
+	}
+	if params != nil {
+		instance.Spec.Parameters = BuildParameters(params)
+	}
+	if secrets != nil {
+		instance.Spec.ParametersFrom = BuildParametersFrom(secrets)
+	}
+
+	result, err := sdk.ServiceCatalog().ServiceInstances(namespace).Update(instance)
+	if err != nil {
+		return nil, fmt.Errorf("update request failed (%s)", err)
 	}
 	return result, nil
 }
 
+// setInstancePlan references the plan with the given external name in the
+// same way as the class of the instance is referenced, by external name, by
+// external id or by k8s name, because the API server requires the class and
+// the plan to be referenced with the same kind of field.
+func (sdk *SDK) setInstancePlan(instance *v1beta1.ServiceInstance, class Class, planName string) error {
+	ref := &instance.Spec.PlanReference
+	if ref.ClusterServiceClassExternalName != "" || ref.ServiceClassExternalName != "" {
+		if classScope(class) == NamespaceScope {
+			ref.ServicePlanExternalName = planName
+		} else {
+			ref.ClusterServicePlanExternalName = planName
+		}
+		return nil
+	}
+
+	plan, err := sdk.retrievePlanByClassAndName(class, planName)
+	if err != nil {
+		return err
+	}
+	switch {
+	case ref.ClusterServiceClassExternalID != "":
+		ref.ClusterServicePlanExternalID = plan.GetSpec().ExternalID
+	case ref.ClusterServiceClassName != "":
+		ref.ClusterServicePlanName = plan.GetName()
+	case ref.ServiceClassExternalID != "":
+		ref.ServicePlanExternalID = plan.GetSpec().ExternalID
+	case ref.ServiceClassName != "":
+		ref.ServicePlanName = plan.GetName()
+	default:
+		return fmt.Errorf("the class of instance '%s/%s' is not referenced", instance.Namespace, instance.Name)
+	}
+	return nil
+}
+
+// CurrentPlanName returns the external name of the plan of an instance, or an
+// empty string when the plan is not referenced by its external name and the
+// instance has not been provisioned yet.
+func CurrentPlanName(instance *v1beta1.ServiceInstance) string {
+	if instance.Spec.ClusterServicePlanExternalName != "" {
+		return instance.Spec.ClusterServicePlanExternalName
+	}
+	if instance.Spec.ServicePlanExternalName != "" {
+		return instance.Spec.ServicePlanExternalName
+	}
+	if props := instance.Status.ExternalProperties; props != nil {
+		if props.ClusterServicePlanExternalName != "" {
+			return props.ClusterServicePlanExternalName
+		}
+		return props.ServicePlanExternalName
+	}
+	return ""
+}
+
+// retrieveInstanceClass gets the class of an instance, using the reference
+// resolved by the controller when it is set, or else the external name of
+// the class.
+func (sdk *SDK) retrieveInstanceClass(instance *v1beta1.ServiceInstance) (Class, error) {
+	switch {
+	case instance.Spec.ClusterServiceClassRef != nil:
+		class, err := sdk.ServiceCatalog().ClusterServiceClasses().Get(instance.Spec.ClusterServiceClassRef.Name, v1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("unable to get class (%s)", err)
+		}
+		return class, nil
+	case instance.Spec.ServiceClassRef != nil:
+		class, err := sdk.ServiceCatalog().ServiceClasses(instance.Namespace).Get(instance.Spec.ServiceClassRef.Name, v1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("unable to get class (%s)", err)
+		}
+		return class, nil
+	case instance.Spec.ClusterServiceClassExternalName != "":
+		return sdk.retrieveScopedClassByName(instance.Spec.ClusterServiceClassExternalName,
+			ScopeOptions{Scope: ClusterScope})
+	case instance.Spec.ServiceClassExternalName != "":
+		return sdk.retrieveScopedClassByName(instance.Spec.ServiceClassExternalName,
+			ScopeOptions{Namespace: instance.Namespace, Scope: NamespaceScope})
+	}
+	return nil, fmt.Errorf("the class of instance '%s/%s' has not been resolved yet", instance.Namespace, instance.Name)
+}
+
+// retrieveInstancePlan gets the plan of an instance, using the reference
+// resolved by the controller.
+func (sdk *SDK) retrieveInstancePlan(instance *v1beta1.ServiceInstance) (Plan, error) {
+	switch {
+	case instance.Spec.ClusterServicePlanRef != nil:
+		plan, err := sdk.ServiceCatalog().ClusterServicePlans().Get(instance.Spec.ClusterServicePlanRef.Name, v1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("unable to get plan (%s)", err)
+		}
+		return plan, nil
+	case instance.Spec.ServicePlanRef != nil:
+		plan, err := sdk.ServiceCatalog().ServicePlans(instance.Namespace).Get(instance.Spec.ServicePlanRef.Name, v1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("unable to get plan (%s)", err)
+		}
+		return plan, nil
+	}
+	return nil, fmt.Errorf("the plan of instance '%s/%s' has not been resolved yet", instance.Namespace, instance.Name)
+}
+
 // Deprovision deletes an instance.
 func (sdk *SDK) Deprovision(namespace, instanceName string) error {
 	err := sdk.ServiceCatalog().ServiceInstances(namespace).Delete(instanceName, &v1.DeleteOptions{})
@@ -265,6 +468,12 @@ func (sdk *SDK) WaitForInstance(ns, name string, interval time.Duration, timeout
 				return false, nil
 			}
 
+			// The conditions are stale until the controller observes the
+			// latest spec of an updated instance
+			if instance.Status.ObservedGeneration > 0 && instance.Status.ObservedGeneration < instance.Generation {
+				return false, nil
+			}
+
 			isDone := (sdk.IsInstanceReady(instance) || sdk.IsInstanceFailed(instance)) && !instance.Status.AsyncOpInProgress
 			return isDone, nil
 		},
@@ -29,6 +29,7 @@ import (
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/testing"
 
 	. "github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
@@ -227,7 +228,51 @@ var _ = Describe("Instances", func() {
 			Expect(actions[0].(testing.ListActionImpl).GetListRestrictions().Fields.Matches(opts)).To(BeTrue())
 		})
 	})
-	Describe("UpdateInstance", func() {
+	Describe("WatchInstances", func() {
+		It("Watches the instances in the namespace from the specified resource version", func() {
+			_, err := sdk.WatchInstances(si.Namespace, "", "", "42")
+
+			Expect(err).NotTo(HaveOccurred())
+			actions := svcCatClient.Actions()
+			Expect(actions[0].Matches("watch", "serviceinstances")).To(BeTrue())
+			Expect(actions[0].(testing.WatchActionImpl).GetNamespace()).To(Equal(si.Namespace))
+			Expect(actions[0].(testing.WatchActionImpl).GetWatchRestrictions().ResourceVersion).To(Equal("42"))
+		})
+		It("Only reports the changes to the instances matching the filters", func() {
+			fakeWatch := watch.NewFake()
+			svcCatClient.PrependWatchReactor("serviceinstances", testing.DefaultWatchReactor(fakeWatch, nil))
+			si.Spec.ClusterServiceClassExternalName = "mysql"
+			si2.Spec.ClusterServiceClassExternalName = "redis"
+			go func() {
+				fakeWatch.Add(si)
+				fakeWatch.Add(si2)
+				fakeWatch.Stop()
+			}()
+
+			w, err := sdk.WatchInstances(si.Namespace, "redis", "", "")
+
+			Expect(err).NotTo(HaveOccurred())
+			var names []string
+			for event := range w.ResultChan() {
+				names = append(names, event.Object.(*v1beta1.ServiceInstance).Name)
+			}
+			Expect(names).To(Equal([]string{si2.Name}))
+		})
+		It("Bubbles up errors", func() {
+			badClient := &fake.Clientset{}
+			errorMessage := "error watching instances"
+			badClient.AddWatchReactor("serviceinstances", func(action testing.Action) (bool, watch.Interface, error) {
+				return true, nil, errors.New(errorMessage)
+			})
+			sdk.ServiceCatalogClient = badClient
+
+			_, err := sdk.WatchInstances(si.Namespace, "", "", "")
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(errorMessage))
+		})
+	})
+	Describe("TouchInstance", func() {
 		It("Properly increments the update requests field", func() {
 			namespace := "cherry_namespace"
 			instanceName := "cherry"
@@ -240,7 +285,7 @@ var _ = Describe("Instances", func() {
 			secrets["password"] = "abc123"
 			retries := 3
 
-			provisionedInstance, err := sdk.Provision(namespace, instanceName, "", className, planName, params, secrets)
+			provisionedInstance, err := sdk.Provision(namespace, instanceName, "", className, planName, params, secrets, ClusterScope)
 			Expect(err).To(BeNil())
 			// once for the provision request
 			actions := svcCatClient.Actions()
@@ -279,6 +324,126 @@ var _ = Describe("Instances", func() {
 			Expect(obj.Spec.UpdateRequests).To(Equal(int64(1)))
 		})
 	})
+	Describe("UpdateInstance", func() {
+		var (
+			class    *v1beta1.ClusterServiceClass
+			instance *v1beta1.ServiceInstance
+		)
+
+		BeforeEach(func() {
+			class = &v1beta1.ClusterServiceClass{
+				ObjectMeta: metav1.ObjectMeta{Name: "cherry_class_uuid"},
+				Spec: v1beta1.ClusterServiceClassSpec{
+					CommonServiceClassSpec: v1beta1.CommonServiceClassSpec{
+						ExternalName:  "cherry_class",
+						PlanUpdatable: true,
+					},
+				},
+			}
+			instance = &v1beta1.ServiceInstance{
+				ObjectMeta: metav1.ObjectMeta{Name: "cherry", Namespace: "cherry_namespace"},
+				Spec: v1beta1.ServiceInstanceSpec{
+					PlanReference: v1beta1.PlanReference{
+						ClusterServiceClassExternalName: "cherry_class",
+						ClusterServicePlanExternalName:  "cherry_plan",
+					},
+					ClusterServiceClassRef: &v1beta1.ClusterObjectReference{Name: "cherry_class_uuid"},
+					Parameters:             &runtime.RawExtension{Raw: []byte(`{"foo":"bar"}`)},
+					ParametersFrom: []v1beta1.ParametersFromSource{
+						{SecretKeyRef: &v1beta1.SecretKeyReference{Name: "cherry_secret", Key: "params"}},
+					},
+				},
+			}
+		})
+
+		It("Changes the plan and replaces the parameters of the instance", func() {
+			svcCatClient = fake.NewSimpleClientset(class, instance)
+			sdk.ServiceCatalogClient = svcCatClient
+			params := map[string]string{"foo": "baz"}
+			secrets := map[string]string{"other_secret": "params"}
+
+			updated, err := sdk.UpdateInstance(instance.Namespace, instance.Name, "premium_plan", params, secrets)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updated.Spec.ClusterServicePlanExternalName).To(Equal("premium_plan"))
+			Expect(updated.Spec.Parameters.Raw).To(Equal([]byte(`{"foo":"baz"}`)))
+			Expect(updated.Spec.ParametersFrom).To(ConsistOf(v1beta1.ParametersFromSource{
+				SecretKeyRef: &v1beta1.SecretKeyReference{Name: "other_secret", Key: "params"},
+			}))
+
+			actions := svcCatClient.Actions()
+			Expect(actions[len(actions)-1].Matches("update", "serviceinstances")).To(BeTrue())
+		})
+		It("References the new plan by k8s name when the class is referenced by k8s name", func() {
+			instance.Spec.PlanReference = v1beta1.PlanReference{
+				ClusterServiceClassName: "cherry_class_uuid",
+				ClusterServicePlanName:  "cherry_plan_uuid",
+			}
+			plan := &v1beta1.ClusterServicePlan{
+				ObjectMeta: metav1.ObjectMeta{Name: "premium_plan_uuid"},
+				Spec: v1beta1.ClusterServicePlanSpec{
+					CommonServicePlanSpec: v1beta1.CommonServicePlanSpec{
+						ExternalName: "premium_plan",
+						ExternalID:   "premium_plan_external_id",
+					},
+					ClusterServiceClassRef: v1beta1.ClusterObjectReference{Name: "cherry_class_uuid"},
+				},
+			}
+			svcCatClient = fake.NewSimpleClientset(class, plan, instance)
+			sdk.ServiceCatalogClient = svcCatClient
+
+			updated, err := sdk.UpdateInstance(instance.Namespace, instance.Name, "premium_plan", nil, nil)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updated.Spec.PlanReference).To(Equal(v1beta1.PlanReference{
+				ClusterServiceClassName: "cherry_class_uuid",
+				ClusterServicePlanName:  "premium_plan_uuid",
+			}))
+		})
+		It("Keeps the plan and the parameters that are not specified", func() {
+			svcCatClient = fake.NewSimpleClientset(class, instance)
+			sdk.ServiceCatalogClient = svcCatClient
+
+			updated, err := sdk.UpdateInstance(instance.Namespace, instance.Name, "", map[string]string{"foo": "baz"}, nil)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updated.Spec.ClusterServicePlanExternalName).To(Equal("cherry_plan"))
+			Expect(updated.Spec.ParametersFrom).To(Equal(instance.Spec.ParametersFrom))
+
+			// The class is only retrieved to change the plan
+			for _, action := range svcCatClient.Actions() {
+				Expect(action.GetResource().Resource).NotTo(Equal("clusterserviceclasses"))
+			}
+		})
+		It("Refuses to change the plan when the class is not plan updatable", func() {
+			class.Spec.PlanUpdatable = false
+			svcCatClient = fake.NewSimpleClientset(class, instance)
+			sdk.ServiceCatalogClient = svcCatClient
+
+			updated, err := sdk.UpdateInstance(instance.Namespace, instance.Name, "premium_plan", nil, nil)
+
+			Expect(updated).To(BeNil())
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("class 'cherry_class' is not plan updatable"))
+			for _, action := range svcCatClient.Actions() {
+				Expect(action.GetVerb()).NotTo(Equal("update"))
+			}
+		})
+		It("Bubbles up errors", func() {
+			errorMessage := "instance not found"
+			badClient := &fake.Clientset{}
+			badClient.AddReactor("get", "serviceinstances", func(action testing.Action) (bool, runtime.Object, error) {
+				return true, nil, errors.New(errorMessage)
+			})
+			sdk.ServiceCatalogClient = badClient
+
+			updated, err := sdk.UpdateInstance(instance.Namespace, instance.Name, "premium_plan", nil, nil)
+
+			Expect(updated).To(BeNil())
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(errorMessage))
+		})
+	})
 	Describe("InstanceParentHierarchy", func() {
 		It("calls the v1beta1 generated Get function repeatedly to build the heirarchy of the passed in service isntance", func() {
 			broker := &v1beta1.ClusterServiceBroker{ObjectMeta: metav1.ObjectMeta{Name: "foobar_broker"}}
@@ -314,9 +479,9 @@ var _ = Describe("Instances", func() {
 			sdk.ServiceCatalogClient = linkedClient
 			retClass, retPlan, retBroker, err := sdk.InstanceParentHierarchy(si)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(retClass.Name).To(Equal(class.Name))
-			Expect(retPlan.Name).To(Equal(plan.Name))
-			Expect(retBroker.Name).To(Equal(broker.Name))
+			Expect(retClass.GetName()).To(Equal(class.Name))
+			Expect(retPlan.GetName()).To(Equal(plan.Name))
+			Expect(retBroker.GetName()).To(Equal(broker.Name))
 			actions := linkedClient.Actions()
 			getClass := testing.GetActionImpl{
 				ActionImpl: testing.ActionImpl{
@@ -355,6 +520,31 @@ var _ = Describe("Instances", func() {
 			Expect(actions).Should(ContainElement(getPlan))
 			Expect(actions).Should(ContainElement(getBroker))
 		})
+		It("Retrieves the namespaced ancestors of an instance", func() {
+			broker := &v1beta1.ServiceBroker{ObjectMeta: metav1.ObjectMeta{Name: "foobar_broker", Namespace: "foobar_namespace"}}
+			class := &v1beta1.ServiceClass{
+				ObjectMeta: metav1.ObjectMeta{Name: "foobar_class", Namespace: "foobar_namespace"},
+				Spec:       v1beta1.ServiceClassSpec{ServiceBrokerName: broker.Name},
+			}
+			plan := &v1beta1.ServicePlan{ObjectMeta: metav1.ObjectMeta{Name: "foobar_plan", Namespace: "foobar_namespace"}}
+			si = &v1beta1.ServiceInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foobar",
+					Namespace: "foobar_namespace",
+				},
+				Spec: v1beta1.ServiceInstanceSpec{
+					ServiceClassRef: &v1beta1.LocalObjectReference{Name: class.Name},
+					ServicePlanRef:  &v1beta1.LocalObjectReference{Name: plan.Name},
+				},
+			}
+			sdk.ServiceCatalogClient = fake.NewSimpleClientset(si, class, plan, broker)
+
+			retClass, retPlan, retBroker, err := sdk.InstanceParentHierarchy(si)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(retClass).To(Equal(class))
+			Expect(retPlan).To(Equal(plan))
+			Expect(retBroker).To(Equal(broker))
+		})
 		It("Bubbles up errors", func() {
 			si = &v1beta1.ServiceInstance{
 				ObjectMeta: metav1.ObjectMeta{
@@ -479,6 +669,20 @@ var _ = Describe("Instances", func() {
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring(errorMessage))
 		})
+		It("Returns an error when the class and plan of the instance have not been resolved", func() {
+			si = &v1beta1.ServiceInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foobar",
+					Namespace: "foobar_namespace",
+				},
+			}
+
+			a, b, err := sdk.InstanceToServiceClassAndPlan(si)
+			Expect(a).To(BeNil())
+			Expect(b).To(BeNil())
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("has not been resolved yet"))
+		})
 	})
 	Describe("Provision", func() {
 		It("Calls the v1beta1 Create method with the passed in arguments", func() {
@@ -493,7 +697,7 @@ var _ = Describe("Instances", func() {
 			secrets["username"] = "admin"
 			secrets["password"] = "abc123"
 
-			service, err := sdk.Provision(namespace, instanceName, externalID, className, planName, params, secrets)
+			service, err := sdk.Provision(namespace, instanceName, externalID, className, planName, params, secrets, ClusterScope)
 
 			Expect(err).NotTo(HaveOccurred())
 			Expect(service.Namespace).To(Equal(namespace))
@@ -525,6 +729,56 @@ var _ = Describe("Instances", func() {
 			Expect(objectFromRequest.Spec.ParametersFrom).Should(ConsistOf(param, param2))
 			Expect(objectFromRequest.Spec.ExternalID).To(Equal(externalID))
 		})
+		It("Creates an instance of a namespaced class and plan", func() {
+			service, err := sdk.Provision("cherry_namespace", "cherry", "", "cherry_class", "cherry_plan", nil, nil, NamespaceScope)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(service.Spec.PlanReference.ServiceClassExternalName).To(Equal("cherry_class"))
+			Expect(service.Spec.PlanReference.ServicePlanExternalName).To(Equal("cherry_plan"))
+			Expect(service.Spec.PlanReference.ClusterServiceClassSpecified()).To(BeFalse())
+			Expect(service.Spec.PlanReference.ClusterServicePlanSpecified()).To(BeFalse())
+		})
+		It("Looks up the scope of the class when provisioning in all scopes", func() {
+			class := &v1beta1.ServiceClass{
+				ObjectMeta: metav1.ObjectMeta{Name: "cherry_class_uuid", Namespace: "cherry_namespace"},
+				Spec: v1beta1.ServiceClassSpec{
+					CommonServiceClassSpec: v1beta1.CommonServiceClassSpec{ExternalName: "cherry_class"},
+				},
+			}
+			linkedClient := fake.NewSimpleClientset(class)
+			sdk.ServiceCatalogClient = linkedClient
+
+			service, err := sdk.Provision("cherry_namespace", "cherry", "", "cherry_class", "cherry_plan", nil, nil, AllScope)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(service.Spec.PlanReference.ServiceClassExternalName).To(Equal("cherry_class"))
+			Expect(service.Spec.PlanReference.ServicePlanExternalName).To(Equal("cherry_plan"))
+			actions := linkedClient.Actions()
+			Expect(actions[0].Matches("list", "serviceclasses")).To(BeTrue())
+			Expect(actions[1].Matches("list", "clusterserviceclasses")).To(BeTrue())
+			Expect(actions[2].Matches("create", "serviceinstances")).To(BeTrue())
+		})
+		It("Refuses to guess the scope of a class defined in both scopes", func() {
+			nsClass := &v1beta1.ServiceClass{
+				ObjectMeta: metav1.ObjectMeta{Name: "cherry_class_uuid", Namespace: "cherry_namespace"},
+				Spec: v1beta1.ServiceClassSpec{
+					CommonServiceClassSpec: v1beta1.CommonServiceClassSpec{ExternalName: "cherry_class"},
+				},
+			}
+			clusterClass := &v1beta1.ClusterServiceClass{
+				ObjectMeta: metav1.ObjectMeta{Name: "cherry_class_uuid"},
+				Spec: v1beta1.ClusterServiceClassSpec{
+					CommonServiceClassSpec: v1beta1.CommonServiceClassSpec{ExternalName: "cherry_class"},
+				},
+			}
+			sdk.ServiceCatalogClient = fake.NewSimpleClientset(nsClass, clusterClass)
+
+			service, err := sdk.Provision("cherry_namespace", "cherry", "", "cherry_class", "cherry_plan", nil, nil, AllScope)
+
+			Expect(service).To(BeNil())
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("defined both in namespace"))
+		})
 		It("Bubbles up errors", func() {
 			errorMessage := "error retrieving list"
 			namespace := "cherry_namespace"
@@ -542,7 +796,7 @@ var _ = Describe("Instances", func() {
 			})
 			sdk.ServiceCatalogClient = badClient
 
-			service, err := sdk.Provision(namespace, instanceName, "", className, planName, params, secrets)
+			service, err := sdk.Provision(namespace, instanceName, "", className, planName, params, secrets, ClusterScope)
 			Expect(service).To(BeNil())
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring(errorMessage))
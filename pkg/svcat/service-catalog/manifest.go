@@ -0,0 +1,344 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecatalog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/pkg/errors"
+	apicorev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// Manifest is a portable description of the instances and bindings of a
+// namespace, which can be applied to another namespace to recreate them.
+type Manifest struct {
+	Instances []v1beta1.ServiceInstance
+	Bindings  []v1beta1.ServiceBinding
+}
+
+// ApplyResult describes what applying a resource of a manifest did.
+type ApplyResult string
+
+const (
+	// ApplyCreated is the result of applying a resource that did not exist.
+	ApplyCreated ApplyResult = "created"
+	// ApplyUpdated is the result of applying a resource that had a different spec.
+	ApplyUpdated ApplyResult = "updated"
+	// ApplyUnchanged is the result of applying a resource that was already up to date.
+	ApplyUnchanged ApplyResult = "unchanged"
+)
+
+// ExportManifest builds a manifest of the instances and bindings of a
+// namespace. Everything that is generated by the API server or the
+// controller is stripped: the status, the external IDs, the resolved
+// references to classes and plans, the namespace and the generated metadata.
+// Parameters from secrets are kept as references to the secrets.
+func (sdk *SDK) ExportManifest(namespace string) (*Manifest, error) {
+	instances, err := sdk.RetrieveInstances(namespace, "", "")
+	if err != nil {
+		return nil, err
+	}
+	bindings, err := sdk.RetrieveBindings(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &Manifest{
+		Instances: make([]v1beta1.ServiceInstance, 0, len(instances.Items)),
+		Bindings:  make([]v1beta1.ServiceBinding, 0, len(bindings.Items)),
+	}
+	for _, instance := range instances.Items {
+		manifest.Instances = append(manifest.Instances, exportInstance(instance))
+	}
+	for _, binding := range bindings.Items {
+		manifest.Bindings = append(manifest.Bindings, exportBinding(binding))
+	}
+	return manifest, nil
+}
+
+func exportInstance(instance v1beta1.ServiceInstance) v1beta1.ServiceInstance {
+	return v1beta1.ServiceInstance{
+		TypeMeta: v1.TypeMeta{
+			APIVersion: v1beta1.SchemeGroupVersion.String(),
+			Kind:       "ServiceInstance",
+		},
+		ObjectMeta: exportObjectMeta(instance.ObjectMeta),
+		Spec: v1beta1.ServiceInstanceSpec{
+			PlanReference:  instance.Spec.PlanReference,
+			Parameters:     instance.Spec.Parameters,
+			ParametersFrom: instance.Spec.ParametersFrom,
+		},
+	}
+}
+
+func exportBinding(binding v1beta1.ServiceBinding) v1beta1.ServiceBinding {
+	return v1beta1.ServiceBinding{
+		TypeMeta: v1.TypeMeta{
+			APIVersion: v1beta1.SchemeGroupVersion.String(),
+			Kind:       "ServiceBinding",
+		},
+		ObjectMeta: exportObjectMeta(binding.ObjectMeta),
+		Spec: v1beta1.ServiceBindingSpec{
+			ServiceInstanceRef: binding.Spec.ServiceInstanceRef,
+			Parameters:         binding.Spec.Parameters,
+			ParametersFrom:     binding.Spec.ParametersFrom,
+			SecretName:         binding.Spec.SecretName,
+			SecretTransforms:   binding.Spec.SecretTransforms,
+			Outputs:            binding.Spec.Outputs,
+		},
+	}
+}
+
+// exportObjectMeta keeps the metadata that is set by users.
+func exportObjectMeta(meta v1.ObjectMeta) v1.ObjectMeta {
+	return v1.ObjectMeta{
+		Name:        meta.Name,
+		Labels:      meta.Labels,
+		Annotations: meta.Annotations,
+	}
+}
+
+// List converts the manifest to a v1 List, with the instances before the
+// bindings, so that it can also be used with kubectl.
+func (m *Manifest) List() (*apicorev1.List, error) {
+	list := &apicorev1.List{
+		TypeMeta: v1.TypeMeta{APIVersion: "v1", Kind: "List"},
+		Items:    make([]runtime.RawExtension, 0, len(m.Instances)+len(m.Bindings)),
+	}
+	for i := range m.Instances {
+		item, err := manifestItem(&m.Instances[i])
+		if err != nil {
+			return nil, err
+		}
+		list.Items = append(list.Items, item)
+	}
+	for i := range m.Bindings {
+		item, err := manifestItem(&m.Bindings[i])
+		if err != nil {
+			return nil, err
+		}
+		list.Items = append(list.Items, item)
+	}
+	return list, nil
+}
+
+// manifestItem serializes an exported resource, leaving out the fields that
+// are always empty once exported.
+func manifestItem(obj interface{}) (runtime.RawExtension, error) {
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		return runtime.RawExtension{}, err
+	}
+	var item map[string]interface{}
+	if err := json.Unmarshal(raw, &item); err != nil {
+		return runtime.RawExtension{}, err
+	}
+	delete(item, "status")
+	if meta, ok := item["metadata"].(map[string]interface{}); ok {
+		delete(meta, "creationTimestamp")
+	}
+	if spec, ok := item["spec"].(map[string]interface{}); ok {
+		delete(spec, "externalID")
+		delete(spec, "updateRequests")
+		delete(spec, "rotationRequests")
+	}
+	raw, err = json.Marshal(item)
+	if err != nil {
+		return runtime.RawExtension{}, err
+	}
+	return runtime.RawExtension{Raw: raw}, nil
+}
+
+// ParseManifest reads the instances and bindings of a manifest, in YAML or
+// JSON. The manifest is either a v1 List, or a stream of resources and Lists
+// separated by "---".
+func ParseManifest(r io.Reader) (*Manifest, error) {
+	manifest := &Manifest{}
+	decoder := yaml.NewYAMLOrJSONDecoder(r, 4096)
+	for {
+		var raw json.RawMessage
+		err := decoder.Decode(&raw)
+		if err == io.EOF {
+			return manifest, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse the manifest (%s)", err)
+		}
+		if err := manifest.add(raw); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (m *Manifest) add(raw json.RawMessage) error {
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return nil
+	}
+
+	var obj struct {
+		v1.TypeMeta `json:",inline"`
+		Items       []json.RawMessage `json:"items"`
+	}
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return fmt.Errorf("unable to parse the manifest (%s)", err)
+	}
+
+	switch {
+	case obj.Kind == "List":
+		for _, item := range obj.Items {
+			if err := m.add(item); err != nil {
+				return err
+			}
+		}
+	case obj.Kind == "ServiceInstance" && obj.APIVersion == v1beta1.SchemeGroupVersion.String():
+		var instance v1beta1.ServiceInstance
+		if err := json.Unmarshal(raw, &instance); err != nil {
+			return fmt.Errorf("unable to parse the manifest (%s)", err)
+		}
+		m.Instances = append(m.Instances, instance)
+	case obj.Kind == "ServiceBinding" && obj.APIVersion == v1beta1.SchemeGroupVersion.String():
+		var binding v1beta1.ServiceBinding
+		if err := json.Unmarshal(raw, &binding); err != nil {
+			return fmt.Errorf("unable to parse the manifest (%s)", err)
+		}
+		m.Bindings = append(m.Bindings, binding)
+	default:
+		return fmt.Errorf("unsupported resource %s %q in the manifest, only %s instances and bindings can be applied",
+			obj.Kind, obj.APIVersion, v1beta1.SchemeGroupVersion.String())
+	}
+	return nil
+}
+
+// ApplyInstance creates an instance of a manifest in a namespace, or when an
+// instance with the same name already exists, updates its plan and
+// parameters to match the manifest.
+func (sdk *SDK) ApplyInstance(namespace string, instance *v1beta1.ServiceInstance) (*v1beta1.ServiceInstance, ApplyResult, error) {
+	existing, err := sdk.ServiceCatalog().ServiceInstances(namespace).Get(instance.Name, v1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		request := instance.DeepCopy()
+		request.Namespace = namespace
+		result, err := sdk.ServiceCatalog().ServiceInstances(namespace).Create(request)
+		if err != nil {
+			return nil, "", errors.Wrap(err, "provision request failed")
+		}
+		return result, ApplyCreated, nil
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to get instance '%s.%s' (%s)", namespace, instance.Name, err)
+	}
+
+	if existing.Spec.PlanReference == instance.Spec.PlanReference &&
+		sameParameters(existing.Spec.Parameters, instance.Spec.Parameters) &&
+		sameParametersFrom(existing.Spec.ParametersFrom, instance.Spec.ParametersFrom) {
+		return existing, ApplyUnchanged, nil
+	}
+
+	existing.Spec.PlanReference = instance.Spec.PlanReference
+	existing.Spec.Parameters = instance.Spec.Parameters
+	existing.Spec.ParametersFrom = instance.Spec.ParametersFrom
+	result, err := sdk.ServiceCatalog().ServiceInstances(namespace).Update(existing)
+	if err != nil {
+		return nil, "", fmt.Errorf("update request failed (%s)", err)
+	}
+	return result, ApplyUpdated, nil
+}
+
+// ApplyBinding creates a binding of a manifest in a namespace, or when a
+// binding with the same name already exists, updates its parameters to match
+// the manifest. The rest of the spec of a binding cannot be changed, so
+// applying a binding that already exists with a different instance, secret,
+// secret transforms or outputs is an error.
+func (sdk *SDK) ApplyBinding(namespace string, binding *v1beta1.ServiceBinding) (*v1beta1.ServiceBinding, ApplyResult, error) {
+	existing, err := sdk.ServiceCatalog().ServiceBindings(namespace).Get(binding.Name, v1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		request := binding.DeepCopy()
+		request.Namespace = namespace
+		result, err := sdk.ServiceCatalog().ServiceBindings(namespace).Create(request)
+		if err != nil {
+			return nil, "", errors.Wrap(err, "bind request failed")
+		}
+		return result, ApplyCreated, nil
+	}
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "unable to get binding '%s.%s'", namespace, binding.Name)
+	}
+
+	if existing.Spec.ServiceInstanceRef != binding.Spec.ServiceInstanceRef ||
+		(binding.Spec.SecretName != "" && existing.Spec.SecretName != binding.Spec.SecretName) ||
+		!reflect.DeepEqual(existing.Spec.SecretTransforms, binding.Spec.SecretTransforms) ||
+		!sameOutputs(existing.Spec.Outputs, binding.Spec.Outputs) {
+		return nil, "", fmt.Errorf("binding '%s.%s' already exists with a different instance, secret, secret transforms or outputs, unbind it before applying the manifest",
+			namespace, binding.Name)
+	}
+
+	if sameParameters(existing.Spec.Parameters, binding.Spec.Parameters) &&
+		sameParametersFrom(existing.Spec.ParametersFrom, binding.Spec.ParametersFrom) {
+		return existing, ApplyUnchanged, nil
+	}
+
+	existing.Spec.Parameters = binding.Spec.Parameters
+	existing.Spec.ParametersFrom = binding.Spec.ParametersFrom
+	result, err := sdk.ServiceCatalog().ServiceBindings(namespace).Update(existing)
+	if err != nil {
+		return nil, "", fmt.Errorf("update request failed (%s)", err)
+	}
+	return result, ApplyUpdated, nil
+}
+
+// sameParameters compares parameters by their value rather than by their
+// serialization. Missing parameters are the same as empty parameters.
+func sameParameters(a, b *runtime.RawExtension) bool {
+	return reflect.DeepEqual(parametersValue(a), parametersValue(b))
+}
+
+func parametersValue(params *runtime.RawExtension) interface{} {
+	value := map[string]interface{}{}
+	if params == nil || len(params.Raw) == 0 {
+		return value
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(params.Raw, &decoded); err != nil {
+		return string(params.Raw)
+	}
+	if decoded == nil {
+		return value
+	}
+	return decoded
+}
+
+func sameParametersFrom(a, b []v1beta1.ParametersFromSource) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func sameOutputs(a, b []v1beta1.ServiceBindingOutput) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
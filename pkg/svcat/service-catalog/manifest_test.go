@@ -0,0 +1,277 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecatalog_test
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/clientset/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	. "github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Manifest", func() {
+	var (
+		sdk          *SDK
+		svcCatClient *fake.Clientset
+		si           *v1beta1.ServiceInstance
+		sb           *v1beta1.ServiceBinding
+		secretParams []v1beta1.ParametersFromSource
+		outputs      []v1beta1.ServiceBindingOutput
+	)
+
+	BeforeEach(func() {
+		secretParams = []v1beta1.ParametersFromSource{
+			{SecretKeyRef: &v1beta1.SecretKeyReference{Name: "mysecret", Key: "params"}},
+		}
+		outputs = []v1beta1.ServiceBindingOutput{
+			{Name: "myconfig", Kind: v1beta1.ServiceBindingOutputKindConfigMap, Keys: []v1beta1.ServiceBindingOutputKey{{Key: "uri"}}},
+		}
+		si = &v1beta1.ServiceInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "myinstance",
+				Namespace:       "dev",
+				UID:             "instance-uid",
+				ResourceVersion: "42",
+				Generation:      2,
+				Labels:          map[string]string{"app": "web"},
+				Finalizers:      []string{"kubernetes-incubator/service-catalog"},
+			},
+			Spec: v1beta1.ServiceInstanceSpec{
+				PlanReference: v1beta1.PlanReference{
+					ClusterServiceClassExternalName: "mysqldb",
+					ClusterServicePlanExternalName:  "free",
+				},
+				ClusterServiceClassRef: &v1beta1.ClusterObjectReference{Name: "class-uuid"},
+				ClusterServicePlanRef:  &v1beta1.ClusterObjectReference{Name: "plan-uuid"},
+				Parameters:             &runtime.RawExtension{Raw: []byte(`{"location": "eastus"}`)},
+				ParametersFrom:         secretParams,
+				ExternalID:             "instance-external-id",
+				UserInfo:               &v1beta1.UserInfo{Username: "alice"},
+				UpdateRequests:         1,
+			},
+			Status: v1beta1.ServiceInstanceStatus{ReconciledGeneration: 2},
+		}
+		sb = &v1beta1.ServiceBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "mybinding", Namespace: "dev", UID: "binding-uid"},
+			Spec: v1beta1.ServiceBindingSpec{
+				ServiceInstanceRef: v1beta1.LocalObjectReference{Name: "myinstance"},
+				SecretName:         "mysecret",
+				ParametersFrom:     secretParams,
+				Outputs:            outputs,
+				ExternalID:         "binding-external-id",
+				UserInfo:           &v1beta1.UserInfo{Username: "alice"},
+			},
+		}
+		svcCatClient = fake.NewSimpleClientset(si, sb)
+		sdk = &SDK{
+			ServiceCatalogClient: svcCatClient,
+		}
+	})
+
+	Describe("ExportManifest", func() {
+		It("Strips everything that is generated from the instances and bindings", func() {
+			manifest, err := sdk.ExportManifest("dev")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(manifest.Instances).To(HaveLen(1))
+			instance := manifest.Instances[0]
+			Expect(instance.Kind).To(Equal("ServiceInstance"))
+			Expect(instance.APIVersion).To(Equal("servicecatalog.k8s.io/v1beta1"))
+			Expect(instance.ObjectMeta).To(Equal(metav1.ObjectMeta{Name: "myinstance", Labels: map[string]string{"app": "web"}}))
+			Expect(instance.Spec).To(Equal(v1beta1.ServiceInstanceSpec{
+				PlanReference:  si.Spec.PlanReference,
+				Parameters:     si.Spec.Parameters,
+				ParametersFrom: secretParams,
+			}))
+			Expect(instance.Status).To(Equal(v1beta1.ServiceInstanceStatus{}))
+
+			Expect(manifest.Bindings).To(HaveLen(1))
+			binding := manifest.Bindings[0]
+			Expect(binding.Kind).To(Equal("ServiceBinding"))
+			Expect(binding.ObjectMeta).To(Equal(metav1.ObjectMeta{Name: "mybinding"}))
+			Expect(binding.Spec).To(Equal(v1beta1.ServiceBindingSpec{
+				ServiceInstanceRef: sb.Spec.ServiceInstanceRef,
+				SecretName:         "mysecret",
+				ParametersFrom:     secretParams,
+				Outputs:            outputs,
+			}))
+		})
+	})
+
+	Describe("ParseManifest", func() {
+		It("Reads back an exported manifest", func() {
+			manifest, err := sdk.ExportManifest("dev")
+			Expect(err).NotTo(HaveOccurred())
+			list, err := manifest.List()
+			Expect(err).NotTo(HaveOccurred())
+			raw, err := yaml.Marshal(list)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(raw)).NotTo(ContainSubstring("status"))
+			Expect(string(raw)).NotTo(ContainSubstring("externalID"))
+
+			parsed, err := ParseManifest(bytes.NewReader(raw))
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.Instances).To(HaveLen(1))
+			Expect(parsed.Instances[0].Name).To(Equal("myinstance"))
+			Expect(parsed.Instances[0].Spec.ParametersFrom).To(Equal(secretParams))
+			Expect(parsed.Bindings).To(HaveLen(1))
+			Expect(parsed.Bindings[0].Spec.ServiceInstanceRef.Name).To(Equal("myinstance"))
+		})
+		It("Reads a stream of resources", func() {
+			raw := `
+apiVersion: servicecatalog.k8s.io/v1beta1
+kind: ServiceBinding
+metadata:
+  name: mybinding
+spec:
+  instanceRef:
+    name: myinstance
+---
+apiVersion: servicecatalog.k8s.io/v1beta1
+kind: ServiceInstance
+metadata:
+  name: myinstance
+spec:
+  clusterServiceClassExternalName: mysqldb
+  clusterServicePlanExternalName: free
+---
+`
+			parsed, err := ParseManifest(strings.NewReader(raw))
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.Instances).To(HaveLen(1))
+			Expect(parsed.Instances[0].Spec.ClusterServicePlanExternalName).To(Equal("free"))
+			Expect(parsed.Bindings).To(HaveLen(1))
+		})
+		It("Rejects the resources that are not instances or bindings", func() {
+			raw := `{"apiVersion": "v1", "kind": "Secret", "metadata": {"name": "mysecret"}}`
+
+			_, err := ParseManifest(strings.NewReader(raw))
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("unsupported resource Secret \"v1\""))
+		})
+	})
+
+	Describe("ApplyInstance", func() {
+		It("Creates the instances that do not exist", func() {
+			manifest, err := sdk.ExportManifest("dev")
+			Expect(err).NotTo(HaveOccurred())
+
+			instance, result, err := sdk.ApplyInstance("preview", &manifest.Instances[0])
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(ApplyCreated))
+			Expect(instance.Namespace).To(Equal("preview"))
+			actions := svcCatClient.Actions()
+			Expect(actions[len(actions)-1].GetVerb()).To(Equal("create"))
+		})
+		It("Leaves the instances that are up to date alone", func() {
+			manifest, err := sdk.ExportManifest("dev")
+			Expect(err).NotTo(HaveOccurred())
+			manifest.Instances[0].Spec.Parameters = &runtime.RawExtension{Raw: []byte(`{"location":"eastus"}`)}
+
+			_, result, err := sdk.ApplyInstance("dev", &manifest.Instances[0])
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(ApplyUnchanged))
+			for _, action := range svcCatClient.Actions() {
+				Expect(action.GetVerb()).NotTo(Equal("update"))
+			}
+		})
+		It("Updates the plan and parameters of existing instances", func() {
+			manifest, err := sdk.ExportManifest("dev")
+			Expect(err).NotTo(HaveOccurred())
+			manifest.Instances[0].Spec.ClusterServicePlanExternalName = "premium"
+			manifest.Instances[0].Spec.ParametersFrom = nil
+
+			instance, result, err := sdk.ApplyInstance("dev", &manifest.Instances[0])
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(ApplyUpdated))
+			Expect(instance.Spec.ClusterServicePlanExternalName).To(Equal("premium"))
+			Expect(instance.Spec.ParametersFrom).To(BeEmpty())
+			Expect(instance.Spec.ExternalID).To(Equal("instance-external-id"))
+		})
+	})
+
+	Describe("ApplyBinding", func() {
+		It("Creates the bindings that do not exist", func() {
+			manifest, err := sdk.ExportManifest("dev")
+			Expect(err).NotTo(HaveOccurred())
+
+			binding, result, err := sdk.ApplyBinding("preview", &manifest.Bindings[0])
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(ApplyCreated))
+			Expect(binding.Namespace).To(Equal("preview"))
+		})
+		It("Leaves the bindings that are up to date alone", func() {
+			manifest, err := sdk.ExportManifest("dev")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, result, err := sdk.ApplyBinding("dev", &manifest.Bindings[0])
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(ApplyUnchanged))
+		})
+		It("Updates the parameters of existing bindings", func() {
+			manifest, err := sdk.ExportManifest("dev")
+			Expect(err).NotTo(HaveOccurred())
+			manifest.Bindings[0].Spec.Parameters = &runtime.RawExtension{Raw: []byte(`{"readonly":true}`)}
+			manifest.Bindings[0].Spec.ParametersFrom = nil
+
+			binding, result, err := sdk.ApplyBinding("dev", &manifest.Bindings[0])
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(ApplyUpdated))
+			Expect(string(binding.Spec.Parameters.Raw)).To(Equal(`{"readonly":true}`))
+			Expect(binding.Spec.ParametersFrom).To(BeEmpty())
+			Expect(binding.Spec.ExternalID).To(Equal("binding-external-id"))
+		})
+		It("Refuses to change the secret of existing bindings", func() {
+			manifest, err := sdk.ExportManifest("dev")
+			Expect(err).NotTo(HaveOccurred())
+			manifest.Bindings[0].Spec.SecretName = "othersecret"
+
+			_, _, err = sdk.ApplyBinding("dev", &manifest.Bindings[0])
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("binding 'dev.mybinding' already exists with a different instance, secret, secret transforms or outputs"))
+		})
+		It("Refuses to change the outputs of existing bindings", func() {
+			manifest, err := sdk.ExportManifest("dev")
+			Expect(err).NotTo(HaveOccurred())
+			manifest.Bindings[0].Spec.Outputs = nil
+
+			_, _, err = sdk.ApplyBinding("dev", &manifest.Bindings[0])
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("binding 'dev.mybinding' already exists with a different instance, secret, secret transforms or outputs"))
+		})
+	})
+})
@@ -0,0 +1,189 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecatalog
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MarketplaceOptions limits the offerings returned by RetrieveMarketplace.
+// Empty fields do not filter.
+type MarketplaceOptions struct {
+	Namespace string
+	Scope     Scope
+
+	// Tags that the class of an offering must all have.
+	Tags []string
+	// Broker that must offer the class of an offering.
+	Broker string
+	// FreeOnly excludes the plans that are not free.
+	FreeOnly bool
+	// Search is a keyword that must be found in the name, description or
+	// external metadata of the class or of the plan of an offering.
+	Search string
+}
+
+// Offering is a plan that can be provisioned, joined with its class and the
+// broker offering them.
+type Offering struct {
+	Class Class `json:"class"`
+	Plan  Plan  `json:"plan"`
+	// Broker is nil when the broker of the class no longer exists.
+	Broker Broker `json:"broker"`
+}
+
+// IsFree returns whether the plan of the offering is free.
+func (o Offering) IsFree() bool {
+	return o.Plan.GetSpec().Free
+}
+
+// IsBindable returns whether instances of the offering can be bound. The
+// bindable flag of the plan, when set, overrides the flag of the class.
+func (o Offering) IsBindable() bool {
+	if bindable := o.Plan.GetSpec().Bindable; bindable != nil {
+		return *bindable
+	}
+	return o.Class.GetSpec().Bindable
+}
+
+// RetrieveMarketplace lists the plans of the classes in the scope of opts,
+// joined with their class and broker, and filtered by opts. The offerings are
+// sorted by class, plan and namespace.
+func (sdk *SDK) RetrieveMarketplace(opts MarketplaceOptions) ([]Offering, error) {
+	scopeOpts := ScopeOptions{Namespace: opts.Namespace, Scope: opts.Scope}
+	classes, err := sdk.RetrieveClasses(scopeOpts)
+	if err != nil {
+		return nil, err
+	}
+	plans, err := sdk.RetrievePlans(RetrievePlanOptions{Namespace: opts.Namespace, Scope: opts.Scope})
+	if err != nil {
+		return nil, err
+	}
+	brokers, err := sdk.RetrieveBrokers(scopeOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	classesByKey := make(map[string]Class, len(classes))
+	for _, class := range classes {
+		classesByKey[marketplaceKey(class.GetNamespace(), class.GetName())] = class
+	}
+	brokersByKey := make(map[string]Broker, len(brokers))
+	for _, broker := range brokers {
+		brokersByKey[marketplaceKey(broker.GetNamespace(), broker.GetName())] = broker
+	}
+
+	var offerings []Offering
+	for _, plan := range plans {
+		class, ok := classesByKey[marketplaceKey(plan.GetNamespace(), plan.GetClassID())]
+		if !ok {
+			continue
+		}
+		offering := Offering{
+			Class:  class,
+			Plan:   plan,
+			Broker: brokersByKey[marketplaceKey(class.GetNamespace(), class.GetServiceBrokerName())],
+		}
+		if opts.matches(offering) {
+			offerings = append(offerings, offering)
+		}
+	}
+
+	sort.Sort(byOffering(offerings))
+	return offerings, nil
+}
+
+// byOffering implements sort.Interface for []Offering, sorting by class,
+// plan and namespace.
+type byOffering []Offering
+
+func (a byOffering) Len() int {
+	return len(a)
+}
+func (a byOffering) Swap(i, j int) {
+	a[i], a[j] = a[j], a[i]
+}
+func (a byOffering) Less(i, j int) bool {
+	if a[i].Class.GetExternalName() != a[j].Class.GetExternalName() {
+		return a[i].Class.GetExternalName() < a[j].Class.GetExternalName()
+	}
+	if a[i].Plan.GetExternalName() != a[j].Plan.GetExternalName() {
+		return a[i].Plan.GetExternalName() < a[j].Plan.GetExternalName()
+	}
+	return a[i].Plan.GetNamespace() < a[j].Plan.GetNamespace()
+}
+
+// matches determines if an offering satisfies the filters of the options.
+func (opts MarketplaceOptions) matches(offering Offering) bool {
+	if opts.FreeOnly && !offering.IsFree() {
+		return false
+	}
+	if opts.Broker != "" && offering.Class.GetServiceBrokerName() != opts.Broker {
+		return false
+	}
+	classSpec := offering.Class.GetSpec()
+	for _, tag := range opts.Tags {
+		if !containsFold(classSpec.Tags, tag) {
+			return false
+		}
+	}
+	if opts.Search != "" {
+		planSpec := offering.Plan.GetSpec()
+		fields := []string{
+			classSpec.ExternalName,
+			classSpec.Description,
+			planSpec.ExternalName,
+			planSpec.Description,
+		}
+		if classSpec.ExternalMetadata != nil {
+			fields = append(fields, string(classSpec.ExternalMetadata.Raw))
+		}
+		if planSpec.ExternalMetadata != nil {
+			fields = append(fields, string(planSpec.ExternalMetadata.Raw))
+		}
+		keyword := strings.ToLower(opts.Search)
+		found := false
+		for _, field := range fields {
+			if strings.Contains(strings.ToLower(field), keyword) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// containsFold determines if a list contains a value, ignoring case.
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// marketplaceKey identifies a resource by its namespace, which is empty for
+// cluster-scoped resources, and its name.
+func marketplaceKey(namespace, name string) string {
+	return fmt.Sprintf("%s/%s", namespace, name)
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecatalog_test
+
+import (
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/clientset/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	. "github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Marketplace", func() {
+	var (
+		sdk          *SDK
+		svcCatClient *fake.Clientset
+		csb          *v1beta1.ClusterServiceBroker
+		csc          *v1beta1.ClusterServiceClass
+		freePlan     *v1beta1.ClusterServicePlan
+		paidPlan     *v1beta1.ClusterServicePlan
+		sb           *v1beta1.ServiceBroker
+		sc           *v1beta1.ServiceClass
+		sp           *v1beta1.ServicePlan
+	)
+
+	BeforeEach(func() {
+		notBindable := false
+		csb = &v1beta1.ClusterServiceBroker{ObjectMeta: metav1.ObjectMeta{Name: "cluster-broker"}}
+		csc = &v1beta1.ClusterServiceClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "mysql-uuid"},
+			Spec: v1beta1.ClusterServiceClassSpec{
+				CommonServiceClassSpec: v1beta1.CommonServiceClassSpec{
+					ExternalName: "mysqldb",
+					Description:  "Managed MySQL",
+					Bindable:     true,
+					Tags:         []string{"database", "MySQL"},
+				},
+				ClusterServiceBrokerName: csb.Name,
+			},
+		}
+		freePlan = &v1beta1.ClusterServicePlan{
+			ObjectMeta: metav1.ObjectMeta{Name: "free-uuid"},
+			Spec: v1beta1.ClusterServicePlanSpec{
+				CommonServicePlanSpec: v1beta1.CommonServicePlanSpec{
+					ExternalName: "free",
+					Free:         true,
+				},
+				ClusterServiceClassRef: v1beta1.ClusterObjectReference{Name: csc.Name},
+			},
+		}
+		paidPlan = &v1beta1.ClusterServicePlan{
+			ObjectMeta: metav1.ObjectMeta{Name: "paid-uuid"},
+			Spec: v1beta1.ClusterServicePlanSpec{
+				CommonServicePlanSpec: v1beta1.CommonServicePlanSpec{
+					ExternalName:     "backup",
+					Bindable:         &notBindable,
+					ExternalMetadata: &runtime.RawExtension{Raw: []byte(`{"displayName":"Nightly Snapshots"}`)},
+				},
+				ClusterServiceClassRef: v1beta1.ClusterObjectReference{Name: csc.Name},
+			},
+		}
+		sb = &v1beta1.ServiceBroker{ObjectMeta: metav1.ObjectMeta{Name: "ns-broker", Namespace: "dev"}}
+		sc = &v1beta1.ServiceClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "redis-uuid", Namespace: "dev"},
+			Spec: v1beta1.ServiceClassSpec{
+				CommonServiceClassSpec: v1beta1.CommonServiceClassSpec{
+					ExternalName: "redis",
+					Tags:         []string{"cache"},
+				},
+				ServiceBrokerName: sb.Name,
+			},
+		}
+		sp = &v1beta1.ServicePlan{
+			ObjectMeta: metav1.ObjectMeta{Name: "small-uuid", Namespace: "dev"},
+			Spec: v1beta1.ServicePlanSpec{
+				CommonServicePlanSpec: v1beta1.CommonServicePlanSpec{
+					ExternalName: "small",
+					Free:         true,
+				},
+				ServiceClassRef: v1beta1.LocalObjectReference{Name: sc.Name},
+			},
+		}
+		svcCatClient = fake.NewSimpleClientset(csb, csc, freePlan, paidPlan, sb, sc, sp)
+		sdk = &SDK{
+			ServiceCatalogClient: svcCatClient,
+		}
+	})
+
+	Describe("RetrieveMarketplace", func() {
+		It("Joins the plans with their class and broker across scopes", func() {
+			offerings, err := sdk.RetrieveMarketplace(MarketplaceOptions{Namespace: "dev", Scope: AllScope})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(offerings).To(Equal([]Offering{
+				{Class: csc, Plan: paidPlan, Broker: csb},
+				{Class: csc, Plan: freePlan, Broker: csb},
+				{Class: sc, Plan: sp, Broker: sb},
+			}))
+		})
+		It("Limits the offerings to a scope", func() {
+			offerings, err := sdk.RetrieveMarketplace(MarketplaceOptions{Namespace: "dev", Scope: NamespaceScope})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(offerings).To(Equal([]Offering{{Class: sc, Plan: sp, Broker: sb}}))
+		})
+		It("Leaves the broker empty when it no longer exists", func() {
+			sdk.ServiceCatalogClient = fake.NewSimpleClientset(csc, freePlan)
+
+			offerings, err := sdk.RetrieveMarketplace(MarketplaceOptions{Scope: ClusterScope})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(offerings).To(HaveLen(1))
+			Expect(offerings[0].Broker).To(BeNil())
+		})
+		It("Filters by tag, ignoring case", func() {
+			offerings, err := sdk.RetrieveMarketplace(MarketplaceOptions{Namespace: "dev", Scope: AllScope, Tags: []string{"mysql", "database"}})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(offerings).To(HaveLen(2))
+			Expect(offerings[0].Class).To(Equal(csc))
+			Expect(offerings[1].Class).To(Equal(csc))
+		})
+		It("Filters by broker", func() {
+			offerings, err := sdk.RetrieveMarketplace(MarketplaceOptions{Namespace: "dev", Scope: AllScope, Broker: sb.Name})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(offerings).To(Equal([]Offering{{Class: sc, Plan: sp, Broker: sb}}))
+		})
+		It("Filters out the paid plans", func() {
+			offerings, err := sdk.RetrieveMarketplace(MarketplaceOptions{Namespace: "dev", Scope: AllScope, FreeOnly: true})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(offerings).To(HaveLen(2))
+			for _, offering := range offerings {
+				Expect(offering.IsFree()).To(BeTrue())
+			}
+		})
+		It("Searches the descriptions and the external metadata", func() {
+			offerings, err := sdk.RetrieveMarketplace(MarketplaceOptions{Namespace: "dev", Scope: AllScope, Search: "snapshots"})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(offerings).To(Equal([]Offering{{Class: csc, Plan: paidPlan, Broker: csb}}))
+
+			offerings, err = sdk.RetrieveMarketplace(MarketplaceOptions{Namespace: "dev", Scope: AllScope, Search: "managed mysql"})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(offerings).To(HaveLen(2))
+		})
+	})
+
+	Describe("Offering", func() {
+		It("Lets the plan override whether the class is bindable", func() {
+			Expect(Offering{Class: csc, Plan: freePlan}.IsBindable()).To(BeTrue())
+			Expect(Offering{Class: csc, Plan: paidPlan}.IsBindable()).To(BeFalse())
+			Expect(Offering{Class: sc, Plan: sp}.IsBindable()).To(BeFalse())
+		})
+	})
+})
@@ -37,4 +37,17 @@ type RegisterOptions struct {
 	RelistBehavior    v1beta1.ServiceBrokerRelistBehavior
 	RelistDuration    *metav1.Duration
 	SkipTLS           bool
+
+	// Scope is the scope of the broker, a cluster broker is registered
+	// unless it is NamespaceScope.
+	Scope Scope
+
+	// BasicUsername and BasicPassword are stored in a new secret, named
+	// after BasicSecret or the broker, that the broker uses for basic auth.
+	BasicUsername string
+	BasicPassword string
+
+	// BearerToken is stored in a new secret, named after BearerSecret or the
+	// broker, that the broker uses for bearer auth.
+	BearerToken string
 }
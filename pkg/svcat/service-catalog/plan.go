@@ -31,6 +31,9 @@ const (
 
 	// FieldServiceClassRef is the jsonpath to a plan's associated class name.
 	FieldServiceClassRef = "spec.clusterServiceClassRef.name"
+
+	// FieldNamespacedServiceClassRef is the jsonpath to a namespaced plan's associated class name.
+	FieldNamespacedServiceClassRef = "spec.serviceClassRef.name"
 )
 
 // RetrievePlanOptions allows to specify which plans will be retrieved
@@ -57,6 +60,9 @@ type Plan interface {
 
 	// GetClassID returns the plan's class name.
 	GetClassID() string
+
+	// GetSpec returns the spec that is common to plans of both scopes.
+	GetSpec() v1beta1.CommonServicePlanSpec
 }
 
 // RetrievePlans lists all plans defined in the cluster.
@@ -155,3 +161,47 @@ func (sdk *SDK) RetrievePlanByClassAndPlanNames(className, planName string,
 	}
 	return &searchResults.Items[0], nil
 }
+
+// retrievePlanByClassAndName gets a plan of a class by its external name. The
+// plan is searched for in the scope of the class.
+func (sdk *SDK) retrievePlanByClassAndName(class Class, planName string) (Plan, error) {
+	var plans []Plan
+	if ns := class.GetNamespace(); ns != "" {
+		opts := v1.ListOptions{
+			FieldSelector: fields.AndSelectors(
+				fields.OneTermEqualSelector(FieldNamespacedServiceClassRef, class.GetName()),
+				fields.OneTermEqualSelector(FieldExternalPlanName, planName),
+			).String(),
+		}
+		sp, err := sdk.ServiceCatalog().ServicePlans(ns).List(opts)
+		if err != nil {
+			return nil, fmt.Errorf("unable to search plans by class/plan name '%s/%s' in %q (%s)", class.GetExternalName(), planName, ns, err)
+		}
+		for i := range sp.Items {
+			plans = append(plans, &sp.Items[i])
+		}
+	} else {
+		opts := v1.ListOptions{
+			FieldSelector: fields.AndSelectors(
+				fields.OneTermEqualSelector(FieldServiceClassRef, class.GetName()),
+				fields.OneTermEqualSelector(FieldExternalPlanName, planName),
+			).String(),
+		}
+		csp, err := sdk.ServiceCatalog().ClusterServicePlans().List(opts)
+		if err != nil {
+			return nil, fmt.Errorf("unable to search plans by class/plan name '%s/%s' (%s)", class.GetExternalName(), planName, err)
+		}
+		for i := range csp.Items {
+			plans = append(plans, &csp.Items[i])
+		}
+	}
+
+	if len(plans) == 0 {
+		return nil, fmt.Errorf("plan not found '%s/%s'", class.GetExternalName(), planName)
+	}
+	if len(plans) > 1 {
+		// Note: Should never occur, as class/plan name combo must be unique
+		return nil, fmt.Errorf("more than one matching plan found for '%s/%s'", class.GetExternalName(), planName)
+	}
+	return plans[0], nil
+}
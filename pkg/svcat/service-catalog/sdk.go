@@ -25,6 +25,7 @@ import (
 	apicorev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes"
 	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 )
@@ -32,25 +33,32 @@ import (
 // SvcatClient is an interface containing the variuos actions in the svcat pkg lib
 // This interface is then faked with Counterfeiter for the cmd/svcat unit tests
 type SvcatClient interface {
+	ApplyBinding(string, *apiv1beta1.ServiceBinding) (*apiv1beta1.ServiceBinding, ApplyResult, error)
 	Bind(string, string, string, string, string, interface{}, map[string]string) (*apiv1beta1.ServiceBinding, error)
-	BindingParentHierarchy(*apiv1beta1.ServiceBinding) (*apiv1beta1.ServiceInstance, *apiv1beta1.ClusterServiceClass, *apiv1beta1.ClusterServicePlan, *apiv1beta1.ClusterServiceBroker, error)
+	BindingParentHierarchy(*apiv1beta1.ServiceBinding) (*apiv1beta1.ServiceInstance, Class, Plan, Broker, error)
 	DeleteBinding(string, string) error
 	DeleteBindings([]types.NamespacedName) ([]types.NamespacedName, error)
+	DiagnoseBinding(string, string) ([]Diagnosis, error)
+	DryRunBind(string, string, string, string, string, interface{}, map[string]string, *apiv1beta1.UserInfo) (*BindPreview, error)
 	IsBindingFailed(*apiv1beta1.ServiceBinding) bool
 	IsBindingReady(*apiv1beta1.ServiceBinding) bool
 	RetrieveBinding(string, string) (*apiv1beta1.ServiceBinding, error)
 	RetrieveBindings(string) (*apiv1beta1.ServiceBindingList, error)
 	RetrieveBindingsByInstance(*apiv1beta1.ServiceInstance) ([]apiv1beta1.ServiceBinding, error)
+	RetrieveBindingTimeline(*apiv1beta1.ServiceBinding) ([]TimelineEntry, error)
 	Unbind(string, string) ([]types.NamespacedName, error)
 	WaitForBinding(string, string, time.Duration, *time.Duration) (*apiv1beta1.ServiceBinding, error)
+	WatchBindings(string, string) (watch.Interface, error)
 
 	Deregister(string) error
+	DiagnoseBroker(string) ([]Diagnosis, error)
 	RetrieveBrokers(opts ScopeOptions) ([]Broker, error)
 	RetrieveBroker(string) (*apiv1beta1.ClusterServiceBroker, error)
-	RetrieveBrokerByClass(*apiv1beta1.ClusterServiceClass) (*apiv1beta1.ClusterServiceBroker, error)
-	Register(string, string, *RegisterOptions) (*apiv1beta1.ClusterServiceBroker, error)
+	RetrieveBrokerByClass(Class) (Broker, error)
+	RetrieveBrokerInScope(string, ScopeOptions) (Broker, error)
+	Register(string, string, *RegisterOptions) (Broker, error)
 	Sync(string, int) error
-	WaitForBroker(string, time.Duration, *time.Duration) (Broker, error)
+	WaitForBroker(string, ScopeOptions, time.Duration, *time.Duration) (Broker, error)
 
 	RetrieveClasses(ScopeOptions) ([]Class, error)
 	RetrieveClassByName(string) (*apiv1beta1.ClusterServiceClass, error)
@@ -58,25 +66,36 @@ type SvcatClient interface {
 	RetrieveClassByPlan(*apiv1beta1.ClusterServicePlan) (*apiv1beta1.ClusterServiceClass, error)
 	CreateClass(*apiv1beta1.ClusterServiceClass) (*apiv1beta1.ClusterServiceClass, error)
 
+	ApplyInstance(string, *apiv1beta1.ServiceInstance) (*apiv1beta1.ServiceInstance, ApplyResult, error)
 	Deprovision(string, string) error
-	InstanceParentHierarchy(*apiv1beta1.ServiceInstance) (*apiv1beta1.ClusterServiceClass, *apiv1beta1.ClusterServicePlan, *apiv1beta1.ClusterServiceBroker, error)
-	InstanceToServiceClassAndPlan(*apiv1beta1.ServiceInstance) (*apiv1beta1.ClusterServiceClass, *apiv1beta1.ClusterServicePlan, error)
+	DiagnoseInstance(string, string) ([]Diagnosis, error)
+	DryRunProvision(string, string, string, string, string, interface{}, map[string]string, Scope, *apiv1beta1.UserInfo) (*ProvisionPreview, error)
+	InstanceParentHierarchy(*apiv1beta1.ServiceInstance) (Class, Plan, Broker, error)
+	InstanceToServiceClassAndPlan(*apiv1beta1.ServiceInstance) (Class, Plan, error)
 	IsInstanceFailed(*apiv1beta1.ServiceInstance) bool
 	IsInstanceReady(*apiv1beta1.ServiceInstance) bool
-	Provision(string, string, string, string, string, interface{}, map[string]string) (*apiv1beta1.ServiceInstance, error)
+	Provision(string, string, string, string, string, interface{}, map[string]string, Scope) (*apiv1beta1.ServiceInstance, error)
 	RetrieveInstance(string, string) (*apiv1beta1.ServiceInstance, error)
 	RetrieveInstanceByBinding(*apiv1beta1.ServiceBinding) (*apiv1beta1.ServiceInstance, error)
 	RetrieveInstances(string, string, string) (*apiv1beta1.ServiceInstanceList, error)
 	RetrieveInstancesByPlan(*apiv1beta1.ClusterServicePlan) ([]apiv1beta1.ServiceInstance, error)
+	RetrieveInstanceTimeline(*apiv1beta1.ServiceInstance) ([]TimelineEntry, error)
 	TouchInstance(string, string, int) error
+	UpdateInstance(string, string, string, interface{}, map[string]string) (*apiv1beta1.ServiceInstance, error)
 	WaitForInstance(string, string, time.Duration, *time.Duration) (*apiv1beta1.ServiceInstance, error)
 	WaitForInstanceToNotExist(string, string, time.Duration, *time.Duration) (*apiv1beta1.ServiceInstance, error)
+	WatchInstances(string, string, string, string) (watch.Interface, error)
+
+	ExportManifest(string) (*Manifest, error)
+
+	RetrieveMarketplace(MarketplaceOptions) ([]Offering, error)
 
 	RetrievePlans(RetrievePlanOptions) ([]Plan, error)
 	RetrievePlanByName(string) (*apiv1beta1.ClusterServicePlan, error)
 	RetrievePlanByID(string) (*apiv1beta1.ClusterServicePlan, error)
 	RetrievePlanByClassAndPlanNames(string, string) (*apiv1beta1.ClusterServicePlan, error)
 
+	RetrieveCredentials(string, string) (map[string]string, error)
 	RetrieveSecretByBinding(*apiv1beta1.ServiceBinding) (*apicorev1.Secret, error)
 
 	ServerVersion() (*version.Info, error)
@@ -41,3 +41,28 @@ func (sdk *SDK) RetrieveSecretByBinding(binding *v1beta1.ServiceBinding) (*corev
 
 	return secret, nil
 }
+
+// RetrieveCredentials gets the decoded credentials of a binding. The
+// controller applies the SecretTransforms of the binding when it writes the
+// secret, so these are the credentials seen by the applications that consume
+// the secret.
+func (sdk *SDK) RetrieveCredentials(ns, bindingName string) (map[string]string, error) {
+	binding, err := sdk.RetrieveBinding(ns, bindingName)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := sdk.RetrieveSecretByBinding(binding)
+	if err != nil {
+		return nil, err
+	}
+	if secret == nil {
+		return nil, fmt.Errorf("the credentials of binding '%s.%s' are not available until the binding is ready", ns, bindingName)
+	}
+
+	credentials := make(map[string]string, len(secret.Data))
+	for key, value := range secret.Data {
+		credentials[key] = string(value)
+	}
+	return credentials, nil
+}
@@ -112,4 +112,27 @@ var _ = Describe("Secret", func() {
 		})
 	})
 
+	Describe("RetrieveCredentials", func() {
+		It("Decodes the secret of the binding", func() {
+			boundSecret.Data = map[string][]byte{"username": []byte("admin"), "password": []byte("s3cret")}
+			sdk.K8sClient = k8sfake.NewSimpleClientset(boundSecret)
+
+			credentials, err := sdk.RetrieveCredentials(readyBinding.Namespace, readyBinding.Name)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(credentials).To(Equal(map[string]string{"username": "admin", "password": "s3cret"}))
+		})
+		It("Fails when the binding is not ready", func() {
+			_, err := sdk.RetrieveCredentials(unreadyBinding.Namespace, unreadyBinding.Name)
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("not available until the binding is ready"))
+		})
+		It("Bubbles up errors", func() {
+			_, err := sdk.RetrieveCredentials(readyBinding.Namespace, "missing")
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("unable to get binding"))
+		})
+	})
 })
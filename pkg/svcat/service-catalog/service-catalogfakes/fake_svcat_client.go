@@ -26,9 +26,26 @@ import (
 	apicorev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 type FakeSvcatClient struct {
+	ApplyBindingStub        func(string, *apiv1beta1.ServiceBinding) (*apiv1beta1.ServiceBinding, servicecatalog.ApplyResult, error)
+	applyBindingMutex       sync.RWMutex
+	applyBindingArgsForCall []struct {
+		arg1 string
+		arg2 *apiv1beta1.ServiceBinding
+	}
+	applyBindingReturns struct {
+		result1 *apiv1beta1.ServiceBinding
+		result2 servicecatalog.ApplyResult
+		result3 error
+	}
+	applyBindingReturnsOnCall map[int]struct {
+		result1 *apiv1beta1.ServiceBinding
+		result2 servicecatalog.ApplyResult
+		result3 error
+	}
 	BindStub        func(string, string, string, string, string, interface{}, map[string]string) (*apiv1beta1.ServiceBinding, error)
 	bindMutex       sync.RWMutex
 	bindArgsForCall []struct {
@@ -48,23 +65,23 @@ type FakeSvcatClient struct {
 		result1 *apiv1beta1.ServiceBinding
 		result2 error
 	}
-	BindingParentHierarchyStub        func(*apiv1beta1.ServiceBinding) (*apiv1beta1.ServiceInstance, *apiv1beta1.ClusterServiceClass, *apiv1beta1.ClusterServicePlan, *apiv1beta1.ClusterServiceBroker, error)
+	BindingParentHierarchyStub        func(*apiv1beta1.ServiceBinding) (*apiv1beta1.ServiceInstance, servicecatalog.Class, servicecatalog.Plan, servicecatalog.Broker, error)
 	bindingParentHierarchyMutex       sync.RWMutex
 	bindingParentHierarchyArgsForCall []struct {
 		arg1 *apiv1beta1.ServiceBinding
 	}
 	bindingParentHierarchyReturns struct {
 		result1 *apiv1beta1.ServiceInstance
-		result2 *apiv1beta1.ClusterServiceClass
-		result3 *apiv1beta1.ClusterServicePlan
-		result4 *apiv1beta1.ClusterServiceBroker
+		result2 servicecatalog.Class
+		result3 servicecatalog.Plan
+		result4 servicecatalog.Broker
 		result5 error
 	}
 	bindingParentHierarchyReturnsOnCall map[int]struct {
 		result1 *apiv1beta1.ServiceInstance
-		result2 *apiv1beta1.ClusterServiceClass
-		result3 *apiv1beta1.ClusterServicePlan
-		result4 *apiv1beta1.ClusterServiceBroker
+		result2 servicecatalog.Class
+		result3 servicecatalog.Plan
+		result4 servicecatalog.Broker
 		result5 error
 	}
 	DeleteBindingStub        func(string, string) error
@@ -92,6 +109,40 @@ type FakeSvcatClient struct {
 		result1 []types.NamespacedName
 		result2 error
 	}
+	DiagnoseBindingStub        func(string, string) ([]servicecatalog.Diagnosis, error)
+	diagnoseBindingMutex       sync.RWMutex
+	diagnoseBindingArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	diagnoseBindingReturns struct {
+		result1 []servicecatalog.Diagnosis
+		result2 error
+	}
+	diagnoseBindingReturnsOnCall map[int]struct {
+		result1 []servicecatalog.Diagnosis
+		result2 error
+	}
+	DryRunBindStub        func(string, string, string, string, string, interface{}, map[string]string, *apiv1beta1.UserInfo) (*servicecatalog.BindPreview, error)
+	dryRunBindMutex       sync.RWMutex
+	dryRunBindArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+		arg6 interface{}
+		arg7 map[string]string
+		arg8 *apiv1beta1.UserInfo
+	}
+	dryRunBindReturns struct {
+		result1 *servicecatalog.BindPreview
+		result2 error
+	}
+	dryRunBindReturnsOnCall map[int]struct {
+		result1 *servicecatalog.BindPreview
+		result2 error
+	}
 	IsBindingFailedStub        func(*apiv1beta1.ServiceBinding) bool
 	isBindingFailedMutex       sync.RWMutex
 	isBindingFailedArgsForCall []struct {
@@ -154,6 +205,19 @@ type FakeSvcatClient struct {
 		result1 []apiv1beta1.ServiceBinding
 		result2 error
 	}
+	RetrieveBindingTimelineStub        func(*apiv1beta1.ServiceBinding) ([]servicecatalog.TimelineEntry, error)
+	retrieveBindingTimelineMutex       sync.RWMutex
+	retrieveBindingTimelineArgsForCall []struct {
+		arg1 *apiv1beta1.ServiceBinding
+	}
+	retrieveBindingTimelineReturns struct {
+		result1 []servicecatalog.TimelineEntry
+		result2 error
+	}
+	retrieveBindingTimelineReturnsOnCall map[int]struct {
+		result1 []servicecatalog.TimelineEntry
+		result2 error
+	}
 	UnbindStub        func(string, string) ([]types.NamespacedName, error)
 	unbindMutex       sync.RWMutex
 	unbindArgsForCall []struct {
@@ -184,6 +248,20 @@ type FakeSvcatClient struct {
 		result1 *apiv1beta1.ServiceBinding
 		result2 error
 	}
+	WatchBindingsStub        func(string, string) (watch.Interface, error)
+	watchBindingsMutex       sync.RWMutex
+	watchBindingsArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	watchBindingsReturns struct {
+		result1 watch.Interface
+		result2 error
+	}
+	watchBindingsReturnsOnCall map[int]struct {
+		result1 watch.Interface
+		result2 error
+	}
 	DeregisterStub        func(string) error
 	deregisterMutex       sync.RWMutex
 	deregisterArgsForCall []struct {
@@ -195,6 +273,19 @@ type FakeSvcatClient struct {
 	deregisterReturnsOnCall map[int]struct {
 		result1 error
 	}
+	DiagnoseBrokerStub        func(string) ([]servicecatalog.Diagnosis, error)
+	diagnoseBrokerMutex       sync.RWMutex
+	diagnoseBrokerArgsForCall []struct {
+		arg1 string
+	}
+	diagnoseBrokerReturns struct {
+		result1 []servicecatalog.Diagnosis
+		result2 error
+	}
+	diagnoseBrokerReturnsOnCall map[int]struct {
+		result1 []servicecatalog.Diagnosis
+		result2 error
+	}
 	RetrieveBrokersStub        func(opts servicecatalog.ScopeOptions) ([]servicecatalog.Broker, error)
 	retrieveBrokersMutex       sync.RWMutex
 	retrieveBrokersArgsForCall []struct {
@@ -221,20 +312,34 @@ type FakeSvcatClient struct {
 		result1 *apiv1beta1.ClusterServiceBroker
 		result2 error
 	}
-	RetrieveBrokerByClassStub        func(*apiv1beta1.ClusterServiceClass) (*apiv1beta1.ClusterServiceBroker, error)
+	RetrieveBrokerByClassStub        func(servicecatalog.Class) (servicecatalog.Broker, error)
 	retrieveBrokerByClassMutex       sync.RWMutex
 	retrieveBrokerByClassArgsForCall []struct {
-		arg1 *apiv1beta1.ClusterServiceClass
+		arg1 servicecatalog.Class
 	}
 	retrieveBrokerByClassReturns struct {
-		result1 *apiv1beta1.ClusterServiceBroker
+		result1 servicecatalog.Broker
 		result2 error
 	}
 	retrieveBrokerByClassReturnsOnCall map[int]struct {
-		result1 *apiv1beta1.ClusterServiceBroker
+		result1 servicecatalog.Broker
 		result2 error
 	}
-	RegisterStub        func(string, string, *servicecatalog.RegisterOptions) (*apiv1beta1.ClusterServiceBroker, error)
+	RetrieveBrokerInScopeStub        func(string, servicecatalog.ScopeOptions) (servicecatalog.Broker, error)
+	retrieveBrokerInScopeMutex       sync.RWMutex
+	retrieveBrokerInScopeArgsForCall []struct {
+		arg1 string
+		arg2 servicecatalog.ScopeOptions
+	}
+	retrieveBrokerInScopeReturns struct {
+		result1 servicecatalog.Broker
+		result2 error
+	}
+	retrieveBrokerInScopeReturnsOnCall map[int]struct {
+		result1 servicecatalog.Broker
+		result2 error
+	}
+	RegisterStub        func(string, string, *servicecatalog.RegisterOptions) (servicecatalog.Broker, error)
 	registerMutex       sync.RWMutex
 	registerArgsForCall []struct {
 		arg1 string
@@ -242,11 +347,11 @@ type FakeSvcatClient struct {
 		arg3 *servicecatalog.RegisterOptions
 	}
 	registerReturns struct {
-		result1 *apiv1beta1.ClusterServiceBroker
+		result1 servicecatalog.Broker
 		result2 error
 	}
 	registerReturnsOnCall map[int]struct {
-		result1 *apiv1beta1.ClusterServiceBroker
+		result1 servicecatalog.Broker
 		result2 error
 	}
 	SyncStub        func(string, int) error
@@ -261,12 +366,13 @@ type FakeSvcatClient struct {
 	syncReturnsOnCall map[int]struct {
 		result1 error
 	}
-	WaitForBrokerStub        func(string, time.Duration, *time.Duration) (servicecatalog.Broker, error)
+	WaitForBrokerStub        func(string, servicecatalog.ScopeOptions, time.Duration, *time.Duration) (servicecatalog.Broker, error)
 	waitForBrokerMutex       sync.RWMutex
 	waitForBrokerArgsForCall []struct {
 		arg1 string
-		arg2 time.Duration
-		arg3 *time.Duration
+		arg2 servicecatalog.ScopeOptions
+		arg3 time.Duration
+		arg4 *time.Duration
 	}
 	waitForBrokerReturns struct {
 		result1 servicecatalog.Broker
@@ -341,6 +447,22 @@ type FakeSvcatClient struct {
 		result1 *apiv1beta1.ClusterServiceClass
 		result2 error
 	}
+	ApplyInstanceStub        func(string, *apiv1beta1.ServiceInstance) (*apiv1beta1.ServiceInstance, servicecatalog.ApplyResult, error)
+	applyInstanceMutex       sync.RWMutex
+	applyInstanceArgsForCall []struct {
+		arg1 string
+		arg2 *apiv1beta1.ServiceInstance
+	}
+	applyInstanceReturns struct {
+		result1 *apiv1beta1.ServiceInstance
+		result2 servicecatalog.ApplyResult
+		result3 error
+	}
+	applyInstanceReturnsOnCall map[int]struct {
+		result1 *apiv1beta1.ServiceInstance
+		result2 servicecatalog.ApplyResult
+		result3 error
+	}
 	DeprovisionStub        func(string, string) error
 	deprovisionMutex       sync.RWMutex
 	deprovisionArgsForCall []struct {
@@ -353,36 +475,71 @@ type FakeSvcatClient struct {
 	deprovisionReturnsOnCall map[int]struct {
 		result1 error
 	}
-	InstanceParentHierarchyStub        func(*apiv1beta1.ServiceInstance) (*apiv1beta1.ClusterServiceClass, *apiv1beta1.ClusterServicePlan, *apiv1beta1.ClusterServiceBroker, error)
+	DiagnoseInstanceStub        func(string, string) ([]servicecatalog.Diagnosis, error)
+	diagnoseInstanceMutex       sync.RWMutex
+	diagnoseInstanceArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	diagnoseInstanceReturns struct {
+		result1 []servicecatalog.Diagnosis
+		result2 error
+	}
+	diagnoseInstanceReturnsOnCall map[int]struct {
+		result1 []servicecatalog.Diagnosis
+		result2 error
+	}
+	DryRunProvisionStub        func(string, string, string, string, string, interface{}, map[string]string, servicecatalog.Scope, *apiv1beta1.UserInfo) (*servicecatalog.ProvisionPreview, error)
+	dryRunProvisionMutex       sync.RWMutex
+	dryRunProvisionArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+		arg6 interface{}
+		arg7 map[string]string
+		arg8 servicecatalog.Scope
+		arg9 *apiv1beta1.UserInfo
+	}
+	dryRunProvisionReturns struct {
+		result1 *servicecatalog.ProvisionPreview
+		result2 error
+	}
+	dryRunProvisionReturnsOnCall map[int]struct {
+		result1 *servicecatalog.ProvisionPreview
+		result2 error
+	}
+	InstanceParentHierarchyStub        func(*apiv1beta1.ServiceInstance) (servicecatalog.Class, servicecatalog.Plan, servicecatalog.Broker, error)
 	instanceParentHierarchyMutex       sync.RWMutex
 	instanceParentHierarchyArgsForCall []struct {
 		arg1 *apiv1beta1.ServiceInstance
 	}
 	instanceParentHierarchyReturns struct {
-		result1 *apiv1beta1.ClusterServiceClass
-		result2 *apiv1beta1.ClusterServicePlan
-		result3 *apiv1beta1.ClusterServiceBroker
+		result1 servicecatalog.Class
+		result2 servicecatalog.Plan
+		result3 servicecatalog.Broker
 		result4 error
 	}
 	instanceParentHierarchyReturnsOnCall map[int]struct {
-		result1 *apiv1beta1.ClusterServiceClass
-		result2 *apiv1beta1.ClusterServicePlan
-		result3 *apiv1beta1.ClusterServiceBroker
+		result1 servicecatalog.Class
+		result2 servicecatalog.Plan
+		result3 servicecatalog.Broker
 		result4 error
 	}
-	InstanceToServiceClassAndPlanStub        func(*apiv1beta1.ServiceInstance) (*apiv1beta1.ClusterServiceClass, *apiv1beta1.ClusterServicePlan, error)
+	InstanceToServiceClassAndPlanStub        func(*apiv1beta1.ServiceInstance) (servicecatalog.Class, servicecatalog.Plan, error)
 	instanceToServiceClassAndPlanMutex       sync.RWMutex
 	instanceToServiceClassAndPlanArgsForCall []struct {
 		arg1 *apiv1beta1.ServiceInstance
 	}
 	instanceToServiceClassAndPlanReturns struct {
-		result1 *apiv1beta1.ClusterServiceClass
-		result2 *apiv1beta1.ClusterServicePlan
+		result1 servicecatalog.Class
+		result2 servicecatalog.Plan
 		result3 error
 	}
 	instanceToServiceClassAndPlanReturnsOnCall map[int]struct {
-		result1 *apiv1beta1.ClusterServiceClass
-		result2 *apiv1beta1.ClusterServicePlan
+		result1 servicecatalog.Class
+		result2 servicecatalog.Plan
 		result3 error
 	}
 	IsInstanceFailedStub        func(*apiv1beta1.ServiceInstance) bool
@@ -407,7 +564,7 @@ type FakeSvcatClient struct {
 	isInstanceReadyReturnsOnCall map[int]struct {
 		result1 bool
 	}
-	ProvisionStub        func(string, string, string, string, string, interface{}, map[string]string) (*apiv1beta1.ServiceInstance, error)
+	ProvisionStub        func(string, string, string, string, string, interface{}, map[string]string, servicecatalog.Scope) (*apiv1beta1.ServiceInstance, error)
 	provisionMutex       sync.RWMutex
 	provisionArgsForCall []struct {
 		arg1 string
@@ -417,6 +574,7 @@ type FakeSvcatClient struct {
 		arg5 string
 		arg6 interface{}
 		arg7 map[string]string
+		arg8 servicecatalog.Scope
 	}
 	provisionReturns struct {
 		result1 *apiv1beta1.ServiceInstance
@@ -481,6 +639,19 @@ type FakeSvcatClient struct {
 		result1 []apiv1beta1.ServiceInstance
 		result2 error
 	}
+	RetrieveInstanceTimelineStub        func(*apiv1beta1.ServiceInstance) ([]servicecatalog.TimelineEntry, error)
+	retrieveInstanceTimelineMutex       sync.RWMutex
+	retrieveInstanceTimelineArgsForCall []struct {
+		arg1 *apiv1beta1.ServiceInstance
+	}
+	retrieveInstanceTimelineReturns struct {
+		result1 []servicecatalog.TimelineEntry
+		result2 error
+	}
+	retrieveInstanceTimelineReturnsOnCall map[int]struct {
+		result1 []servicecatalog.TimelineEntry
+		result2 error
+	}
 	TouchInstanceStub        func(string, string, int) error
 	touchInstanceMutex       sync.RWMutex
 	touchInstanceArgsForCall []struct {
@@ -494,6 +665,23 @@ type FakeSvcatClient struct {
 	touchInstanceReturnsOnCall map[int]struct {
 		result1 error
 	}
+	UpdateInstanceStub        func(string, string, string, interface{}, map[string]string) (*apiv1beta1.ServiceInstance, error)
+	updateInstanceMutex       sync.RWMutex
+	updateInstanceArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 interface{}
+		arg5 map[string]string
+	}
+	updateInstanceReturns struct {
+		result1 *apiv1beta1.ServiceInstance
+		result2 error
+	}
+	updateInstanceReturnsOnCall map[int]struct {
+		result1 *apiv1beta1.ServiceInstance
+		result2 error
+	}
 	WaitForInstanceStub        func(string, string, time.Duration, *time.Duration) (*apiv1beta1.ServiceInstance, error)
 	waitForInstanceMutex       sync.RWMutex
 	waitForInstanceArgsForCall []struct {
@@ -526,6 +714,48 @@ type FakeSvcatClient struct {
 		result1 *apiv1beta1.ServiceInstance
 		result2 error
 	}
+	WatchInstancesStub        func(string, string, string, string) (watch.Interface, error)
+	watchInstancesMutex       sync.RWMutex
+	watchInstancesArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+	}
+	watchInstancesReturns struct {
+		result1 watch.Interface
+		result2 error
+	}
+	watchInstancesReturnsOnCall map[int]struct {
+		result1 watch.Interface
+		result2 error
+	}
+	ExportManifestStub        func(string) (*servicecatalog.Manifest, error)
+	exportManifestMutex       sync.RWMutex
+	exportManifestArgsForCall []struct {
+		arg1 string
+	}
+	exportManifestReturns struct {
+		result1 *servicecatalog.Manifest
+		result2 error
+	}
+	exportManifestReturnsOnCall map[int]struct {
+		result1 *servicecatalog.Manifest
+		result2 error
+	}
+	RetrieveMarketplaceStub        func(servicecatalog.MarketplaceOptions) ([]servicecatalog.Offering, error)
+	retrieveMarketplaceMutex       sync.RWMutex
+	retrieveMarketplaceArgsForCall []struct {
+		arg1 servicecatalog.MarketplaceOptions
+	}
+	retrieveMarketplaceReturns struct {
+		result1 []servicecatalog.Offering
+		result2 error
+	}
+	retrieveMarketplaceReturnsOnCall map[int]struct {
+		result1 []servicecatalog.Offering
+		result2 error
+	}
 	RetrievePlansStub        func(servicecatalog.RetrievePlanOptions) ([]servicecatalog.Plan, error)
 	retrievePlansMutex       sync.RWMutex
 	retrievePlansArgsForCall []struct {
@@ -579,6 +809,20 @@ type FakeSvcatClient struct {
 		result1 *apiv1beta1.ClusterServicePlan
 		result2 error
 	}
+	RetrieveCredentialsStub        func(string, string) (map[string]string, error)
+	retrieveCredentialsMutex       sync.RWMutex
+	retrieveCredentialsArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	retrieveCredentialsReturns struct {
+		result1 map[string]string
+		result2 error
+	}
+	retrieveCredentialsReturnsOnCall map[int]struct {
+		result1 map[string]string
+		result2 error
+	}
 	RetrieveSecretByBindingStub        func(*apiv1beta1.ServiceBinding) (*apicorev1.Secret, error)
 	retrieveSecretByBindingMutex       sync.RWMutex
 	retrieveSecretByBindingArgsForCall []struct {
@@ -607,6 +851,61 @@ type FakeSvcatClient struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeSvcatClient) ApplyBinding(arg1 string, arg2 *apiv1beta1.ServiceBinding) (*apiv1beta1.ServiceBinding, servicecatalog.ApplyResult, error) {
+	fake.applyBindingMutex.Lock()
+	ret, specificReturn := fake.applyBindingReturnsOnCall[len(fake.applyBindingArgsForCall)]
+	fake.applyBindingArgsForCall = append(fake.applyBindingArgsForCall, struct {
+		arg1 string
+		arg2 *apiv1beta1.ServiceBinding
+	}{arg1, arg2})
+	fake.recordInvocation("ApplyBinding", []interface{}{arg1, arg2})
+	fake.applyBindingMutex.Unlock()
+	if fake.ApplyBindingStub != nil {
+		return fake.ApplyBindingStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.applyBindingReturns.result1, fake.applyBindingReturns.result2, fake.applyBindingReturns.result3
+}
+
+func (fake *FakeSvcatClient) ApplyBindingCallCount() int {
+	fake.applyBindingMutex.RLock()
+	defer fake.applyBindingMutex.RUnlock()
+	return len(fake.applyBindingArgsForCall)
+}
+
+func (fake *FakeSvcatClient) ApplyBindingArgsForCall(i int) (string, *apiv1beta1.ServiceBinding) {
+	fake.applyBindingMutex.RLock()
+	defer fake.applyBindingMutex.RUnlock()
+	return fake.applyBindingArgsForCall[i].arg1, fake.applyBindingArgsForCall[i].arg2
+}
+
+func (fake *FakeSvcatClient) ApplyBindingReturns(result1 *apiv1beta1.ServiceBinding, result2 servicecatalog.ApplyResult, result3 error) {
+	fake.ApplyBindingStub = nil
+	fake.applyBindingReturns = struct {
+		result1 *apiv1beta1.ServiceBinding
+		result2 servicecatalog.ApplyResult
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeSvcatClient) ApplyBindingReturnsOnCall(i int, result1 *apiv1beta1.ServiceBinding, result2 servicecatalog.ApplyResult, result3 error) {
+	fake.ApplyBindingStub = nil
+	if fake.applyBindingReturnsOnCall == nil {
+		fake.applyBindingReturnsOnCall = make(map[int]struct {
+			result1 *apiv1beta1.ServiceBinding
+			result2 servicecatalog.ApplyResult
+			result3 error
+		})
+	}
+	fake.applyBindingReturnsOnCall[i] = struct {
+		result1 *apiv1beta1.ServiceBinding
+		result2 servicecatalog.ApplyResult
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeSvcatClient) Bind(arg1 string, arg2 string, arg3 string, arg4 string, arg5 string, arg6 interface{}, arg7 map[string]string) (*apiv1beta1.ServiceBinding, error) {
 	fake.bindMutex.Lock()
 	ret, specificReturn := fake.bindReturnsOnCall[len(fake.bindArgsForCall)]
@@ -664,7 +963,7 @@ func (fake *FakeSvcatClient) BindReturnsOnCall(i int, result1 *apiv1beta1.Servic
 	}{result1, result2}
 }
 
-func (fake *FakeSvcatClient) BindingParentHierarchy(arg1 *apiv1beta1.ServiceBinding) (*apiv1beta1.ServiceInstance, *apiv1beta1.ClusterServiceClass, *apiv1beta1.ClusterServicePlan, *apiv1beta1.ClusterServiceBroker, error) {
+func (fake *FakeSvcatClient) BindingParentHierarchy(arg1 *apiv1beta1.ServiceBinding) (*apiv1beta1.ServiceInstance, servicecatalog.Class, servicecatalog.Plan, servicecatalog.Broker, error) {
 	fake.bindingParentHierarchyMutex.Lock()
 	ret, specificReturn := fake.bindingParentHierarchyReturnsOnCall[len(fake.bindingParentHierarchyArgsForCall)]
 	fake.bindingParentHierarchyArgsForCall = append(fake.bindingParentHierarchyArgsForCall, struct {
@@ -693,33 +992,33 @@ func (fake *FakeSvcatClient) BindingParentHierarchyArgsForCall(i int) *apiv1beta
 	return fake.bindingParentHierarchyArgsForCall[i].arg1
 }
 
-func (fake *FakeSvcatClient) BindingParentHierarchyReturns(result1 *apiv1beta1.ServiceInstance, result2 *apiv1beta1.ClusterServiceClass, result3 *apiv1beta1.ClusterServicePlan, result4 *apiv1beta1.ClusterServiceBroker, result5 error) {
+func (fake *FakeSvcatClient) BindingParentHierarchyReturns(result1 *apiv1beta1.ServiceInstance, result2 servicecatalog.Class, result3 servicecatalog.Plan, result4 servicecatalog.Broker, result5 error) {
 	fake.BindingParentHierarchyStub = nil
 	fake.bindingParentHierarchyReturns = struct {
 		result1 *apiv1beta1.ServiceInstance
-		result2 *apiv1beta1.ClusterServiceClass
-		result3 *apiv1beta1.ClusterServicePlan
-		result4 *apiv1beta1.ClusterServiceBroker
+		result2 servicecatalog.Class
+		result3 servicecatalog.Plan
+		result4 servicecatalog.Broker
 		result5 error
 	}{result1, result2, result3, result4, result5}
 }
 
-func (fake *FakeSvcatClient) BindingParentHierarchyReturnsOnCall(i int, result1 *apiv1beta1.ServiceInstance, result2 *apiv1beta1.ClusterServiceClass, result3 *apiv1beta1.ClusterServicePlan, result4 *apiv1beta1.ClusterServiceBroker, result5 error) {
+func (fake *FakeSvcatClient) BindingParentHierarchyReturnsOnCall(i int, result1 *apiv1beta1.ServiceInstance, result2 servicecatalog.Class, result3 servicecatalog.Plan, result4 servicecatalog.Broker, result5 error) {
 	fake.BindingParentHierarchyStub = nil
 	if fake.bindingParentHierarchyReturnsOnCall == nil {
 		fake.bindingParentHierarchyReturnsOnCall = make(map[int]struct {
 			result1 *apiv1beta1.ServiceInstance
-			result2 *apiv1beta1.ClusterServiceClass
-			result3 *apiv1beta1.ClusterServicePlan
-			result4 *apiv1beta1.ClusterServiceBroker
+			result2 servicecatalog.Class
+			result3 servicecatalog.Plan
+			result4 servicecatalog.Broker
 			result5 error
 		})
 	}
 	fake.bindingParentHierarchyReturnsOnCall[i] = struct {
 		result1 *apiv1beta1.ServiceInstance
-		result2 *apiv1beta1.ClusterServiceClass
-		result3 *apiv1beta1.ClusterServicePlan
-		result4 *apiv1beta1.ClusterServiceBroker
+		result2 servicecatalog.Class
+		result3 servicecatalog.Plan
+		result4 servicecatalog.Broker
 		result5 error
 	}{result1, result2, result3, result4, result5}
 }
@@ -829,6 +1128,116 @@ func (fake *FakeSvcatClient) DeleteBindingsReturnsOnCall(i int, result1 []types.
 	}{result1, result2}
 }
 
+func (fake *FakeSvcatClient) DiagnoseBinding(arg1 string, arg2 string) ([]servicecatalog.Diagnosis, error) {
+	fake.diagnoseBindingMutex.Lock()
+	ret, specificReturn := fake.diagnoseBindingReturnsOnCall[len(fake.diagnoseBindingArgsForCall)]
+	fake.diagnoseBindingArgsForCall = append(fake.diagnoseBindingArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("DiagnoseBinding", []interface{}{arg1, arg2})
+	fake.diagnoseBindingMutex.Unlock()
+	if fake.DiagnoseBindingStub != nil {
+		return fake.DiagnoseBindingStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.diagnoseBindingReturns.result1, fake.diagnoseBindingReturns.result2
+}
+
+func (fake *FakeSvcatClient) DiagnoseBindingCallCount() int {
+	fake.diagnoseBindingMutex.RLock()
+	defer fake.diagnoseBindingMutex.RUnlock()
+	return len(fake.diagnoseBindingArgsForCall)
+}
+
+func (fake *FakeSvcatClient) DiagnoseBindingArgsForCall(i int) (string, string) {
+	fake.diagnoseBindingMutex.RLock()
+	defer fake.diagnoseBindingMutex.RUnlock()
+	return fake.diagnoseBindingArgsForCall[i].arg1, fake.diagnoseBindingArgsForCall[i].arg2
+}
+
+func (fake *FakeSvcatClient) DiagnoseBindingReturns(result1 []servicecatalog.Diagnosis, result2 error) {
+	fake.DiagnoseBindingStub = nil
+	fake.diagnoseBindingReturns = struct {
+		result1 []servicecatalog.Diagnosis
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) DiagnoseBindingReturnsOnCall(i int, result1 []servicecatalog.Diagnosis, result2 error) {
+	fake.DiagnoseBindingStub = nil
+	if fake.diagnoseBindingReturnsOnCall == nil {
+		fake.diagnoseBindingReturnsOnCall = make(map[int]struct {
+			result1 []servicecatalog.Diagnosis
+			result2 error
+		})
+	}
+	fake.diagnoseBindingReturnsOnCall[i] = struct {
+		result1 []servicecatalog.Diagnosis
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) DryRunBind(arg1 string, arg2 string, arg3 string, arg4 string, arg5 string, arg6 interface{}, arg7 map[string]string, arg8 *apiv1beta1.UserInfo) (*servicecatalog.BindPreview, error) {
+	fake.dryRunBindMutex.Lock()
+	ret, specificReturn := fake.dryRunBindReturnsOnCall[len(fake.dryRunBindArgsForCall)]
+	fake.dryRunBindArgsForCall = append(fake.dryRunBindArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+		arg6 interface{}
+		arg7 map[string]string
+		arg8 *apiv1beta1.UserInfo
+	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8})
+	fake.recordInvocation("DryRunBind", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8})
+	fake.dryRunBindMutex.Unlock()
+	if fake.DryRunBindStub != nil {
+		return fake.DryRunBindStub(arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.dryRunBindReturns.result1, fake.dryRunBindReturns.result2
+}
+
+func (fake *FakeSvcatClient) DryRunBindCallCount() int {
+	fake.dryRunBindMutex.RLock()
+	defer fake.dryRunBindMutex.RUnlock()
+	return len(fake.dryRunBindArgsForCall)
+}
+
+func (fake *FakeSvcatClient) DryRunBindArgsForCall(i int) (string, string, string, string, string, interface{}, map[string]string, *apiv1beta1.UserInfo) {
+	fake.dryRunBindMutex.RLock()
+	defer fake.dryRunBindMutex.RUnlock()
+	return fake.dryRunBindArgsForCall[i].arg1, fake.dryRunBindArgsForCall[i].arg2, fake.dryRunBindArgsForCall[i].arg3, fake.dryRunBindArgsForCall[i].arg4, fake.dryRunBindArgsForCall[i].arg5, fake.dryRunBindArgsForCall[i].arg6, fake.dryRunBindArgsForCall[i].arg7, fake.dryRunBindArgsForCall[i].arg8
+}
+
+func (fake *FakeSvcatClient) DryRunBindReturns(result1 *servicecatalog.BindPreview, result2 error) {
+	fake.DryRunBindStub = nil
+	fake.dryRunBindReturns = struct {
+		result1 *servicecatalog.BindPreview
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) DryRunBindReturnsOnCall(i int, result1 *servicecatalog.BindPreview, result2 error) {
+	fake.DryRunBindStub = nil
+	if fake.dryRunBindReturnsOnCall == nil {
+		fake.dryRunBindReturnsOnCall = make(map[int]struct {
+			result1 *servicecatalog.BindPreview
+			result2 error
+		})
+	}
+	fake.dryRunBindReturnsOnCall[i] = struct {
+		result1 *servicecatalog.BindPreview
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeSvcatClient) IsBindingFailed(arg1 *apiv1beta1.ServiceBinding) bool {
 	fake.isBindingFailedMutex.Lock()
 	ret, specificReturn := fake.isBindingFailedReturnsOnCall[len(fake.isBindingFailedArgsForCall)]
@@ -1079,6 +1488,57 @@ func (fake *FakeSvcatClient) RetrieveBindingsByInstanceReturnsOnCall(i int, resu
 	}{result1, result2}
 }
 
+func (fake *FakeSvcatClient) RetrieveBindingTimeline(arg1 *apiv1beta1.ServiceBinding) ([]servicecatalog.TimelineEntry, error) {
+	fake.retrieveBindingTimelineMutex.Lock()
+	ret, specificReturn := fake.retrieveBindingTimelineReturnsOnCall[len(fake.retrieveBindingTimelineArgsForCall)]
+	fake.retrieveBindingTimelineArgsForCall = append(fake.retrieveBindingTimelineArgsForCall, struct {
+		arg1 *apiv1beta1.ServiceBinding
+	}{arg1})
+	fake.recordInvocation("RetrieveBindingTimeline", []interface{}{arg1})
+	fake.retrieveBindingTimelineMutex.Unlock()
+	if fake.RetrieveBindingTimelineStub != nil {
+		return fake.RetrieveBindingTimelineStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.retrieveBindingTimelineReturns.result1, fake.retrieveBindingTimelineReturns.result2
+}
+
+func (fake *FakeSvcatClient) RetrieveBindingTimelineCallCount() int {
+	fake.retrieveBindingTimelineMutex.RLock()
+	defer fake.retrieveBindingTimelineMutex.RUnlock()
+	return len(fake.retrieveBindingTimelineArgsForCall)
+}
+
+func (fake *FakeSvcatClient) RetrieveBindingTimelineArgsForCall(i int) *apiv1beta1.ServiceBinding {
+	fake.retrieveBindingTimelineMutex.RLock()
+	defer fake.retrieveBindingTimelineMutex.RUnlock()
+	return fake.retrieveBindingTimelineArgsForCall[i].arg1
+}
+
+func (fake *FakeSvcatClient) RetrieveBindingTimelineReturns(result1 []servicecatalog.TimelineEntry, result2 error) {
+	fake.RetrieveBindingTimelineStub = nil
+	fake.retrieveBindingTimelineReturns = struct {
+		result1 []servicecatalog.TimelineEntry
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) RetrieveBindingTimelineReturnsOnCall(i int, result1 []servicecatalog.TimelineEntry, result2 error) {
+	fake.RetrieveBindingTimelineStub = nil
+	if fake.retrieveBindingTimelineReturnsOnCall == nil {
+		fake.retrieveBindingTimelineReturnsOnCall = make(map[int]struct {
+			result1 []servicecatalog.TimelineEntry
+			result2 error
+		})
+	}
+	fake.retrieveBindingTimelineReturnsOnCall[i] = struct {
+		result1 []servicecatalog.TimelineEntry
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeSvcatClient) Unbind(arg1 string, arg2 string) ([]types.NamespacedName, error) {
 	fake.unbindMutex.Lock()
 	ret, specificReturn := fake.unbindReturnsOnCall[len(fake.unbindArgsForCall)]
@@ -1185,6 +1645,58 @@ func (fake *FakeSvcatClient) WaitForBindingReturnsOnCall(i int, result1 *apiv1be
 	}{result1, result2}
 }
 
+func (fake *FakeSvcatClient) WatchBindings(arg1 string, arg2 string) (watch.Interface, error) {
+	fake.watchBindingsMutex.Lock()
+	ret, specificReturn := fake.watchBindingsReturnsOnCall[len(fake.watchBindingsArgsForCall)]
+	fake.watchBindingsArgsForCall = append(fake.watchBindingsArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("WatchBindings", []interface{}{arg1, arg2})
+	fake.watchBindingsMutex.Unlock()
+	if fake.WatchBindingsStub != nil {
+		return fake.WatchBindingsStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.watchBindingsReturns.result1, fake.watchBindingsReturns.result2
+}
+
+func (fake *FakeSvcatClient) WatchBindingsCallCount() int {
+	fake.watchBindingsMutex.RLock()
+	defer fake.watchBindingsMutex.RUnlock()
+	return len(fake.watchBindingsArgsForCall)
+}
+
+func (fake *FakeSvcatClient) WatchBindingsArgsForCall(i int) (string, string) {
+	fake.watchBindingsMutex.RLock()
+	defer fake.watchBindingsMutex.RUnlock()
+	return fake.watchBindingsArgsForCall[i].arg1, fake.watchBindingsArgsForCall[i].arg2
+}
+
+func (fake *FakeSvcatClient) WatchBindingsReturns(result1 watch.Interface, result2 error) {
+	fake.WatchBindingsStub = nil
+	fake.watchBindingsReturns = struct {
+		result1 watch.Interface
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) WatchBindingsReturnsOnCall(i int, result1 watch.Interface, result2 error) {
+	fake.WatchBindingsStub = nil
+	if fake.watchBindingsReturnsOnCall == nil {
+		fake.watchBindingsReturnsOnCall = make(map[int]struct {
+			result1 watch.Interface
+			result2 error
+		})
+	}
+	fake.watchBindingsReturnsOnCall[i] = struct {
+		result1 watch.Interface
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeSvcatClient) Deregister(arg1 string) error {
 	fake.deregisterMutex.Lock()
 	ret, specificReturn := fake.deregisterReturnsOnCall[len(fake.deregisterArgsForCall)]
@@ -1233,6 +1745,57 @@ func (fake *FakeSvcatClient) DeregisterReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeSvcatClient) DiagnoseBroker(arg1 string) ([]servicecatalog.Diagnosis, error) {
+	fake.diagnoseBrokerMutex.Lock()
+	ret, specificReturn := fake.diagnoseBrokerReturnsOnCall[len(fake.diagnoseBrokerArgsForCall)]
+	fake.diagnoseBrokerArgsForCall = append(fake.diagnoseBrokerArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("DiagnoseBroker", []interface{}{arg1})
+	fake.diagnoseBrokerMutex.Unlock()
+	if fake.DiagnoseBrokerStub != nil {
+		return fake.DiagnoseBrokerStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.diagnoseBrokerReturns.result1, fake.diagnoseBrokerReturns.result2
+}
+
+func (fake *FakeSvcatClient) DiagnoseBrokerCallCount() int {
+	fake.diagnoseBrokerMutex.RLock()
+	defer fake.diagnoseBrokerMutex.RUnlock()
+	return len(fake.diagnoseBrokerArgsForCall)
+}
+
+func (fake *FakeSvcatClient) DiagnoseBrokerArgsForCall(i int) string {
+	fake.diagnoseBrokerMutex.RLock()
+	defer fake.diagnoseBrokerMutex.RUnlock()
+	return fake.diagnoseBrokerArgsForCall[i].arg1
+}
+
+func (fake *FakeSvcatClient) DiagnoseBrokerReturns(result1 []servicecatalog.Diagnosis, result2 error) {
+	fake.DiagnoseBrokerStub = nil
+	fake.diagnoseBrokerReturns = struct {
+		result1 []servicecatalog.Diagnosis
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) DiagnoseBrokerReturnsOnCall(i int, result1 []servicecatalog.Diagnosis, result2 error) {
+	fake.DiagnoseBrokerStub = nil
+	if fake.diagnoseBrokerReturnsOnCall == nil {
+		fake.diagnoseBrokerReturnsOnCall = make(map[int]struct {
+			result1 []servicecatalog.Diagnosis
+			result2 error
+		})
+	}
+	fake.diagnoseBrokerReturnsOnCall[i] = struct {
+		result1 []servicecatalog.Diagnosis
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeSvcatClient) RetrieveBrokers(opts servicecatalog.ScopeOptions) ([]servicecatalog.Broker, error) {
 	fake.retrieveBrokersMutex.Lock()
 	ret, specificReturn := fake.retrieveBrokersReturnsOnCall[len(fake.retrieveBrokersArgsForCall)]
@@ -1335,11 +1898,11 @@ func (fake *FakeSvcatClient) RetrieveBrokerReturnsOnCall(i int, result1 *apiv1be
 	}{result1, result2}
 }
 
-func (fake *FakeSvcatClient) RetrieveBrokerByClass(arg1 *apiv1beta1.ClusterServiceClass) (*apiv1beta1.ClusterServiceBroker, error) {
+func (fake *FakeSvcatClient) RetrieveBrokerByClass(arg1 servicecatalog.Class) (servicecatalog.Broker, error) {
 	fake.retrieveBrokerByClassMutex.Lock()
 	ret, specificReturn := fake.retrieveBrokerByClassReturnsOnCall[len(fake.retrieveBrokerByClassArgsForCall)]
 	fake.retrieveBrokerByClassArgsForCall = append(fake.retrieveBrokerByClassArgsForCall, struct {
-		arg1 *apiv1beta1.ClusterServiceClass
+		arg1 servicecatalog.Class
 	}{arg1})
 	fake.recordInvocation("RetrieveBrokerByClass", []interface{}{arg1})
 	fake.retrieveBrokerByClassMutex.Unlock()
@@ -1358,35 +1921,87 @@ func (fake *FakeSvcatClient) RetrieveBrokerByClassCallCount() int {
 	return len(fake.retrieveBrokerByClassArgsForCall)
 }
 
-func (fake *FakeSvcatClient) RetrieveBrokerByClassArgsForCall(i int) *apiv1beta1.ClusterServiceClass {
-	fake.retrieveBrokerByClassMutex.RLock()
-	defer fake.retrieveBrokerByClassMutex.RUnlock()
-	return fake.retrieveBrokerByClassArgsForCall[i].arg1
+func (fake *FakeSvcatClient) RetrieveBrokerByClassArgsForCall(i int) servicecatalog.Class {
+	fake.retrieveBrokerByClassMutex.RLock()
+	defer fake.retrieveBrokerByClassMutex.RUnlock()
+	return fake.retrieveBrokerByClassArgsForCall[i].arg1
+}
+
+func (fake *FakeSvcatClient) RetrieveBrokerByClassReturns(result1 servicecatalog.Broker, result2 error) {
+	fake.RetrieveBrokerByClassStub = nil
+	fake.retrieveBrokerByClassReturns = struct {
+		result1 servicecatalog.Broker
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) RetrieveBrokerByClassReturnsOnCall(i int, result1 servicecatalog.Broker, result2 error) {
+	fake.RetrieveBrokerByClassStub = nil
+	if fake.retrieveBrokerByClassReturnsOnCall == nil {
+		fake.retrieveBrokerByClassReturnsOnCall = make(map[int]struct {
+			result1 servicecatalog.Broker
+			result2 error
+		})
+	}
+	fake.retrieveBrokerByClassReturnsOnCall[i] = struct {
+		result1 servicecatalog.Broker
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) RetrieveBrokerInScope(arg1 string, arg2 servicecatalog.ScopeOptions) (servicecatalog.Broker, error) {
+	fake.retrieveBrokerInScopeMutex.Lock()
+	ret, specificReturn := fake.retrieveBrokerInScopeReturnsOnCall[len(fake.retrieveBrokerInScopeArgsForCall)]
+	fake.retrieveBrokerInScopeArgsForCall = append(fake.retrieveBrokerInScopeArgsForCall, struct {
+		arg1 string
+		arg2 servicecatalog.ScopeOptions
+	}{arg1, arg2})
+	fake.recordInvocation("RetrieveBrokerInScope", []interface{}{arg1, arg2})
+	fake.retrieveBrokerInScopeMutex.Unlock()
+	if fake.RetrieveBrokerInScopeStub != nil {
+		return fake.RetrieveBrokerInScopeStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.retrieveBrokerInScopeReturns.result1, fake.retrieveBrokerInScopeReturns.result2
+}
+
+func (fake *FakeSvcatClient) RetrieveBrokerInScopeCallCount() int {
+	fake.retrieveBrokerInScopeMutex.RLock()
+	defer fake.retrieveBrokerInScopeMutex.RUnlock()
+	return len(fake.retrieveBrokerInScopeArgsForCall)
+}
+
+func (fake *FakeSvcatClient) RetrieveBrokerInScopeArgsForCall(i int) (string, servicecatalog.ScopeOptions) {
+	fake.retrieveBrokerInScopeMutex.RLock()
+	defer fake.retrieveBrokerInScopeMutex.RUnlock()
+	return fake.retrieveBrokerInScopeArgsForCall[i].arg1, fake.retrieveBrokerInScopeArgsForCall[i].arg2
 }
 
-func (fake *FakeSvcatClient) RetrieveBrokerByClassReturns(result1 *apiv1beta1.ClusterServiceBroker, result2 error) {
-	fake.RetrieveBrokerByClassStub = nil
-	fake.retrieveBrokerByClassReturns = struct {
-		result1 *apiv1beta1.ClusterServiceBroker
+func (fake *FakeSvcatClient) RetrieveBrokerInScopeReturns(result1 servicecatalog.Broker, result2 error) {
+	fake.RetrieveBrokerInScopeStub = nil
+	fake.retrieveBrokerInScopeReturns = struct {
+		result1 servicecatalog.Broker
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeSvcatClient) RetrieveBrokerByClassReturnsOnCall(i int, result1 *apiv1beta1.ClusterServiceBroker, result2 error) {
-	fake.RetrieveBrokerByClassStub = nil
-	if fake.retrieveBrokerByClassReturnsOnCall == nil {
-		fake.retrieveBrokerByClassReturnsOnCall = make(map[int]struct {
-			result1 *apiv1beta1.ClusterServiceBroker
+func (fake *FakeSvcatClient) RetrieveBrokerInScopeReturnsOnCall(i int, result1 servicecatalog.Broker, result2 error) {
+	fake.RetrieveBrokerInScopeStub = nil
+	if fake.retrieveBrokerInScopeReturnsOnCall == nil {
+		fake.retrieveBrokerInScopeReturnsOnCall = make(map[int]struct {
+			result1 servicecatalog.Broker
 			result2 error
 		})
 	}
-	fake.retrieveBrokerByClassReturnsOnCall[i] = struct {
-		result1 *apiv1beta1.ClusterServiceBroker
+	fake.retrieveBrokerInScopeReturnsOnCall[i] = struct {
+		result1 servicecatalog.Broker
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeSvcatClient) Register(arg1 string, arg2 string, arg3 *servicecatalog.RegisterOptions) (*apiv1beta1.ClusterServiceBroker, error) {
+func (fake *FakeSvcatClient) Register(arg1 string, arg2 string, arg3 *servicecatalog.RegisterOptions) (servicecatalog.Broker, error) {
 	fake.registerMutex.Lock()
 	ret, specificReturn := fake.registerReturnsOnCall[len(fake.registerArgsForCall)]
 	fake.registerArgsForCall = append(fake.registerArgsForCall, struct {
@@ -1417,24 +2032,24 @@ func (fake *FakeSvcatClient) RegisterArgsForCall(i int) (string, string, *servic
 	return fake.registerArgsForCall[i].arg1, fake.registerArgsForCall[i].arg2, fake.registerArgsForCall[i].arg3
 }
 
-func (fake *FakeSvcatClient) RegisterReturns(result1 *apiv1beta1.ClusterServiceBroker, result2 error) {
+func (fake *FakeSvcatClient) RegisterReturns(result1 servicecatalog.Broker, result2 error) {
 	fake.RegisterStub = nil
 	fake.registerReturns = struct {
-		result1 *apiv1beta1.ClusterServiceBroker
+		result1 servicecatalog.Broker
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeSvcatClient) RegisterReturnsOnCall(i int, result1 *apiv1beta1.ClusterServiceBroker, result2 error) {
+func (fake *FakeSvcatClient) RegisterReturnsOnCall(i int, result1 servicecatalog.Broker, result2 error) {
 	fake.RegisterStub = nil
 	if fake.registerReturnsOnCall == nil {
 		fake.registerReturnsOnCall = make(map[int]struct {
-			result1 *apiv1beta1.ClusterServiceBroker
+			result1 servicecatalog.Broker
 			result2 error
 		})
 	}
 	fake.registerReturnsOnCall[i] = struct {
-		result1 *apiv1beta1.ClusterServiceBroker
+		result1 servicecatalog.Broker
 		result2 error
 	}{result1, result2}
 }
@@ -1488,18 +2103,19 @@ func (fake *FakeSvcatClient) SyncReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeSvcatClient) WaitForBroker(arg1 string, arg2 time.Duration, arg3 *time.Duration) (servicecatalog.Broker, error) {
+func (fake *FakeSvcatClient) WaitForBroker(arg1 string, arg2 servicecatalog.ScopeOptions, arg3 time.Duration, arg4 *time.Duration) (servicecatalog.Broker, error) {
 	fake.waitForBrokerMutex.Lock()
 	ret, specificReturn := fake.waitForBrokerReturnsOnCall[len(fake.waitForBrokerArgsForCall)]
 	fake.waitForBrokerArgsForCall = append(fake.waitForBrokerArgsForCall, struct {
 		arg1 string
-		arg2 time.Duration
-		arg3 *time.Duration
-	}{arg1, arg2, arg3})
-	fake.recordInvocation("WaitForBroker", []interface{}{arg1, arg2, arg3})
+		arg2 servicecatalog.ScopeOptions
+		arg3 time.Duration
+		arg4 *time.Duration
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("WaitForBroker", []interface{}{arg1, arg2, arg3, arg4})
 	fake.waitForBrokerMutex.Unlock()
 	if fake.WaitForBrokerStub != nil {
-		return fake.WaitForBrokerStub(arg1, arg2, arg3)
+		return fake.WaitForBrokerStub(arg1, arg2, arg3, arg4)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -1513,10 +2129,10 @@ func (fake *FakeSvcatClient) WaitForBrokerCallCount() int {
 	return len(fake.waitForBrokerArgsForCall)
 }
 
-func (fake *FakeSvcatClient) WaitForBrokerArgsForCall(i int) (string, time.Duration, *time.Duration) {
+func (fake *FakeSvcatClient) WaitForBrokerArgsForCall(i int) (string, servicecatalog.ScopeOptions, time.Duration, *time.Duration) {
 	fake.waitForBrokerMutex.RLock()
 	defer fake.waitForBrokerMutex.RUnlock()
-	return fake.waitForBrokerArgsForCall[i].arg1, fake.waitForBrokerArgsForCall[i].arg2, fake.waitForBrokerArgsForCall[i].arg3
+	return fake.waitForBrokerArgsForCall[i].arg1, fake.waitForBrokerArgsForCall[i].arg2, fake.waitForBrokerArgsForCall[i].arg3, fake.waitForBrokerArgsForCall[i].arg4
 }
 
 func (fake *FakeSvcatClient) WaitForBrokerReturns(result1 servicecatalog.Broker, result2 error) {
@@ -1796,6 +2412,61 @@ func (fake *FakeSvcatClient) CreateClassReturnsOnCall(i int, result1 *apiv1beta1
 	}{result1, result2}
 }
 
+func (fake *FakeSvcatClient) ApplyInstance(arg1 string, arg2 *apiv1beta1.ServiceInstance) (*apiv1beta1.ServiceInstance, servicecatalog.ApplyResult, error) {
+	fake.applyInstanceMutex.Lock()
+	ret, specificReturn := fake.applyInstanceReturnsOnCall[len(fake.applyInstanceArgsForCall)]
+	fake.applyInstanceArgsForCall = append(fake.applyInstanceArgsForCall, struct {
+		arg1 string
+		arg2 *apiv1beta1.ServiceInstance
+	}{arg1, arg2})
+	fake.recordInvocation("ApplyInstance", []interface{}{arg1, arg2})
+	fake.applyInstanceMutex.Unlock()
+	if fake.ApplyInstanceStub != nil {
+		return fake.ApplyInstanceStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.applyInstanceReturns.result1, fake.applyInstanceReturns.result2, fake.applyInstanceReturns.result3
+}
+
+func (fake *FakeSvcatClient) ApplyInstanceCallCount() int {
+	fake.applyInstanceMutex.RLock()
+	defer fake.applyInstanceMutex.RUnlock()
+	return len(fake.applyInstanceArgsForCall)
+}
+
+func (fake *FakeSvcatClient) ApplyInstanceArgsForCall(i int) (string, *apiv1beta1.ServiceInstance) {
+	fake.applyInstanceMutex.RLock()
+	defer fake.applyInstanceMutex.RUnlock()
+	return fake.applyInstanceArgsForCall[i].arg1, fake.applyInstanceArgsForCall[i].arg2
+}
+
+func (fake *FakeSvcatClient) ApplyInstanceReturns(result1 *apiv1beta1.ServiceInstance, result2 servicecatalog.ApplyResult, result3 error) {
+	fake.ApplyInstanceStub = nil
+	fake.applyInstanceReturns = struct {
+		result1 *apiv1beta1.ServiceInstance
+		result2 servicecatalog.ApplyResult
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeSvcatClient) ApplyInstanceReturnsOnCall(i int, result1 *apiv1beta1.ServiceInstance, result2 servicecatalog.ApplyResult, result3 error) {
+	fake.ApplyInstanceStub = nil
+	if fake.applyInstanceReturnsOnCall == nil {
+		fake.applyInstanceReturnsOnCall = make(map[int]struct {
+			result1 *apiv1beta1.ServiceInstance
+			result2 servicecatalog.ApplyResult
+			result3 error
+		})
+	}
+	fake.applyInstanceReturnsOnCall[i] = struct {
+		result1 *apiv1beta1.ServiceInstance
+		result2 servicecatalog.ApplyResult
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeSvcatClient) Deprovision(arg1 string, arg2 string) error {
 	fake.deprovisionMutex.Lock()
 	ret, specificReturn := fake.deprovisionReturnsOnCall[len(fake.deprovisionArgsForCall)]
@@ -1845,7 +2516,118 @@ func (fake *FakeSvcatClient) DeprovisionReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeSvcatClient) InstanceParentHierarchy(arg1 *apiv1beta1.ServiceInstance) (*apiv1beta1.ClusterServiceClass, *apiv1beta1.ClusterServicePlan, *apiv1beta1.ClusterServiceBroker, error) {
+func (fake *FakeSvcatClient) DiagnoseInstance(arg1 string, arg2 string) ([]servicecatalog.Diagnosis, error) {
+	fake.diagnoseInstanceMutex.Lock()
+	ret, specificReturn := fake.diagnoseInstanceReturnsOnCall[len(fake.diagnoseInstanceArgsForCall)]
+	fake.diagnoseInstanceArgsForCall = append(fake.diagnoseInstanceArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("DiagnoseInstance", []interface{}{arg1, arg2})
+	fake.diagnoseInstanceMutex.Unlock()
+	if fake.DiagnoseInstanceStub != nil {
+		return fake.DiagnoseInstanceStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.diagnoseInstanceReturns.result1, fake.diagnoseInstanceReturns.result2
+}
+
+func (fake *FakeSvcatClient) DiagnoseInstanceCallCount() int {
+	fake.diagnoseInstanceMutex.RLock()
+	defer fake.diagnoseInstanceMutex.RUnlock()
+	return len(fake.diagnoseInstanceArgsForCall)
+}
+
+func (fake *FakeSvcatClient) DiagnoseInstanceArgsForCall(i int) (string, string) {
+	fake.diagnoseInstanceMutex.RLock()
+	defer fake.diagnoseInstanceMutex.RUnlock()
+	return fake.diagnoseInstanceArgsForCall[i].arg1, fake.diagnoseInstanceArgsForCall[i].arg2
+}
+
+func (fake *FakeSvcatClient) DiagnoseInstanceReturns(result1 []servicecatalog.Diagnosis, result2 error) {
+	fake.DiagnoseInstanceStub = nil
+	fake.diagnoseInstanceReturns = struct {
+		result1 []servicecatalog.Diagnosis
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) DiagnoseInstanceReturnsOnCall(i int, result1 []servicecatalog.Diagnosis, result2 error) {
+	fake.DiagnoseInstanceStub = nil
+	if fake.diagnoseInstanceReturnsOnCall == nil {
+		fake.diagnoseInstanceReturnsOnCall = make(map[int]struct {
+			result1 []servicecatalog.Diagnosis
+			result2 error
+		})
+	}
+	fake.diagnoseInstanceReturnsOnCall[i] = struct {
+		result1 []servicecatalog.Diagnosis
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) DryRunProvision(arg1 string, arg2 string, arg3 string, arg4 string, arg5 string, arg6 interface{}, arg7 map[string]string, arg8 servicecatalog.Scope, arg9 *apiv1beta1.UserInfo) (*servicecatalog.ProvisionPreview, error) {
+	fake.dryRunProvisionMutex.Lock()
+	ret, specificReturn := fake.dryRunProvisionReturnsOnCall[len(fake.dryRunProvisionArgsForCall)]
+	fake.dryRunProvisionArgsForCall = append(fake.dryRunProvisionArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+		arg6 interface{}
+		arg7 map[string]string
+		arg8 servicecatalog.Scope
+		arg9 *apiv1beta1.UserInfo
+	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9})
+	fake.recordInvocation("DryRunProvision", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9})
+	fake.dryRunProvisionMutex.Unlock()
+	if fake.DryRunProvisionStub != nil {
+		return fake.DryRunProvisionStub(arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.dryRunProvisionReturns.result1, fake.dryRunProvisionReturns.result2
+}
+
+func (fake *FakeSvcatClient) DryRunProvisionCallCount() int {
+	fake.dryRunProvisionMutex.RLock()
+	defer fake.dryRunProvisionMutex.RUnlock()
+	return len(fake.dryRunProvisionArgsForCall)
+}
+
+func (fake *FakeSvcatClient) DryRunProvisionArgsForCall(i int) (string, string, string, string, string, interface{}, map[string]string, servicecatalog.Scope, *apiv1beta1.UserInfo) {
+	fake.dryRunProvisionMutex.RLock()
+	defer fake.dryRunProvisionMutex.RUnlock()
+	return fake.dryRunProvisionArgsForCall[i].arg1, fake.dryRunProvisionArgsForCall[i].arg2, fake.dryRunProvisionArgsForCall[i].arg3, fake.dryRunProvisionArgsForCall[i].arg4, fake.dryRunProvisionArgsForCall[i].arg5, fake.dryRunProvisionArgsForCall[i].arg6, fake.dryRunProvisionArgsForCall[i].arg7, fake.dryRunProvisionArgsForCall[i].arg8, fake.dryRunProvisionArgsForCall[i].arg9
+}
+
+func (fake *FakeSvcatClient) DryRunProvisionReturns(result1 *servicecatalog.ProvisionPreview, result2 error) {
+	fake.DryRunProvisionStub = nil
+	fake.dryRunProvisionReturns = struct {
+		result1 *servicecatalog.ProvisionPreview
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) DryRunProvisionReturnsOnCall(i int, result1 *servicecatalog.ProvisionPreview, result2 error) {
+	fake.DryRunProvisionStub = nil
+	if fake.dryRunProvisionReturnsOnCall == nil {
+		fake.dryRunProvisionReturnsOnCall = make(map[int]struct {
+			result1 *servicecatalog.ProvisionPreview
+			result2 error
+		})
+	}
+	fake.dryRunProvisionReturnsOnCall[i] = struct {
+		result1 *servicecatalog.ProvisionPreview
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) InstanceParentHierarchy(arg1 *apiv1beta1.ServiceInstance) (servicecatalog.Class, servicecatalog.Plan, servicecatalog.Broker, error) {
 	fake.instanceParentHierarchyMutex.Lock()
 	ret, specificReturn := fake.instanceParentHierarchyReturnsOnCall[len(fake.instanceParentHierarchyArgsForCall)]
 	fake.instanceParentHierarchyArgsForCall = append(fake.instanceParentHierarchyArgsForCall, struct {
@@ -1874,35 +2656,35 @@ func (fake *FakeSvcatClient) InstanceParentHierarchyArgsForCall(i int) *apiv1bet
 	return fake.instanceParentHierarchyArgsForCall[i].arg1
 }
 
-func (fake *FakeSvcatClient) InstanceParentHierarchyReturns(result1 *apiv1beta1.ClusterServiceClass, result2 *apiv1beta1.ClusterServicePlan, result3 *apiv1beta1.ClusterServiceBroker, result4 error) {
+func (fake *FakeSvcatClient) InstanceParentHierarchyReturns(result1 servicecatalog.Class, result2 servicecatalog.Plan, result3 servicecatalog.Broker, result4 error) {
 	fake.InstanceParentHierarchyStub = nil
 	fake.instanceParentHierarchyReturns = struct {
-		result1 *apiv1beta1.ClusterServiceClass
-		result2 *apiv1beta1.ClusterServicePlan
-		result3 *apiv1beta1.ClusterServiceBroker
+		result1 servicecatalog.Class
+		result2 servicecatalog.Plan
+		result3 servicecatalog.Broker
 		result4 error
 	}{result1, result2, result3, result4}
 }
 
-func (fake *FakeSvcatClient) InstanceParentHierarchyReturnsOnCall(i int, result1 *apiv1beta1.ClusterServiceClass, result2 *apiv1beta1.ClusterServicePlan, result3 *apiv1beta1.ClusterServiceBroker, result4 error) {
+func (fake *FakeSvcatClient) InstanceParentHierarchyReturnsOnCall(i int, result1 servicecatalog.Class, result2 servicecatalog.Plan, result3 servicecatalog.Broker, result4 error) {
 	fake.InstanceParentHierarchyStub = nil
 	if fake.instanceParentHierarchyReturnsOnCall == nil {
 		fake.instanceParentHierarchyReturnsOnCall = make(map[int]struct {
-			result1 *apiv1beta1.ClusterServiceClass
-			result2 *apiv1beta1.ClusterServicePlan
-			result3 *apiv1beta1.ClusterServiceBroker
+			result1 servicecatalog.Class
+			result2 servicecatalog.Plan
+			result3 servicecatalog.Broker
 			result4 error
 		})
 	}
 	fake.instanceParentHierarchyReturnsOnCall[i] = struct {
-		result1 *apiv1beta1.ClusterServiceClass
-		result2 *apiv1beta1.ClusterServicePlan
-		result3 *apiv1beta1.ClusterServiceBroker
+		result1 servicecatalog.Class
+		result2 servicecatalog.Plan
+		result3 servicecatalog.Broker
 		result4 error
 	}{result1, result2, result3, result4}
 }
 
-func (fake *FakeSvcatClient) InstanceToServiceClassAndPlan(arg1 *apiv1beta1.ServiceInstance) (*apiv1beta1.ClusterServiceClass, *apiv1beta1.ClusterServicePlan, error) {
+func (fake *FakeSvcatClient) InstanceToServiceClassAndPlan(arg1 *apiv1beta1.ServiceInstance) (servicecatalog.Class, servicecatalog.Plan, error) {
 	fake.instanceToServiceClassAndPlanMutex.Lock()
 	ret, specificReturn := fake.instanceToServiceClassAndPlanReturnsOnCall[len(fake.instanceToServiceClassAndPlanArgsForCall)]
 	fake.instanceToServiceClassAndPlanArgsForCall = append(fake.instanceToServiceClassAndPlanArgsForCall, struct {
@@ -1931,27 +2713,27 @@ func (fake *FakeSvcatClient) InstanceToServiceClassAndPlanArgsForCall(i int) *ap
 	return fake.instanceToServiceClassAndPlanArgsForCall[i].arg1
 }
 
-func (fake *FakeSvcatClient) InstanceToServiceClassAndPlanReturns(result1 *apiv1beta1.ClusterServiceClass, result2 *apiv1beta1.ClusterServicePlan, result3 error) {
+func (fake *FakeSvcatClient) InstanceToServiceClassAndPlanReturns(result1 servicecatalog.Class, result2 servicecatalog.Plan, result3 error) {
 	fake.InstanceToServiceClassAndPlanStub = nil
 	fake.instanceToServiceClassAndPlanReturns = struct {
-		result1 *apiv1beta1.ClusterServiceClass
-		result2 *apiv1beta1.ClusterServicePlan
+		result1 servicecatalog.Class
+		result2 servicecatalog.Plan
 		result3 error
 	}{result1, result2, result3}
 }
 
-func (fake *FakeSvcatClient) InstanceToServiceClassAndPlanReturnsOnCall(i int, result1 *apiv1beta1.ClusterServiceClass, result2 *apiv1beta1.ClusterServicePlan, result3 error) {
+func (fake *FakeSvcatClient) InstanceToServiceClassAndPlanReturnsOnCall(i int, result1 servicecatalog.Class, result2 servicecatalog.Plan, result3 error) {
 	fake.InstanceToServiceClassAndPlanStub = nil
 	if fake.instanceToServiceClassAndPlanReturnsOnCall == nil {
 		fake.instanceToServiceClassAndPlanReturnsOnCall = make(map[int]struct {
-			result1 *apiv1beta1.ClusterServiceClass
-			result2 *apiv1beta1.ClusterServicePlan
+			result1 servicecatalog.Class
+			result2 servicecatalog.Plan
 			result3 error
 		})
 	}
 	fake.instanceToServiceClassAndPlanReturnsOnCall[i] = struct {
-		result1 *apiv1beta1.ClusterServiceClass
-		result2 *apiv1beta1.ClusterServicePlan
+		result1 servicecatalog.Class
+		result2 servicecatalog.Plan
 		result3 error
 	}{result1, result2, result3}
 }
@@ -2052,7 +2834,7 @@ func (fake *FakeSvcatClient) IsInstanceReadyReturnsOnCall(i int, result1 bool) {
 	}{result1}
 }
 
-func (fake *FakeSvcatClient) Provision(arg1 string, arg2 string, arg3 string, arg4 string, arg5 string, arg6 interface{}, arg7 map[string]string) (*apiv1beta1.ServiceInstance, error) {
+func (fake *FakeSvcatClient) Provision(arg1 string, arg2 string, arg3 string, arg4 string, arg5 string, arg6 interface{}, arg7 map[string]string, arg8 servicecatalog.Scope) (*apiv1beta1.ServiceInstance, error) {
 	fake.provisionMutex.Lock()
 	ret, specificReturn := fake.provisionReturnsOnCall[len(fake.provisionArgsForCall)]
 	fake.provisionArgsForCall = append(fake.provisionArgsForCall, struct {
@@ -2063,11 +2845,12 @@ func (fake *FakeSvcatClient) Provision(arg1 string, arg2 string, arg3 string, ar
 		arg5 string
 		arg6 interface{}
 		arg7 map[string]string
-	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7})
-	fake.recordInvocation("Provision", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7})
+		arg8 servicecatalog.Scope
+	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8})
+	fake.recordInvocation("Provision", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8})
 	fake.provisionMutex.Unlock()
 	if fake.ProvisionStub != nil {
-		return fake.ProvisionStub(arg1, arg2, arg3, arg4, arg5, arg6, arg7)
+		return fake.ProvisionStub(arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -2081,10 +2864,10 @@ func (fake *FakeSvcatClient) ProvisionCallCount() int {
 	return len(fake.provisionArgsForCall)
 }
 
-func (fake *FakeSvcatClient) ProvisionArgsForCall(i int) (string, string, string, string, string, interface{}, map[string]string) {
+func (fake *FakeSvcatClient) ProvisionArgsForCall(i int) (string, string, string, string, string, interface{}, map[string]string, servicecatalog.Scope) {
 	fake.provisionMutex.RLock()
 	defer fake.provisionMutex.RUnlock()
-	return fake.provisionArgsForCall[i].arg1, fake.provisionArgsForCall[i].arg2, fake.provisionArgsForCall[i].arg3, fake.provisionArgsForCall[i].arg4, fake.provisionArgsForCall[i].arg5, fake.provisionArgsForCall[i].arg6, fake.provisionArgsForCall[i].arg7
+	return fake.provisionArgsForCall[i].arg1, fake.provisionArgsForCall[i].arg2, fake.provisionArgsForCall[i].arg3, fake.provisionArgsForCall[i].arg4, fake.provisionArgsForCall[i].arg5, fake.provisionArgsForCall[i].arg6, fake.provisionArgsForCall[i].arg7, fake.provisionArgsForCall[i].arg8
 }
 
 func (fake *FakeSvcatClient) ProvisionReturns(result1 *apiv1beta1.ServiceInstance, result2 error) {
@@ -2316,6 +3099,57 @@ func (fake *FakeSvcatClient) RetrieveInstancesByPlanReturnsOnCall(i int, result1
 	}{result1, result2}
 }
 
+func (fake *FakeSvcatClient) RetrieveInstanceTimeline(arg1 *apiv1beta1.ServiceInstance) ([]servicecatalog.TimelineEntry, error) {
+	fake.retrieveInstanceTimelineMutex.Lock()
+	ret, specificReturn := fake.retrieveInstanceTimelineReturnsOnCall[len(fake.retrieveInstanceTimelineArgsForCall)]
+	fake.retrieveInstanceTimelineArgsForCall = append(fake.retrieveInstanceTimelineArgsForCall, struct {
+		arg1 *apiv1beta1.ServiceInstance
+	}{arg1})
+	fake.recordInvocation("RetrieveInstanceTimeline", []interface{}{arg1})
+	fake.retrieveInstanceTimelineMutex.Unlock()
+	if fake.RetrieveInstanceTimelineStub != nil {
+		return fake.RetrieveInstanceTimelineStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.retrieveInstanceTimelineReturns.result1, fake.retrieveInstanceTimelineReturns.result2
+}
+
+func (fake *FakeSvcatClient) RetrieveInstanceTimelineCallCount() int {
+	fake.retrieveInstanceTimelineMutex.RLock()
+	defer fake.retrieveInstanceTimelineMutex.RUnlock()
+	return len(fake.retrieveInstanceTimelineArgsForCall)
+}
+
+func (fake *FakeSvcatClient) RetrieveInstanceTimelineArgsForCall(i int) *apiv1beta1.ServiceInstance {
+	fake.retrieveInstanceTimelineMutex.RLock()
+	defer fake.retrieveInstanceTimelineMutex.RUnlock()
+	return fake.retrieveInstanceTimelineArgsForCall[i].arg1
+}
+
+func (fake *FakeSvcatClient) RetrieveInstanceTimelineReturns(result1 []servicecatalog.TimelineEntry, result2 error) {
+	fake.RetrieveInstanceTimelineStub = nil
+	fake.retrieveInstanceTimelineReturns = struct {
+		result1 []servicecatalog.TimelineEntry
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) RetrieveInstanceTimelineReturnsOnCall(i int, result1 []servicecatalog.TimelineEntry, result2 error) {
+	fake.RetrieveInstanceTimelineStub = nil
+	if fake.retrieveInstanceTimelineReturnsOnCall == nil {
+		fake.retrieveInstanceTimelineReturnsOnCall = make(map[int]struct {
+			result1 []servicecatalog.TimelineEntry
+			result2 error
+		})
+	}
+	fake.retrieveInstanceTimelineReturnsOnCall[i] = struct {
+		result1 []servicecatalog.TimelineEntry
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeSvcatClient) TouchInstance(arg1 string, arg2 string, arg3 int) error {
 	fake.touchInstanceMutex.Lock()
 	ret, specificReturn := fake.touchInstanceReturnsOnCall[len(fake.touchInstanceArgsForCall)]
@@ -2366,6 +3200,61 @@ func (fake *FakeSvcatClient) TouchInstanceReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeSvcatClient) UpdateInstance(arg1 string, arg2 string, arg3 string, arg4 interface{}, arg5 map[string]string) (*apiv1beta1.ServiceInstance, error) {
+	fake.updateInstanceMutex.Lock()
+	ret, specificReturn := fake.updateInstanceReturnsOnCall[len(fake.updateInstanceArgsForCall)]
+	fake.updateInstanceArgsForCall = append(fake.updateInstanceArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 interface{}
+		arg5 map[string]string
+	}{arg1, arg2, arg3, arg4, arg5})
+	fake.recordInvocation("UpdateInstance", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.updateInstanceMutex.Unlock()
+	if fake.UpdateInstanceStub != nil {
+		return fake.UpdateInstanceStub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.updateInstanceReturns.result1, fake.updateInstanceReturns.result2
+}
+
+func (fake *FakeSvcatClient) UpdateInstanceCallCount() int {
+	fake.updateInstanceMutex.RLock()
+	defer fake.updateInstanceMutex.RUnlock()
+	return len(fake.updateInstanceArgsForCall)
+}
+
+func (fake *FakeSvcatClient) UpdateInstanceArgsForCall(i int) (string, string, string, interface{}, map[string]string) {
+	fake.updateInstanceMutex.RLock()
+	defer fake.updateInstanceMutex.RUnlock()
+	return fake.updateInstanceArgsForCall[i].arg1, fake.updateInstanceArgsForCall[i].arg2, fake.updateInstanceArgsForCall[i].arg3, fake.updateInstanceArgsForCall[i].arg4, fake.updateInstanceArgsForCall[i].arg5
+}
+
+func (fake *FakeSvcatClient) UpdateInstanceReturns(result1 *apiv1beta1.ServiceInstance, result2 error) {
+	fake.UpdateInstanceStub = nil
+	fake.updateInstanceReturns = struct {
+		result1 *apiv1beta1.ServiceInstance
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) UpdateInstanceReturnsOnCall(i int, result1 *apiv1beta1.ServiceInstance, result2 error) {
+	fake.UpdateInstanceStub = nil
+	if fake.updateInstanceReturnsOnCall == nil {
+		fake.updateInstanceReturnsOnCall = make(map[int]struct {
+			result1 *apiv1beta1.ServiceInstance
+			result2 error
+		})
+	}
+	fake.updateInstanceReturnsOnCall[i] = struct {
+		result1 *apiv1beta1.ServiceInstance
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeSvcatClient) WaitForInstanceToNotExist(arg1 string, arg2 string, arg3 time.Duration, arg4 *time.Duration) (*apiv1beta1.ServiceInstance, error) {
 	fake.waitForInstanceToNotExistMutex.Lock()
 	ret, specificReturn := fake.waitForInstanceToNotExistReturnsOnCall[len(fake.waitForInstanceToNotExistArgsForCall)]
@@ -2386,6 +3275,162 @@ func (fake *FakeSvcatClient) WaitForInstanceToNotExist(arg1 string, arg2 string,
 	return fake.waitForInstanceToNotExistReturns.result1, fake.waitForInstanceToNotExistReturns.result2
 }
 
+func (fake *FakeSvcatClient) WatchInstances(arg1 string, arg2 string, arg3 string, arg4 string) (watch.Interface, error) {
+	fake.watchInstancesMutex.Lock()
+	ret, specificReturn := fake.watchInstancesReturnsOnCall[len(fake.watchInstancesArgsForCall)]
+	fake.watchInstancesArgsForCall = append(fake.watchInstancesArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("WatchInstances", []interface{}{arg1, arg2, arg3, arg4})
+	fake.watchInstancesMutex.Unlock()
+	if fake.WatchInstancesStub != nil {
+		return fake.WatchInstancesStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.watchInstancesReturns.result1, fake.watchInstancesReturns.result2
+}
+
+func (fake *FakeSvcatClient) WatchInstancesCallCount() int {
+	fake.watchInstancesMutex.RLock()
+	defer fake.watchInstancesMutex.RUnlock()
+	return len(fake.watchInstancesArgsForCall)
+}
+
+func (fake *FakeSvcatClient) WatchInstancesArgsForCall(i int) (string, string, string, string) {
+	fake.watchInstancesMutex.RLock()
+	defer fake.watchInstancesMutex.RUnlock()
+	return fake.watchInstancesArgsForCall[i].arg1, fake.watchInstancesArgsForCall[i].arg2, fake.watchInstancesArgsForCall[i].arg3, fake.watchInstancesArgsForCall[i].arg4
+}
+
+func (fake *FakeSvcatClient) WatchInstancesReturns(result1 watch.Interface, result2 error) {
+	fake.WatchInstancesStub = nil
+	fake.watchInstancesReturns = struct {
+		result1 watch.Interface
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) WatchInstancesReturnsOnCall(i int, result1 watch.Interface, result2 error) {
+	fake.WatchInstancesStub = nil
+	if fake.watchInstancesReturnsOnCall == nil {
+		fake.watchInstancesReturnsOnCall = make(map[int]struct {
+			result1 watch.Interface
+			result2 error
+		})
+	}
+	fake.watchInstancesReturnsOnCall[i] = struct {
+		result1 watch.Interface
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) ExportManifest(arg1 string) (*servicecatalog.Manifest, error) {
+	fake.exportManifestMutex.Lock()
+	ret, specificReturn := fake.exportManifestReturnsOnCall[len(fake.exportManifestArgsForCall)]
+	fake.exportManifestArgsForCall = append(fake.exportManifestArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("ExportManifest", []interface{}{arg1})
+	fake.exportManifestMutex.Unlock()
+	if fake.ExportManifestStub != nil {
+		return fake.ExportManifestStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.exportManifestReturns.result1, fake.exportManifestReturns.result2
+}
+
+func (fake *FakeSvcatClient) ExportManifestCallCount() int {
+	fake.exportManifestMutex.RLock()
+	defer fake.exportManifestMutex.RUnlock()
+	return len(fake.exportManifestArgsForCall)
+}
+
+func (fake *FakeSvcatClient) ExportManifestArgsForCall(i int) string {
+	fake.exportManifestMutex.RLock()
+	defer fake.exportManifestMutex.RUnlock()
+	return fake.exportManifestArgsForCall[i].arg1
+}
+
+func (fake *FakeSvcatClient) ExportManifestReturns(result1 *servicecatalog.Manifest, result2 error) {
+	fake.ExportManifestStub = nil
+	fake.exportManifestReturns = struct {
+		result1 *servicecatalog.Manifest
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) ExportManifestReturnsOnCall(i int, result1 *servicecatalog.Manifest, result2 error) {
+	fake.ExportManifestStub = nil
+	if fake.exportManifestReturnsOnCall == nil {
+		fake.exportManifestReturnsOnCall = make(map[int]struct {
+			result1 *servicecatalog.Manifest
+			result2 error
+		})
+	}
+	fake.exportManifestReturnsOnCall[i] = struct {
+		result1 *servicecatalog.Manifest
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) RetrieveMarketplace(arg1 servicecatalog.MarketplaceOptions) ([]servicecatalog.Offering, error) {
+	fake.retrieveMarketplaceMutex.Lock()
+	ret, specificReturn := fake.retrieveMarketplaceReturnsOnCall[len(fake.retrieveMarketplaceArgsForCall)]
+	fake.retrieveMarketplaceArgsForCall = append(fake.retrieveMarketplaceArgsForCall, struct {
+		arg1 servicecatalog.MarketplaceOptions
+	}{arg1})
+	fake.recordInvocation("RetrieveMarketplace", []interface{}{arg1})
+	fake.retrieveMarketplaceMutex.Unlock()
+	if fake.RetrieveMarketplaceStub != nil {
+		return fake.RetrieveMarketplaceStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.retrieveMarketplaceReturns.result1, fake.retrieveMarketplaceReturns.result2
+}
+
+func (fake *FakeSvcatClient) RetrieveMarketplaceCallCount() int {
+	fake.retrieveMarketplaceMutex.RLock()
+	defer fake.retrieveMarketplaceMutex.RUnlock()
+	return len(fake.retrieveMarketplaceArgsForCall)
+}
+
+func (fake *FakeSvcatClient) RetrieveMarketplaceArgsForCall(i int) servicecatalog.MarketplaceOptions {
+	fake.retrieveMarketplaceMutex.RLock()
+	defer fake.retrieveMarketplaceMutex.RUnlock()
+	return fake.retrieveMarketplaceArgsForCall[i].arg1
+}
+
+func (fake *FakeSvcatClient) RetrieveMarketplaceReturns(result1 []servicecatalog.Offering, result2 error) {
+	fake.RetrieveMarketplaceStub = nil
+	fake.retrieveMarketplaceReturns = struct {
+		result1 []servicecatalog.Offering
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) RetrieveMarketplaceReturnsOnCall(i int, result1 []servicecatalog.Offering, result2 error) {
+	fake.RetrieveMarketplaceStub = nil
+	if fake.retrieveMarketplaceReturnsOnCall == nil {
+		fake.retrieveMarketplaceReturnsOnCall = make(map[int]struct {
+			result1 []servicecatalog.Offering
+			result2 error
+		})
+	}
+	fake.retrieveMarketplaceReturnsOnCall[i] = struct {
+		result1 []servicecatalog.Offering
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeSvcatClient) WaitForInstance(arg1 string, arg2 string, arg3 time.Duration, arg4 *time.Duration) (*apiv1beta1.ServiceInstance, error) {
 	fake.waitForInstanceMutex.Lock()
 	ret, specificReturn := fake.waitForInstanceReturnsOnCall[len(fake.waitForInstanceArgsForCall)]
@@ -2645,6 +3690,58 @@ func (fake *FakeSvcatClient) RetrievePlanByClassAndPlanNamesReturnsOnCall(i int,
 	}{result1, result2}
 }
 
+func (fake *FakeSvcatClient) RetrieveCredentials(arg1 string, arg2 string) (map[string]string, error) {
+	fake.retrieveCredentialsMutex.Lock()
+	ret, specificReturn := fake.retrieveCredentialsReturnsOnCall[len(fake.retrieveCredentialsArgsForCall)]
+	fake.retrieveCredentialsArgsForCall = append(fake.retrieveCredentialsArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("RetrieveCredentials", []interface{}{arg1, arg2})
+	fake.retrieveCredentialsMutex.Unlock()
+	if fake.RetrieveCredentialsStub != nil {
+		return fake.RetrieveCredentialsStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.retrieveCredentialsReturns.result1, fake.retrieveCredentialsReturns.result2
+}
+
+func (fake *FakeSvcatClient) RetrieveCredentialsCallCount() int {
+	fake.retrieveCredentialsMutex.RLock()
+	defer fake.retrieveCredentialsMutex.RUnlock()
+	return len(fake.retrieveCredentialsArgsForCall)
+}
+
+func (fake *FakeSvcatClient) RetrieveCredentialsArgsForCall(i int) (string, string) {
+	fake.retrieveCredentialsMutex.RLock()
+	defer fake.retrieveCredentialsMutex.RUnlock()
+	return fake.retrieveCredentialsArgsForCall[i].arg1, fake.retrieveCredentialsArgsForCall[i].arg2
+}
+
+func (fake *FakeSvcatClient) RetrieveCredentialsReturns(result1 map[string]string, result2 error) {
+	fake.RetrieveCredentialsStub = nil
+	fake.retrieveCredentialsReturns = struct {
+		result1 map[string]string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) RetrieveCredentialsReturnsOnCall(i int, result1 map[string]string, result2 error) {
+	fake.RetrieveCredentialsStub = nil
+	if fake.retrieveCredentialsReturnsOnCall == nil {
+		fake.retrieveCredentialsReturnsOnCall = make(map[int]struct {
+			result1 map[string]string
+			result2 error
+		})
+	}
+	fake.retrieveCredentialsReturnsOnCall[i] = struct {
+		result1 map[string]string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeSvcatClient) RetrieveSecretByBinding(arg1 *apiv1beta1.ServiceBinding) (*apicorev1.Secret, error) {
 	fake.retrieveSecretByBindingMutex.Lock()
 	ret, specificReturn := fake.retrieveSecretByBindingReturnsOnCall[len(fake.retrieveSecretByBindingArgsForCall)]
@@ -2742,6 +3839,8 @@ func (fake *FakeSvcatClient) ServerVersionReturnsOnCall(i int, result1 *version.
 func (fake *FakeSvcatClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.applyBindingMutex.RLock()
+	defer fake.applyBindingMutex.RUnlock()
 	fake.bindMutex.RLock()
 	defer fake.bindMutex.RUnlock()
 	fake.bindingParentHierarchyMutex.RLock()
@@ -2750,6 +3849,10 @@ func (fake *FakeSvcatClient) Invocations() map[string][][]interface{} {
 	defer fake.deleteBindingMutex.RUnlock()
 	fake.deleteBindingsMutex.RLock()
 	defer fake.deleteBindingsMutex.RUnlock()
+	fake.diagnoseBindingMutex.RLock()
+	defer fake.diagnoseBindingMutex.RUnlock()
+	fake.dryRunBindMutex.RLock()
+	defer fake.dryRunBindMutex.RUnlock()
 	fake.isBindingFailedMutex.RLock()
 	defer fake.isBindingFailedMutex.RUnlock()
 	fake.isBindingReadyMutex.RLock()
@@ -2760,18 +3863,26 @@ func (fake *FakeSvcatClient) Invocations() map[string][][]interface{} {
 	defer fake.retrieveBindingsMutex.RUnlock()
 	fake.retrieveBindingsByInstanceMutex.RLock()
 	defer fake.retrieveBindingsByInstanceMutex.RUnlock()
+	fake.retrieveBindingTimelineMutex.RLock()
+	defer fake.retrieveBindingTimelineMutex.RUnlock()
 	fake.unbindMutex.RLock()
 	defer fake.unbindMutex.RUnlock()
 	fake.waitForBindingMutex.RLock()
 	defer fake.waitForBindingMutex.RUnlock()
+	fake.watchBindingsMutex.RLock()
+	defer fake.watchBindingsMutex.RUnlock()
 	fake.deregisterMutex.RLock()
 	defer fake.deregisterMutex.RUnlock()
+	fake.diagnoseBrokerMutex.RLock()
+	defer fake.diagnoseBrokerMutex.RUnlock()
 	fake.retrieveBrokersMutex.RLock()
 	defer fake.retrieveBrokersMutex.RUnlock()
 	fake.retrieveBrokerMutex.RLock()
 	defer fake.retrieveBrokerMutex.RUnlock()
 	fake.retrieveBrokerByClassMutex.RLock()
 	defer fake.retrieveBrokerByClassMutex.RUnlock()
+	fake.retrieveBrokerInScopeMutex.RLock()
+	defer fake.retrieveBrokerInScopeMutex.RUnlock()
 	fake.registerMutex.RLock()
 	defer fake.registerMutex.RUnlock()
 	fake.syncMutex.RLock()
@@ -2788,8 +3899,14 @@ func (fake *FakeSvcatClient) Invocations() map[string][][]interface{} {
 	defer fake.retrieveClassByPlanMutex.RUnlock()
 	fake.createClassMutex.RLock()
 	defer fake.createClassMutex.RUnlock()
+	fake.applyInstanceMutex.RLock()
+	defer fake.applyInstanceMutex.RUnlock()
 	fake.deprovisionMutex.RLock()
 	defer fake.deprovisionMutex.RUnlock()
+	fake.diagnoseInstanceMutex.RLock()
+	defer fake.diagnoseInstanceMutex.RUnlock()
+	fake.dryRunProvisionMutex.RLock()
+	defer fake.dryRunProvisionMutex.RUnlock()
 	fake.instanceParentHierarchyMutex.RLock()
 	defer fake.instanceParentHierarchyMutex.RUnlock()
 	fake.instanceToServiceClassAndPlanMutex.RLock()
@@ -2808,10 +3925,20 @@ func (fake *FakeSvcatClient) Invocations() map[string][][]interface{} {
 	defer fake.retrieveInstancesMutex.RUnlock()
 	fake.retrieveInstancesByPlanMutex.RLock()
 	defer fake.retrieveInstancesByPlanMutex.RUnlock()
+	fake.retrieveInstanceTimelineMutex.RLock()
+	defer fake.retrieveInstanceTimelineMutex.RUnlock()
 	fake.touchInstanceMutex.RLock()
 	defer fake.touchInstanceMutex.RUnlock()
+	fake.updateInstanceMutex.RLock()
+	defer fake.updateInstanceMutex.RUnlock()
 	fake.waitForInstanceToNotExistMutex.RLock()
 	defer fake.waitForInstanceToNotExistMutex.RUnlock()
+	fake.watchInstancesMutex.RLock()
+	defer fake.watchInstancesMutex.RUnlock()
+	fake.exportManifestMutex.RLock()
+	defer fake.exportManifestMutex.RUnlock()
+	fake.retrieveMarketplaceMutex.RLock()
+	defer fake.retrieveMarketplaceMutex.RUnlock()
 	fake.waitForInstanceMutex.RLock()
 	defer fake.waitForInstanceMutex.RUnlock()
 	fake.retrievePlansMutex.RLock()
@@ -2822,6 +3949,8 @@ func (fake *FakeSvcatClient) Invocations() map[string][][]interface{} {
 	defer fake.retrievePlanByIDMutex.RUnlock()
 	fake.retrievePlanByClassAndPlanNamesMutex.RLock()
 	defer fake.retrievePlanByClassAndPlanNamesMutex.RUnlock()
+	fake.retrieveCredentialsMutex.RLock()
+	defer fake.retrieveCredentialsMutex.RUnlock()
 	fake.retrieveSecretByBindingMutex.RLock()
 	defer fake.retrieveSecretByBindingMutex.RUnlock()
 	fake.serverVersionMutex.RLock()
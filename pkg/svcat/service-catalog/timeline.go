@@ -0,0 +1,130 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecatalog
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+// TimelineEntry is something that happened to an instance or a binding:
+// either an event that was recorded for it, or a transition of one of its
+// conditions.
+type TimelineEntry struct {
+	Time v1.Time `json:"time"`
+	// Type is the type of an event, Normal or Warning, or the type and the
+	// status of a condition, such as Ready=True.
+	Type    string `json:"type"`
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+	// Count is the number of times an event occurred. It is 1 for conditions.
+	Count int32 `json:"count"`
+}
+
+// byTime sorts a timeline from the oldest entry to the most recent one.
+type byTime []TimelineEntry
+
+func (t byTime) Len() int {
+	return len(t)
+}
+func (t byTime) Swap(i, j int) {
+	t[i], t[j] = t[j], t[i]
+}
+func (t byTime) Less(i, j int) bool {
+	return t[i].Time.Before(&t[j].Time)
+}
+
+// RetrieveInstanceTimeline merges the events recorded for an instance with
+// the transitions of its conditions, from the oldest to the most recent.
+func (sdk *SDK) RetrieveInstanceTimeline(instance *v1beta1.ServiceInstance) ([]TimelineEntry, error) {
+	timeline, err := sdk.retrieveEvents(instance.Namespace, "ServiceInstance", instance.Name)
+	if err != nil {
+		return nil, err
+	}
+	for _, cond := range instance.Status.Conditions {
+		timeline = append(timeline, conditionEntry(string(cond.Type), cond.Status, cond.Reason, cond.Message, cond.LastTransitionTime))
+	}
+	sort.Stable(byTime(timeline))
+	return timeline, nil
+}
+
+// RetrieveBindingTimeline merges the events recorded for a binding with the
+// transitions of its conditions, from the oldest to the most recent.
+func (sdk *SDK) RetrieveBindingTimeline(binding *v1beta1.ServiceBinding) ([]TimelineEntry, error) {
+	timeline, err := sdk.retrieveEvents(binding.Namespace, "ServiceBinding", binding.Name)
+	if err != nil {
+		return nil, err
+	}
+	for _, cond := range binding.Status.Conditions {
+		timeline = append(timeline, conditionEntry(string(cond.Type), cond.Status, cond.Reason, cond.Message, cond.LastTransitionTime))
+	}
+	sort.Stable(byTime(timeline))
+	return timeline, nil
+}
+
+func (sdk *SDK) retrieveEvents(namespace, kind, name string) ([]TimelineEntry, error) {
+	selector := fields.AndSelectors(
+		fields.OneTermEqualSelector("involvedObject.kind", kind),
+		fields.OneTermEqualSelector("involvedObject.name", name),
+	)
+	events, err := sdk.Core().Events(namespace).List(v1.ListOptions{FieldSelector: selector.String()})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list the events of %s '%s.%s' (%s)", kind, namespace, name, err)
+	}
+
+	timeline := make([]TimelineEntry, 0, len(events.Items))
+	for _, event := range events.Items {
+		timeline = append(timeline, eventEntry(event))
+	}
+	return timeline, nil
+}
+
+func eventEntry(event corev1.Event) TimelineEntry {
+	t := event.LastTimestamp
+	if t.IsZero() {
+		t = event.FirstTimestamp
+	}
+	if t.IsZero() {
+		t = v1.NewTime(event.EventTime.Time)
+	}
+	count := event.Count
+	if count == 0 {
+		count = 1
+	}
+	return TimelineEntry{
+		Time:    t,
+		Type:    event.Type,
+		Reason:  event.Reason,
+		Message: event.Message,
+		Count:   count,
+	}
+}
+
+func conditionEntry(condType string, status v1beta1.ConditionStatus, reason, message string, t v1.Time) TimelineEntry {
+	return TimelineEntry{
+		Time:    t,
+		Type:    fmt.Sprintf("%s=%s", condType, status),
+		Reason:  reason,
+		Message: message,
+		Count:   1,
+	}
+}
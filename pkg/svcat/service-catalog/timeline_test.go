@@ -0,0 +1,123 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecatalog_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/testing"
+
+	. "github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Timeline", func() {
+	var (
+		sdk       *SDK
+		k8sClient *k8sfake.Clientset
+		start     time.Time
+		si        *v1beta1.ServiceInstance
+	)
+
+	BeforeEach(func() {
+		start = time.Date(2018, 1, 11, 20, 59, 45, 0, time.UTC)
+		si = &v1beta1.ServiceInstance{ObjectMeta: metav1.ObjectMeta{Name: "foobar", Namespace: "foobar_namespace"}}
+		si.Status.Conditions = []v1beta1.ServiceInstanceCondition{{
+			Type:               v1beta1.ServiceInstanceConditionReady,
+			Status:             v1beta1.ConditionTrue,
+			Reason:             "ProvisionedSuccessfully",
+			LastTransitionTime: metav1.NewTime(start.Add(2 * time.Second)),
+		}}
+		k8sClient = k8sfake.NewSimpleClientset(
+			&corev1.Event{
+				ObjectMeta:     metav1.ObjectMeta{Name: "foobar.2", Namespace: "foobar_namespace"},
+				Type:           corev1.EventTypeNormal,
+				Reason:         "ProvisionedSuccessfully",
+				LastTimestamp:  metav1.NewTime(start.Add(3 * time.Second)),
+				FirstTimestamp: metav1.NewTime(start.Add(3 * time.Second)),
+			},
+			&corev1.Event{
+				ObjectMeta:     metav1.ObjectMeta{Name: "foobar.1", Namespace: "foobar_namespace"},
+				Type:           corev1.EventTypeWarning,
+				Reason:         "ErrorCallingProvision",
+				FirstTimestamp: metav1.NewTime(start),
+				Count:          2,
+			},
+		)
+		sdk = &SDK{
+			K8sClient: k8sClient,
+		}
+	})
+
+	Describe("RetrieveInstanceTimeline", func() {
+		It("Merges the events and the conditions of the instance from the oldest to the most recent", func() {
+			timeline, err := sdk.RetrieveInstanceTimeline(si)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(timeline).To(HaveLen(3))
+			Expect(timeline[0].Reason).To(Equal("ErrorCallingProvision"))
+			Expect(timeline[0].Type).To(Equal(corev1.EventTypeWarning))
+			Expect(timeline[0].Count).To(Equal(int32(2)))
+			Expect(timeline[1].Type).To(Equal("Ready=True"))
+			Expect(timeline[1].Count).To(Equal(int32(1)))
+			Expect(timeline[2].Reason).To(Equal("ProvisionedSuccessfully"))
+			Expect(timeline[2].Type).To(Equal(corev1.EventTypeNormal))
+			Expect(timeline[2].Count).To(Equal(int32(1)))
+
+			actions := k8sClient.Actions()
+			Expect(actions[0].Matches("list", "events")).To(BeTrue())
+			fieldSelector := actions[0].(testing.ListActionImpl).GetListRestrictions().Fields.String()
+			Expect(fieldSelector).To(Equal("involvedObject.kind=ServiceInstance,involvedObject.name=foobar"))
+		})
+		It("Bubbles up errors", func() {
+			badClient := &k8sfake.Clientset{}
+			errorMessage := "error retrieving list"
+			badClient.AddReactor("list", "events", func(action testing.Action) (bool, runtime.Object, error) {
+				return true, nil, errors.New(errorMessage)
+			})
+			sdk.K8sClient = badClient
+
+			_, err := sdk.RetrieveInstanceTimeline(si)
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(errorMessage))
+		})
+	})
+
+	Describe("RetrieveBindingTimeline", func() {
+		It("Lists the events of the binding", func() {
+			sb := &v1beta1.ServiceBinding{ObjectMeta: metav1.ObjectMeta{Name: "foobar", Namespace: "foobar_namespace"}}
+			sdk.K8sClient = k8sfake.NewSimpleClientset()
+
+			timeline, err := sdk.RetrieveBindingTimeline(sb)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(timeline).To(BeEmpty())
+			actions := sdk.K8sClient.(*k8sfake.Clientset).Actions()
+			fieldSelector := actions[0].(testing.ListActionImpl).GetListRestrictions().Fields.String()
+			Expect(fieldSelector).To(Equal("involvedObject.kind=ServiceBinding,involvedObject.name=foobar"))
+		})
+	})
+})
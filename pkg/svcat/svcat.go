@@ -17,6 +17,7 @@ limitations under the License.
 package svcat
 
 import (
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/clientset"
 	"github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
 	k8sclient "k8s.io/client-go/kubernetes"
@@ -28,6 +29,9 @@ type App struct {
 	servicecatalog.SvcatClient
 	// CurrentNamespace is the namespace set in the current context.
 	CurrentNamespace string
+	// CurrentUser is the user authenticated by the credentials of the current
+	// context, or nil when it cannot be determined locally.
+	CurrentUser *v1beta1.UserInfo
 }
 
 // NewApp creates an svcat application.
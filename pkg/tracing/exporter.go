@@ -0,0 +1,133 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	basictracer "github.com/opentracing/basictracer-go"
+	opentracing "github.com/opentracing/opentracing-go"
+)
+
+const (
+	// ExporterNone disables tracing.
+	ExporterNone = ""
+	// ExporterStdout writes the finished spans to the standard output.
+	ExporterStdout = "stdout"
+	// ExporterFile writes the finished spans to a file.
+	ExporterFile = "file"
+)
+
+// NewTracer creates a tracer whose finished spans are exported by the
+// exporter of the given kind. The file exporter appends the spans to the
+// file at the given path. It returns an opentracing.NoopTracer for
+// ExporterNone.
+func NewTracer(kind, path string) (opentracing.Tracer, error) {
+	switch kind {
+	case ExporterNone:
+		return opentracing.NoopTracer{}, nil
+	case ExporterStdout:
+		return NewRecordingTracer(NewWriterRecorder(os.Stdout)), nil
+	case ExporterFile:
+		if path == "" {
+			return nil, fmt.Errorf("the path of the file to write the spans to is required by the %q tracing exporter", kind)
+		}
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open the tracing file: %v", err)
+		}
+		return NewRecordingTracer(NewWriterRecorder(f)), nil
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q, expected %q or %q", kind, ExporterStdout, ExporterFile)
+	}
+}
+
+// NewRecordingTracer creates a tracer that samples every span and hands the
+// finished spans to the given recorder.
+func NewRecordingTracer(recorder basictracer.SpanRecorder) opentracing.Tracer {
+	options := basictracer.DefaultOptions()
+	options.ShouldSample = func(traceID uint64) bool { return true }
+	options.Recorder = recorder
+	return basictracer.NewWithOptions(options)
+}
+
+// spanRecord is the JSON representation of a finished span.
+type spanRecord struct {
+	TraceID      string                 `json:"traceID"`
+	SpanID       string                 `json:"spanID"`
+	ParentSpanID string                 `json:"parentSpanID,omitempty"`
+	Name         string                 `json:"name"`
+	Start        time.Time              `json:"start"`
+	End          time.Time              `json:"end"`
+	Tags         map[string]interface{} `json:"tags,omitempty"`
+	Error        string                 `json:"error,omitempty"`
+}
+
+func newSpanRecord(span basictracer.RawSpan) *spanRecord {
+	record := &spanRecord{
+		TraceID: fmt.Sprintf("%016x", span.Context.TraceID),
+		SpanID:  fmt.Sprintf("%016x", span.Context.SpanID),
+		Name:    span.Operation,
+		Start:   span.Start,
+		End:     span.Start.Add(span.Duration),
+		Tags:    span.Tags,
+	}
+	if span.ParentSpanID != 0 {
+		record.ParentSpanID = fmt.Sprintf("%016x", span.ParentSpanID)
+	}
+	for _, l := range span.Logs {
+		for _, field := range l.Fields {
+			if field.Key() == "error" {
+				record.Error = fmt.Sprint(field.Value())
+			}
+		}
+	}
+	return record
+}
+
+// writerRecorder writes each finished span as a line of JSON, which needs no
+// tracing backend to be reachable.
+type writerRecorder struct {
+	mutex sync.Mutex
+	w     io.Writer
+}
+
+// NewWriterRecorder creates a recorder that writes each finished span to w
+// as a line of JSON.
+func NewWriterRecorder(w io.Writer) basictracer.SpanRecorder {
+	return &writerRecorder{w: w}
+}
+
+func (r *writerRecorder) RecordSpan(span basictracer.RawSpan) {
+	b, err := json.Marshal(newSpanRecord(span))
+	if err != nil {
+		glog.Errorf("Error marshaling span %q: %v", span.Operation, err)
+		return
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if _, err := r.w.Write(append(b, '\n')); err != nil {
+		glog.Errorf("Error exporting span %q: %v", span.Operation, err)
+	}
+}
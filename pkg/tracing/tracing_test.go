@@ -0,0 +1,101 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	opentracing "github.com/opentracing/opentracing-go"
+)
+
+func TestWriterRecorder(t *testing.T) {
+	var buf bytes.Buffer
+	tracer := NewRecordingTracer(NewWriterRecorder(&buf))
+
+	parent := tracer.StartSpan("parent")
+	child := tracer.StartSpan("child", opentracing.ChildOf(parent.Context()))
+	child.SetTag("broker", "test-broker")
+	FinishSpan(child, errors.New("test error"))
+	FinishSpan(parent, nil)
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	if len(lines) != 2 {
+		t.Fatalf("expected a line per span, got %q", buf.String())
+	}
+	var childRecord, parentRecord spanRecord
+	if err := json.Unmarshal(lines[0], &childRecord); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := json.Unmarshal(lines[1], &parentRecord); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if e, a := "child", childRecord.Name; e != a {
+		t.Fatalf("expected the name of the span to be %q, got %q", e, a)
+	}
+	if e, a := parentRecord.TraceID, childRecord.TraceID; e != a {
+		t.Fatalf("expected the child span to be in the trace of its parent %q, got %q", e, a)
+	}
+	if e, a := parentRecord.SpanID, childRecord.ParentSpanID; e != a {
+		t.Fatalf("expected the parent of the child span to be %q, got %q", e, a)
+	}
+	if parentRecord.ParentSpanID != "" {
+		t.Fatalf("expected the root span to have no parent, got %q", parentRecord.ParentSpanID)
+	}
+	if e, a := "test error", childRecord.Error; e != a {
+		t.Fatalf("expected the error of the span to be %q, got %q", e, a)
+	}
+	if parentRecord.Error != "" {
+		t.Fatalf("expected the parent span to have no error, got %q", parentRecord.Error)
+	}
+	if e, a := "test-broker", childRecord.Tags["broker"]; e != a {
+		t.Fatalf("expected the broker tag of the span to be %q, got %q", e, a)
+	}
+	if childRecord.End.Before(childRecord.Start) {
+		t.Fatalf("expected the span to end after it started, got %v and %v", childRecord.Start, childRecord.End)
+	}
+}
+
+func TestNewTracer(t *testing.T) {
+	cases := []struct {
+		name string
+		kind string
+		path string
+		noop bool
+		err  bool
+	}{
+		{name: "none", kind: ExporterNone, noop: true},
+		{name: "stdout", kind: ExporterStdout},
+		{name: "file without path", kind: ExporterFile, err: true},
+		{name: "unknown", kind: "jaeger", err: true},
+	}
+	for _, tc := range cases {
+		tracer, err := NewTracer(tc.kind, tc.path)
+		if tc.err != (err != nil) {
+			t.Errorf("%v: unexpected error: %v", tc.name, err)
+		}
+		if tc.err {
+			continue
+		}
+		if _, noop := tracer.(opentracing.NoopTracer); tc.noop != noop {
+			t.Errorf("%v: unexpected tracer: %T", tc.name, tracer)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jsonschema
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/xeipuuv/gojsonschema"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	// contextDelimiter separates the elements of a gojsonschema context path.
+	// A NUL byte is used so that parameter names containing dots are not split.
+	contextDelimiter = "\x00"
+
+	// rootContext is the name gojsonschema gives to the root of the document.
+	rootContext = "(root)"
+
+	// requiredErrorType is the gojsonschema error type for a missing property.
+	requiredErrorType = "required"
+)
+
+// IsEmpty returns true if the given schema does not constrain anything,
+// either because it was not supplied or because it is an empty document.
+func IsEmpty(schema *runtime.RawExtension) bool {
+	if schema == nil || len(schema.Raw) == 0 {
+		return true
+	}
+	trimmed := strings.TrimSpace(string(schema.Raw))
+	return trimmed == "" || trimmed == "{}" || trimmed == "null"
+}
+
+// ValidateParameters validates a set of parameters against a JSON schema as
+// published by a broker for a plan. Every violation of the schema is returned
+// as a field error rooted at fldPath. A nil or empty schema accepts any
+// parameters. An error is returned if the schema itself cannot be compiled.
+func ValidateParameters(schema *runtime.RawExtension, params map[string]interface{}, fldPath *field.Path) (field.ErrorList, error) {
+	if IsEmpty(schema) {
+		return nil, nil
+	}
+
+	compiled, err := gojsonschema.NewSchema(gojsonschema.NewBytesLoader(schema.Raw))
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile parameter schema: %v", err)
+	}
+
+	// No parameters are sent to the broker as an empty object, which still
+	// needs to satisfy any required properties of the schema.
+	if params == nil {
+		params = map[string]interface{}{}
+	}
+
+	result, err := compiled.Validate(gojsonschema.NewGoLoader(params))
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate parameters against schema: %v", err)
+	}
+
+	allErrs := field.ErrorList{}
+	for _, resultErr := range result.Errors() {
+		path := contextToPath(resultErr.Context(), fldPath)
+		if resultErr.Type() == requiredErrorType {
+			if property, ok := resultErr.Details()["property"].(string); ok {
+				path = path.Child(property)
+			}
+			allErrs = append(allErrs, field.Required(path, resultErr.Description()))
+			continue
+		}
+		allErrs = append(allErrs, field.Invalid(path, resultErr.Value(), resultErr.Description()))
+	}
+	return allErrs, nil
+}
+
+// contextToPath converts the location of a schema violation reported by
+// gojsonschema into a field path below fldPath.
+func contextToPath(context *gojsonschema.JsonContext, fldPath *field.Path) *field.Path {
+	path := fldPath
+	if context == nil {
+		return path
+	}
+	for _, element := range strings.Split(context.String(contextDelimiter), contextDelimiter) {
+		if element == rootContext {
+			continue
+		}
+		if index, err := strconv.Atoi(element); err == nil {
+			path = path.Index(index)
+		} else {
+			path = path.Child(element)
+		}
+	}
+	return path
+}
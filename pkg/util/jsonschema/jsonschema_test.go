@@ -0,0 +1,127 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jsonschema
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const testSchema = `{
+  "$schema": "http://json-schema.org/draft-04/schema#",
+  "type": "object",
+  "properties": {
+    "location": {"type": "string", "enum": ["eastus", "westus"]},
+    "size": {"type": "integer", "minimum": 1},
+    "tags": {"type": "array", "items": {"type": "string"}},
+    "network": {
+      "type": "object",
+      "properties": {"cidr": {"type": "string"}},
+      "required": ["cidr"]
+    }
+  },
+  "required": ["location"]
+}`
+
+func TestValidateParameters(t *testing.T) {
+	cases := []struct {
+		name   string
+		schema *runtime.RawExtension
+		params map[string]interface{}
+		errors map[string]field.ErrorType
+		error  bool
+	}{
+		{
+			name:   "nil schema",
+			params: map[string]interface{}{"anything": "goes"},
+		},
+		{
+			name:   "empty schema",
+			schema: &runtime.RawExtension{Raw: []byte("{}")},
+			params: map[string]interface{}{"anything": "goes"},
+		},
+		{
+			name:   "valid parameters",
+			schema: &runtime.RawExtension{Raw: []byte(testSchema)},
+			params: map[string]interface{}{"location": "eastus", "size": 3},
+		},
+		{
+			name:   "missing required parameter",
+			schema: &runtime.RawExtension{Raw: []byte(testSchema)},
+			errors: map[string]field.ErrorType{
+				"spec.parameters.location": field.ErrorTypeRequired,
+			},
+		},
+		{
+			name:   "invalid enum value",
+			schema: &runtime.RawExtension{Raw: []byte(testSchema)},
+			params: map[string]interface{}{"location": "northpole"},
+			errors: map[string]field.ErrorType{
+				"spec.parameters.location": field.ErrorTypeInvalid,
+			},
+		},
+		{
+			name:   "nested errors",
+			schema: &runtime.RawExtension{Raw: []byte(testSchema)},
+			params: map[string]interface{}{
+				"location": "westus",
+				"size":     0,
+				"tags":     []interface{}{"a", 2},
+				"network":  map[string]interface{}{},
+			},
+			errors: map[string]field.ErrorType{
+				"spec.parameters.size":         field.ErrorTypeInvalid,
+				"spec.parameters.tags[1]":      field.ErrorTypeInvalid,
+				"spec.parameters.network.cidr": field.ErrorTypeRequired,
+			},
+		},
+		{
+			name:   "invalid schema",
+			schema: &runtime.RawExtension{Raw: []byte(`{"type": 5}`)},
+			error:  true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs, err := ValidateParameters(tc.schema, tc.params, field.NewPath("spec", "parameters"))
+			if err != nil {
+				if !tc.error {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if tc.error {
+				t.Fatal("expected an error compiling the schema")
+			}
+			if len(errs) != len(tc.errors) {
+				t.Fatalf("expected %d field errors, got %d: %v", len(tc.errors), len(errs), errs)
+			}
+			for _, e := range errs {
+				expected, ok := tc.errors[e.Field]
+				if !ok {
+					t.Errorf("unexpected error for field %v: %v", e.Field, e)
+					continue
+				}
+				if e.Type != expected {
+					t.Errorf("expected error of type %v for field %v, got %v", expected, e.Field, e.Type)
+				}
+			}
+		})
+	}
+}
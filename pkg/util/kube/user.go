@@ -0,0 +1,119 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"strings"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// authenticatedGroup is the group added to every authenticated user.
+	authenticatedGroup = "system:authenticated"
+	// serviceAccountUsernamePrefix prefixes the username of service accounts.
+	serviceAccountUsernamePrefix = "system:serviceaccount:"
+	// serviceAccountGroupPrefix prefixes the groups of service accounts.
+	serviceAccountGroupPrefix = "system:serviceaccounts"
+)
+
+// GetUserInfo returns the user that the API server will authenticate the
+// credentials of a client config as, when it can be determined locally from
+// a client certificate, basic auth or a service account token. Otherwise nil
+// is returned.
+func GetUserInfo(config *rest.Config) *v1beta1.UserInfo {
+	if config == nil {
+		return nil
+	}
+
+	if user := userFromCertificate(config); user != nil {
+		return user
+	}
+	if config.Username != "" {
+		return &v1beta1.UserInfo{
+			Username: config.Username,
+			Groups:   []string{authenticatedGroup},
+		}
+	}
+	return userFromServiceAccountToken(config.BearerToken)
+}
+
+// userFromCertificate returns the user of a client certificate, which is the
+// common name of its subject, member of the organizations of its subject.
+func userFromCertificate(config *rest.Config) *v1beta1.UserInfo {
+	data := config.TLSClientConfig.CertData
+	if len(data) == 0 && config.TLSClientConfig.CertFile != "" {
+		var err error
+		if data, err = ioutil.ReadFile(config.TLSClientConfig.CertFile); err != nil {
+			return nil
+		}
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil || cert.Subject.CommonName == "" {
+		return nil
+	}
+	return &v1beta1.UserInfo{
+		Username: cert.Subject.CommonName,
+		Groups:   append(cert.Subject.Organization, authenticatedGroup),
+	}
+}
+
+// userFromServiceAccountToken returns the service account of a token. The
+// token is not verified, its claims are only used to name the user.
+func userFromServiceAccountToken(token string) *v1beta1.UserInfo {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil
+	}
+	claims := struct {
+		Subject string `json:"sub"`
+		UID     string `json:"kubernetes.io/serviceaccount/service-account.uid"`
+	}{}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil
+	}
+	if !strings.HasPrefix(claims.Subject, serviceAccountUsernamePrefix) {
+		return nil
+	}
+	nameParts := strings.Split(strings.TrimPrefix(claims.Subject, serviceAccountUsernamePrefix), ":")
+	if len(nameParts) != 2 {
+		return nil
+	}
+	return &v1beta1.UserInfo{
+		Username: claims.Subject,
+		UID:      claims.UID,
+		Groups: []string{
+			serviceAccountGroupPrefix,
+			serviceAccountGroupPrefix + ":" + nameParts[0],
+			authenticatedGroup,
+		},
+	}
+}
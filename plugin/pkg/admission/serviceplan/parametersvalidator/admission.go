@@ -0,0 +1,546 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parametersvalidator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/golang/glog"
+
+	authorizationapi "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apiserver/pkg/admission"
+	"k8s.io/apiserver/pkg/authentication/user"
+	kubeclientset "k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
+	scadmission "github.com/kubernetes-incubator/service-catalog/pkg/apiserver/admission"
+	informers "github.com/kubernetes-incubator/service-catalog/pkg/client/informers_generated/internalversion"
+	internalversion "github.com/kubernetes-incubator/service-catalog/pkg/client/listers_generated/servicecatalog/internalversion"
+	"github.com/kubernetes-incubator/service-catalog/pkg/util/jsonschema"
+)
+
+const (
+	// PluginName is name of admission plug-in
+	PluginName = "ServicePlanParametersValidator"
+)
+
+// Register registers a plugin
+func Register(plugins *admission.Plugins) {
+	plugins.Register(PluginName, func(io.Reader) (admission.Interface, error) {
+		return NewParametersValidator()
+	})
+}
+
+// validateParametersAgainstPlanSchema is an implementation of
+// admission.ValidationInterface.
+// It checks the parameters of a ServiceInstance or ServiceBinding against the
+// schema published by the broker for the plan being used, and rejects the
+// request if the parameters do not satisfy that schema.
+//
+// Parameters sourced from Secrets are only considered if the requesting user
+// is allowed to read the Secret. If any of the parameters cannot be resolved,
+// the request is let through and the controller will deal with the parameters
+// when it talks to the broker.
+type validateParametersAgainstPlanSchema struct {
+	*admission.Handler
+	cscLister      internalversion.ClusterServiceClassLister
+	cspLister      internalversion.ClusterServicePlanLister
+	scLister       internalversion.ServiceClassLister
+	spLister       internalversion.ServicePlanLister
+	instanceLister internalversion.ServiceInstanceLister
+	kubeClient     kubeclientset.Interface
+}
+
+var _ = scadmission.WantsInternalServiceCatalogInformerFactory(&validateParametersAgainstPlanSchema{})
+var _ = scadmission.WantsKubeClientSet(&validateParametersAgainstPlanSchema{})
+var _ = admission.ValidationInterface(&validateParametersAgainstPlanSchema{})
+
+// Validate rejects a ServiceInstance or ServiceBinding whose parameters do
+// not satisfy the parameter schema of its plan. Validation, rather than
+// mutation, is used so that plugins such as DefaultServicePlan have already
+// filled in the plan by the time the parameters are checked.
+func (v *validateParametersAgainstPlanSchema) Validate(a admission.Attributes) error {
+	// we need to wait for our caches to warm
+	if !v.WaitForReady() {
+		return admission.NewForbidden(a, fmt.Errorf("not yet ready to handle request"))
+	}
+
+	if a.GetResource().Group != servicecatalog.GroupName {
+		return nil
+	}
+
+	// We don't want to deal with any sub resources
+	if a.GetSubresource() != "" {
+		return nil
+	}
+
+	switch a.GetResource().GroupResource() {
+	case servicecatalog.Resource("serviceinstances"):
+		instance, ok := a.GetObject().(*servicecatalog.ServiceInstance)
+		if !ok {
+			return apierrors.NewBadRequest("Resource was marked with kind ServiceInstance but was unable to be converted")
+		}
+		return v.validateServiceInstance(a, instance)
+	case servicecatalog.Resource("servicebindings"):
+		binding, ok := a.GetObject().(*servicecatalog.ServiceBinding)
+		if !ok {
+			return apierrors.NewBadRequest("Resource was marked with kind ServiceBinding but was unable to be converted")
+		}
+		return v.validateServiceBinding(a, binding)
+	}
+	return nil
+}
+
+func (v *validateParametersAgainstPlanSchema) validateServiceInstance(a admission.Attributes, instance *servicecatalog.ServiceInstance) error {
+	if instance.DeletionTimestamp != nil {
+		return nil
+	}
+
+	if a.GetOperation() == admission.Update {
+		oldInstance, ok := a.GetOldObject().(*servicecatalog.ServiceInstance)
+		if !ok {
+			return apierrors.NewBadRequest("Resource was marked with kind ServiceInstance but was unable to be converted")
+		}
+		// Only check the parameters when the user changed them or moved to
+		// another plan, so that unrelated updates (such as the controller
+		// removing its finalizer) are never blocked by a schema.
+		if !parametersChanged(oldInstance.Spec.Parameters, oldInstance.Spec.ParametersFrom, instance.Spec.Parameters, instance.Spec.ParametersFrom) &&
+			oldInstance.Spec.PlanReference == instance.Spec.PlanReference {
+			return nil
+		}
+	}
+
+	planSpec, err := v.getServicePlanSpecForInstance(instance)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			glog.V(5).Infof(`ServiceInstance "%s/%s": could not locate plan %v, skipping parameter validation`,
+				instance.Namespace, instance.Name, instance.Spec.PlanReference)
+			return nil
+		}
+		return admission.NewForbidden(a, err)
+	}
+
+	schema := planSpec.ServiceInstanceCreateParameterSchema
+	if a.GetOperation() == admission.Update {
+		schema = planSpec.ServiceInstanceUpdateParameterSchema
+	}
+	if jsonschema.IsEmpty(schema) {
+		return nil
+	}
+
+	errs, err := v.validateParameters(a, instance.Namespace, schema, instance.Spec.Parameters, instance.Spec.ParametersFrom)
+	if err != nil {
+		glog.V(4).Infof(`ServiceInstance "%s/%s": skipping parameter validation: %v`, instance.Namespace, instance.Name, err)
+		return nil
+	}
+	if len(errs) > 0 {
+		return apierrors.NewInvalid(servicecatalog.Kind("ServiceInstance"), instance.Name, errs)
+	}
+	return nil
+}
+
+func (v *validateParametersAgainstPlanSchema) validateServiceBinding(a admission.Attributes, binding *servicecatalog.ServiceBinding) error {
+	if binding.DeletionTimestamp != nil {
+		return nil
+	}
+
+	if a.GetOperation() == admission.Update {
+		oldBinding, ok := a.GetOldObject().(*servicecatalog.ServiceBinding)
+		if !ok {
+			return apierrors.NewBadRequest("Resource was marked with kind ServiceBinding but was unable to be converted")
+		}
+		if !parametersChanged(oldBinding.Spec.Parameters, oldBinding.Spec.ParametersFrom, binding.Spec.Parameters, binding.Spec.ParametersFrom) {
+			return nil
+		}
+	}
+
+	instance, err := v.instanceLister.ServiceInstances(binding.Namespace).Get(binding.Spec.ServiceInstanceRef.Name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return admission.NewForbidden(a, err)
+	}
+
+	// The plan of an instance is only known once the controller has resolved
+	// its references.
+	var planSpec *servicecatalog.CommonServicePlanSpec
+	if instance.Spec.ClusterServicePlanRef != nil {
+		plan, err := v.cspLister.Get(instance.Spec.ClusterServicePlanRef.Name)
+		if err == nil {
+			planSpec = &plan.Spec.CommonServicePlanSpec
+		}
+	} else if instance.Spec.ServicePlanRef != nil {
+		plan, err := v.spLister.ServicePlans(instance.Namespace).Get(instance.Spec.ServicePlanRef.Name)
+		if err == nil {
+			planSpec = &plan.Spec.CommonServicePlanSpec
+		}
+	}
+	if planSpec == nil || jsonschema.IsEmpty(planSpec.ServiceBindingCreateParameterSchema) {
+		return nil
+	}
+
+	errs, err := v.validateParameters(a, binding.Namespace, planSpec.ServiceBindingCreateParameterSchema, binding.Spec.Parameters, binding.Spec.ParametersFrom)
+	if err != nil {
+		glog.V(4).Infof(`ServiceBinding "%s/%s": skipping parameter validation: %v`, binding.Namespace, binding.Name, err)
+		return nil
+	}
+	if len(errs) > 0 {
+		return apierrors.NewInvalid(servicecatalog.Kind("ServiceBinding"), binding.Name, errs)
+	}
+	return nil
+}
+
+// validateParameters merges the inline parameters with the parameters from
+// secrets and validates the result against the schema. An error is returned
+// if the parameters could not be resolved or the schema could not be
+// compiled; in that case the caller should not reject the request.
+func (v *validateParametersAgainstPlanSchema) validateParameters(a admission.Attributes, namespace string, schema *runtime.RawExtension, parameters *runtime.RawExtension, parametersFrom []servicecatalog.ParametersFromSource) (field.ErrorList, error) {
+	parametersPath := field.NewPath("spec", "parameters")
+	parametersFromPath := field.NewPath("spec", "parametersFrom")
+
+	params := make(map[string]interface{})
+	// secretKeys tracks which top-level parameters were read from a Secret so
+	// that their values are never echoed back in an error.
+	secretKeys := make(map[string]*field.Path)
+	for i, p := range parametersFrom {
+		if p.SecretKeyRef == nil {
+			continue
+		}
+		path := parametersFromPath.Index(i).Child("secretKeyRef")
+		fps, err := v.fetchSecretKeyParameters(a.GetUserInfo(), namespace, p.SecretKeyRef)
+		if err != nil {
+			return nil, err
+		}
+		for k, val := range fps {
+			if _, ok := params[k]; ok {
+				return field.ErrorList{field.Duplicate(path, k)}, nil
+			}
+			params[k] = val
+			secretKeys[k] = path
+		}
+	}
+	if parameters != nil && len(parameters.Raw) > 0 {
+		pp := make(map[string]interface{})
+		if err := yaml.Unmarshal(parameters.Raw, &pp); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal parameters: %v", err)
+		}
+		for k, val := range pp {
+			if _, ok := params[k]; ok {
+				return field.ErrorList{field.Duplicate(parametersPath, k)}, nil
+			}
+			params[k] = val
+		}
+	}
+
+	errs, err := jsonschema.ValidateParameters(schema, params, parametersPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range errs {
+		// Keywords such as additionalProperties, oneOf or not report the
+		// whole object holding the parameters as the invalid value, so no
+		// value is echoed back once any parameter was read from a Secret.
+		if len(secretKeys) > 0 && e.Type != field.ErrorTypeRequired {
+			e.BadValue = "<redacted>"
+		}
+		for k, path := range secretKeys {
+			if !isFieldOrChild(e.Field, parametersPath.Child(k).String()) {
+				continue
+			}
+			e.Detail = fmt.Sprintf("%s (parameter %q is sourced from %s)", e.Detail, k, path)
+		}
+	}
+	return errs, nil
+}
+
+// fetchSecretKeyParameters returns the parameters stored in the given key of
+// a Secret, as long as the user is allowed to read that Secret.
+func (v *validateParametersAgainstPlanSchema) fetchSecretKeyParameters(userInfo user.Info, namespace string, ref *servicecatalog.SecretKeyReference) (map[string]interface{}, error) {
+	sar := &authorizationapi.SubjectAccessReview{
+		Spec: authorizationapi.SubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationapi.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      "get",
+				Group:     corev1.SchemeGroupVersion.Group,
+				Version:   corev1.SchemeGroupVersion.Version,
+				Resource:  corev1.ResourceSecrets.String(),
+				Name:      ref.Name,
+			},
+			User:   userInfo.GetName(),
+			Groups: userInfo.GetGroups(),
+			Extra:  convertToSARExtra(userInfo.GetExtra()),
+			UID:    userInfo.GetUID(),
+		},
+	}
+	sar, err := v.kubeClient.AuthorizationV1().SubjectAccessReviews().Create(sar)
+	if err != nil {
+		return nil, err
+	}
+	if !sar.Status.Allowed {
+		return nil, fmt.Errorf("user %q is not allowed to read secret %s/%s", userInfo.GetName(), namespace, ref.Name)
+	}
+
+	secret, err := v.kubeClient.CoreV1().Secrets(namespace).Get(ref.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	data, ok := secret.Data[ref.Key]
+	if !ok {
+		return nil, fmt.Errorf("secret %s/%s does not contain key %q", namespace, ref.Name, ref.Key)
+	}
+	params := make(map[string]interface{})
+	if err := json.Unmarshal(data, &params); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal parameters from secret %s/%s as JSON object: %v", namespace, ref.Name, err)
+	}
+	return params, nil
+}
+
+// getServicePlanSpecForInstance returns the spec of the (Cluster)ServicePlan
+// used by the instance, resolving the plan reference specified by the user if
+// the controller has not done so yet.
+func (v *validateParametersAgainstPlanSchema) getServicePlanSpecForInstance(instance *servicecatalog.ServiceInstance) (*servicecatalog.CommonServicePlanSpec, error) {
+	if instance.Spec.ClusterServicePlanRef != nil {
+		plan, err := v.cspLister.Get(instance.Spec.ClusterServicePlanRef.Name)
+		if err != nil {
+			return nil, err
+		}
+		return &plan.Spec.CommonServicePlanSpec, nil
+	}
+	if instance.Spec.ServicePlanRef != nil {
+		plan, err := v.spLister.ServicePlans(instance.Namespace).Get(instance.Spec.ServicePlanRef.Name)
+		if err != nil {
+			return nil, err
+		}
+		return &plan.Spec.CommonServicePlanSpec, nil
+	}
+
+	ref := instance.Spec.PlanReference
+	if ref.ClusterServiceClassSpecified() {
+		plan, err := v.resolveClusterServicePlan(ref)
+		if err != nil {
+			return nil, err
+		}
+		return &plan.Spec.CommonServicePlanSpec, nil
+	}
+	if ref.ServiceClassSpecified() {
+		plan, err := v.resolveServicePlan(instance.Namespace, ref)
+		if err != nil {
+			return nil, err
+		}
+		return &plan.Spec.CommonServicePlanSpec, nil
+	}
+	return nil, apierrors.NewNotFound(servicecatalog.Resource("clusterserviceplans"), "")
+}
+
+func (v *validateParametersAgainstPlanSchema) resolveClusterServicePlan(ref servicecatalog.PlanReference) (*servicecatalog.ClusterServicePlan, error) {
+	if ref.ClusterServicePlanName != "" {
+		return v.cspLister.Get(ref.ClusterServicePlanName)
+	}
+
+	var class *servicecatalog.ClusterServiceClass
+	if ref.ClusterServiceClassName != "" {
+		c, err := v.cscLister.Get(ref.ClusterServiceClassName)
+		if err != nil {
+			return nil, err
+		}
+		class = c
+	} else {
+		classes, err := v.cscLister.List(labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+		var matches []*servicecatalog.ClusterServiceClass
+		for _, c := range classes {
+			if (ref.ClusterServiceClassExternalName != "" && c.Spec.ExternalName == ref.ClusterServiceClassExternalName) ||
+				(ref.ClusterServiceClassExternalID != "" && c.Spec.ExternalID == ref.ClusterServiceClassExternalID) {
+				matches = append(matches, c)
+			}
+		}
+		if len(matches) != 1 {
+			return nil, apierrors.NewNotFound(servicecatalog.Resource("clusterserviceclasses"), ref.GetSpecifiedClusterServiceClass())
+		}
+		class = matches[0]
+	}
+
+	plans, err := v.cspLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range plans {
+		if p.Spec.ClusterServiceClassRef.Name != class.Name {
+			continue
+		}
+		if (ref.ClusterServicePlanExternalName != "" && p.Spec.ExternalName == ref.ClusterServicePlanExternalName) ||
+			(ref.ClusterServicePlanExternalID != "" && p.Spec.ExternalID == ref.ClusterServicePlanExternalID) {
+			return p, nil
+		}
+	}
+	return nil, apierrors.NewNotFound(servicecatalog.Resource("clusterserviceplans"), ref.GetSpecifiedClusterServicePlan())
+}
+
+func (v *validateParametersAgainstPlanSchema) resolveServicePlan(namespace string, ref servicecatalog.PlanReference) (*servicecatalog.ServicePlan, error) {
+	if ref.ServicePlanName != "" {
+		return v.spLister.ServicePlans(namespace).Get(ref.ServicePlanName)
+	}
+
+	var class *servicecatalog.ServiceClass
+	if ref.ServiceClassName != "" {
+		c, err := v.scLister.ServiceClasses(namespace).Get(ref.ServiceClassName)
+		if err != nil {
+			return nil, err
+		}
+		class = c
+	} else {
+		classes, err := v.scLister.ServiceClasses(namespace).List(labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+		var matches []*servicecatalog.ServiceClass
+		for _, c := range classes {
+			if (ref.ServiceClassExternalName != "" && c.Spec.ExternalName == ref.ServiceClassExternalName) ||
+				(ref.ServiceClassExternalID != "" && c.Spec.ExternalID == ref.ServiceClassExternalID) {
+				matches = append(matches, c)
+			}
+		}
+		if len(matches) != 1 {
+			return nil, apierrors.NewNotFound(servicecatalog.Resource("serviceclasses"), ref.GetSpecifiedServiceClass())
+		}
+		class = matches[0]
+	}
+
+	plans, err := v.spLister.ServicePlans(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range plans {
+		if p.Spec.ServiceClassRef.Name != class.Name {
+			continue
+		}
+		if (ref.ServicePlanExternalName != "" && p.Spec.ExternalName == ref.ServicePlanExternalName) ||
+			(ref.ServicePlanExternalID != "" && p.Spec.ExternalID == ref.ServicePlanExternalID) {
+			return p, nil
+		}
+	}
+	return nil, apierrors.NewNotFound(servicecatalog.Resource("serviceplans"), ref.GetSpecifiedServicePlan())
+}
+
+// parametersChanged returns true if either the inline parameters or the
+// parameter sources differ between two specs.
+func parametersChanged(oldParameters *runtime.RawExtension, oldParametersFrom []servicecatalog.ParametersFromSource, newParameters *runtime.RawExtension, newParametersFrom []servicecatalog.ParametersFromSource) bool {
+	var oldRaw, newRaw []byte
+	if oldParameters != nil {
+		oldRaw = oldParameters.Raw
+	}
+	if newParameters != nil {
+		newRaw = newParameters.Raw
+	}
+	return string(oldRaw) != string(newRaw) || !reflect.DeepEqual(oldParametersFrom, newParametersFrom)
+}
+
+// isFieldOrChild returns true if the path is the given field or one of its
+// children.
+func isFieldOrChild(path, fieldPath string) bool {
+	if !strings.HasPrefix(path, fieldPath) {
+		return false
+	}
+	rest := path[len(fieldPath):]
+	return rest == "" || strings.HasPrefix(rest, ".") || strings.HasPrefix(rest, "[")
+}
+
+func convertToSARExtra(extra map[string][]string) map[string]authorizationapi.ExtraValue {
+	if extra == nil {
+		return nil
+	}
+
+	ret := map[string]authorizationapi.ExtraValue{}
+	for k, v := range extra {
+		ret[k] = authorizationapi.ExtraValue(v)
+	}
+
+	return ret
+}
+
+// NewParametersValidator creates a new admission control handler that
+// validates the parameters of ServiceInstances and ServiceBindings against
+// the parameter schemas of their plan.
+func NewParametersValidator() (admission.Interface, error) {
+	return &validateParametersAgainstPlanSchema{
+		Handler: admission.NewHandler(admission.Create, admission.Update),
+	}, nil
+}
+
+func (v *validateParametersAgainstPlanSchema) SetInternalServiceCatalogInformerFactory(f informers.SharedInformerFactory) {
+	cscInformer := f.Servicecatalog().InternalVersion().ClusterServiceClasses()
+	cspInformer := f.Servicecatalog().InternalVersion().ClusterServicePlans()
+	scInformer := f.Servicecatalog().InternalVersion().ServiceClasses()
+	spInformer := f.Servicecatalog().InternalVersion().ServicePlans()
+	instanceInformer := f.Servicecatalog().InternalVersion().ServiceInstances()
+	v.cscLister = cscInformer.Lister()
+	v.cspLister = cspInformer.Lister()
+	v.scLister = scInformer.Lister()
+	v.spLister = spInformer.Lister()
+	v.instanceLister = instanceInformer.Lister()
+
+	readyFunc := func() bool {
+		return cscInformer.Informer().HasSynced() &&
+			cspInformer.Informer().HasSynced() &&
+			scInformer.Informer().HasSynced() &&
+			spInformer.Informer().HasSynced() &&
+			instanceInformer.Informer().HasSynced()
+	}
+
+	v.SetReadyFunc(readyFunc)
+}
+
+func (v *validateParametersAgainstPlanSchema) SetKubeClientSet(client kubeclientset.Interface) {
+	v.kubeClient = client
+}
+
+func (v *validateParametersAgainstPlanSchema) ValidateInitialization() error {
+	if v.cscLister == nil {
+		return errors.New("missing cluster service class lister")
+	}
+	if v.cspLister == nil {
+		return errors.New("missing cluster service plan lister")
+	}
+	if v.scLister == nil {
+		return errors.New("missing service class lister")
+	}
+	if v.spLister == nil {
+		return errors.New("missing service plan lister")
+	}
+	if v.instanceLister == nil {
+		return errors.New("missing instance lister")
+	}
+	if v.kubeClient == nil {
+		return errors.New("missing kube client")
+	}
+	return nil
+}
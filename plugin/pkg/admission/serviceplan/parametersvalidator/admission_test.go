@@ -0,0 +1,417 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parametersvalidator
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	authorizationapi "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apiserver/pkg/admission"
+	"k8s.io/apiserver/pkg/authentication/user"
+	kubeclientset "k8s.io/client-go/kubernetes"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
+	scadmission "github.com/kubernetes-incubator/service-catalog/pkg/apiserver/admission"
+	"github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/internalclientset"
+	"github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/internalclientset/fake"
+	informers "github.com/kubernetes-incubator/service-catalog/pkg/client/informers_generated/internalversion"
+)
+
+const (
+	testNamespace = "test-ns"
+	testSchema    = `{
+  "$schema": "http://json-schema.org/draft-04/schema#",
+  "type": "object",
+  "properties": {
+    "location": {"type": "string", "enum": ["eastus", "westus"]},
+    "password": {"type": "string", "minLength": 8}
+  },
+  "required": ["location"]
+}`
+)
+
+// newHandlerForTest returns a configured handler for testing.
+func newHandlerForTest(internalClient internalclientset.Interface, kubeClient kubeclientset.Interface) (admission.Interface, informers.SharedInformerFactory, error) {
+	f := informers.NewSharedInformerFactory(internalClient, 5*time.Minute)
+	handler, err := NewParametersValidator()
+	if err != nil {
+		return nil, f, err
+	}
+	pluginInitializer := scadmission.NewPluginInitializer(internalClient, f, kubeClient, nil)
+	pluginInitializer.Initialize(handler)
+	err = admission.ValidateInitialization(handler)
+	return handler, f, err
+}
+
+// newFakeServiceCatalogClientForTest creates a fake clientset that lists a
+// single ClusterServiceClass with a single ClusterServicePlan using
+// testSchema for all of its parameter schemas, and the given instances.
+func newFakeServiceCatalogClientForTest(instances ...servicecatalog.ServiceInstance) *fake.Clientset {
+	return newFakeServiceCatalogClientWithSchemaForTest(testSchema, instances...)
+}
+
+// newFakeServiceCatalogClientWithSchemaForTest returns a fake client whose
+// test plan has the given parameter schema.
+func newFakeServiceCatalogClientWithSchemaForTest(parameterSchema string, instances ...servicecatalog.ServiceInstance) *fake.Clientset {
+	fakeClient := &fake.Clientset{}
+
+	classList := &servicecatalog.ClusterServiceClassList{
+		ListMeta: metav1.ListMeta{ResourceVersion: "1"},
+		Items: []servicecatalog.ClusterServiceClass{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "class-guid"},
+				Spec: servicecatalog.ClusterServiceClassSpec{
+					CommonServiceClassSpec: servicecatalog.CommonServiceClassSpec{
+						ExternalName: "test-class",
+						ExternalID:   "class-guid",
+					},
+				},
+			},
+		},
+	}
+	schema := &runtime.RawExtension{Raw: []byte(parameterSchema)}
+	planList := &servicecatalog.ClusterServicePlanList{
+		ListMeta: metav1.ListMeta{ResourceVersion: "1"},
+		Items: []servicecatalog.ClusterServicePlan{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "plan-guid"},
+				Spec: servicecatalog.ClusterServicePlanSpec{
+					CommonServicePlanSpec: servicecatalog.CommonServicePlanSpec{
+						ExternalName:                         "test-plan",
+						ExternalID:                           "plan-guid",
+						ServiceInstanceCreateParameterSchema: schema,
+						ServiceInstanceUpdateParameterSchema: schema,
+						ServiceBindingCreateParameterSchema:  schema,
+					},
+					ClusterServiceClassRef: servicecatalog.ClusterObjectReference{Name: "class-guid"},
+				},
+			},
+		},
+	}
+	instanceList := &servicecatalog.ServiceInstanceList{
+		ListMeta: metav1.ListMeta{ResourceVersion: "1"},
+		Items:    instances,
+	}
+
+	fakeClient.AddReactor("list", "clusterserviceclasses", func(action core.Action) (bool, runtime.Object, error) {
+		return true, classList, nil
+	})
+	fakeClient.AddReactor("list", "clusterserviceplans", func(action core.Action) (bool, runtime.Object, error) {
+		return true, planList, nil
+	})
+	fakeClient.AddReactor("list", "serviceinstances", func(action core.Action) (bool, runtime.Object, error) {
+		return true, instanceList, nil
+	})
+	return fakeClient
+}
+
+// newFakeKubeClientForTest creates a fake kubernetes client holding a secret
+// with parameters, answering SARs for the "forbidden" user with a denial.
+func newFakeKubeClientForTest(secretParameters string) *kubefake.Clientset {
+	kubeClient := kubefake.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "param-secret", Namespace: testNamespace},
+		Data:       map[string][]byte{"params": []byte(secretParameters)},
+	})
+	kubeClient.PrependReactor("create", "subjectaccessreviews", func(action core.Action) (bool, runtime.Object, error) {
+		sar := action.(core.CreateAction).GetObject().(*authorizationapi.SubjectAccessReview)
+		sar.Status.Allowed = sar.Spec.User != "forbidden"
+		return true, sar, nil
+	})
+	return kubeClient
+}
+
+// newServiceInstance returns a new instance of the test plan with the given
+// inline parameters.
+func newServiceInstance(parameters string) servicecatalog.ServiceInstance {
+	instance := servicecatalog.ServiceInstance{
+		ObjectMeta: metav1.ObjectMeta{Name: "instance", Namespace: testNamespace},
+		Spec: servicecatalog.ServiceInstanceSpec{
+			PlanReference: servicecatalog.PlanReference{
+				ClusterServiceClassExternalName: "test-class",
+				ClusterServicePlanExternalName:  "test-plan",
+			},
+		},
+	}
+	if parameters != "" {
+		instance.Spec.Parameters = &runtime.RawExtension{Raw: []byte(parameters)}
+	}
+	return instance
+}
+
+// newServiceBinding returns a new binding to the test instance with the given
+// inline parameters.
+func newServiceBinding(parameters string) servicecatalog.ServiceBinding {
+	binding := servicecatalog.ServiceBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "binding", Namespace: testNamespace},
+		Spec: servicecatalog.ServiceBindingSpec{
+			ServiceInstanceRef: servicecatalog.LocalObjectReference{Name: "instance"},
+		},
+	}
+	if parameters != "" {
+		binding.Spec.Parameters = &runtime.RawExtension{Raw: []byte(parameters)}
+	}
+	return binding
+}
+
+func withParametersFromSecret(instance servicecatalog.ServiceInstance) servicecatalog.ServiceInstance {
+	instance.Spec.ParametersFrom = []servicecatalog.ParametersFromSource{
+		{
+			SecretKeyRef: &servicecatalog.SecretKeyReference{Name: "param-secret", Key: "params"},
+		},
+	}
+	return instance
+}
+
+func TestValidateServiceInstanceParameters(t *testing.T) {
+	resolvedInstance := newServiceInstance(`{"location": "eastus"}`)
+	resolvedInstance.Spec.ClusterServiceClassRef = &servicecatalog.ClusterObjectReference{Name: "class-guid"}
+	resolvedInstance.Spec.ClusterServicePlanRef = &servicecatalog.ClusterObjectReference{Name: "plan-guid"}
+
+	cases := []struct {
+		name             string
+		operation        admission.Operation
+		instance         servicecatalog.ServiceInstance
+		oldInstance      *servicecatalog.ServiceInstance
+		secretParameters string
+		user             string
+		errorFields      []string
+		errorContains    string
+	}{
+		{
+			name:      "valid parameters",
+			operation: admission.Create,
+			instance:  newServiceInstance(`{"location": "eastus"}`),
+		},
+		{
+			name:        "missing required parameter",
+			operation:   admission.Create,
+			instance:    newServiceInstance(""),
+			errorFields: []string{"spec.parameters.location"},
+		},
+		{
+			name:        "invalid parameter",
+			operation:   admission.Create,
+			instance:    newServiceInstance(`{"location": "moon"}`),
+			errorFields: []string{"spec.parameters.location"},
+		},
+		{
+			name:             "valid parameters from secret",
+			operation:        admission.Create,
+			instance:         withParametersFromSecret(newServiceInstance(`{"location": "eastus"}`)),
+			secretParameters: `{"password": "correcthorse"}`,
+		},
+		{
+			name:             "invalid parameters from secret are redacted",
+			operation:        admission.Create,
+			instance:         withParametersFromSecret(newServiceInstance(`{"location": "eastus"}`)),
+			secretParameters: `{"password": "short"}`,
+			errorFields:      []string{"spec.parameters.password"},
+			errorContains:    "<redacted>",
+		},
+		{
+			name:             "parameters from unreadable secret are not validated",
+			operation:        admission.Create,
+			instance:         withParametersFromSecret(newServiceInstance(`{"location": "moon"}`)),
+			secretParameters: `{"password": "short"}`,
+			user:             "forbidden",
+		},
+		{
+			name:             "duplicate parameter",
+			operation:        admission.Create,
+			instance:         withParametersFromSecret(newServiceInstance(`{"location": "eastus"}`)),
+			secretParameters: `{"location": "eastus"}`,
+			errorFields:      []string{"spec.parameters"},
+		},
+		{
+			name:        "invalid parameters on update",
+			operation:   admission.Update,
+			instance:    newServiceInstance(`{"location": "moon"}`),
+			oldInstance: &resolvedInstance,
+			errorFields: []string{"spec.parameters.location"},
+		},
+		{
+			name:      "unchanged parameters on update are not validated",
+			operation: admission.Update,
+			instance: func() servicecatalog.ServiceInstance {
+				i := newServiceInstance("")
+				i.Finalizers = []string{"foo"}
+				return i
+			}(),
+			oldInstance: func() *servicecatalog.ServiceInstance {
+				i := newServiceInstance("")
+				return &i
+			}(),
+		},
+		{
+			name:      "unknown plan",
+			operation: admission.Create,
+			instance: func() servicecatalog.ServiceInstance {
+				i := newServiceInstance("")
+				i.Spec.ClusterServicePlanExternalName = "other-plan"
+				return i
+			}(),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := newFakeServiceCatalogClientForTest(resolvedInstance)
+			handler, informerFactory, err := newHandlerForTest(fakeClient, newFakeKubeClientForTest(tc.secretParameters))
+			if err != nil {
+				t.Fatalf("unexpected error initializing handler: %v", err)
+			}
+			informerFactory.Start(wait.NeverStop)
+
+			var oldObject runtime.Object
+			if tc.oldInstance != nil {
+				oldObject = tc.oldInstance
+			}
+			err = handler.(admission.ValidationInterface).Validate(admission.NewAttributesRecord(&tc.instance, oldObject, servicecatalog.Kind("ServiceInstance").WithVersion("version"), tc.instance.Namespace, tc.instance.Name, servicecatalog.Resource("serviceinstances").WithVersion("version"), "", tc.operation, &user.DefaultInfo{Name: tc.user}))
+			checkError(t, err, tc.errorFields, tc.errorContains)
+		})
+	}
+}
+
+// TestValidateServiceInstanceParametersFromSecretRedacted tests that the
+// values of parameters read from a secret are not echoed back by the schema
+// keywords that report the whole parameters object as the invalid value.
+func TestValidateServiceInstanceParametersFromSecretRedacted(t *testing.T) {
+	const secretValue = "correcthorsebatterystaple"
+	cases := []struct {
+		name        string
+		schema      string
+		errorFields []string
+	}{
+		{
+			name: "additionalProperties",
+			schema: `{
+  "type": "object",
+  "properties": {
+    "location": {"type": "string"}
+  },
+  "additionalProperties": false
+}`,
+			errorFields: []string{"spec.parameters"},
+		},
+		{
+			name: "oneOf",
+			schema: `{
+  "type": "object",
+  "oneOf": [
+    {"properties": {"password": {"type": "string", "maxLength": 4}}},
+    {"properties": {"password": {"type": "number"}}}
+  ]
+}`,
+			errorFields: []string{"spec.parameters"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := newFakeServiceCatalogClientWithSchemaForTest(tc.schema)
+			handler, informerFactory, err := newHandlerForTest(fakeClient, newFakeKubeClientForTest(`{"password": "`+secretValue+`"}`))
+			if err != nil {
+				t.Fatalf("unexpected error initializing handler: %v", err)
+			}
+			informerFactory.Start(wait.NeverStop)
+
+			instance := withParametersFromSecret(newServiceInstance(`{"location": "eastus"}`))
+			err = handler.(admission.ValidationInterface).Validate(admission.NewAttributesRecord(&instance, nil, servicecatalog.Kind("ServiceInstance").WithVersion("version"), instance.Namespace, instance.Name, servicecatalog.Resource("serviceinstances").WithVersion("version"), "", admission.Create, &user.DefaultInfo{}))
+			checkError(t, err, tc.errorFields, "<redacted>")
+			if strings.Contains(err.Error(), secretValue) {
+				t.Fatalf("expected the value read from the secret to be redacted, got %q", err)
+			}
+		})
+	}
+}
+
+func TestValidateServiceBindingParameters(t *testing.T) {
+	resolvedInstance := newServiceInstance(`{"location": "eastus"}`)
+	resolvedInstance.Spec.ClusterServiceClassRef = &servicecatalog.ClusterObjectReference{Name: "class-guid"}
+	resolvedInstance.Spec.ClusterServicePlanRef = &servicecatalog.ClusterObjectReference{Name: "plan-guid"}
+	unresolvedInstance := newServiceInstance(`{"location": "eastus"}`)
+
+	cases := []struct {
+		name        string
+		instance    servicecatalog.ServiceInstance
+		binding     servicecatalog.ServiceBinding
+		errorFields []string
+	}{
+		{
+			name:     "valid parameters",
+			instance: resolvedInstance,
+			binding:  newServiceBinding(`{"location": "westus"}`),
+		},
+		{
+			name:        "invalid parameters",
+			instance:    resolvedInstance,
+			binding:     newServiceBinding(`{"location": "westus", "password": "short"}`),
+			errorFields: []string{"spec.parameters.password"},
+		},
+		{
+			name:     "instance without a resolved plan",
+			instance: unresolvedInstance,
+			binding:  newServiceBinding(`{"password": "short"}`),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := newFakeServiceCatalogClientForTest(tc.instance)
+			handler, informerFactory, err := newHandlerForTest(fakeClient, newFakeKubeClientForTest(""))
+			if err != nil {
+				t.Fatalf("unexpected error initializing handler: %v", err)
+			}
+			informerFactory.Start(wait.NeverStop)
+
+			err = handler.(admission.ValidationInterface).Validate(admission.NewAttributesRecord(&tc.binding, nil, servicecatalog.Kind("ServiceBinding").WithVersion("version"), tc.binding.Namespace, tc.binding.Name, servicecatalog.Resource("servicebindings").WithVersion("version"), "", admission.Create, &user.DefaultInfo{}))
+			checkError(t, err, tc.errorFields, "")
+		})
+	}
+}
+
+func checkError(t *testing.T, err error, errorFields []string, errorContains string) {
+	if len(errorFields) == 0 {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return
+	}
+	if err == nil {
+		t.Fatalf("expected an error for fields %v", errorFields)
+	}
+	if !apierrors.IsInvalid(err) {
+		t.Fatalf("expected an invalid error, got %v", err)
+	}
+	for _, f := range errorFields {
+		if !strings.Contains(err.Error(), f) {
+			t.Errorf("expected error for field %q, got %q", f, err)
+		}
+	}
+	if errorContains != "" && !strings.Contains(err.Error(), errorContains) {
+		t.Errorf("expected error to contain %q, got %q", errorContains, err)
+	}
+}
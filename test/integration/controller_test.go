@@ -51,6 +51,7 @@ import (
 	informers "github.com/kubernetes-incubator/service-catalog/pkg/client/informers_generated/externalversions/servicecatalog/v1beta1"
 	"github.com/kubernetes-incubator/service-catalog/pkg/controller"
 	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
+	fakeosbclient "github.com/kubernetes-incubator/service-catalog/pkg/osbclient/fake"
 	"github.com/kubernetes-incubator/service-catalog/pkg/registry/servicecatalog/server"
 	"github.com/kubernetes-incubator/service-catalog/test/util"
 )
@@ -757,7 +758,7 @@ func newControllerTestTestController(ct *controllerTest) (
 	})
 
 	fakeOSBClient := fakeosb.NewFakeClient(getTestHappyPathBrokerClientConfig())
-	brokerClFunc := fakeosb.ReturnFakeClientFunc(fakeOSBClient)
+	brokerClFunc := fakeosbclient.ReturnFakeClientFunc(fakeosbclient.WrapFakeClient(fakeOSBClient))
 
 	// create informers
 	informerFactory := scinformers.NewSharedInformerFactory(catalogClient, 10*time.Second)
@@ -787,6 +788,11 @@ func newControllerTestTestController(ct *controllerTest) (
 		7*24*time.Hour,
 		controller.DefaultClusterIDConfigMapName,
 		controller.DefaultClusterIDConfigMapNamespace,
+		false,
+		0,
+		time.Minute,
+		10*time.Minute,
+		time.Hour,
 	)
 	t.Log("controller start")
 	if err != nil {
@@ -917,7 +923,7 @@ func newTestController(t *testing.T) (
 	})
 
 	fakeOSBClient := fakeosb.NewFakeClient(getTestHappyPathBrokerClientConfig())
-	brokerClFunc := fakeosb.ReturnFakeClientFunc(fakeOSBClient)
+	brokerClFunc := fakeosbclient.ReturnFakeClientFunc(fakeosbclient.WrapFakeClient(fakeOSBClient))
 
 	// create informers
 	informerFactory := scinformers.NewSharedInformerFactory(catalogClient, 10*time.Second)
@@ -947,6 +953,11 @@ func newTestController(t *testing.T) (
 		7*24*time.Hour,
 		controller.DefaultClusterIDConfigMapName,
 		controller.DefaultClusterIDConfigMapNamespace,
+		false,
+		0,
+		time.Minute,
+		10*time.Minute,
+		time.Hour,
 	)
 	t.Log("controller start")
 	if err != nil {